@@ -11,7 +11,11 @@ import (
 
 	"github.com/inngest/inngest/cmd/commands/internal/localconfig"
 	"github.com/inngest/inngest/pkg/config"
+	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/devserver"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/eventschema"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
 	"github.com/inngest/inngest/pkg/headers"
 	itrace "github.com/inngest/inngest/pkg/telemetry/trace"
 	"github.com/spf13/cobra"
@@ -46,6 +50,14 @@ func NewCmdDev(rootCmd *cobra.Command) *cobra.Command {
 	advancedFlags.Int("queue-workers", devserver.DefaultQueueWorkers, "Number of executor workers to execute steps from the queue")
 	advancedFlags.Int("tick", devserver.DefaultTick, "The interval (in milliseconds) at which the executor polls the queue")
 	advancedFlags.Int("connect-gateway-port", devserver.DefaultConnectGatewayPort, "Port to expose connect gateway endpoint")
+	advancedFlags.String("cron-catch-up", enums.CronCatchUpSkip.String(), "How to handle cron ticks missed while the server was down: skip, once, or all")
+	advancedFlags.String("event-schemas", "", "Path to a JSON file mapping event names to JSON Schemas which events are validated against")
+	advancedFlags.String("event-schema-mode", string(eventschema.ModeReject), "How to handle events failing schema validation: reject or quarantine")
+	advancedFlags.Duration("event-dedup-window", 0, "Window in which events sent with the same ID are deduplicated, eg. 24h.  Duplicates are acknowledged but not published.  Disabled by default")
+	advancedFlags.Duration("event-schedule-min-delay", scheduledevent.DefaultMinDelay, "Minimum time an event's timestamp must be in the future for the event to be held until that time")
+	advancedFlags.String("realtime-routes", "", "Path to a JSON file of routes which publish matching events to realtime channels")
+	advancedFlags.Int64("priority-factor-min", consts.PriorityFactorMin, "Minimum run priority factor in seconds, limiting how far functions can deprioritize runs")
+	advancedFlags.Int64("priority-factor-max", consts.PriorityFactorMax, "Maximum run priority factor in seconds, limiting how far functions can prioritize runs")
 
 	cmd.Flags().AddFlagSet(advancedFlags)
 	groups = append(groups, FlagGroup{name: "Advanced Flags:", fs: advancedFlags})
@@ -129,6 +141,18 @@ func doDev(cmd *cobra.Command, args []string) {
 	tick := viper.GetInt("tick")
 	connectGatewayPort := viper.GetInt("connect-gateway-port")
 
+	cronCatchUp, err := enums.CronCatchUpString(viper.GetString("cron-catch-up"))
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	schemaMode, err := eventschema.ParseMode(viper.GetString("event-schema-mode"))
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
 	traceEndpoint := fmt.Sprintf("localhost:%d", port)
 	if err := itrace.NewUserTracer(ctx, itrace.TracerOpts{
 		ServiceName:   "tracing",
@@ -159,16 +183,24 @@ func doDev(cmd *cobra.Command, args []string) {
 	conf.ServerKind = headers.ServerKindDev
 
 	opts := devserver.StartOpts{
-		Autodiscover:       !noDiscovery,
-		Config:             *conf,
-		Poll:               !noPoll,
-		PollInterval:       pollInterval,
-		RetryInterval:      retryInterval,
-		QueueWorkers:       queueWorkers,
-		Tick:               time.Duration(tick) * time.Millisecond,
-		URLs:               urls,
-		ConnectGatewayPort: connectGatewayPort,
-		ConnectGatewayHost: conf.CoreAPI.Addr,
+		Autodiscover:          !noDiscovery,
+		Config:                *conf,
+		Poll:                  !noPoll,
+		PollInterval:          pollInterval,
+		RetryInterval:         retryInterval,
+		QueueWorkers:          queueWorkers,
+		Tick:                  time.Duration(tick) * time.Millisecond,
+		URLs:                  urls,
+		ConnectGatewayPort:    connectGatewayPort,
+		ConnectGatewayHost:    conf.CoreAPI.Addr,
+		CronCatchUp:           cronCatchUp,
+		EventSchemas:          viper.GetString("event-schemas"),
+		EventSchemaMode:       schemaMode,
+		EventDedupWindow:      viper.GetDuration("event-dedup-window"),
+		EventScheduleMinDelay: viper.GetDuration("event-schedule-min-delay"),
+		RealtimeRoutes:        viper.GetString("realtime-routes"),
+		PriorityFactorMin:     viper.GetInt64("priority-factor-min"),
+		PriorityFactorMax:     viper.GetInt64("priority-factor-max"),
 	}
 
 	err = devserver.New(ctx, opts)
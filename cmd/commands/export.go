@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/inngest/inngest/pkg/archive"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewCmdExport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export events, apps, functions and runs to newline-delimited JSON.",
+		Long: "Export events, apps, functions and runs to newline-delimited JSON.\n\n" +
+			"The archive is written to stdout if no file is given.  Archives are zstd-compressed\n" +
+			"when using --compress or when the file ends in .zst.",
+		Example: "inngest export backup.ndjson.zst",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    doExport,
+	}
+
+	cmd.Flags().AddFlagSet(archiveStoreFlags())
+	cmd.Flags().Bool("compress", false, "zstd-compress the archive")
+	cmd.Flags().Int("page-size", archive.DefaultPageSize, "Number of rows to load from the database at once")
+
+	return cmd
+}
+
+func doExport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	mgr, db, err := openArchiveStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	compress, _ := cmd.Flags().GetBool("compress")
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+
+	var out io.Writer = os.Stdout
+	if len(args) == 1 && args[0] != "-" {
+		f, err := os.Create(args[0])
+		if err != nil {
+			return fmt.Errorf("error creating archive: %w", err)
+		}
+		defer f.Close()
+		out = f
+		compress = compress || strings.HasSuffix(args[0], ".zst")
+	}
+
+	counts, err := archive.Export(ctx, mgr, out, archive.ExportOpts{
+		Compress: compress,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "Exported:")
+	printArchiveCounts(counts)
+	return nil
+}
+
+// archiveStoreFlags returns the flags used to select the database to export
+// from or import into, matching `inngest start`.
+func archiveStoreFlags() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("store", pflag.ExitOnError)
+	fs.String("sqlite-dir", "", "Directory containing the SQLite database.")
+	fs.String("postgres-uri", "", "PostgreSQL database URI.  Defaults to the SQLite database.")
+	return fs
+}
+
+func openArchiveStore(cmd *cobra.Command) (cqrs.Manager, *sql.DB, error) {
+	sqliteDir, _ := cmd.Flags().GetString("sqlite-dir")
+	postgresURI, _ := cmd.Flags().GetString("postgres-uri")
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{
+		PostgresURI: postgresURI,
+		Directory:   sqliteDir,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening database: %w", err)
+	}
+
+	dbDriver := "sqlite"
+	if postgresURI != "" {
+		dbDriver = "postgres"
+	}
+	return base_cqrs.NewCQRS(db, dbDriver), db, nil
+}
+
+func printArchiveCounts(counts archive.Counts) {
+	for _, kind := range archive.Kinds {
+		fmt.Fprintf(os.Stderr, "  %-14s %d\n", kind, counts[kind])
+	}
+}
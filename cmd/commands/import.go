@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/inngest/inngest/pkg/archive"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import an archive written by `inngest export`.",
+		Long: "Import an archive written by `inngest export`.\n\n" +
+			"The archive is read from stdin if no file is given.  Importing is idempotent: apps and\n" +
+			"functions are updated, and events and runs that already exist are skipped.\n\n" +
+			"With --retrigger-url, imported events are sent to a running server's event API instead\n" +
+			"of being written directly, triggering functions as if the events were sent again.",
+		Example: "inngest import backup.ndjson.zst\n" +
+			"inngest import backup.ndjson --retrigger-url http://localhost:8288/e/key",
+		Args: cobra.MaximumNArgs(1),
+		RunE: doImport,
+	}
+
+	cmd.Flags().AddFlagSet(archiveStoreFlags())
+	cmd.Flags().String("retrigger-url", "", "Event API URL to re-send imported events to")
+
+	return cmd
+}
+
+func doImport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	var in io.Reader = os.Stdin
+	if len(args) == 1 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("error opening archive: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	mgr, db, err := openArchiveStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	opts := archive.ImportOpts{}
+	if url, _ := cmd.Flags().GetString("retrigger-url"); url != "" {
+		opts.Retrigger = archive.HTTPRetrigger(nil, url)
+	}
+
+	res, err := archive.Import(ctx, mgr, in, opts)
+	fmt.Fprintln(os.Stderr, "Imported:")
+	printArchiveCounts(res.Imported)
+	fmt.Fprintln(os.Stderr, "Skipped (already exists):")
+	printArchiveCounts(res.Skipped)
+	return err
+}
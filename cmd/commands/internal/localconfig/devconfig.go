@@ -98,6 +98,14 @@ func mapDevFlags(cmd *cobra.Command) error {
 	err = errors.Join(err, viper.BindPFlag("tick", cmd.Flags().Lookup("tick")))
 	err = errors.Join(err, viper.BindPFlag("sdk-url", cmd.Flags().Lookup("sdk-url")))
 	err = errors.Join(err, viper.BindPFlag("connect-gateway-port", cmd.Flags().Lookup("connect-gateway-port")))
+	err = errors.Join(err, viper.BindPFlag("cron-catch-up", cmd.Flags().Lookup("cron-catch-up")))
+	err = errors.Join(err, viper.BindPFlag("event-schemas", cmd.Flags().Lookup("event-schemas")))
+	err = errors.Join(err, viper.BindPFlag("event-schema-mode", cmd.Flags().Lookup("event-schema-mode")))
+	err = errors.Join(err, viper.BindPFlag("event-dedup-window", cmd.Flags().Lookup("event-dedup-window")))
+	err = errors.Join(err, viper.BindPFlag("event-schedule-min-delay", cmd.Flags().Lookup("event-schedule-min-delay")))
+	err = errors.Join(err, viper.BindPFlag("realtime-routes", cmd.Flags().Lookup("realtime-routes")))
+	err = errors.Join(err, viper.BindPFlag("priority-factor-min", cmd.Flags().Lookup("priority-factor-min")))
+	err = errors.Join(err, viper.BindPFlag("priority-factor-max", cmd.Flags().Lookup("priority-factor-max")))
 
 	return err
 }
@@ -118,6 +126,14 @@ func mapStartFlags(cmd *cobra.Command) error {
 	err = errors.Join(err, viper.BindPFlag("sqlite-dir", cmd.Flags().Lookup("sqlite-dir")))
 	err = errors.Join(err, viper.BindPFlag("tick", cmd.Flags().Lookup("tick")))
 	err = errors.Join(err, viper.BindPFlag("connect-gateway-port", cmd.Flags().Lookup("connect-gateway-port")))
+	err = errors.Join(err, viper.BindPFlag("cron-catch-up", cmd.Flags().Lookup("cron-catch-up")))
+	err = errors.Join(err, viper.BindPFlag("event-schemas", cmd.Flags().Lookup("event-schemas")))
+	err = errors.Join(err, viper.BindPFlag("event-schema-mode", cmd.Flags().Lookup("event-schema-mode")))
+	err = errors.Join(err, viper.BindPFlag("event-dedup-window", cmd.Flags().Lookup("event-dedup-window")))
+	err = errors.Join(err, viper.BindPFlag("event-schedule-min-delay", cmd.Flags().Lookup("event-schedule-min-delay")))
+	err = errors.Join(err, viper.BindPFlag("realtime-routes", cmd.Flags().Lookup("realtime-routes")))
+	err = errors.Join(err, viper.BindPFlag("priority-factor-min", cmd.Flags().Lookup("priority-factor-min")))
+	err = errors.Join(err, viper.BindPFlag("priority-factor-max", cmd.Flags().Lookup("priority-factor-max")))
 
 	return err
 }
@@ -72,6 +72,8 @@ func Execute() {
 	rootCmd.AddCommand(NewCmdDev(rootCmd))
 	rootCmd.AddCommand(NewCmdVersion())
 	rootCmd.AddCommand(NewCmdStart(rootCmd))
+	rootCmd.AddCommand(NewCmdExport())
+	rootCmd.AddCommand(NewCmdImport())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
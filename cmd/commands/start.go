@@ -8,7 +8,11 @@ import (
 
 	"github.com/inngest/inngest/cmd/commands/internal/localconfig"
 	"github.com/inngest/inngest/pkg/config"
+	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/devserver"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/eventschema"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
 	"github.com/inngest/inngest/pkg/lite"
 	itrace "github.com/inngest/inngest/pkg/telemetry/trace"
 	"github.com/spf13/cobra"
@@ -45,7 +49,8 @@ func NewCmdStart(rootCmd *cobra.Command) *cobra.Command {
 	persistenceFlags := pflag.NewFlagSet("persistence", pflag.ExitOnError)
 	persistenceFlags.String("sqlite-dir", "", "Directory for where to write SQLite database.")
 	persistenceFlags.String("redis-uri", "", "Redis server URI for external queue and run state. Defaults to self-contained, in-memory Redis server with periodic snapshot backups.")
-	persistenceFlags.String("postgres-uri", "", "[Experimental] PostgreSQL database URI for configuration and history persistence. Defaults to SQLite database.")
+	persistenceFlags.String("postgres-uri", "", "[Experimental] PostgreSQL database URI for configuration, history, and function state persistence. Defaults to SQLite database.")
+	persistenceFlags.Bool("postgres-queue", false, "[Experimental] Run the queue, debounces, batches, and crons on PostgreSQL instead of Redis. Requires --postgres-uri. Functions using throttling or concurrency keys can't be synced.")
 	cmd.Flags().AddFlagSet(persistenceFlags)
 	groups = append(groups, FlagGroup{name: "Persistence Flags:", fs: persistenceFlags})
 
@@ -55,6 +60,14 @@ func NewCmdStart(rootCmd *cobra.Command) *cobra.Command {
 	advancedFlags.Int("queue-workers", devserver.DefaultQueueWorkers, "Number of executor workers to execute steps from the queue")
 	advancedFlags.Int("tick", devserver.DefaultTick, "The interval (in milliseconds) at which the executor polls the queue")
 	advancedFlags.Int("connect-gateway-port", devserver.DefaultConnectGatewayPort, "Port to expose connect gateway endpoint")
+	advancedFlags.String("cron-catch-up", enums.CronCatchUpSkip.String(), "How to handle cron ticks missed while the server was down: skip, once, or all")
+	advancedFlags.String("event-schemas", "", "Path to a JSON file mapping event names to JSON Schemas which events are validated against")
+	advancedFlags.String("event-schema-mode", string(eventschema.ModeReject), "How to handle events failing schema validation: reject or quarantine")
+	advancedFlags.Duration("event-dedup-window", 0, "Window in which events sent with the same ID are deduplicated, eg. 24h.  Duplicates are acknowledged but not published.  Disabled by default")
+	advancedFlags.Duration("event-schedule-min-delay", scheduledevent.DefaultMinDelay, "Minimum time an event's timestamp must be in the future for the event to be held until that time")
+	advancedFlags.String("realtime-routes", "", "Path to a JSON file of routes which publish matching events to realtime channels")
+	advancedFlags.Int64("priority-factor-min", consts.PriorityFactorMin, "Minimum run priority factor in seconds, limiting how far functions can deprioritize runs")
+	advancedFlags.Int64("priority-factor-max", consts.PriorityFactorMax, "Maximum run priority factor in seconds, limiting how far functions can prioritize runs")
 	cmd.Flags().AddFlagSet(advancedFlags)
 	groups = append(groups, FlagGroup{name: "Advanced Flags:", fs: advancedFlags})
 
@@ -146,19 +159,40 @@ func doStart(cmd *cobra.Command, args []string) {
 		tick = devserver.DefaultTick
 	}
 
+	cronCatchUp, err := enums.CronCatchUpString(viper.GetString("cron-catch-up"))
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	schemaMode, err := eventschema.ParseMode(viper.GetString("event-schema-mode"))
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
 	opts := lite.StartOpts{
-		Config:             *conf,
-		PollInterval:       viper.GetInt("poll-interval"),
-		RedisURI:           viper.GetString("redis-uri"),
-		PostgresURI:        viper.GetString("postgres-uri"),
-		RetryInterval:      viper.GetInt("retry-interval"),
-		QueueWorkers:       viper.GetInt("queue-workers"),
-		Tick:               time.Duration(tick) * time.Millisecond,
-		URLs:               viper.GetStringSlice("sdk-url"),
-		SQLiteDir:          viper.GetString("sqlite-dir"),
-		SigningKey:         viper.GetString("signing-key"),
-		EventKey:           viper.GetStringSlice("event-key"),
-		ConnectGatewayPort: viper.GetInt("connect-gateway-port"),
+		Config:                *conf,
+		PollInterval:          viper.GetInt("poll-interval"),
+		RedisURI:              viper.GetString("redis-uri"),
+		PostgresURI:           viper.GetString("postgres-uri"),
+		PostgresQueue:         viper.GetBool("postgres-queue"),
+		RetryInterval:         viper.GetInt("retry-interval"),
+		QueueWorkers:          viper.GetInt("queue-workers"),
+		Tick:                  time.Duration(tick) * time.Millisecond,
+		URLs:                  viper.GetStringSlice("sdk-url"),
+		SQLiteDir:             viper.GetString("sqlite-dir"),
+		SigningKey:            viper.GetString("signing-key"),
+		EventKey:              viper.GetStringSlice("event-key"),
+		ConnectGatewayPort:    viper.GetInt("connect-gateway-port"),
+		CronCatchUp:           cronCatchUp,
+		EventSchemas:          viper.GetString("event-schemas"),
+		EventSchemaMode:       schemaMode,
+		EventDedupWindow:      viper.GetDuration("event-dedup-window"),
+		EventScheduleMinDelay: viper.GetDuration("event-schedule-min-delay"),
+		RealtimeRoutes:        viper.GetString("realtime-routes"),
+		PriorityFactorMin:     viper.GetInt64("priority-factor-min"),
+		PriorityFactorMax:     viper.GetInt64("priority-factor-max"),
 	}
 
 	err = lite.New(ctx, opts)
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -13,12 +14,18 @@ import (
 	"github.com/inngest/inngest/pkg/config"
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/coreapi/apiutil"
+	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventdedup"
+	"github.com/inngest/inngest/pkg/eventkey"
+	"github.com/inngest/inngest/pkg/eventschema"
 	"github.com/inngest/inngest/pkg/eventstream"
+	"github.com/inngest/inngest/pkg/execution/ratelimit"
 	"github.com/inngest/inngest/pkg/headers"
 	"github.com/inngest/inngest/pkg/logger"
 	"github.com/inngest/inngest/pkg/publicerr"
 	itrace "github.com/inngest/inngest/pkg/telemetry/trace"
+	"github.com/inngest/inngest/pkg/webhook"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
@@ -42,18 +49,57 @@ type Options struct {
 	// the server will still boot but core actions such as syncing, runs, and
 	// ingesting events will not work.
 	RequireKeys bool
+
+	// EventValidator, if set, validates events against registered event
+	// schemas at ingest.
+	EventValidator eventschema.Validator
+	// EventSchemaMode determines how events failing validation are handled.
+	EventSchemaMode eventschema.Mode
+	// Quarantine stores events failing validation when EventSchemaMode is
+	// eventschema.ModeQuarantine.
+	Quarantine cqrs.EventSchemaWriter
+
+	// EventKeys, if set, stores scoped event keys which are accepted in
+	// addition to LocalEventKeys.
+	EventKeys cqrs.EventKeyReadWriter
+	// RateLimiter enforces per-key ingest rate limits for scoped event keys.
+	RateLimiter ratelimit.RateLimiter
+	// EventKeyAuthorizer, if set, authorizes event keys instead of an
+	// authorizer built from LocalEventKeys, EventKeys and RateLimiter.  This
+	// lets callers share the authorizer's caches with the API.
+	EventKeyAuthorizer eventkey.Authorizer
+
+	// WebhookSources, if set, loads webhook sources which receive arbitrary
+	// payloads at /w/{id}.
+	WebhookSources cqrs.WebhookSourceReader
+
+	// Deduplicator, if set, deduplicates events by their ID within each
+	// workspace's dedup window before they're published.
+	Deduplicator eventdedup.Deduplicator
 }
 
 func NewAPI(o Options) (chi.Router, error) {
 	logger := o.Logger.With("caller", "api")
 
+	keys := o.EventKeyAuthorizer
+	if keys == nil {
+		keys = eventkey.NewAuthorizer(o.LocalEventKeys, o.EventKeys, o.RateLimiter)
+	}
+
 	api := &API{
 		Router:         chi.NewMux(),
 		config:         o.Config,
 		handler:        o.EventHandler,
 		log:            logger,
 		localEventKeys: o.LocalEventKeys,
+		keys:           keys,
 		requireKeys:    o.RequireKeys,
+		validator:      o.EventValidator,
+		schemaMode:     o.EventSchemaMode,
+		quarantine:     o.Quarantine,
+		webhooks:       o.WebhookSources,
+		transforms:     &webhook.Cache{},
+		dedup:          o.Deduplicator,
 	}
 
 	cors := cors.New(cors.Options{
@@ -69,6 +115,7 @@ func NewAPI(o Options) (chi.Router, error) {
 
 	api.Get("/health", api.HealthCheck)
 	api.Post("/e/{key}", api.ReceiveEvent)
+	api.Post("/w/{id}", api.ReceiveWebhook)
 	api.Post("/invoke/{slug}", api.Invoke)
 
 	return api, nil
@@ -88,12 +135,23 @@ type API struct {
 	// from an app. If this is set, only keys that match one of these values
 	// will be accepted.
 	localEventKeys []string
+	// keys authorizes both local and scoped event keys.
+	keys eventkey.Authorizer
 
 	// requireKeys defines whether event and signing keys are required for the
 	// server to function. If this is true and signing keys are not defined,
 	// the server will still boot but core actions such as syncing, runs, and
 	// ingesting events will not work.
 	requireKeys bool
+
+	validator  eventschema.Validator
+	schemaMode eventschema.Mode
+	quarantine cqrs.EventSchemaWriter
+
+	webhooks   cqrs.WebhookSourceReader
+	transforms *webhook.Cache
+
+	dedup eventdedup.Deduplicator
 }
 
 func (a *API) AddRoutes() {
@@ -141,17 +199,6 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	defer r.Body.Close()
 
-	// If self hosting and keys are not defined, error.
-	if a.requireKeys && len(a.localEventKeys) == 0 {
-		a.log.Error("rejecting event; event keys are required to process events securely")
-		w.Header().Add("Content-Type", "application/json")
-		a.writeResponse(w, apiResponse{
-			StatusCode: http.StatusServiceUnavailable,
-			Error:      "Event keys are required to process events securely",
-		})
-		return
-	}
-
 	key := chi.URLParam(r, "key")
 	if key == "" {
 		a.log.Error("rejecting event; event key is required")
@@ -164,16 +211,32 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(a.localEventKeys) > 0 {
-		var found bool
-		for _, k := range a.localEventKeys {
-			if k == key {
-				found = true
-				break
-			}
+	scope, err := a.keys.Authorize(ctx, key)
+	switch {
+	case errors.Is(err, eventkey.ErrNotFound):
+		configured, cerr := a.keys.Configured(ctx)
+		if cerr != nil {
+			a.log.Error("error loading event keys", "error", cerr)
+			w.Header().Add("Content-Type", "application/json")
+			a.writeResponse(w, apiResponse{
+				StatusCode: http.StatusInternalServerError,
+				Error:      "Error loading event keys",
+			})
+			return
 		}
 
-		if !found {
+		// If self hosting and keys are not defined, error.
+		if a.requireKeys && !configured {
+			a.log.Error("rejecting event; event keys are required to process events securely")
+			w.Header().Add("Content-Type", "application/json")
+			a.writeResponse(w, apiResponse{
+				StatusCode: http.StatusServiceUnavailable,
+				Error:      "Event keys are required to process events securely",
+			})
+			return
+		}
+
+		if configured {
 			a.log.Error("rejecting event; event key not recognized")
 			w.Header().Add("Content-Type", "application/json")
 			a.writeResponse(w, apiResponse{
@@ -182,6 +245,40 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
+
+		// No keys are configured, so any key may send any event.
+		scope = &eventkey.Scope{}
+	case errors.Is(err, eventkey.ErrRevoked), errors.Is(err, eventkey.ErrExpired):
+		a.log.Error("rejecting event; event key is not active", "error", err)
+		w.Header().Add("Content-Type", "application/json")
+		a.writeResponse(w, apiResponse{
+			StatusCode: http.StatusUnauthorized,
+			Error:      err.Error(),
+		})
+		return
+	case err != nil:
+		a.log.Error("error authorizing event key", "error", err)
+		w.Header().Add("Content-Type", "application/json")
+		a.writeResponse(w, apiResponse{
+			StatusCode: http.StatusInternalServerError,
+			Error:      "Error authorizing event key",
+		})
+		return
+	}
+
+	// Rate limits apply per request, such that batches of events count once.
+	if err := scope.RateLimit(ctx); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, eventkey.ErrRateLimited) {
+			status = http.StatusTooManyRequests
+		}
+		a.log.Warn("rejecting event; event key rate limit", "error", err)
+		w.Header().Add("Content-Type", "application/json")
+		a.writeResponse(w, apiResponse{
+			StatusCode: status,
+			Error:      err.Error(),
+		})
+		return
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -204,6 +301,16 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 			int
 			string
 		})
+		// schemaErrs holds per-event schema validation errors by index.
+		schemaErrs = map[int]string{}
+		// received and rejected count the events in the request and those
+		// rejected by schema validation.
+		received int
+		rejected int
+		// quarantined holds the IDs of quarantined events by index.
+		quarantined = map[int]string{}
+		// duplicates holds the indexes of events which were deduplicated.
+		duplicates []int
 	)
 	eg.Go(func() error {
 		for item := range idChan {
@@ -223,32 +330,41 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 		index := 0
 		for s := range stream {
 			index++
+			received++
 			evt := event.Event{}
 			if err := json.Unmarshal(s.Item, &evt); err != nil {
 				return err
 			}
 
-			if evt.IsInternal() {
-				err := fmt.Errorf("event name is reserved for internal use: %s", evt.Name)
+			ts := time.Now()
+			if err := prepareEvent(ctx, &evt, ts); err != nil {
 				return err
 			}
 
-			// External event (i.e. doesn't have the "inngest/" prefix) data
-			// must not have internal metadata since it can cause issues. For
-			// example, if an invoked function's event data is forwarded into a
-			// new event then it may accidentally fulfill the invocation
-			delete(evt.Data, "_inngest")
-
-			ts := time.Now()
-			if evt.Timestamp == 0 {
-				evt.Timestamp = ts.UnixMilli()
-			}
-			if evt.User == nil {
-				evt.User = map[string]any{}
+			if err := scope.Allow(ctx, evt.Name); err != nil {
+				return err
 			}
 
-			if err := evt.Validate(ctx); err != nil {
-				return err
+			if id, err := a.validateSchema(ctx, evt); err != nil {
+				if !errors.As(err, &eventschema.ValidationError{}) {
+					return err
+				}
+				// The event failed schema validation and was either rejected
+				// or quarantined.  Record the error and continue to the next
+				// event.
+				schemaErrs[s.N] = err.Error()
+				if id == "" {
+					rejected++
+				} else {
+					quarantined[s.N] = id
+				}
+				// Quarantined events aren't published, so their IDs are
+				// reported separately from published event IDs.
+				idChan <- struct {
+					int
+					string
+				}{s.N, ""}
+				continue
 			}
 
 			ctx, span := itrace.UserTracer().Provider().
@@ -264,11 +380,14 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 				r.Header.Get(headers.HeaderEventIDSeed),
 				index,
 			)
-			id, err := a.handler(ctx, &evt, seed)
+			id, dup, err := a.handleDeduped(ctx, &evt, seed)
 			if err != nil {
 				a.log.Error("error handling event", "error", err, "event", evt.Name)
 				return err
 			}
+			if dup {
+				duplicates = append(duplicates, s.N)
+			}
 			idChan <- struct {
 				int
 				string
@@ -278,19 +397,41 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 		return nil
 	})
 
-	err := eg.Wait()
+	err = eg.Wait()
 	cancel()
 
 	if max+1 > len(ids) {
 		max = len(ids) - 1
 	}
 
+	if len(schemaErrs) == 0 {
+		schemaErrs = nil
+	}
+	if len(quarantined) == 0 {
+		quarantined = nil
+	}
+
+	// Valid events in a batch are published as they're received, so the
+	// request only fails if every event was rejected.  Otherwise rejected
+	// events are reported by index, as with quarantined events.
+	if err == nil && rejected > 0 && rejected == received {
+		err = fmt.Errorf("%d event(s) failed schema validation", rejected)
+	}
+
 	if err != nil {
-		w.WriteHeader(400)
+		status := http.StatusBadRequest
+		if errors.Is(err, eventkey.ErrEventNotAllowed) {
+			status = http.StatusForbidden
+		}
+
+		w.WriteHeader(status)
 		_ = json.NewEncoder(w).Encode(apiutil.EventAPIResponse{
-			IDs:    ids[0 : max+1],
-			Status: 400,
-			Error:  err.Error(),
+			IDs:         ids[0 : max+1],
+			Status:      status,
+			Error:       err.Error(),
+			Errors:      schemaErrs,
+			Duplicates:  duplicates,
+			Quarantined: quarantined,
 		})
 
 		return
@@ -298,11 +439,65 @@ func (a API) ReceiveEvent(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(200)
 	_ = json.NewEncoder(w).Encode(apiutil.EventAPIResponse{
-		IDs:    ids[0 : max+1],
-		Status: 200,
+		IDs:         ids[0 : max+1],
+		Status:      200,
+		Errors:      schemaErrs,
+		Duplicates:  duplicates,
+		Quarantined: quarantined,
 	})
 }
 
+// prepareEvent validates and normalizes an externally sent event before it's
+// handled.
+func prepareEvent(ctx context.Context, evt *event.Event, ts time.Time) error {
+	// Signal events are the only internal events which may be sent
+	// externally, allowing signals to be delivered via the event API.
+	if evt.IsInternal() && !evt.IsSignalEvent() {
+		return fmt.Errorf("event name is reserved for internal use: %s", evt.Name)
+	}
+
+	// External event (i.e. doesn't have the "inngest/" prefix) data
+	// must not have internal metadata since it can cause issues. For
+	// example, if an invoked function's event data is forwarded into a
+	// new event then it may accidentally fulfill the invocation
+	delete(evt.Data, "_inngest")
+
+	if evt.Timestamp == 0 {
+		evt.Timestamp = ts.UnixMilli()
+	}
+	if evt.User == nil {
+		evt.User = map[string]any{}
+	}
+
+	return evt.Validate(ctx)
+}
+
+// validateSchema validates the event against any registered event schemas,
+// returning an eventschema.ValidationError if the event fails validation.  If
+// the event was quarantined, the quarantined event's ID is also returned.
+func (a API) validateSchema(ctx context.Context, evt event.Event) (string, error) {
+	if a.validator == nil {
+		return "", nil
+	}
+
+	verr := a.validator.Validate(ctx, evt)
+	if verr == nil || !errors.As(verr, &eventschema.ValidationError{}) {
+		return "", verr
+	}
+
+	if a.schemaMode != eventschema.ModeQuarantine || a.quarantine == nil {
+		a.log.Warn("rejecting event failing schema validation", "event", evt.Name, "error", verr)
+		return "", verr
+	}
+
+	id, err := eventschema.Quarantine(ctx, a.quarantine, evt, verr)
+	if err != nil {
+		return "", err
+	}
+	a.log.Warn("quarantined event failing schema validation", "event", evt.Name, "id", id, "error", verr)
+	return id, verr
+}
+
 // Invoke creates an event to invoke a specific function.
 func (a API) Invoke(w http.ResponseWriter, r *http.Request) {
 	// XXX: In OSS self hosting, check signing keys here.
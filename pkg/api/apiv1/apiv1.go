@@ -11,8 +11,11 @@ import (
 	"github.com/inngest/inngest/pkg/api/apiv1/apiv1auth"
 	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/execution"
+	"github.com/inngest/inngest/pkg/execution/batch"
 	"github.com/inngest/inngest/pkg/execution/queue"
 	"github.com/inngest/inngest/pkg/execution/realtime"
+	"github.com/inngest/inngest/pkg/execution/replay"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
 	"github.com/inngest/inngest/pkg/execution/state/redis_state"
 	"github.com/inngest/inngest/pkg/headers"
 )
@@ -46,6 +49,16 @@ type Opts struct {
 	RealtimeJWTSecret []byte
 	// TraceReader reads traces from a backing store.
 	TraceReader cqrs.TraceReader
+	// DeadLetterReadWriter reads and redrives permanently failed runs.
+	DeadLetterReadWriter cqrs.DeadLetterReadWriter
+	// FunctionPauseWriter pauses and unpauses functions.
+	FunctionPauseWriter cqrs.FunctionPauseWriter
+	// Replays backfills runs skipped while a function was paused.
+	Replays *replay.Manager
+	// EventScheduler cancels events scheduled with a future timestamp.
+	EventScheduler scheduledevent.Scheduler
+	// Batcher lists, flushes and discards functions' open event batches.
+	Batcher batch.BatchManager
 }
 
 // AddRoutes adds a new API handler to the given router.
@@ -109,16 +122,27 @@ func (a *router) setup() {
 			r.Get("/events", a.getEvents)
 			r.Get("/events/{eventID}", a.getEvent)
 			r.Get("/events/{eventID}/runs", a.getEventRuns)
+			r.Delete("/events/{eventID}/scheduled", a.cancelScheduledEvent)
 			r.Get("/runs/{runID}", a.GetFunctionRun)
 			r.Delete("/runs/{runID}", a.cancelFunctionRun)
 			r.Get("/runs/{runID}/jobs", a.GetFunctionRunJobs)
 
 			r.Get("/apps/{appName}/functions", a.GetAppFunctions) // Returns an app and all of its functions.
+			r.Post("/apps/{appName}/functions/{functionID}/pause", a.pauseFunction)
+			r.Post("/apps/{appName}/functions/{functionID}/unpause", a.unpauseFunction)
+			r.Get("/functions/{functionID}/queue", a.getFunctionQueue)
+			r.Get("/functions/{functionID}/batches", a.getFunctionBatches)
+			r.Post("/functions/{functionID}/batches/{batchID}/flush", a.flushBatch)
+			r.Delete("/functions/{functionID}/batches/{batchID}", a.discardBatch)
 
 			r.Post("/cancellations", a.createCancellation)
 			r.Get("/cancellations", a.getCancellations)
 			r.Delete("/cancellations/{id}", a.deleteCancellation)
 
+			r.Get("/dlq", a.getDeadLetters)
+			r.Post("/dlq/redrive", a.bulkRedriveDeadLetters)
+			r.Post("/dlq/{id}/redrive", a.redriveDeadLetter)
+
 			r.Get("/prom/{env}", a.promScrape)
 
 			r.Post("/traces/userland", a.traces)
@@ -0,0 +1,217 @@
+package apiv1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution"
+	"github.com/inngest/inngest/pkg/execution/batch"
+	"github.com/inngest/inngest/pkg/publicerr"
+	"github.com/oklog/ulid/v2"
+)
+
+// FunctionBatch is an open batch of events which has not yet started a run.
+type FunctionBatch struct {
+	ID ulid.ULID `json:"id"`
+	// Key is the evaluated batch key, or "default" if the function has no key.
+	Key string `json:"key"`
+	// Size is the number of events in the batch.
+	Size      int       `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+	// AgeMS is the time since the batch was created, in milliseconds.
+	AgeMS int64 `json:"age_ms"`
+}
+
+// GetFunctionBatches returns a function's open batches, oldest first, optionally
+// filtered by batch key.
+func (a API) GetFunctionBatches(ctx context.Context, fnID uuid.UUID, key string) ([]FunctionBatch, error) {
+	if _, err := a.batchFunction(ctx, fnID); err != nil {
+		return nil, err
+	}
+
+	open, err := a.opts.Batcher.OpenBatches(ctx, fnID)
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 500, "Unable to read batches: %s", err)
+	}
+
+	now := time.Now()
+	batches := make([]FunctionBatch, 0, len(open))
+	for _, b := range open {
+		if key != "" && b.Key != key {
+			continue
+		}
+		batches = append(batches, FunctionBatch{
+			ID:        b.ID,
+			Key:       b.Key,
+			Size:      b.Size,
+			CreatedAt: b.CreatedAt,
+			AgeMS:     now.Sub(b.CreatedAt).Milliseconds(),
+		})
+	}
+	return batches, nil
+}
+
+// FlushBatch starts a run for an open batch immediately, without waiting for the
+// batch to fill or time out.
+func (a API) FlushBatch(ctx context.Context, fnID uuid.UUID, batchID ulid.ULID) error {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.Executor == nil {
+		return publicerr.Errorf(500, "No executor specified")
+	}
+
+	cfn, err := a.batchFunction(ctx, fnID)
+	if err != nil {
+		return err
+	}
+	fn, err := cfn.InngestFunction()
+	if err != nil {
+		return publicerr.Wrap(err, 500, "Unable to load function")
+	}
+
+	open, err := a.opts.Batcher.OpenBatches(ctx, fnID)
+	if err != nil {
+		return publicerr.Wrapf(err, 500, "Unable to read batches: %s", err)
+	}
+	var found *batch.OpenBatch
+	for _, b := range open {
+		if b.ID == batchID {
+			found = &b
+			break
+		}
+	}
+	if found == nil {
+		return publicerr.Errorf(404, "Batch not found")
+	}
+
+	status, err := a.opts.Batcher.StartExecution(ctx, fnID, batchID, found.Pointer)
+	if err != nil {
+		return publicerr.Wrap(err, 500, "Unable to start batch")
+	}
+	switch status {
+	case enums.BatchStatusStarted.String():
+		return publicerr.Errorf(409, "Batch has already started")
+	case enums.BatchStatusAbsent.String():
+		return publicerr.Errorf(404, "Batch not found")
+	}
+
+	err = a.opts.Executor.RetrieveAndScheduleBatch(ctx, *fn, batch.ScheduleBatchPayload{
+		BatchID:         batchID,
+		BatchPointer:    found.Pointer,
+		AccountID:       auth.AccountID(),
+		WorkspaceID:     auth.WorkspaceID(),
+		AppID:           cfn.AppID,
+		FunctionID:      fnID,
+		FunctionVersion: fn.FunctionVersion,
+	}, &execution.BatchExecOpts{
+		FunctionPausedAt: cfn.PausedAt,
+	})
+	if err != nil {
+		// Re-open the batch so that the flush can be retried, or the batch
+		// flushed when it times out.
+		if rerr := a.opts.Batcher.ResetExecution(ctx, fnID, *found); rerr != nil {
+			err = errors.Join(err, rerr)
+		}
+		return publicerr.Wrapf(err, 500, "Unable to schedule batch: %s", err)
+	}
+	return nil
+}
+
+// DiscardBatch drops an open batch's events without starting a run.
+func (a API) DiscardBatch(ctx context.Context, fnID uuid.UUID, batchID ulid.ULID) error {
+	if _, err := a.batchFunction(ctx, fnID); err != nil {
+		return err
+	}
+
+	err := a.opts.Batcher.DiscardBatch(ctx, fnID, batchID)
+	switch {
+	case errors.Is(err, batch.ErrBatchNotFound):
+		return publicerr.Errorf(404, "Batch not found")
+	case errors.Is(err, batch.ErrBatchStarted):
+		return publicerr.Errorf(409, "Batch has already started")
+	case err != nil:
+		return publicerr.Wrapf(err, 500, "Unable to discard batch: %s", err)
+	}
+	return nil
+}
+
+// batchFunction loads the function for the authenticated workspace, ensuring
+// batches can be managed.
+func (a API) batchFunction(ctx context.Context, fnID uuid.UUID) (*cqrs.Function, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.Batcher == nil {
+		return nil, publicerr.Errorf(500, "No batch manager specified")
+	}
+
+	fn, err := a.opts.FunctionReader.GetFunctionByInternalUUID(ctx, auth.WorkspaceID(), fnID)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 404, "function not found")
+	}
+	return fn, nil
+}
+
+func (a router) getFunctionBatches(w http.ResponseWriter, r *http.Request) {
+	fnID, err := uuid.Parse(chi.URLParam(r, "functionID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid function ID: %s", chi.URLParam(r, "functionID")))
+		return
+	}
+
+	batches, err := a.API.GetFunctionBatches(r.Context(), fnID, r.FormValue("key"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, batches)
+}
+
+func (a router) flushBatch(w http.ResponseWriter, r *http.Request) {
+	fnID, batchID, err := batchParams(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	if err := a.API.FlushBatch(r.Context(), fnID, batchID); err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, map[string]any{"id": batchID, "flushed": true})
+}
+
+func (a router) discardBatch(w http.ResponseWriter, r *http.Request) {
+	fnID, batchID, err := batchParams(r)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	if err := a.API.DiscardBatch(r.Context(), fnID, batchID); err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, map[string]any{"id": batchID, "discarded": true})
+}
+
+func batchParams(r *http.Request) (uuid.UUID, ulid.ULID, error) {
+	fnID, err := uuid.Parse(chi.URLParam(r, "functionID"))
+	if err != nil {
+		return uuid.Nil, ulid.ULID{}, publicerr.Wrapf(err, 400, "Invalid function ID: %s", chi.URLParam(r, "functionID"))
+	}
+	batchID, err := ulid.Parse(chi.URLParam(r, "batchID"))
+	if err != nil {
+		return uuid.Nil, ulid.ULID{}, publicerr.Wrapf(err, 400, "Invalid batch ID: %s", chi.URLParam(r, "batchID"))
+	}
+	return fnID, batchID, nil
+}
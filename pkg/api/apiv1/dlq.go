@@ -0,0 +1,231 @@
+package apiv1
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/dateutil"
+	"github.com/inngest/inngest/pkg/execution/deadletter"
+	"github.com/inngest/inngest/pkg/publicerr"
+	"github.com/inngest/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	DefaultDeadLetters = 20
+)
+
+// GetDeadLetters returns permanently failed runs in reverse chronological order
+// for a workspace, with optional pagination and filtering params.
+func (a API) GetDeadLetters(ctx context.Context, opts *cqrs.DeadLettersOpts) ([]cqrs.DeadLetter, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.DeadLetterReadWriter == nil {
+		return nil, publicerr.Errorf(500, "No dead letter reader specified")
+	}
+
+	dls, err := a.opts.DeadLetterReadWriter.DeadLetters(ctx, auth.WorkspaceID(), opts)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to query dead letters")
+	}
+	return dls, nil
+}
+
+func (a router) getDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	opts := cqrs.DeadLettersOpts{}
+
+	limit, _ := strconv.Atoi(r.FormValue("limit"))
+	if limit == 0 {
+		limit = DefaultDeadLetters
+	}
+	opts.Limit = util.Bound(limit, 1, cqrs.MaxDeadLetters)
+
+	if cursor := r.FormValue("cursor"); cursor != "" {
+		parsed, err := ulid.Parse(cursor)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid cursor query parameter"))
+			return
+		}
+		opts.Cursor = &parsed
+	}
+
+	if before := r.FormValue("failed_before"); before != "" {
+		parsed, err := dateutil.Parse(before)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid failed_before query parameter"))
+			return
+		}
+		opts.Newest = parsed
+	}
+
+	if after := r.FormValue("failed_after"); after != "" {
+		parsed, err := dateutil.Parse(after)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid failed_after query parameter"))
+			return
+		}
+		opts.Oldest = parsed
+	}
+
+	if fnID := r.FormValue("function_id"); fnID != "" {
+		fn, err := a.API.findFunction(ctx, r.FormValue("app_id"), fnID)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, err)
+			return
+		}
+		opts.FunctionID = &fn
+	}
+
+	dls, err := a.API.GetDeadLetters(ctx, &opts)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	// Do not cache this response.
+	_ = WriteResponse(w, dls)
+}
+
+// RedriveDeadLetter schedules a new run for the given dead letter, returning
+// the new run's ID.
+func (a API) RedriveDeadLetter(ctx context.Context, id ulid.ULID) (*ulid.ULID, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.DeadLetterReadWriter == nil {
+		return nil, publicerr.Errorf(500, "No dead letter reader specified")
+	}
+
+	dl, err := a.opts.DeadLetterReadWriter.DeadLetter(ctx, auth.WorkspaceID(), id)
+	if err == sql.ErrNoRows {
+		return nil, publicerr.Wrap(err, 404, "Dead letter not found")
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to load dead letter")
+	}
+
+	runID, err := a.redriver().Redrive(ctx, *dl)
+	if errors.Is(err, deadletter.ErrAlreadyRedriven) {
+		return nil, publicerr.Wrap(err, 409, "Dead letter has already been redriven")
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to redrive dead letter")
+	}
+	return &runID, nil
+}
+
+func (a router) redriveDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := ulid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid dead letter ID"))
+		return
+	}
+
+	runID, err := a.API.RedriveDeadLetter(r.Context(), id)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, map[string]any{"run_id": runID})
+}
+
+type BulkRedriveBody struct {
+	// AppID is the client ID specified via the SDK in the app that defines the function.
+	AppID string `json:"app_id,omitempty"`
+	// FunctionID is the function ID string specified in configuration via the SDK.
+	FunctionID   string `json:"function_id,omitempty"`
+	FailedAfter  string `json:"failed_after,omitempty"`
+	FailedBefore string `json:"failed_before,omitempty"`
+}
+
+// BulkRedriveDeadLetters redrives every dead letter matching the given filter
+// which hasn't already been redriven.
+func (a API) BulkRedriveDeadLetters(ctx context.Context, body BulkRedriveBody) ([]deadletter.RedriveResult, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.DeadLetterReadWriter == nil {
+		return nil, publicerr.Errorf(500, "No dead letter reader specified")
+	}
+
+	f := deadletter.Filter{}
+	if body.FunctionID != "" {
+		fn, err := a.findFunction(ctx, body.AppID, body.FunctionID)
+		if err != nil {
+			return nil, err
+		}
+		f.FunctionID = &fn
+	}
+	if body.FailedAfter != "" {
+		if f.FailedAfter, err = dateutil.Parse(body.FailedAfter); err != nil {
+			return nil, publicerr.Wrap(err, 400, "Invalid failed_after")
+		}
+	}
+	if body.FailedBefore != "" {
+		if f.FailedBefore, err = dateutil.Parse(body.FailedBefore); err != nil {
+			return nil, publicerr.Wrap(err, 400, "Invalid failed_before")
+		}
+	}
+
+	res, err := a.redriver().RedriveMany(ctx, auth.WorkspaceID(), f)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to redrive dead letters")
+	}
+	return res, nil
+}
+
+func (a router) bulkRedriveDeadLetters(w http.ResponseWriter, r *http.Request) {
+	body := BulkRedriveBody{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid request body"))
+		return
+	}
+
+	res, err := a.API.BulkRedriveDeadLetters(r.Context(), body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, res)
+}
+
+func (a API) redriver() deadletter.Redriver {
+	return deadletter.Redriver{
+		DeadLetters: a.opts.DeadLetterReadWriter,
+		Functions:   a.opts.FunctionReader,
+		Runs:        a.opts.FunctionRunReader,
+		Executor:    a.opts.Executor,
+	}
+}
+
+// findFunction returns the internal ID of a function given its app and
+// function IDs, as specified via the SDK.
+func (a API) findFunction(ctx context.Context, appID, fnID string) (uuid.UUID, error) {
+	if appID == "" {
+		return uuid.UUID{}, publicerr.Errorf(400, "app_id is required when filtering by function_id")
+	}
+
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return uuid.UUID{}, publicerr.Wrap(err, 401, "No auth found")
+	}
+
+	fn, err := a.opts.FunctionReader.GetFunctionByExternalID(ctx, auth.WorkspaceID(), appID, fnID)
+	if err != nil {
+		return uuid.UUID{}, publicerr.Wrap(err, 404, "function not found")
+	}
+	return fn.ID, nil
+}
@@ -3,6 +3,7 @@ package apiv1
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/dateutil"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
 	"github.com/inngest/inngest/pkg/logger"
 	"github.com/inngest/inngest/pkg/publicerr"
 	"github.com/inngest/inngest/pkg/util"
@@ -160,3 +162,40 @@ func (a router) getEventRuns(w http.ResponseWriter, r *http.Request) {
 	}
 	_ = WriteCachedResponse(w, runs, 5*time.Second)
 }
+
+// CancelScheduledEvent cancels an event sent with a future timestamp which has
+// not yet been published.  The event is identified by the ID it was sent with,
+// or by its internal ID if it was sent without one.
+func (a API) CancelScheduledEvent(ctx context.Context, eventID string) error {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return publicerr.Wrap(err, 401, "No auth found")
+	}
+
+	if a.opts.EventScheduler == nil {
+		return publicerr.Errorf(501, "Scheduled events are not enabled")
+	}
+
+	err = a.opts.EventScheduler.Cancel(ctx, auth.AccountID(), auth.WorkspaceID(), eventID)
+	if errors.Is(err, scheduledevent.ErrNotFound) {
+		return publicerr.Wrap(err, 404, "Scheduled event not found")
+	}
+	if err != nil {
+		return publicerr.Wrap(err, 500, "Unable to cancel scheduled event")
+	}
+	return nil
+}
+
+func (a router) cancelScheduledEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	eventID := chi.URLParam(r, "eventID")
+	if eventID == "" {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(400, "Missing event ID"))
+		return
+	}
+	if err := a.CancelScheduledEvent(ctx, eventID); err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, map[string]any{"ok": true})
+}
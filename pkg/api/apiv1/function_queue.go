@@ -0,0 +1,109 @@
+package apiv1
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/publicerr"
+	"github.com/inngest/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	DefaultFunctionQueueItems = 50
+	MaxFunctionQueueItems     = 1000
+)
+
+// FunctionQueueItem is a pending item in a function's queue.
+type FunctionQueueItem struct {
+	ID      string    `json:"id"`
+	RunID   ulid.ULID `json:"run_id"`
+	Kind    string    `json:"kind"`
+	Attempt int       `json:"attempt"`
+	// Position is the item's position in the function's queue.
+	Position int64 `json:"position"`
+	// At is the time the item is scheduled for.
+	At time.Time `json:"at"`
+	// PriorityFactor is the run's priority factor in seconds, evaluated from
+	// the function's priority expression when the run was scheduled.
+	PriorityFactor int64 `json:"priority_factor"`
+	// EffectiveAt is the time used to order the item after applying the run's
+	// priority factor.
+	EffectiveAt time.Time `json:"effective_at"`
+}
+
+// GetFunctionQueue returns pending items in a function's queue, in the order
+// in which they're processed.
+func (a API) GetFunctionQueue(ctx context.Context, fnID uuid.UUID, limit, offset int64) ([]FunctionQueueItem, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.JobQueueReader == nil {
+		return nil, publicerr.Errorf(500, "No job queue reader specified")
+	}
+
+	if _, err := a.opts.FunctionReader.GetFunctionByInternalUUID(ctx, auth.WorkspaceID(), fnID); err != nil {
+		return nil, publicerr.Wrap(err, 404, "function not found")
+	}
+
+	shard, err := a.opts.QueueShardSelector(ctx, auth.AccountID(), nil)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Internal server error")
+	}
+
+	jobs, err := a.opts.JobQueueReader.FunctionJobs(ctx, shard.Name, auth.WorkspaceID(), fnID, limit, offset)
+	if err != nil {
+		return nil, publicerr.Wrapf(err, 500, "Unable to read function queue: %s", err)
+	}
+
+	items := make([]FunctionQueueItem, 0, len(jobs))
+	for _, j := range jobs {
+		qi, ok := j.Raw.(*queue.QueueItem)
+		if !ok {
+			continue
+		}
+		items = append(items, FunctionQueueItem{
+			ID:             qi.ID,
+			RunID:          qi.Data.Identifier.RunID,
+			Kind:           j.Kind,
+			Attempt:        j.Attempt,
+			Position:       j.Position,
+			At:             j.At,
+			PriorityFactor: qi.Data.GetPriorityFactor() / 1000,
+			EffectiveAt:    time.UnixMilli(j.Score),
+		})
+	}
+	return items, nil
+}
+
+func (a router) getFunctionQueue(w http.ResponseWriter, r *http.Request) {
+	fnID, err := uuid.Parse(chi.URLParam(r, "functionID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid function ID: %s", chi.URLParam(r, "functionID")))
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.FormValue("limit"))
+	if limit == 0 {
+		limit = DefaultFunctionQueueItems
+	}
+	offset, _ := strconv.ParseInt(r.FormValue("offset"), 10, 64)
+
+	items, err := a.API.GetFunctionQueue(
+		r.Context(),
+		fnID,
+		int64(util.Bound(limit, 1, MaxFunctionQueueItems)),
+		max(offset, 0),
+	)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, items)
+}
@@ -3,10 +3,12 @@ package apiv1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/execution/functionpause"
 	"github.com/inngest/inngest/pkg/publicerr"
 )
 
@@ -39,3 +41,97 @@ func (a router) GetAppFunctions(w http.ResponseWriter, r *http.Request) {
 	}
 	_ = json.NewEncoder(w).Encode(fns)
 }
+
+// PauseFunction pauses a function, skipping any new runs until it's unpaused.
+func (a API) PauseFunction(ctx context.Context, appName, fnID string) (*cqrs.Function, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.FunctionPauseWriter == nil {
+		return nil, publicerr.Errorf(500, "No function pause writer specified")
+	}
+
+	id, err := a.findFunction(ctx, appName, fnID)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := a.pauser().Pause(ctx, auth.WorkspaceID(), id)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to pause function")
+	}
+	return fn, nil
+}
+
+func (a router) pauseFunction(w http.ResponseWriter, r *http.Request) {
+	fn, err := a.API.PauseFunction(r.Context(), chi.URLParam(r, "appName"), chi.URLParam(r, "functionID"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, fn)
+}
+
+type UnpauseFunctionBody struct {
+	// Backfill replays every run skipped while the function was paused.
+	Backfill bool `json:"backfill"`
+}
+
+type UnpauseFunctionResponse struct {
+	Function *cqrs.Function `json:"function"`
+	// Backfill is the replay of runs skipped while the function was paused,
+	// if a backfill was requested.
+	Backfill *cqrs.Replay `json:"backfill,omitempty"`
+}
+
+// UnpauseFunction unpauses a function, optionally backfilling the runs skipped
+// while the function was paused.
+func (a API) UnpauseFunction(ctx context.Context, appName, fnID string, body UnpauseFunctionBody) (*UnpauseFunctionResponse, error) {
+	auth, err := a.opts.AuthFinder(ctx)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 401, "No auth found")
+	}
+	if a.opts.FunctionPauseWriter == nil {
+		return nil, publicerr.Errorf(500, "No function pause writer specified")
+	}
+
+	id, err := a.findFunction(ctx, appName, fnID)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, backfill, err := a.pauser().Unpause(ctx, auth.AccountID(), auth.WorkspaceID(), id, body.Backfill)
+	if errors.Is(err, functionpause.ErrBackfillUnavailable) {
+		return nil, publicerr.Wrap(err, 400, "Backfilling skipped runs is not available")
+	}
+	if err != nil {
+		return nil, publicerr.Wrap(err, 500, "Unable to unpause function")
+	}
+	return &UnpauseFunctionResponse{Function: fn, Backfill: backfill}, nil
+}
+
+func (a router) unpauseFunction(w http.ResponseWriter, r *http.Request) {
+	body := UnpauseFunctionBody{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, 400, "Invalid request body"))
+			return
+		}
+	}
+
+	res, err := a.API.UnpauseFunction(r.Context(), chi.URLParam(r, "appName"), chi.URLParam(r, "functionID"), body)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+	_ = WriteResponse(w, res)
+}
+
+func (a API) pauser() functionpause.Pauser {
+	return functionpause.Pauser{
+		Functions: a.opts.FunctionReader,
+		Writer:    a.opts.FunctionPauseWriter,
+		Replays:   a.opts.Replays,
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/oklog/ulid/v2"
+)
+
+// handleDeduped handles the given event, first claiming the event's ID within
+// the workspace's dedup window.  If the event is a duplicate, it's not
+// published and the original event's internal ID is returned along with true.
+func (a API) handleDeduped(ctx context.Context, evt *event.Event, seed *event.SeededID) (string, bool, error) {
+	if a.dedup == nil || evt.ID == "" {
+		id, err := a.handler(ctx, evt, seed)
+		return id, false, err
+	}
+
+	// Decide the internal ID before publishing so that it can be recorded
+	// with the claim, then publish the event using the same ID.
+	internalID := ulid.Make()
+	if seed != nil {
+		if id, err := seed.ToULID(); err == nil {
+			internalID = id
+		}
+	}
+	seed = &event.SeededID{
+		Millis:  int64(internalID.Time()),
+		Entropy: internalID.Entropy(),
+	}
+
+	// There are no workspaces in OSS yet.
+	wsID := consts.DevServerEnvID
+
+	original, dup, err := a.dedup.Claim(ctx, wsID, evt.ID, internalID)
+	if err != nil {
+		return "", false, err
+	}
+	if dup {
+		a.log.Debug("skipping duplicate event", "event", evt.Name, "external_id", evt.ID, "internal_id", original)
+		return original.String(), true, nil
+	}
+
+	id, err := a.handler(ctx, evt, seed)
+	if err != nil {
+		// Release the claim so that the event may be retried.
+		if rerr := a.dedup.Release(ctx, wsID, evt.ID); rerr != nil {
+			return "", false, errors.Join(err, rerr)
+		}
+		return "", false, err
+	}
+	return id, false, nil
+}
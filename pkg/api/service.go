@@ -8,9 +8,16 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/inngest/inngest/pkg/api/apiv1/apiv1auth"
 	"github.com/inngest/inngest/pkg/config"
 	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventdedup"
+	"github.com/inngest/inngest/pkg/eventkey"
+	"github.com/inngest/inngest/pkg/eventschema"
+	"github.com/inngest/inngest/pkg/execution/ratelimit"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
 	"github.com/inngest/inngest/pkg/logger"
 	"github.com/inngest/inngest/pkg/pubsub"
 	"github.com/inngest/inngest/pkg/service"
@@ -47,15 +54,55 @@ type APIServiceOptions struct {
 	RequireKeys bool
 
 	Logger logger.Logger
+
+	// EventValidator, EventSchemaMode, and Quarantine configure event schema
+	// validation at ingest.  See Options for details.
+	EventValidator  eventschema.Validator
+	EventSchemaMode eventschema.Mode
+	Quarantine      cqrs.EventSchemaWriter
+
+	// EventKeys, RateLimiter and EventKeyAuthorizer configure scoped event
+	// keys.  See Options for details.
+	EventKeys          cqrs.EventKeyReadWriter
+	RateLimiter        ratelimit.RateLimiter
+	EventKeyAuthorizer eventkey.Authorizer
+
+	// WebhookSources loads webhook sources received at /w/{id}.
+	WebhookSources cqrs.WebhookSourceReader
+
+	// Deduplicator deduplicates events by ID at ingest.
+	Deduplicator eventdedup.Deduplicator
+
+	// EventScheduler, if set, holds events sent with a future timestamp
+	// until the timestamp is reached instead of publishing them immediately.
+	EventScheduler scheduledevent.Scheduler
+
+	// AuthFinder returns the account and workspace that received events
+	// belong to.  This defaults to the dev server's account and workspace.
+	AuthFinder apiv1auth.AuthFinder
 }
 
 func NewService(opts APIServiceOptions) service.Service {
+	if opts.AuthFinder == nil {
+		opts.AuthFinder = apiv1auth.NilAuthFinder
+	}
+
 	return &apiServer{
 		config:         opts.Config,
 		mounts:         opts.Mounts,
 		localEventKeys: opts.LocalEventKeys,
 		requireKeys:    opts.RequireKeys,
 		log:            opts.Logger,
+		validator:      opts.EventValidator,
+		schemaMode:     opts.EventSchemaMode,
+		quarantine:     opts.Quarantine,
+		eventKeys:      opts.EventKeys,
+		rl:             opts.RateLimiter,
+		keys:           opts.EventKeyAuthorizer,
+		webhooks:       opts.WebhookSources,
+		dedup:          opts.Deduplicator,
+		scheduler:      opts.EventScheduler,
+		authFinder:     opts.AuthFinder,
 	}
 }
 
@@ -77,6 +124,20 @@ type apiServer struct {
 	// ingesting events will not work.
 	requireKeys bool
 	log         logger.Logger
+
+	validator  eventschema.Validator
+	schemaMode eventschema.Mode
+	quarantine cqrs.EventSchemaWriter
+
+	eventKeys cqrs.EventKeyReadWriter
+	rl        ratelimit.RateLimiter
+	keys      eventkey.Authorizer
+
+	webhooks cqrs.WebhookSourceReader
+	dedup    eventdedup.Deduplicator
+
+	scheduler  scheduledevent.Scheduler
+	authFinder apiv1auth.AuthFinder
 }
 
 func (a *apiServer) Name() string {
@@ -87,11 +148,19 @@ func (a *apiServer) Pre(ctx context.Context) error {
 	var err error
 
 	api, err := NewAPI(Options{
-		Config:         a.config,
-		Logger:         a.log,
-		EventHandler:   a.handleEvent,
-		LocalEventKeys: a.localEventKeys,
-		RequireKeys:    a.requireKeys,
+		Config:             a.config,
+		Logger:             a.log,
+		EventHandler:       a.handleEvent,
+		LocalEventKeys:     a.localEventKeys,
+		RequireKeys:        a.requireKeys,
+		EventValidator:     a.validator,
+		EventSchemaMode:    a.schemaMode,
+		Quarantine:         a.quarantine,
+		EventKeys:          a.eventKeys,
+		RateLimiter:        a.rl,
+		EventKeyAuthorizer: a.keys,
+		WebhookSources:     a.webhooks,
+		Deduplicator:       a.dedup,
 	})
 	if err != nil {
 		return err
@@ -140,6 +209,31 @@ func (a *apiServer) handleEvent(
 		seed,
 	)
 
+	if a.scheduler != nil && a.scheduler.ShouldSchedule(trackedEvent.GetEvent()) {
+		l.Info("scheduling event",
+			"event_name", trackedEvent.GetEvent().Name,
+			"internal_id", trackedEvent.GetInternalID().String(),
+			"external_id", trackedEvent.GetEvent().ID,
+			"at", trackedEvent.GetEvent().Time(),
+		)
+
+		auth, err := a.authFinder(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		err = a.scheduler.Schedule(ctx, scheduledevent.Payload{
+			AccountID:   auth.AccountID(),
+			WorkspaceID: trackedEvent.GetWorkspaceID(),
+			InternalID:  trackedEvent.GetInternalID(),
+			Event:       trackedEvent.GetEvent(),
+		})
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return trackedEvent.GetInternalID().String(), err
+	}
+
 	byt, err := json.Marshal(trackedEvent)
 	if err != nil {
 		l.Error("error unmarshalling event as JSON", "error", err)
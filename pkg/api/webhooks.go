@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/coreapi/apiutil"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventschema"
+	"github.com/inngest/inngest/pkg/headers"
+	"github.com/inngest/inngest/pkg/publicerr"
+	itrace "github.com/inngest/inngest/pkg/telemetry/trace"
+	"github.com/inngest/inngest/pkg/webhook"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ReceiveWebhook receives an arbitrary HTTP payload for a webhook source,
+// transforming the request into events using the source's transform.
+func (a API) ReceiveWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	if a.webhooks == nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusNotFound, "Webhook sources are not enabled"))
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusNotFound, "Webhook source not found"))
+		return
+	}
+	src, err := a.webhooks.WebhookSource(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusNotFound, "Webhook source not found"))
+		return
+	}
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusInternalServerError, "Error loading webhook source"))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, int64(consts.AbsoluteMaxEventSize)+1))
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusBadRequest, "Unable to read request body"))
+		return
+	}
+	if len(body) > consts.AbsoluteMaxEventSize {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusRequestEntityTooLarge, "Request body is too large"))
+		return
+	}
+
+	req := webhook.Request{
+		Headers: r.Header,
+		Query:   r.URL.Query(),
+		Body:    body,
+	}
+
+	if src.Signature != nil {
+		if err := webhook.Verify(*src.Signature, req); err != nil {
+			a.log.Warn("rejecting webhook with invalid signature", "source_id", src.ID)
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusUnauthorized, "Invalid webhook signature"))
+			return
+		}
+	}
+
+	transform, err := a.transforms.Transform(*src)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusInternalServerError, "Invalid webhook transform"))
+		return
+	}
+	evts, err := transform.Events(ctx, req)
+	if err != nil {
+		a.log.Warn("error transforming webhook", "source_id", src.ID, "error", err)
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusBadRequest, err.Error()))
+		return
+	}
+	if len(evts) > consts.MaxEvents {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusBadRequest, "Webhook transform returned more than %d events", consts.MaxEvents))
+		return
+	}
+
+	// Create a new trace that may have a link to a previous one
+	ctx = itrace.UserTracer().Propagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	var (
+		ids         = make([]string, 0, len(evts))
+		schemaErrs  = map[int]string{}
+		rejected    int
+		quarantined = map[int]string{}
+		duplicates  []int
+	)
+	for n := range evts {
+		evt := evts[n]
+		ts := time.Now()
+		if err := prepareEvent(ctx, &evt, ts); err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusBadRequest, err.Error()))
+			return
+		}
+
+		if id, err := a.validateSchema(ctx, evt); err != nil {
+			if !errors.As(err, &eventschema.ValidationError{}) {
+				_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusInternalServerError, "Error validating event"))
+				return
+			}
+			schemaErrs[n] = err.Error()
+			if id == "" {
+				rejected++
+			} else {
+				quarantined[n] = id
+			}
+			ids = append(ids, "")
+			continue
+		}
+
+		id, dup, err := a.handleWebhookEvent(ctx, r, &evt, ts, n+1)
+		if err != nil {
+			a.log.Error("error handling webhook event", "error", err, "event", evt.Name, "source_id", src.ID)
+			_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusInternalServerError, "Error handling event"))
+			return
+		}
+		if dup {
+			duplicates = append(duplicates, n)
+		}
+		ids = append(ids, id)
+	}
+
+	resp := apiutil.EventAPIResponse{
+		IDs:        ids,
+		Status:     http.StatusOK,
+		Duplicates: duplicates,
+	}
+	// Match the event API, which rejects requests containing events failing
+	// schema validation.
+	if rejected > 0 {
+		resp.Status = http.StatusBadRequest
+		resp.Error = fmt.Sprintf("%d event(s) failed schema validation", rejected)
+	}
+	if len(schemaErrs) > 0 {
+		resp.Errors = schemaErrs
+	}
+	if len(quarantined) > 0 {
+		resp.Quarantined = quarantined
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.Status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (a API) handleWebhookEvent(ctx context.Context, r *http.Request, evt *event.Event, ts time.Time, index int) (string, bool, error) {
+	ctx, span := itrace.UserTracer().Provider().
+		Tracer(consts.OtelScopeEvent).
+		Start(ctx, consts.OtelSpanEvent,
+			trace.WithTimestamp(ts),
+			trace.WithNewRoot(),
+			trace.WithLinks(trace.LinkFromContext(ctx)),
+		)
+	defer span.End()
+
+	seed := event.SeededIDFromString(r.Header.Get(headers.HeaderEventIDSeed), index)
+	id, dup, err := a.handleDeduped(ctx, evt, seed)
+	if err != nil {
+		return "", false, fmt.Errorf("error handling event: %w", err)
+	}
+	return id, dup, nil
+}
@@ -0,0 +1,96 @@
+// Package archive exports the data held in a cqrs.Manager to newline-delimited
+// JSON, and imports it back into another store.
+//
+// Each line of an archive is a Record holding a single app, function, event,
+// function run, history row or trace run.  Archives are written in dependency order so that
+// an import may be streamed, and may optionally be zstd-compressed.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultPageSize is the number of rows loaded from the store at once when
+// exporting.
+const DefaultPageSize = 500
+
+// zstdMagic prefixes every zstd frame, and is used to detect compressed
+// archives when importing.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// Kind is the kind of row held within a record.
+type Kind string
+
+const (
+	KindApp         Kind = "app"
+	KindFunction    Kind = "function"
+	KindEvent       Kind = "event"
+	KindFunctionRun Kind = "function_run"
+	KindHistory     Kind = "history"
+	KindTraceRun    Kind = "trace_run"
+)
+
+// Kinds lists every kind in the order they're written to an archive.
+var Kinds = []Kind{KindApp, KindFunction, KindEvent, KindFunctionRun, KindHistory, KindTraceRun}
+
+// Record is a single line within an archive.
+type Record struct {
+	Kind Kind            `json:"kind"`
+	Data json.RawMessage `json:"data"`
+}
+
+// TraceRun is the data for a KindTraceRun record, holding the trace run along
+// with its spans.
+type TraceRun struct {
+	cqrs.TraceRun
+	Spans []*cqrs.Span `json:"spans"`
+}
+
+// Counts records the number of rows of each kind processed.
+type Counts map[Kind]int
+
+// Total returns the number of rows across every kind.
+func (c Counts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// newWriter returns a writer which compresses its output if required.  The
+// returned close func must be called to flush the compressed output.
+func newWriter(w io.Writer, compress bool) (io.Writer, func() error, error) {
+	if !compress {
+		return w, func() error { return nil }, nil
+	}
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zw, zw.Close, nil
+}
+
+// newReader returns a reader for the given archive, decompressing it if the
+// archive starts with a zstd frame.
+func newReader(r io.Reader) (io.Reader, func(), error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	if !bytes.Equal(magic, zstdMagic) {
+		return br, func() {}, nil
+	}
+	zr, err := zstd.NewReader(br)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zr, zr.Close, nil
+}
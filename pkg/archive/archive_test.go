@@ -0,0 +1,286 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/api"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution/history"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// failingTraceRuns fails to insert trace runs after their spans are written.
+type failingTraceRuns struct {
+	cqrs.Manager
+}
+
+func (m failingTraceRuns) WithTx(ctx context.Context) (cqrs.TxManager, error) {
+	tx, err := m.Manager.WithTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return failingTraceRunsTx{TxManager: tx}, nil
+}
+
+type failingTraceRunsTx struct {
+	cqrs.TxManager
+}
+
+func (failingTraceRunsTx) InsertTraceRun(ctx context.Context, run *cqrs.TraceRun) error {
+	return errors.New("insert failed")
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := base_cqrs.NewCQRS(db, "sqlite")
+
+	now := time.Now().Truncate(time.Millisecond)
+	appID, fnID := uuid.New(), uuid.New()
+	evtID, runID := ulid.Make(), ulid.Make()
+
+	_, err = mgr.UpsertApp(ctx, cqrs.UpsertAppParams{
+		ID:       appID,
+		Name:     "app",
+		Checksum: "checksum",
+		Url:      "http://localhost:3000/api/inngest",
+		Metadata: "{}",
+	})
+	require.NoError(t, err)
+	_, err = mgr.InsertFunction(ctx, cqrs.InsertFunctionParams{
+		ID:        fnID,
+		AppID:     appID,
+		Name:      "Function",
+		Slug:      "app-function",
+		Config:    `{"id":"function"}`,
+		CreatedAt: now,
+	})
+	require.NoError(t, err)
+	require.NoError(t, mgr.InsertEvent(ctx, cqrs.Event{
+		ID:         evtID,
+		ReceivedAt: now,
+		EventID:    "evt-1",
+		EventName:  "test/event",
+		EventData:  map[string]any{"ok": true},
+		EventTS:    now.UnixMilli(),
+	}))
+	require.NoError(t, mgr.InsertFunctionRun(ctx, cqrs.FunctionRun{
+		RunID:        runID,
+		RunStartedAt: now,
+		FunctionID:   fnID,
+		WorkspaceID:  consts.DevServerEnvID,
+		EventID:      evtID,
+	}))
+	require.NoError(t, mgr.InsertFunctionFinish(ctx, cqrs.FunctionRunFinish{
+		RunID:     runID,
+		Status:    enums.RunStatusCompleted,
+		Output:    json.RawMessage(`{"result":1}`),
+		CreatedAt: now,
+	}))
+	skipReason := enums.SkipReasonFunctionPaused
+	require.NoError(t, mgr.InsertHistory(ctx, history.History{
+		ID:          ulid.Make(),
+		AccountID:   consts.DevServerAccountID,
+		WorkspaceID: consts.DevServerEnvID,
+		CreatedAt:   now,
+		FunctionID:  fnID,
+		RunID:       runID,
+		EventID:     evtID,
+		Type:        enums.HistoryTypeFunctionSkipped.String(),
+		SkipReason:  &skipReason,
+	}))
+	require.NoError(t, mgr.InsertSpan(ctx, &cqrs.Span{
+		Timestamp:  now,
+		TraceID:    "trace",
+		SpanID:     "span",
+		SpanName:   "function",
+		StatusCode: "OK",
+		RunID:      &runID,
+		Events:     []cqrs.SpanEvent{{Timestamp: now, Name: "output"}},
+	}))
+	require.NoError(t, mgr.InsertTraceRun(ctx, &cqrs.TraceRun{
+		AccountID:   consts.DevServerAccountID,
+		WorkspaceID: consts.DevServerEnvID,
+		AppID:       appID,
+		FunctionID:  fnID,
+		TraceID:     "trace",
+		RunID:       runID.String(),
+		QueuedAt:    now,
+		StartedAt:   now,
+		EndedAt:     now,
+		TriggerIDs:  []string{evtID.String()},
+		Status:      enums.RunStatusCompleted,
+	}))
+
+	buf := &bytes.Buffer{}
+	counts, err := Export(ctx, mgr, buf, ExportOpts{Compress: true, PageSize: 1})
+	require.NoError(t, err)
+	require.Equal(t, Counts{
+		KindApp:         1,
+		KindFunction:    1,
+		KindEvent:       1,
+		KindFunctionRun: 1,
+		KindHistory:     1,
+		KindTraceRun:    1,
+	}, counts)
+	require.Equal(t, zstdMagic, buf.Bytes()[:4])
+	archive := buf.Bytes()
+
+	t.Run("existing rows are skipped", func(t *testing.T) {
+		res, err := Import(ctx, mgr, bytes.NewReader(archive), ImportOpts{})
+		require.NoError(t, err)
+		require.Equal(t, Counts{KindApp: 1, KindFunction: 1}, res.Imported)
+		require.Equal(t, Counts{KindEvent: 1, KindFunctionRun: 1, KindHistory: 1, KindTraceRun: 1}, res.Skipped)
+	})
+
+	t.Run("missing rows are imported", func(t *testing.T) {
+		for _, table := range []string{"events", "function_runs", "function_finishes", "history", "trace_runs", "traces"} {
+			_, err := db.ExecContext(ctx, "DELETE FROM "+table)
+			require.NoError(t, err)
+		}
+
+		res, err := Import(ctx, mgr, bytes.NewReader(archive), ImportOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 6, res.Imported.Total())
+		require.Equal(t, 0, res.Skipped.Total())
+
+		evt, err := mgr.GetEventByInternalID(ctx, evtID)
+		require.NoError(t, err)
+		require.Equal(t, "test/event", evt.EventName)
+		require.Equal(t, now.UnixMilli(), evt.ReceivedAt.UnixMilli())
+
+		run, err := mgr.GetFunctionRun(ctx, consts.DevServerAccountID, consts.DevServerEnvID, runID)
+		require.NoError(t, err)
+		require.Equal(t, enums.RunStatusCompleted, run.Status)
+		require.JSONEq(t, `{"result":1}`, string(run.Output))
+
+		spans, err := mgr.GetTraceSpansByRun(ctx, cqrs.TraceRunIdentifier{TraceID: "trace", RunID: runID})
+		require.NoError(t, err)
+		require.Len(t, spans, 1)
+		require.Len(t, spans[0].Events, 1)
+
+		var skipped string
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT skip_reason FROM history WHERE run_id = ?", runID).Scan(&skipped))
+		require.Equal(t, enums.SkipReasonFunctionPaused.String(), skipped)
+	})
+
+	t.Run("failed trace runs are retried without duplicating spans", func(t *testing.T) {
+		for _, table := range []string{"trace_runs", "traces"} {
+			_, err := db.ExecContext(ctx, "DELETE FROM "+table)
+			require.NoError(t, err)
+		}
+
+		_, err := Import(ctx, failingTraceRuns{Manager: mgr}, bytes.NewReader(archive), ImportOpts{})
+		require.ErrorContains(t, err, "insert failed")
+
+		var n int
+		require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM traces").Scan(&n))
+		require.Equal(t, 0, n)
+
+		res, err := Import(ctx, mgr, bytes.NewReader(archive), ImportOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Imported[KindTraceRun])
+
+		spans, err := mgr.GetTraceSpansByRun(ctx, cqrs.TraceRunIdentifier{TraceID: "trace", RunID: runID})
+		require.NoError(t, err)
+		require.Len(t, spans, 1)
+	})
+
+	t.Run("missing finishes are written", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, "DELETE FROM function_finishes")
+		require.NoError(t, err)
+
+		res, err := Import(ctx, mgr, bytes.NewReader(archive), ImportOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Imported[KindFunctionRun])
+
+		run, err := mgr.GetFunctionRun(ctx, consts.DevServerAccountID, consts.DevServerEnvID, runID)
+		require.NoError(t, err)
+		require.Equal(t, enums.RunStatusCompleted, run.Status)
+	})
+
+	t.Run("uncompressed archives are imported", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_, err := Export(ctx, mgr, buf, ExportOpts{})
+		require.NoError(t, err)
+		require.Equal(t, byte('{'), buf.Bytes()[0])
+
+		res, err := Import(ctx, mgr, buf, ImportOpts{})
+		require.NoError(t, err)
+		require.Equal(t, 4, res.Skipped.Total())
+	})
+
+	t.Run("events are retriggered", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, "DELETE FROM events")
+		require.NoError(t, err)
+
+		var sent []cqrs.Event
+		_, err = Import(ctx, mgr, bytes.NewReader(archive), ImportOpts{
+			Retrigger: func(ctx context.Context, evt cqrs.Event) error {
+				sent = append(sent, evt)
+				return nil
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, sent, 1)
+		require.Equal(t, evtID, sent[0].ID)
+
+		_, err = mgr.GetEventByInternalID(ctx, evtID)
+		require.Error(t, err)
+	})
+
+	t.Run("retriggered events keep their internal ID", func(t *testing.T) {
+		received := 0
+		router, err := api.NewAPI(api.Options{
+			Logger:         logger.StdlibLogger(ctx),
+			LocalEventKeys: []string{"key"},
+			EventHandler: func(ctx context.Context, evt *event.Event, seed *event.SeededID) (string, error) {
+				received++
+				tracked := event.NewOSSTrackedEvent(*evt, seed)
+				err := mgr.InsertEvent(ctx, cqrs.Event{
+					ID:          tracked.GetInternalID(),
+					AccountID:   consts.DevServerAccountID,
+					WorkspaceID: consts.DevServerEnvID,
+					ReceivedAt:  time.Now(),
+					EventID:     evt.ID,
+					EventName:   evt.Name,
+					EventData:   evt.Data,
+				})
+				return tracked.GetInternalID().String(), err
+			},
+		})
+		require.NoError(t, err)
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+
+		opts := ImportOpts{Retrigger: HTTPRetrigger(srv.Client(), srv.URL+"/e/key")}
+		res, err := Import(ctx, mgr, bytes.NewReader(archive), opts)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Imported[KindEvent])
+		require.Equal(t, 1, received)
+
+		_, err = mgr.GetEventByInternalID(ctx, evtID)
+		require.NoError(t, err)
+
+		// Importing again must not re-send the event.
+		res, err = Import(ctx, mgr, bytes.NewReader(archive), opts)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.Skipped[KindEvent])
+		require.Equal(t, 1, received)
+	})
+}
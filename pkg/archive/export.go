@@ -0,0 +1,197 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+)
+
+type ExportOpts struct {
+	// Compress zstd-compresses the archive.
+	Compress bool
+	// PageSize is the number of rows loaded from the store at once,
+	// defaulting to DefaultPageSize.
+	PageSize int
+}
+
+// Export streams every app, function, event, function run, history row and
+// trace run in the store to w, returning the number of records written of each kind.
+func Export(ctx context.Context, m cqrs.Manager, w io.Writer, opts ExportOpts) (Counts, error) {
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+
+	out, closer, err := newWriter(w, opts.Compress)
+	if err != nil {
+		return nil, fmt.Errorf("error creating archive writer: %w", err)
+	}
+
+	e := &exporter{
+		m:      m,
+		enc:    json.NewEncoder(out),
+		page:   opts.PageSize,
+		counts: Counts{},
+	}
+	if err := e.export(ctx); err != nil {
+		_ = closer()
+		return e.counts, err
+	}
+	if err := closer(); err != nil {
+		return e.counts, fmt.Errorf("error flushing archive: %w", err)
+	}
+	return e.counts, nil
+}
+
+type exporter struct {
+	m      cqrs.Manager
+	enc    *json.Encoder
+	page   int
+	counts Counts
+}
+
+func (e *exporter) export(ctx context.Context) error {
+	for _, f := range []func(context.Context) error{
+		e.apps,
+		e.functions,
+		e.events,
+		e.functionRuns,
+		e.history,
+		e.traceRuns,
+	} {
+		if err := f(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) write(kind Kind, v any) error {
+	byt, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error marshalling %s: %w", kind, err)
+	}
+	if err := e.enc.Encode(Record{Kind: kind, Data: byt}); err != nil {
+		return fmt.Errorf("error writing %s: %w", kind, err)
+	}
+	e.counts[kind]++
+	return nil
+}
+
+func (e *exporter) apps(ctx context.Context) error {
+	// There are no workspaces in OSS yet.
+	apps, err := e.m.GetAllApps(ctx, consts.DevServerEnvID)
+	if err != nil {
+		return fmt.Errorf("error loading apps: %w", err)
+	}
+	for _, app := range apps {
+		if err := e.write(KindApp, app); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) functions(ctx context.Context) error {
+	fns, err := e.m.GetFunctions(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading functions: %w", err)
+	}
+	for _, fn := range fns {
+		if err := e.write(KindFunction, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) events(ctx context.Context) error {
+	after := ulid.ULID{}
+	for {
+		evts, err := e.m.ArchiveEvents(ctx, after, e.page)
+		if err != nil {
+			return fmt.Errorf("error loading events: %w", err)
+		}
+		for _, evt := range evts {
+			if err := e.write(KindEvent, evt); err != nil {
+				return err
+			}
+			after = evt.ID
+		}
+		if len(evts) < e.page {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) functionRuns(ctx context.Context) error {
+	after := ulid.ULID{}
+	for {
+		runs, err := e.m.ArchiveFunctionRuns(ctx, after, e.page)
+		if err != nil {
+			return fmt.Errorf("error loading function runs: %w", err)
+		}
+		for _, run := range runs {
+			if err := e.write(KindFunctionRun, run); err != nil {
+				return err
+			}
+			after = run.RunID
+		}
+		if len(runs) < e.page {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) history(ctx context.Context) error {
+	after := ulid.ULID{}
+	for {
+		rows, err := e.m.ArchiveHistory(ctx, after, e.page)
+		if err != nil {
+			return fmt.Errorf("error loading history: %w", err)
+		}
+		for _, row := range rows {
+			if err := e.write(KindHistory, row); err != nil {
+				return err
+			}
+			after = row.ID
+		}
+		if len(rows) < e.page {
+			return nil
+		}
+	}
+}
+
+func (e *exporter) traceRuns(ctx context.Context) error {
+	after := ulid.ULID{}
+	for {
+		runs, err := e.m.ArchiveTraceRuns(ctx, after, e.page)
+		if err != nil {
+			return fmt.Errorf("error loading trace runs: %w", err)
+		}
+		for _, run := range runs {
+			runID, err := ulid.Parse(run.RunID)
+			if err != nil {
+				return fmt.Errorf("invalid trace run id %q: %w", run.RunID, err)
+			}
+			spans, err := e.m.GetTraceSpansByRun(ctx, cqrs.TraceRunIdentifier{
+				TraceID: run.TraceID,
+				RunID:   runID,
+			})
+			if err != nil {
+				return fmt.Errorf("error loading spans for run %s: %w", runID, err)
+			}
+			if err := e.write(KindTraceRun, TraceRun{TraceRun: *run, Spans: spans}); err != nil {
+				return err
+			}
+			after = runID
+		}
+		if len(runs) < e.page {
+			return nil
+		}
+	}
+}
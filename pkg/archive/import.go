@@ -0,0 +1,238 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+)
+
+// RetriggerFunc re-sends an imported event so that it triggers functions.
+type RetriggerFunc func(ctx context.Context, evt cqrs.Event) error
+
+type ImportOpts struct {
+	// Retrigger, if set, is called with each event that doesn't yet exist in
+	// the store instead of inserting the event directly.  The function is
+	// expected to send the event using its original internal ID.
+	Retrigger RetriggerFunc
+}
+
+// ImportResult records the number of rows imported and skipped for each kind.
+type ImportResult struct {
+	Imported Counts
+	// Skipped counts rows which already existed in the store.
+	Skipped Counts
+}
+
+// Import loads an archive written by Export into the store.  Imports are
+// idempotent:  apps and functions are upserted, and events, runs and history
+// that already exist are skipped.  Finished runs missing their finish, eg.
+// because a previous import failed part way through, have it written.
+func Import(ctx context.Context, m cqrs.Manager, r io.Reader, opts ImportOpts) (ImportResult, error) {
+	res := ImportResult{Imported: Counts{}, Skipped: Counts{}}
+
+	in, closer, err := newReader(r)
+	if err != nil {
+		return res, fmt.Errorf("error reading archive: %w", err)
+	}
+	defer closer()
+
+	i := &importer{m: m, opts: opts}
+	dec := json.NewDecoder(in)
+	for line := 1; ; line++ {
+		rec := Record{}
+		if err := dec.Decode(&rec); err == io.EOF {
+			return res, nil
+		} else if err != nil {
+			return res, fmt.Errorf("error reading record %d: %w", line, err)
+		}
+
+		imported, err := i.load(ctx, rec)
+		if err != nil {
+			return res, fmt.Errorf("error importing record %d: %w", line, err)
+		}
+		if imported {
+			res.Imported[rec.Kind]++
+		} else {
+			res.Skipped[rec.Kind]++
+		}
+	}
+}
+
+type importer struct {
+	m    cqrs.Manager
+	opts ImportOpts
+}
+
+// load imports a single record, returning false if the record was skipped.
+func (i *importer) load(ctx context.Context, rec Record) (bool, error) {
+	switch rec.Kind {
+	case KindApp:
+		app := cqrs.App{}
+		if err := json.Unmarshal(rec.Data, &app); err != nil {
+			return false, err
+		}
+		return true, i.app(ctx, app)
+	case KindFunction:
+		fn := cqrs.Function{}
+		if err := json.Unmarshal(rec.Data, &fn); err != nil {
+			return false, err
+		}
+		return true, i.function(ctx, fn)
+	case KindEvent:
+		evt := cqrs.Event{}
+		if err := json.Unmarshal(rec.Data, &evt); err != nil {
+			return false, err
+		}
+		return i.event(ctx, evt)
+	case KindFunctionRun:
+		run := cqrs.FunctionRun{}
+		if err := json.Unmarshal(rec.Data, &run); err != nil {
+			return false, err
+		}
+		return i.functionRun(ctx, run)
+	case KindHistory:
+		h := cqrs.HistoryRow{}
+		if err := json.Unmarshal(rec.Data, &h); err != nil {
+			return false, err
+		}
+		return i.m.InsertHistoryRow(ctx, h)
+	case KindTraceRun:
+		run := TraceRun{}
+		if err := json.Unmarshal(rec.Data, &run); err != nil {
+			return false, err
+		}
+		return i.traceRun(ctx, run)
+	default:
+		return false, fmt.Errorf("unknown record kind: %q", rec.Kind)
+	}
+}
+
+func (i *importer) app(ctx context.Context, app cqrs.App) error {
+	metadata, err := json.Marshal(app.Metadata)
+	if err != nil {
+		return err
+	}
+	_, err = i.m.UpsertApp(ctx, cqrs.UpsertAppParams{
+		ID:          app.ID,
+		Name:        app.Name,
+		SdkLanguage: app.SdkLanguage,
+		SdkVersion:  app.SdkVersion,
+		Framework:   app.Framework,
+		Metadata:    string(metadata),
+		Status:      app.Status,
+		Error:       app.Error,
+		Checksum:    app.Checksum,
+		Url:         app.Url,
+		Method:      app.Method,
+		AppVersion:  app.AppVersion,
+	})
+	return err
+}
+
+func (i *importer) function(ctx context.Context, fn cqrs.Function) error {
+	_, err := i.m.GetFunctionByInternalUUID(ctx, consts.DevServerEnvID, fn.ID)
+	switch {
+	case err == nil:
+		_, err = i.m.UpdateFunctionConfig(ctx, cqrs.UpdateFunctionConfigParams{
+			ID:     fn.ID,
+			Config: string(fn.Config),
+		})
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = i.m.InsertFunction(ctx, cqrs.InsertFunctionParams{
+			ID:        fn.ID,
+			AppID:     fn.AppID,
+			Name:      fn.Name,
+			Slug:      fn.Slug,
+			Config:    string(fn.Config),
+			CreatedAt: fn.CreatedAt,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = i.m.UpdateFunctionPausedAt(ctx, cqrs.UpdateFunctionPausedAtParams{
+		ID:       fn.ID,
+		PausedAt: fn.PausedAt,
+	})
+	return err
+}
+
+func (i *importer) event(ctx context.Context, evt cqrs.Event) (bool, error) {
+	if exists, err := found(i.m.GetEventByInternalID(ctx, evt.ID)); err != nil || exists {
+		return false, err
+	}
+	if i.opts.Retrigger != nil {
+		return true, i.opts.Retrigger(ctx, evt)
+	}
+	return true, i.m.InsertEvent(ctx, evt)
+}
+
+func (i *importer) functionRun(ctx context.Context, run cqrs.FunctionRun) (bool, error) {
+	existing, err := i.m.GetFunctionRun(ctx, consts.DevServerAccountID, run.WorkspaceID, run.RunID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+	if existing == nil {
+		if err := i.m.InsertFunctionRun(ctx, run); err != nil {
+			return false, err
+		}
+	}
+
+	// Runs and their finishes are separate rows, so an import which failed
+	// between writing the two is completed by writing the missing finish.
+	if run.EndedAt == nil || (existing != nil && existing.EndedAt != nil) {
+		return existing == nil, nil
+	}
+	return true, i.m.InsertFunctionFinish(ctx, cqrs.FunctionRunFinish{
+		RunID:     run.RunID,
+		Status:    run.Status,
+		Output:    run.Output,
+		CreatedAt: *run.EndedAt,
+	})
+}
+
+func (i *importer) traceRun(ctx context.Context, run TraceRun) (bool, error) {
+	runID, err := ulid.Parse(run.RunID)
+	if err != nil {
+		return false, fmt.Errorf("invalid trace run id %q: %w", run.RunID, err)
+	}
+	if exists, err := found(i.m.GetTraceRun(ctx, cqrs.TraceRunIdentifier{RunID: runID})); err != nil || exists {
+		return false, err
+	}
+
+	// Spans aren't keyed, so write them in the same transaction as the run:
+	// an import which fails part way through writes neither, and the retry
+	// doesn't duplicate spans.
+	tx, err := i.m.WithTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, span := range run.Spans {
+		if err := tx.InsertSpan(ctx, span); err != nil {
+			_ = tx.Rollback(ctx)
+			return false, err
+		}
+	}
+	if err := tx.InsertTraceRun(ctx, &run.TraceRun); err != nil {
+		_ = tx.Rollback(ctx)
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// found returns whether a lookup found a row, treating sql.ErrNoRows as a
+// missing row rather than an error.
+func found[T any](_ T, err error) (bool, error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,63 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/headers"
+	"github.com/oklog/ulid/v2"
+)
+
+// HTTPRetrigger returns a RetriggerFunc which sends each event to the given
+// event API URL, eg. "http://localhost:8288/e/<key>".  Each event is sent in
+// its own request with an event ID seed which makes the server store the event
+// using its original internal ID, so importing again skips retriggered events.
+func HTTPRetrigger(c *http.Client, url string) RetriggerFunc {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return func(ctx context.Context, evt cqrs.Event) error {
+		byt, err := json.Marshal(evt.Event())
+		if err != nil {
+			return fmt.Errorf("error marshalling event: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(byt))
+		if err != nil {
+			return err
+		}
+		req.Header.Set(headers.HeaderContentType, "application/json")
+		req.Header.Set(headers.HeaderEventIDSeed, idSeed(evt.ID))
+
+		resp, err := c.Do(req)
+		if err != nil {
+			return fmt.Errorf("error sending event: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode > 299 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return fmt.Errorf("error sending event: %d: %s", resp.StatusCode, body)
+		}
+		return nil
+	}
+}
+
+// idSeed returns the event ID seed header value which makes the event API
+// store a single event using the given ID.  The API adds each event's 1-based
+// index within the request to the seed's entropy, so the entropy is
+// decremented by one to offset the index of the only event sent.
+func idSeed(id ulid.ULID) string {
+	entropy := id.Entropy()
+	binary.BigEndian.PutUint32(
+		entropy[6:10],
+		binary.BigEndian.Uint32(entropy[6:10])-1,
+	)
+	return fmt.Sprintf("%d,%s", id.Time(), base64.StdEncoding.EncodeToString(entropy))
+}
@@ -81,6 +81,11 @@ type connectionHandler struct {
 
 	lastHeartbeatLock       sync.Mutex
 	lastHeartbeatReceivedAt time.Time
+
+	// inflight tracks requests forwarded to the worker which weren't replied to yet.
+	// Drained workers are only released once all in-flight requests finished.
+	inflightLock sync.Mutex
+	inflight     map[string]struct{}
 }
 
 func (c *connectionHandler) setLastHeartbeat(time time.Time) {
@@ -95,6 +100,29 @@ func (c *connectionHandler) getLastHeartbeat() time.Time {
 	return c.lastHeartbeatReceivedAt
 }
 
+func (c *connectionHandler) addInflight(requestID string) {
+	c.inflightLock.Lock()
+	defer c.inflightLock.Unlock()
+	c.inflight[requestID] = struct{}{}
+}
+
+func (c *connectionHandler) removeInflight(requestID string) {
+	c.inflightLock.Lock()
+	defer c.inflightLock.Unlock()
+	delete(c.inflight, requestID)
+}
+
+func (c *connectionHandler) inflightRequests() []string {
+	c.inflightLock.Lock()
+	defer c.inflightLock.Unlock()
+
+	requestIDs := make([]string, 0, len(c.inflight))
+	for requestID := range c.inflight {
+		requestIDs = append(requestIDs, requestID)
+	}
+	return requestIDs
+}
+
 var ErrDraining = connecterrors.SocketError{
 	SysCode:    syscode.CodeConnectGatewayClosing,
 	StatusCode: websocket.StatusGoingAway,
@@ -105,6 +133,28 @@ func (c *connectGatewaySvc) closeDraining(ws *websocket.Conn) {
 	c.closeWithConnectError(ws, &ErrDraining)
 }
 
+var ErrWorkerDrained = connecterrors.SocketError{
+	SysCode:    syscode.CodeConnectWorkerDrained,
+	StatusCode: websocket.StatusNormalClosure,
+	Msg:        "Worker was drained",
+}
+
+// isRoutingDrained returns whether operators drained the connection, either directly
+// or by draining all of its worker groups.
+func isRoutingDrained(states *state.RoutingStates, connID ulid.ULID, groups map[string]*state.WorkerGroup) bool {
+	if len(groups) == 0 {
+		return false
+	}
+
+	for hash := range groups {
+		if states.For(connID.String(), hash).Status != state.RoutingStatusDraining {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (c *connectGatewaySvc) Handler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// This context is canceled when the gateway is shutting down. There's no other deadline.
@@ -157,6 +207,7 @@ func (c *connectGatewaySvc) Handler() http.Handler {
 			ws:         ws,
 			updateLock: sync.Mutex{},
 			remoteAddr: remoteAddr,
+			inflight:   map[string]struct{}{},
 		}
 
 		closeReason := connectpb.WorkerDisconnectReason_UNEXPECTED.String()
@@ -647,7 +698,14 @@ func (c *connectionHandler) handleIncomingWebSocketMessage(ctx context.Context,
 			return &ErrDraining
 		}
 
-		err := c.updateConnStatus(connectpb.ConnectionStatus_READY)
+		drained := c.isRoutingDrained(ctx)
+
+		status := connectpb.ConnectionStatus_READY
+		if drained {
+			status = connectpb.ConnectionStatus_DRAINING
+		}
+
+		err := c.updateConnStatus(status)
 		if err != nil {
 			// TODO Should we actually close the connection here?
 			return &connecterrors.SocketError{
@@ -671,6 +729,12 @@ func (c *connectionHandler) handleIncomingWebSocketMessage(ctx context.Context,
 
 		c.setLastHeartbeat(time.Now())
 
+		// Release drained workers once all in-flight requests finished
+		if drained && !c.hasLeasedRequests(ctx) {
+			c.log.Debug("worker was drained, releasing connection")
+			return &ErrWorkerDrained
+		}
+
 		return nil
 	case connectpb.GatewayMessageType_WORKER_PAUSE:
 		if c.svc.isDraining {
@@ -894,6 +958,9 @@ func (c *connectionHandler) receiveRouterMessages(ctx context.Context, onSubscri
 			return
 		}
 
+		// Track the request until the worker replies, such that draining waits for it to finish
+		c.addInflight(data.RequestId)
+
 		// Forward message to SDK!
 		err = wsproto.Write(ctx, c.ws, &connectpb.ConnectMessage{
 			Kind:    connectpb.GatewayMessageType_GATEWAY_EXECUTOR_REQUEST,
@@ -1089,6 +1156,16 @@ func (c *connectionHandler) establishConnection(ctx context.Context) (*state.Con
 		}
 	}
 
+	// Reject workers running drained versions, as they would be released immediately
+	{
+		states, err := c.svc.stateManager.GetRoutingStates(ctx, authResp.EnvID)
+		if err != nil {
+			log.Error("could not get routing states", "err", err)
+		} else if isRoutingDrained(states, connectionId, workerGroups) {
+			return nil, &ErrWorkerDrained
+		}
+	}
+
 	conn := state.Connection{
 		AccountID:    authResp.AccountID,
 		EnvID:        authResp.EnvID,
@@ -1150,6 +1227,8 @@ func (c *connectionHandler) handleSdkReply(ctx context.Context, msg *connectpb.C
 		return fmt.Errorf("could not save response: %w", err)
 	}
 
+	c.removeInflight(data.RequestId)
+
 	// Send a best-effort PubSub message to fast-track the response,
 	// this is unreliable and must be combined with a reliable store like the buffer above.
 	err = c.svc.receiver.NotifyExecutor(ctx, data)
@@ -1174,6 +1253,38 @@ func (c *connectionHandler) handleSdkReply(ctx context.Context, msg *connectpb.C
 	return nil
 }
 
+// isRoutingDrained returns whether operators drained the connection or its worker groups.
+func (c *connectionHandler) isRoutingDrained(ctx context.Context) bool {
+	states, err := c.svc.stateManager.GetRoutingStates(ctx, c.conn.EnvID)
+	if err != nil {
+		c.log.Error("could not get routing states", "err", err)
+		return false
+	}
+
+	return isRoutingDrained(states, c.conn.ConnectionId, c.conn.Groups)
+}
+
+// hasLeasedRequests returns whether any in-flight request is still leased.  Requests
+// without a lease are no longer in flight, eg. as the lease expired after the worker
+// stopped extending it.
+func (c *connectionHandler) hasLeasedRequests(ctx context.Context) bool {
+	for _, requestID := range c.inflightRequests() {
+		leased, err := c.svc.stateManager.IsRequestLeased(ctx, c.conn.EnvID, requestID)
+		if err != nil {
+			c.log.Error("could not check request lease", "err", err, "req_id", requestID)
+			return true
+		}
+
+		if leased {
+			return true
+		}
+
+		c.removeInflight(requestID)
+	}
+
+	return false
+}
+
 func (c *connectionHandler) updateConnStatus(status connectpb.ConnectionStatus) error {
 	c.updateLock.Lock()
 	defer c.updateLock.Unlock()
@@ -490,6 +490,80 @@ func TestDrainingWithForceDisconnect(t *testing.T) {
 	})
 }
 
+func TestWorkerDrainingWaitsForInflightRequests(t *testing.T) {
+	params := testingParameters{
+		consecutiveMissesBeforeClose: 10,
+		heartbeatInterval:            1 * time.Second,
+	}
+	res := createTestingGateway(t, params)
+
+	handshake(t, res)
+
+	requestID := "test-req"
+
+	leaseID, err := res.svc.stateManager.LeaseRequest(context.Background(), res.envID, requestID, time.Second*5)
+	require.NoError(t, err)
+
+	// Publish message to "PubSub"
+	_ = res.testConn.RouteExecutorRequest(context.Background(), res.svc.gatewayId, res.connID, &connect.GatewayExecutorRequestData{
+		RequestId:    requestID,
+		AccountId:    res.accountID.String(),
+		EnvId:        res.envID.String(),
+		AppId:        res.appID.String(),
+		AppName:      res.appName,
+		FunctionId:   res.fnID.String(),
+		FunctionSlug: res.fnSlug,
+		RunId:        res.runID.String(),
+		LeaseId:      leaseID.String(),
+	})
+
+	msg := awaitNextMessage(t, res.ws, 2*time.Second)
+	require.Equal(t, connect.GatewayMessageType_GATEWAY_EXECUTOR_REQUEST, msg.Kind)
+
+	err = res.stateManager.SetWorkerGroupRoutingState(context.Background(), res.envID, res.workerGroup.Hash, state.RoutingState{Status: state.RoutingStatusDraining})
+	require.NoError(t, err)
+
+	// The worker is not released while the request is in flight
+	exchangeHeartbeat(t, res.ws, 2*time.Second)
+
+	conn, err := res.stateManager.GetConnection(context.Background(), res.envID, res.connID)
+	require.NoError(t, err)
+	require.NotNil(t, conn)
+	require.Equal(t, connect.ConnectionStatus_DRAINING, conn.Status)
+
+	// The executor removes the lease once the request finished
+	err = res.stateManager.DeleteLease(context.Background(), res.envID, requestID)
+	require.NoError(t, err)
+
+	// The worker is released on the next heartbeat
+	sendWorkerHeartbeatMessage(t, res.ws)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	for {
+		parsed := connect.ConnectMessage{}
+		err := wsproto.Read(ctx, res.ws, &parsed)
+		if err == nil {
+			// The heartbeat may be acked before the connection is closed
+			require.Equal(t, connect.GatewayMessageType_GATEWAY_HEARTBEAT, parsed.Kind)
+			continue
+		}
+
+		cerr := websocket.CloseError{}
+		require.ErrorAs(t, err, &cerr)
+		require.Equal(t, ErrWorkerDrained.StatusCode, cerr.Code)
+		require.Equal(t, ErrWorkerDrained.SysCode, cerr.Reason)
+		break
+	}
+
+	require.EventuallyWithT(t, func(t *assert.CollectT) {
+		conn, err = res.stateManager.GetConnection(context.Background(), res.envID, res.connID)
+		assert.NoError(t, err)
+		assert.Nil(t, conn)
+	}, 2*time.Second, 100*time.Millisecond)
+}
+
 func TestRejectSetupWhileDraining(t *testing.T) {
 	t.Skip("this test should work but doesn't as we always receive EOF errors")
 
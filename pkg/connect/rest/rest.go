@@ -19,3 +19,11 @@ type WorkerGroup struct {
 type ShowWorkerGroupReply struct {
 	Data *WorkerGroup `json:"data"`
 }
+
+type ShowRoutingReply struct {
+	Data *state.RoutingStates `json:"data"`
+}
+
+type RoutingStateReply struct {
+	Data state.RoutingState `json:"data"`
+}
@@ -0,0 +1,169 @@
+package connectv0
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/connect/rest"
+	"github.com/inngest/inngest/pkg/connect/state"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/publicerr"
+	"github.com/oklog/ulid/v2"
+	"net/http"
+)
+
+// showRouting retrieves the routing states of all worker groups and connections
+// which are draining, cordoned, or canary.
+func (cr *connectApiRouter) showRouting(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	envID, ok := cr.envID(w, r)
+	if !ok {
+		return
+	}
+
+	states, err := cr.RoutingManager.GetRoutingStates(ctx, envID)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(
+			err,
+			http.StatusInternalServerError,
+			err.Error(),
+		))
+		return
+	}
+
+	resp, err := json.Marshal(rest.ShowRoutingReply{Data: states})
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusInternalServerError, "error serializing response"))
+		return
+	}
+
+	_, _ = w.Write(resp)
+}
+
+// setWorkerGroupRouting marks a worker group as active, draining, cordoned, or canary.
+func (cr *connectApiRouter) setWorkerGroupRouting(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	envID, ok := cr.envID(w, r)
+	if !ok {
+		return
+	}
+
+	groupID := chi.URLParam(r, "groupID")
+	if groupID == "" {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusBadRequest, "missing groupID"))
+		return
+	}
+
+	routing, ok := readRoutingState(w, r)
+	if !ok {
+		return
+	}
+
+	err := cr.RoutingManager.SetWorkerGroupRoutingState(ctx, envID, groupID, routing)
+	cr.writeRoutingState(w, routing, err, map[string]any{
+		"envID":   envID,
+		"groupID": groupID,
+	})
+}
+
+// setConnectionRouting marks a single connection as active, draining, cordoned, or canary.
+// This takes precedence over the state of the connection's worker groups.
+func (cr *connectApiRouter) setConnectionRouting(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	envID, ok := cr.envID(w, r)
+	if !ok {
+		return
+	}
+
+	param := chi.URLParam(r, "connID")
+	connID, err := ulid.Parse(param)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Error{
+			Err:     err,
+			Message: "invalid connection ID",
+			Data: map[string]any{
+				"connID": param,
+			},
+			Status: http.StatusBadRequest,
+		})
+		return
+	}
+
+	routing, ok := readRoutingState(w, r)
+	if !ok {
+		return
+	}
+
+	err = cr.RoutingManager.SetConnectionRoutingState(ctx, envID, connID, routing)
+	cr.writeRoutingState(w, routing, err, map[string]any{
+		"envID":  envID,
+		"connID": connID,
+	})
+}
+
+func (cr *connectApiRouter) envID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	if cr.Dev {
+		return consts.DevServerEnvID, true
+	}
+
+	// Expect UUID
+	param := chi.URLParam(r, "envID")
+	id, err := uuid.Parse(param)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Error{
+			Err:     err,
+			Message: "invalid environment ID",
+			Data: map[string]any{
+				"envID": param,
+			},
+			Status: http.StatusBadRequest,
+		})
+		return uuid.UUID{}, false
+	}
+
+	return id, true
+}
+
+func readRoutingState(w http.ResponseWriter, r *http.Request) (state.RoutingState, bool) {
+	var routing state.RoutingState
+	if err := json.NewDecoder(r.Body).Decode(&routing); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusBadRequest, "invalid routing state"))
+		return routing, false
+	}
+
+	if err := routing.Validate(); err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrap(err, http.StatusBadRequest, err.Error()))
+		return routing, false
+	}
+
+	return routing, true
+}
+
+func (cr *connectApiRouter) writeRoutingState(w http.ResponseWriter, routing state.RoutingState, err error, data map[string]any) {
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, state.ErrWorkerGroupNotFound) || errors.Is(err, state.ErrConnectionNotFound) {
+			status = http.StatusNotFound
+		}
+
+		_ = publicerr.WriteHTTP(w, publicerr.Error{
+			Err:     err,
+			Message: err.Error(),
+			Data:    data,
+			Status:  status,
+		})
+		return
+	}
+
+	resp, err := json.Marshal(rest.RoutingStateReply{Data: routing})
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Errorf(http.StatusInternalServerError, "error serializing response"))
+		return
+	}
+
+	_, _ = w.Write(resp)
+}
@@ -18,6 +18,7 @@ type Opts struct {
 	GroupManager               state.WorkerGroupManager
 	ConnectResponseNotifier    pubsub.ResponseNotifier
 	ConnectRequestStateManager state.RequestStateManager
+	RoutingManager             state.RoutingStateManager
 
 	Signer                  auth.SessionTokenSigner
 	RequestAuther           RequestAuther
@@ -86,6 +87,10 @@ func (cr *connectApiRouter) setup() {
 
 			r.Get("/envs/{envID}/conns", cr.showConnections)
 			r.Get("/envs/{envID}/groups/{groupID}", cr.showWorkerGroup)
+
+			r.Get("/envs/{envID}/routing", cr.showRouting)
+			r.Put("/envs/{envID}/groups/{groupID}/routing", cr.setWorkerGroupRouting)
+			r.Put("/envs/{envID}/conns/{connID}/routing", cr.setConnectionRouting)
 		})
 	}
 
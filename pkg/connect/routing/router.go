@@ -106,8 +106,9 @@ func GetRoute(ctx context.Context, stateMgr state.StateManager, rnd *util.FrandR
 }
 
 type connWithGroup struct {
-	conn  *connectpb.ConnMetadata
-	group *state.WorkerGroup
+	conn    *connectpb.ConnMetadata
+	group   *state.WorkerGroup
+	routing state.RoutingState
 }
 
 func getSuitableConnection(ctx context.Context, rnd *util.FrandRNG, stateMgr state.StateManager, envID uuid.UUID, appID uuid.UUID, fnSlug string, log logger.Logger) (*connectpb.ConnMetadata, error) {
@@ -120,13 +121,27 @@ func getSuitableConnection(ctx context.Context, rnd *util.FrandRNG, stateMgr sta
 		return nil, ErrNoHealthyConnection
 	}
 
+	// Routing states are set by operators to drain, cordon, or canary workers. If these
+	// cannot be loaded, route as usual rather than failing the request.
+	routingStates, err := stateMgr.GetRoutingStates(ctx, envID)
+	if err != nil {
+		log.Error("could not get routing states", "err", err)
+	}
+
 	healthy := make([]connWithGroup, 0, len(conns))
 	for _, conn := range conns {
 		res := isHealthy(ctx, stateMgr, envID, appID, fnSlug, conn, log)
 		if res.isHealthy {
+			routing := routingStates.For(conn.Id, res.workerGroup.Hash)
+			if !routing.Routable() {
+				log.Debug("connection is not routable", "conn_id", conn.Id, "routing_status", routing.Status)
+				continue
+			}
+
 			healthy = append(healthy, connWithGroup{
-				conn:  conn,
-				group: res.workerGroup,
+				conn:    conn,
+				group:   res.workerGroup,
+				routing: routing,
 			})
 			continue
 		}
@@ -144,11 +159,45 @@ func getSuitableConnection(ctx context.Context, rnd *util.FrandRNG, stateMgr sta
 		return nil, ErrNoHealthyConnection
 	}
 
-	if len(healthy) == 1 {
-		return healthy[0].conn, nil
+	candidates := selectCanaryCandidates(healthy, rnd)
+
+	if len(candidates) == 1 {
+		return candidates[0].conn, nil
 	}
 
-	return pickConnection(healthy, rnd)
+	return pickConnection(candidates, rnd)
+}
+
+// selectCanaryCandidates splits candidates into canary and active connections, returning
+// the canary connections for the highest canary percentage of requests and the active
+// connections otherwise. If either set is empty, all candidates are returned.
+func selectCanaryCandidates(candidates []connWithGroup, rnd *util.FrandRNG) []connWithGroup {
+	var (
+		canary  = make([]connWithGroup, 0, len(candidates))
+		active  = make([]connWithGroup, 0, len(candidates))
+		percent int
+	)
+	for _, c := range candidates {
+		if c.routing.Status != state.RoutingStatusCanary {
+			active = append(active, c)
+			continue
+		}
+
+		canary = append(canary, c)
+		if c.routing.CanaryPercent > percent {
+			percent = c.routing.CanaryPercent
+		}
+	}
+
+	if len(canary) == 0 || len(active) == 0 {
+		return candidates
+	}
+
+	if rnd.Float64()*100 < float64(percent) {
+		return canary
+	}
+
+	return active
 }
 
 func cleanupUnhealthyGateway(stateManager state.StateManager, conn *connectpb.ConnMetadata, log logger.Logger) {
@@ -180,6 +229,11 @@ func cleanupUnhealthyConnection(stateManager state.StateManager, envID uuid.UUID
 }
 
 func getConnectionWeight(timeRange float64, oldestVersion time.Time, h connWithGroup) float64 {
+	// Draining and cordoned connections must never receive new requests
+	if !h.routing.Routable() {
+		return 0
+	}
+
 	weight := 1.0
 
 	// Calculate weights based on factors like version
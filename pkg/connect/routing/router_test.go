@@ -358,6 +358,94 @@ func TestFullConnectRouting(t *testing.T) {
 		require.NotEqual(t, setupNewVersion.connIds[0].String(), conn.Id)
 		require.Equal(t, setupOldVersion.connIds[0].String(), conn.Id)
 	})
+
+	t.Run("draining and cordoned connections should be filtered out", func(t *testing.T) {
+		stateMan, cleanup := setupRedis(t)
+		defer cleanup()
+
+		setupRes := setup(t, stateMan, setupOpts{},
+			newTestConn(connectpb.ConnectionStatus_READY, time.Now()),
+			newTestConn(connectpb.ConnectionStatus_READY, time.Now()),
+			newTestConn(connectpb.ConnectionStatus_READY, time.Now()),
+		)
+
+		err := stateMan.SetConnectionRoutingState(context.Background(), setupRes.envId, setupRes.connIds[0], state.RoutingState{Status: state.RoutingStatusDraining})
+		require.NoError(t, err)
+
+		err = stateMan.SetConnectionRoutingState(context.Background(), setupRes.envId, setupRes.connIds[1], state.RoutingState{Status: state.RoutingStatusCordoned})
+		require.NoError(t, err)
+
+		for range 20 {
+			conn, err := getSuitableConnection(context.Background(), rnd, stateMan, setupRes.envId, setupRes.appId, setupRes.fnSlug, log)
+			require.NoError(t, err)
+			require.Equal(t, setupRes.connIds[2].String(), conn.Id)
+		}
+
+		err = stateMan.SetConnectionRoutingState(context.Background(), setupRes.envId, setupRes.connIds[2], state.RoutingState{Status: state.RoutingStatusDraining})
+		require.NoError(t, err)
+
+		_, err = getSuitableConnection(context.Background(), rnd, stateMan, setupRes.envId, setupRes.appId, setupRes.fnSlug, log)
+		require.ErrorIs(t, err, ErrNoHealthyConnection)
+	})
+
+	t.Run("draining worker groups should be filtered out", func(t *testing.T) {
+		stateMan, cleanup := setupRedis(t)
+		defer cleanup()
+
+		setupRes := setup(t, stateMan, setupOpts{},
+			newTestConn(connectpb.ConnectionStatus_READY, time.Now()),
+			newTestConn(connectpb.ConnectionStatus_READY, time.Now()),
+		)
+
+		conn, err := stateMan.GetConnection(context.Background(), setupRes.envId, setupRes.connIds[0])
+		require.NoError(t, err)
+		groupHash := conn.SyncedWorkerGroups[setupRes.appId.String()]
+
+		err = stateMan.SetWorkerGroupRoutingState(context.Background(), setupRes.envId, groupHash, state.RoutingState{Status: state.RoutingStatusDraining})
+		require.NoError(t, err)
+
+		_, err = getSuitableConnection(context.Background(), rnd, stateMan, setupRes.envId, setupRes.appId, setupRes.fnSlug, log)
+		require.ErrorIs(t, err, ErrNoHealthyConnection)
+
+		err = stateMan.SetWorkerGroupRoutingState(context.Background(), setupRes.envId, groupHash, state.RoutingState{Status: state.RoutingStatusActive})
+		require.NoError(t, err)
+
+		_, err = getSuitableConnection(context.Background(), rnd, stateMan, setupRes.envId, setupRes.appId, setupRes.fnSlug, log)
+		require.NoError(t, err)
+	})
+
+	t.Run("canary connections should receive their percentage of requests", func(t *testing.T) {
+		stateMan, cleanup := setupRedis(t)
+		defer cleanup()
+
+		setupRes := setup(t, stateMan, setupOpts{},
+			newTestConn(connectpb.ConnectionStatus_READY, time.Now()),
+			newTestConn(connectpb.ConnectionStatus_READY, time.Now()),
+		)
+
+		canaryId := setupRes.connIds[0]
+		err := stateMan.SetConnectionRoutingState(context.Background(), setupRes.envId, canaryId, state.RoutingState{Status: state.RoutingStatusCanary, CanaryPercent: 100})
+		require.NoError(t, err)
+
+		for range 20 {
+			conn, err := getSuitableConnection(context.Background(), rnd, stateMan, setupRes.envId, setupRes.appId, setupRes.fnSlug, log)
+			require.NoError(t, err)
+			require.Equal(t, canaryId.String(), conn.Id)
+		}
+
+		err = stateMan.SetConnectionRoutingState(context.Background(), setupRes.envId, canaryId, state.RoutingState{Status: state.RoutingStatusCanary, CanaryPercent: 20})
+		require.NoError(t, err)
+
+		var canary int
+		for range 1000 {
+			conn, err := getSuitableConnection(context.Background(), rnd, stateMan, setupRes.envId, setupRes.appId, setupRes.fnSlug, log)
+			require.NoError(t, err)
+			if conn.Id == canaryId.String() {
+				canary++
+			}
+		}
+		require.InDelta(t, 200, canary, 60)
+	})
 }
 
 func TestIsHealthy(t *testing.T) {
@@ -529,4 +617,16 @@ func TestGetConnectionWeight(t *testing.T) {
 			require.Greater(t, newConnWeight, oldConnWeight)
 		}
 	})
+
+	t.Run("draining and cordoned connections should not be weighted", func(t *testing.T) {
+		t1 := time.Date(2025, 02, 25, 0, 0, 0, 0, time.Local)
+		conns := createVersion("v", t1, 3)
+		conns[0].routing = state.RoutingState{Status: state.RoutingStatusDraining}
+		conns[1].routing = state.RoutingState{Status: state.RoutingStatusCordoned}
+		conns[2].routing = state.RoutingState{Status: state.RoutingStatusCanary, CanaryPercent: 10}
+
+		require.Zero(t, getConnectionWeight(0, t1, conns[0]))
+		require.Zero(t, getConnectionWeight(0, t1, conns[1]))
+		require.NotZero(t, getConnectionWeight(0, t1, conns[2]))
+	})
 }
@@ -557,6 +557,7 @@ func (r *redisConnectionStateManager) DeleteConnection(ctx context.Context, envI
 	keysDefs := []string{
 		"local indexConnectionsByEnvIdKey = KEYS[1]",
 		"local indexWorkerGroupsByEnvIdKey = KEYS[2]",
+		"local routingStatesByEnvIdKey = KEYS[3]",
 	}
 	keys := []string{
 		// Upsert conn
@@ -564,6 +565,9 @@ func (r *redisConnectionStateManager) DeleteConnection(ctx context.Context, envI
 
 		// Upsert worker groups
 		r.workerGroupHash(envID),
+
+		// Remove routing state
+		r.routingHash(envID),
 	}
 
 	argDefs := []string{
@@ -620,6 +624,9 @@ redis.call("SREM", %s, connID)`, indexVarName, indexVarName))
 -- Remove the connection from the map
 redis.call("HDEL", indexConnectionsByEnvIdKey, connID)
 
+-- Remove the connection's routing state, if any
+redis.call("HDEL", routingStatesByEnvIdKey, "conn:" .. connID)
+
 %s
 
 %s
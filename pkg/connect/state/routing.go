@@ -0,0 +1,195 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+)
+
+var (
+	ErrConnectionNotFound  = fmt.Errorf("connection not found")
+	ErrInvalidRoutingState = fmt.Errorf("invalid routing state")
+)
+
+// RoutingStatus controls whether the router sends new requests to a worker group
+// or connection.
+type RoutingStatus string
+
+const (
+	// RoutingStatusActive routes requests as usual.
+	RoutingStatusActive RoutingStatus = "active"
+	// RoutingStatusDraining stops routing new requests.  Once all in-flight requests
+	// finish, the gateway releases drained workers by closing their connections.
+	RoutingStatusDraining RoutingStatus = "draining"
+	// RoutingStatusCordoned stops routing new requests while keeping workers connected,
+	// eg. to debug a worker without it receiving traffic.
+	RoutingStatusCordoned RoutingStatus = "cordoned"
+	// RoutingStatusCanary routes CanaryPercent of requests to canary workers, with
+	// the remaining requests routed to active workers.
+	RoutingStatusCanary RoutingStatus = "canary"
+)
+
+// RoutingState is the operator-controlled routing state of a worker group or connection.
+type RoutingState struct {
+	Status RoutingStatus `json:"status"`
+	// CanaryPercent is the percentage of requests routed to canary workers, from 1 to 100.
+	CanaryPercent int `json:"canary_percent,omitempty"`
+	// UpdatedAt records the time the state was last changed.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+func (s RoutingState) Validate() error {
+	switch s.Status {
+	case RoutingStatusActive, RoutingStatusDraining, RoutingStatusCordoned:
+		if s.CanaryPercent != 0 {
+			return fmt.Errorf("%w: canary_percent is only supported with the canary status", ErrInvalidRoutingState)
+		}
+	case RoutingStatusCanary:
+		if s.CanaryPercent < 1 || s.CanaryPercent > 100 {
+			return fmt.Errorf("%w: canary_percent must be between 1 and 100", ErrInvalidRoutingState)
+		}
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidRoutingState, s.Status)
+	}
+	return nil
+}
+
+// Routable returns whether new requests may be routed to workers in this state.
+func (s RoutingState) Routable() bool {
+	return s.Status != RoutingStatusDraining && s.Status != RoutingStatusCordoned
+}
+
+// RoutingStates holds the non-active routing states of an environment's worker
+// groups and connections.
+type RoutingStates struct {
+	// Groups maps worker group hashes to their routing state.
+	Groups map[string]RoutingState `json:"groups"`
+	// Conns maps connection IDs to their routing state.
+	Conns map[string]RoutingState `json:"conns"`
+}
+
+// For returns the routing state of a connection for the given worker group.  A
+// connection's own state takes precedence over the state of its worker group.
+func (r *RoutingStates) For(connID string, groupHash string) RoutingState {
+	if r != nil {
+		if s, ok := r.Conns[connID]; ok {
+			return s
+		}
+		if s, ok := r.Groups[groupHash]; ok {
+			return s
+		}
+	}
+	return RoutingState{Status: RoutingStatusActive}
+}
+
+type RoutingStateManager interface {
+	// SetWorkerGroupRoutingState sets the routing state of a worker group.  Group
+	// states outlive the group's connections, such that workers reconnecting with
+	// the same version retain their state until it's set back to active.
+	SetWorkerGroupRoutingState(ctx context.Context, envID uuid.UUID, groupHash string, s RoutingState) error
+	// SetConnectionRoutingState sets the routing state of a connection.  This is
+	// removed once the connection is deleted.
+	SetConnectionRoutingState(ctx context.Context, envID uuid.UUID, connID ulid.ULID, s RoutingState) error
+	// GetRoutingStates returns the routing states of all worker groups and
+	// connections in an environment.
+	GetRoutingStates(ctx context.Context, envID uuid.UUID) (*RoutingStates, error)
+}
+
+const (
+	routingGroupPrefix = "group:"
+	routingConnPrefix  = "conn:"
+)
+
+// routingHash points to the hash storing routing states by environment.  Fields are
+// prefixed with "group:" or "conn:".
+func (r *redisConnectionStateManager) routingHash(envID uuid.UUID) string {
+	return fmt.Sprintf("{%s}:routing", envID)
+}
+
+func (r *redisConnectionStateManager) SetWorkerGroupRoutingState(ctx context.Context, envID uuid.UUID, groupHash string, s RoutingState) error {
+	exists, err := r.client.Do(ctx, r.client.B().Hexists().Key(r.workerGroupHash(envID)).Field(groupHash).Build()).AsBool()
+	if err != nil {
+		return fmt.Errorf("error retrieving worker group: %w", err)
+	}
+	if !exists {
+		return ErrWorkerGroupNotFound
+	}
+
+	return r.setRoutingState(ctx, envID, routingGroupPrefix+groupHash, s)
+}
+
+func (r *redisConnectionStateManager) SetConnectionRoutingState(ctx context.Context, envID uuid.UUID, connID ulid.ULID, s RoutingState) error {
+	exists, err := r.client.Do(ctx, r.client.B().Hexists().Key(r.connectionHash(envID)).Field(connID.String()).Build()).AsBool()
+	if err != nil {
+		return fmt.Errorf("error retrieving connection: %w", err)
+	}
+	if !exists {
+		return ErrConnectionNotFound
+	}
+
+	return r.setRoutingState(ctx, envID, routingConnPrefix+connID.String(), s)
+}
+
+func (r *redisConnectionStateManager) setRoutingState(ctx context.Context, envID uuid.UUID, field string, s RoutingState) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	key := r.routingHash(envID)
+
+	// Active is the default state, so there's no need to store it.
+	if s.Status == RoutingStatusActive {
+		if err := r.client.Do(ctx, r.client.B().Hdel().Key(key).Field(field).Build()).Error(); err != nil {
+			return fmt.Errorf("error removing routing state: %w", err)
+		}
+		return nil
+	}
+
+	if s.UpdatedAt.IsZero() {
+		s.UpdatedAt = r.c.Now()
+	}
+
+	byt, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing routing state: %w", err)
+	}
+
+	if err := r.client.Do(ctx, r.client.B().Hset().Key(key).FieldValue().FieldValue(field, string(byt)).Build()).Error(); err != nil {
+		return fmt.Errorf("error setting routing state: %w", err)
+	}
+
+	return nil
+}
+
+func (r *redisConnectionStateManager) GetRoutingStates(ctx context.Context, envID uuid.UUID) (*RoutingStates, error) {
+	all, err := r.client.Do(ctx, r.client.B().Hgetall().Key(r.routingHash(envID)).Build()).AsStrMap()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("error retrieving routing states: %w", err)
+	}
+
+	states := &RoutingStates{
+		Groups: map[string]RoutingState{},
+		Conns:  map[string]RoutingState{},
+	}
+	for field, val := range all {
+		var s RoutingState
+		if err := json.Unmarshal([]byte(val), &s); err != nil {
+			return nil, fmt.Errorf("error deserializing routing state: %w", err)
+		}
+
+		switch {
+		case strings.HasPrefix(field, routingGroupPrefix):
+			states.Groups[strings.TrimPrefix(field, routingGroupPrefix)] = s
+		case strings.HasPrefix(field, routingConnPrefix):
+			states.Conns[strings.TrimPrefix(field, routingConnPrefix)] = s
+		}
+	}
+
+	return states, nil
+}
@@ -0,0 +1,127 @@
+package state
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/proto/gen/connect/v1"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingStates(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	connManager := NewRedisConnectionStateManager(rc)
+
+	accountId, envId := uuid.New(), uuid.New()
+	connId := ulid.MustNew(ulid.Now(), rand.Reader)
+	group := &WorkerGroup{
+		AccountID:     accountId,
+		EnvID:         envId,
+		AppName:       "app-1",
+		SDKLang:       "go",
+		SDKVersion:    "v-test",
+		FunctionSlugs: []string{"fn-1"},
+		Hash:          "app-1-hash",
+	}
+
+	err = connManager.UpsertConnection(ctx, &Connection{
+		AccountID:    accountId,
+		EnvID:        envId,
+		ConnectionId: connId,
+		Data: &connect.WorkerConnectRequestData{
+			InstanceId: "my-worker",
+		},
+		Groups: map[string]*WorkerGroup{
+			group.Hash: group,
+		},
+		GatewayId: ulid.MustNew(ulid.Now(), rand.Reader),
+	}, connect.ConnectionStatus_READY, time.Now())
+	require.NoError(t, err)
+
+	t.Run("defaults to active", func(t *testing.T) {
+		states, err := connManager.GetRoutingStates(ctx, envId)
+		require.NoError(t, err)
+		require.Empty(t, states.Groups)
+		require.Empty(t, states.Conns)
+		require.Equal(t, RoutingStatusActive, states.For(connId.String(), group.Hash).Status)
+	})
+
+	t.Run("connection states take precedence over group states", func(t *testing.T) {
+		err := connManager.SetWorkerGroupRoutingState(ctx, envId, group.Hash, RoutingState{Status: RoutingStatusCanary, CanaryPercent: 10})
+		require.NoError(t, err)
+
+		states, err := connManager.GetRoutingStates(ctx, envId)
+		require.NoError(t, err)
+		require.Equal(t, RoutingStatusCanary, states.For(connId.String(), group.Hash).Status)
+		require.Equal(t, 10, states.For(connId.String(), group.Hash).CanaryPercent)
+		require.False(t, states.Groups[group.Hash].UpdatedAt.IsZero())
+
+		err = connManager.SetConnectionRoutingState(ctx, envId, connId, RoutingState{Status: RoutingStatusCordoned})
+		require.NoError(t, err)
+
+		states, err = connManager.GetRoutingStates(ctx, envId)
+		require.NoError(t, err)
+		require.Equal(t, RoutingStatusCordoned, states.For(connId.String(), group.Hash).Status)
+		require.False(t, states.For(connId.String(), group.Hash).Routable())
+	})
+
+	t.Run("setting active removes the state", func(t *testing.T) {
+		err := connManager.SetWorkerGroupRoutingState(ctx, envId, group.Hash, RoutingState{Status: RoutingStatusActive})
+		require.NoError(t, err)
+
+		states, err := connManager.GetRoutingStates(ctx, envId)
+		require.NoError(t, err)
+		require.Empty(t, states.Groups)
+		require.Len(t, states.Conns, 1)
+	})
+
+	t.Run("invalid states are rejected", func(t *testing.T) {
+		for _, s := range []RoutingState{
+			{Status: "paused"},
+			{Status: RoutingStatusCanary},
+			{Status: RoutingStatusCanary, CanaryPercent: 101},
+			{Status: RoutingStatusDraining, CanaryPercent: 10},
+		} {
+			err := connManager.SetWorkerGroupRoutingState(ctx, envId, group.Hash, s)
+			require.ErrorIs(t, err, ErrInvalidRoutingState)
+		}
+	})
+
+	t.Run("unknown groups and connections are rejected", func(t *testing.T) {
+		err := connManager.SetWorkerGroupRoutingState(ctx, envId, "missing", RoutingState{Status: RoutingStatusDraining})
+		require.ErrorIs(t, err, ErrWorkerGroupNotFound)
+
+		err = connManager.SetConnectionRoutingState(ctx, envId, ulid.MustNew(ulid.Now(), rand.Reader), RoutingState{Status: RoutingStatusDraining})
+		require.ErrorIs(t, err, ErrConnectionNotFound)
+	})
+
+	t.Run("deleting a connection removes its state", func(t *testing.T) {
+		err := connManager.SetWorkerGroupRoutingState(ctx, envId, group.Hash, RoutingState{Status: RoutingStatusDraining})
+		require.NoError(t, err)
+
+		err = connManager.DeleteConnection(ctx, envId, connId)
+		require.NoError(t, err)
+
+		states, err := connManager.GetRoutingStates(ctx, envId)
+		require.NoError(t, err)
+		require.Empty(t, states.Conns)
+
+		// Group states are retained for workers reconnecting with the same version.
+		require.Equal(t, RoutingStatusDraining, states.Groups[group.Hash].Status)
+	})
+}
@@ -35,6 +35,7 @@ type StateManager interface {
 	WorkerGroupManager
 	GatewayManager
 	RequestStateManager
+	RoutingStateManager
 }
 
 type ConnectionManager interface {
@@ -62,6 +62,9 @@ const (
 	// lets users delay functions for up to MaxDebouncePeriod when events are received.
 	MaxDebouncePeriod = time.Hour * 24 * 7
 
+	// MaxCronJitter is the maximum jitter that can be used to spread a cron's ticks.
+	MaxCronJitter = time.Hour
+
 	// MaxCancellations represents the max automatic cancellation signals per function
 	MaxCancellations = 5
 
@@ -34,6 +34,8 @@ const (
 	OtelSysDebounceID      = "sys.debounce.id"
 	OtelSysDebounceTimeout = "sys.debounce.timeout"
 
+	OtelSysSingletonReplacedByRunID = "sys.singleton.replaced_by.run.id"
+
 	OtelSysFunctionID         = "sys.function.id"
 	OtelSysFunctionSlug       = "sys.function.slug"
 	OtelSysFunctionVersion    = "sys.function.version"
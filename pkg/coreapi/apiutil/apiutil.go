@@ -20,6 +20,17 @@ type EventAPIResponse struct {
 	IDs    []string `json:"ids"`
 	Status int      `json:"status"`
 	Error  string   `json:"error,omitempty"`
+	// Errors contains per-event errors keyed by the event's index in the
+	// request, eg. for events failing schema validation.
+	Errors map[int]string `json:"errors,omitempty"`
+	// Duplicates contains the indexes of events which were sent previously
+	// within the dedup window.  The IDs of duplicate events are the internal
+	// IDs of the originally published events.
+	Duplicates []int `json:"duplicates,omitempty"`
+	// Quarantined contains the IDs of quarantined events keyed by the event's
+	// index in the request.  Quarantined events aren't published, so their
+	// index in IDs is empty.
+	Quarantined map[int]string `json:"quarantined,omitempty"`
 }
 
 // InvokeAPIResponse is the API response sent when responding to an invoke
@@ -22,6 +22,7 @@ import (
 	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/execution"
 	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/replay"
 	"github.com/inngest/inngest/pkg/execution/runner"
 	"github.com/inngest/inngest/pkg/execution/state"
 	"github.com/inngest/inngest/pkg/headers"
@@ -43,6 +44,8 @@ type Options struct {
 	EventHandler  api.EventHandler
 	Executor      execution.Executor
 	HistoryReader history_reader.Reader
+	// Replays creates and manages bulk replays of historical runs.
+	Replays *replay.Manager
 
 	// LocalSigningKey is the key used to sign events for self-hosted services.
 	LocalSigningKey string
@@ -94,9 +97,11 @@ func NewCoreApi(o Options) (*CoreAPI, error) {
 		Queue:           o.Queue,
 		EventHandler:    o.EventHandler,
 		Executor:        o.Executor,
+		Replays:         o.Replays,
 		ServerKind:      o.Config.GetServerKind(),
 		LocalSigningKey: o.LocalSigningKey,
 		RequireKeys:     o.RequireKeys,
+		ConnectRouting:  o.ConnectOpts.RoutingManager,
 	}}))
 
 	// TODO - Add option for enabling GraphQL Playground
@@ -51,6 +51,7 @@ func MakeFunction(f *cqrs.Function) (*Function, error) {
 		Concurrency:   concurrency,
 		Triggers:      triggers,
 		URL:           fn.Steps[0].URI,
+		PausedAt:      f.PausedAt,
 	}, nil
 }
 
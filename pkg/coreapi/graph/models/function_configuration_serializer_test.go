@@ -244,6 +244,22 @@ func TestToFunctionConfiguration(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "singleton cancel",
+			fn: mergeWithDefaultFunction(&inngest.Function{
+				Singleton: &inngest.Singleton{
+					Mode: enums.SingletonModeCancel,
+					Key:  util.StrPtr("event.data.user_id"),
+				},
+			}),
+			planConcurrencyLimit: UnknownPlanConcurrencyLimit,
+			expected: mergeWithDefaultFunctionConfiguration(&FunctionConfiguration{
+				Singleton: &SingletonConfiguration{
+					Mode: SingletonModeCancel,
+					Key:  util.StrPtr("event.data.user_id"),
+				},
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/history_reader"
 	ulid "github.com/oklog/ulid/v2"
 )
@@ -49,6 +50,17 @@ type ConcurrencyLimitConfiguration struct {
 	IsPlanLimit *bool `json:"isPlanLimit,omitempty"`
 }
 
+type ConnectV1RoutingState struct {
+	Status        ConnectV1RoutingStatus `json:"status"`
+	CanaryPercent *int                   `json:"canaryPercent,omitempty"`
+	UpdatedAt     *time.Time             `json:"updatedAt,omitempty"`
+}
+
+type ConnectV1RoutingStateInput struct {
+	Status        ConnectV1RoutingStatus `json:"status"`
+	CanaryPercent *int                   `json:"canaryPercent,omitempty"`
+}
+
 type ConnectV1WorkerConnection struct {
 	ID               ulid.ULID                 `json:"id"`
 	GatewayID        ulid.ULID                 `json:"gatewayId"`
@@ -97,6 +109,27 @@ type CreateAppInput struct {
 	URL string `json:"url"`
 }
 
+type CreateEventKeyInput struct {
+	Name          string                  `json:"name"`
+	AllowedEvents []string                `json:"allowedEvents,omitempty"`
+	RateLimit     *cqrs.EventKeyRateLimit `json:"rateLimit,omitempty"`
+	ExpiresAt     *time.Time              `json:"expiresAt,omitempty"`
+}
+
+type CreateReplayInput struct {
+	FunctionSlug string                  `json:"functionSlug"`
+	Name         *string                 `json:"name,omitempty"`
+	FromTime     time.Time               `json:"fromTime"`
+	ToTime       time.Time               `json:"toTime"`
+	Statuses     []enums.ReplayRunStatus `json:"statuses"`
+	Rate         *int                    `json:"rate,omitempty"`
+}
+
+type CreatedEventKey struct {
+	EventKey *cqrs.EventKey `json:"eventKey"`
+	Key      string         `json:"key"`
+}
+
 type DebounceConfiguration struct {
 	Period string  `json:"period"`
 	Key    *string `json:"key,omitempty"`
@@ -135,6 +168,20 @@ type EventsQuery struct {
 	LastEventID *string `json:"lastEventId,omitempty"`
 }
 
+type ExpressionError struct {
+	Message string `json:"message"`
+	Line    *int   `json:"line,omitempty"`
+	Column  *int   `json:"column,omitempty"`
+}
+
+type ExpressionEvaluation struct {
+	Result            *string          `json:"result,omitempty"`
+	Type              *string          `json:"type,omitempty"`
+	Residual          *string          `json:"residual,omitempty"`
+	UnknownAttributes []string         `json:"unknownAttributes"`
+	Error             *ExpressionError `json:"error,omitempty"`
+}
+
 type Function struct {
 	ID            string                 `json:"id"`
 	Name          string                 `json:"name"`
@@ -146,6 +193,7 @@ type Function struct {
 	URL           string                 `json:"url"`
 	AppID         string                 `json:"appID"`
 	App           *cqrs.App              `json:"app"`
+	PausedAt      *time.Time             `json:"pausedAt,omitempty"`
 }
 
 type FunctionConfiguration struct {
@@ -431,6 +479,22 @@ type WaitForSignalStepInfo struct {
 
 func (WaitForSignalStepInfo) IsStepInfo() {}
 
+type WebhookSignatureInput struct {
+	Secret    string  `json:"secret"`
+	Header    string  `json:"header"`
+	Algorithm *string `json:"algorithm,omitempty"`
+	Encoding  *string `json:"encoding,omitempty"`
+	Prefix    *string `json:"prefix,omitempty"`
+	Scheme    *string `json:"scheme,omitempty"`
+	Tolerance *int    `json:"tolerance,omitempty"`
+}
+
+type WebhookSourceInput struct {
+	Name      string                 `json:"name"`
+	Transform string                 `json:"transform"`
+	Signature *WebhookSignatureInput `json:"signature,omitempty"`
+}
+
 type Workspace struct {
 	ID string `json:"id"`
 }
@@ -566,6 +630,51 @@ func (e ConnectV1ConnectionStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ConnectV1RoutingStatus string
+
+const (
+	ConnectV1RoutingStatusActive   ConnectV1RoutingStatus = "ACTIVE"
+	ConnectV1RoutingStatusDraining ConnectV1RoutingStatus = "DRAINING"
+	ConnectV1RoutingStatusCordoned ConnectV1RoutingStatus = "CORDONED"
+	ConnectV1RoutingStatusCanary   ConnectV1RoutingStatus = "CANARY"
+)
+
+var AllConnectV1RoutingStatus = []ConnectV1RoutingStatus{
+	ConnectV1RoutingStatusActive,
+	ConnectV1RoutingStatusDraining,
+	ConnectV1RoutingStatusCordoned,
+	ConnectV1RoutingStatusCanary,
+}
+
+func (e ConnectV1RoutingStatus) IsValid() bool {
+	switch e {
+	case ConnectV1RoutingStatusActive, ConnectV1RoutingStatusDraining, ConnectV1RoutingStatusCordoned, ConnectV1RoutingStatusCanary:
+		return true
+	}
+	return false
+}
+
+func (e ConnectV1RoutingStatus) String() string {
+	return string(e)
+}
+
+func (e *ConnectV1RoutingStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ConnectV1RoutingStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ConnectV1RoutingStatus", str)
+	}
+	return nil
+}
+
+func (e ConnectV1RoutingStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ConnectV1WorkerConnectionsOrderByDirection string
 
 const (
@@ -699,6 +808,49 @@ func (e EventStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type ExpressionUsage string
+
+const (
+	ExpressionUsageDefault      ExpressionUsage = "DEFAULT"
+	ExpressionUsageCancel       ExpressionUsage = "CANCEL"
+	ExpressionUsageWaitForEvent ExpressionUsage = "WAIT_FOR_EVENT"
+)
+
+var AllExpressionUsage = []ExpressionUsage{
+	ExpressionUsageDefault,
+	ExpressionUsageCancel,
+	ExpressionUsageWaitForEvent,
+}
+
+func (e ExpressionUsage) IsValid() bool {
+	switch e {
+	case ExpressionUsageDefault, ExpressionUsageCancel, ExpressionUsageWaitForEvent:
+		return true
+	}
+	return false
+}
+
+func (e ExpressionUsage) String() string {
+	return string(e)
+}
+
+func (e *ExpressionUsage) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExpressionUsage(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ExpressionUsage", str)
+	}
+	return nil
+}
+
+func (e ExpressionUsage) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type FunctionEventType string
 
 const (
@@ -3,12 +3,14 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"github.com/inngest/inngest/pkg/connect/state"
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/coreapi/graph/models"
 	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/enums"
 	connpb "github.com/inngest/inngest/proto/gen/connect/v1"
 	"github.com/oklog/ulid/v2"
+	"strings"
 	"time"
 )
 
@@ -255,3 +257,43 @@ func toWorkerConnectionsQueryOpt(
 		Items:  uint(items),
 	}
 }
+
+func (r *mutationResolver) SetConnectWorkerGroupRoutingState(ctx context.Context, groupHash string, input models.ConnectV1RoutingStateInput) (*models.ConnectV1RoutingState, error) {
+	routing := toRoutingState(input)
+	if err := r.ConnectRouting.SetWorkerGroupRoutingState(ctx, consts.DevServerEnvID, groupHash, routing); err != nil {
+		return nil, fmt.Errorf("error setting worker group routing state: %w", err)
+	}
+
+	return toRoutingStateModel(routing), nil
+}
+
+func (r *mutationResolver) SetConnectWorkerConnectionRoutingState(ctx context.Context, connectionID ulid.ULID, input models.ConnectV1RoutingStateInput) (*models.ConnectV1RoutingState, error) {
+	routing := toRoutingState(input)
+	if err := r.ConnectRouting.SetConnectionRoutingState(ctx, consts.DevServerEnvID, connectionID, routing); err != nil {
+		return nil, fmt.Errorf("error setting connection routing state: %w", err)
+	}
+
+	return toRoutingStateModel(routing), nil
+}
+
+func toRoutingState(input models.ConnectV1RoutingStateInput) state.RoutingState {
+	routing := state.RoutingState{
+		Status:    state.RoutingStatus(strings.ToLower(input.Status.String())),
+		UpdatedAt: time.Now(),
+	}
+	if input.CanaryPercent != nil {
+		routing.CanaryPercent = *input.CanaryPercent
+	}
+	return routing
+}
+
+func toRoutingStateModel(routing state.RoutingState) *models.ConnectV1RoutingState {
+	m := &models.ConnectV1RoutingState{
+		Status:    models.ConnectV1RoutingStatus(strings.ToUpper(string(routing.Status))),
+		UpdatedAt: &routing.UpdatedAt,
+	}
+	if routing.CanaryPercent != 0 {
+		m.CanaryPercent = &routing.CanaryPercent
+	}
+	return m
+}
@@ -0,0 +1,48 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/coreapi/graph/models"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/eventkey"
+)
+
+func (r *mutationResolver) CreateEventKey(ctx context.Context, input models.CreateEventKeyInput) (*models.CreatedEventKey, error) {
+	key, secret, err := eventkey.Create(ctx, r.Data, eventkey.CreateOpts{
+		Name:          input.Name,
+		AllowedEvents: input.AllowedEvents,
+		RateLimit:     input.RateLimit,
+		ExpiresAt:     input.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.CreatedEventKey{EventKey: key, Key: secret}, nil
+}
+
+func (r *mutationResolver) RevokeEventKey(ctx context.Context, id uuid.UUID) (*cqrs.EventKey, error) {
+	if _, err := r.Data.EventKey(ctx, id); err != nil {
+		return nil, fmt.Errorf("event key not found: %w", err)
+	}
+	if err := r.Data.RevokeEventKey(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+	return r.Data.EventKey(ctx, id)
+}
+
+func (qr *queryResolver) EventKeys(ctx context.Context) ([]*cqrs.EventKey, error) {
+	keys, err := qr.Data.EventKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*cqrs.EventKey, len(keys))
+	for i := range keys {
+		out[i] = &keys[i]
+	}
+	return out, nil
+}
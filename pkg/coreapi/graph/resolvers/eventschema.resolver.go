@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+)
+
+func (qr *queryResolver) EventSchemas(ctx context.Context, eventName *string) ([]*cqrs.EventSchema, error) {
+	var (
+		schemas []cqrs.EventSchema
+		err     error
+	)
+	if eventName != nil {
+		schemas, err = qr.Data.EventSchemasByName(ctx, *eventName)
+	} else {
+		schemas, err = qr.Data.EventSchemas(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*cqrs.EventSchema, len(schemas))
+	for i := range schemas {
+		out[i] = &schemas[i]
+	}
+	return out, nil
+}
+
+func (qr *queryResolver) QuarantinedEvents(ctx context.Context, first int, after *ulid.ULID) ([]*cqrs.QuarantinedEvent, error) {
+	opts := cqrs.QuarantinedEventsOpts{Cursor: after, Limit: first}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	evts, err := qr.Data.QuarantinedEvents(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*cqrs.QuarantinedEvent, len(evts))
+	for i := range evts {
+		out[i] = &evts[i]
+	}
+	return out, nil
+}
+
+func (r *eventSchemaResolver) Schema(ctx context.Context, obj *cqrs.EventSchema) (string, error) {
+	return string(obj.Schema), nil
+}
+
+func (r *quarantinedEventResolver) Event(ctx context.Context, obj *cqrs.QuarantinedEvent) (string, error) {
+	return string(obj.Event), nil
+}
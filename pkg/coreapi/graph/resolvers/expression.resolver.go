@@ -0,0 +1,57 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/inngest/inngest/pkg/coreapi/graph/models"
+	"github.com/inngest/inngest/pkg/expressions"
+	"github.com/inngest/inngest/pkg/util"
+)
+
+func (qr *queryResolver) EvaluateExpression(ctx context.Context, expression string, event map[string]any, async map[string]any, usage *models.ExpressionUsage) (*models.ExpressionEvaluation, error) {
+	data := map[string]any{"event": event}
+	if async != nil {
+		data["async"] = async
+	}
+
+	res, err := expressions.DryRun(ctx, expressionPolicy(usage), expression, data)
+	if err != nil {
+		return nil, err
+	}
+
+	eval := &models.ExpressionEvaluation{
+		Residual:          res.Residual,
+		UnknownAttributes: res.Unknown,
+	}
+	if res.Error != nil {
+		eval.Error = &models.ExpressionError{Message: res.Error.Message}
+		if res.Error.Line > 0 {
+			eval.Error.Line = &res.Error.Line
+			eval.Error.Column = &res.Error.Column
+		}
+		return eval, nil
+	}
+
+	byt, err := json.Marshal(res.Result)
+	if err != nil {
+		return nil, err
+	}
+	eval.Result = util.StrPtr(string(byt))
+	eval.Type = util.StrPtr(res.Type)
+	return eval, nil
+}
+
+// expressionPolicy returns the validation policy applied when registering
+// expressions with the given usage.
+func expressionPolicy(usage *models.ExpressionUsage) *expressions.ValidationPolicy {
+	if usage == nil {
+		return nil
+	}
+	switch *usage {
+	case models.ExpressionUsageCancel, models.ExpressionUsageWaitForEvent:
+		return expressions.DefaultRestrictiveValidationPolicy()
+	default:
+		return nil
+	}
+}
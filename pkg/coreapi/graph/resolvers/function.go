@@ -7,6 +7,7 @@ import (
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/coreapi/graph/models"
 	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/execution/functionpause"
 )
 
 func (r *functionResolver) App(ctx context.Context, obj *models.Function) (*cqrs.App, error) {
@@ -21,3 +22,43 @@ func (qr *queryResolver) FunctionBySlug(ctx context.Context, query models.Functi
 	}
 	return models.MakeFunction(fn)
 }
+
+func (r *mutationResolver) PauseFunction(ctx context.Context, functionSlug string) (*models.Function, error) {
+	fn, err := r.Data.GetFunctionByExternalID(ctx, consts.DevServerEnvID, "local", functionSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err = r.pauser().Pause(ctx, consts.DevServerEnvID, fn.ID)
+	if err != nil {
+		return nil, err
+	}
+	return models.MakeFunction(fn)
+}
+
+func (r *mutationResolver) UnpauseFunction(ctx context.Context, functionSlug string, backfill *bool) (*models.Function, error) {
+	fn, err := r.Data.GetFunctionByExternalID(ctx, consts.DevServerEnvID, "local", functionSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, _, err = r.pauser().Unpause(
+		ctx,
+		consts.DevServerAccountID,
+		consts.DevServerEnvID,
+		fn.ID,
+		backfill != nil && *backfill,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return models.MakeFunction(fn)
+}
+
+func (r *mutationResolver) pauser() functionpause.Pauser {
+	return functionpause.Pauser{
+		Functions: r.Data,
+		Writer:    r.Data,
+		Replays:   r.Replays,
+	}
+}
@@ -0,0 +1,74 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/coreapi/graph/models"
+	"github.com/inngest/inngest/pkg/cqrs"
+)
+
+func (r *mutationResolver) CreateReplay(ctx context.Context, input models.CreateReplayInput) (*cqrs.Replay, error) {
+	if r.Replays == nil {
+		return nil, fmt.Errorf("replays are not enabled")
+	}
+
+	fn, err := r.Data.GetFunctionByExternalID(ctx, consts.DevServerEnvID, "local", input.FunctionSlug)
+	if err != nil {
+		return nil, fmt.Errorf("function not found: %w", err)
+	}
+
+	replay := cqrs.Replay{
+		AccountID:   consts.DevServerAccountID,
+		WorkspaceID: consts.DevServerEnvID,
+		FunctionID:  fn.ID,
+		FromTime:    input.FromTime,
+		ToTime:      input.ToTime,
+		Statuses:    input.Statuses,
+	}
+	if input.Name != nil {
+		replay.Name = *input.Name
+	}
+	if input.Rate != nil {
+		replay.Rate = *input.Rate
+	}
+	return r.Replays.Create(ctx, replay)
+}
+
+func (r *mutationResolver) PauseReplay(ctx context.Context, id uuid.UUID) (*cqrs.Replay, error) {
+	if r.Replays == nil {
+		return nil, fmt.Errorf("replays are not enabled")
+	}
+	return r.Replays.Pause(ctx, consts.DevServerEnvID, id)
+}
+
+func (r *mutationResolver) ResumeReplay(ctx context.Context, id uuid.UUID) (*cqrs.Replay, error) {
+	if r.Replays == nil {
+		return nil, fmt.Errorf("replays are not enabled")
+	}
+	return r.Replays.Resume(ctx, consts.DevServerEnvID, id)
+}
+
+func (qr *queryResolver) Replay(ctx context.Context, id uuid.UUID) (*cqrs.Replay, error) {
+	return qr.Data.Replay(ctx, consts.DevServerEnvID, id)
+}
+
+func (qr *queryResolver) Replays(ctx context.Context, functionSlug string) ([]*cqrs.Replay, error) {
+	fn, err := qr.Data.GetFunctionByExternalID(ctx, consts.DevServerEnvID, "local", functionSlug)
+	if err != nil {
+		return nil, fmt.Errorf("function not found: %w", err)
+	}
+
+	replays, err := qr.Data.FunctionReplays(ctx, consts.DevServerEnvID, fn.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*cqrs.Replay, len(replays))
+	for i := range replays {
+		out[i] = &replays[i]
+	}
+	return out, nil
+}
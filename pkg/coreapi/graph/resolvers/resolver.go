@@ -4,10 +4,12 @@ package resolvers
 
 import (
 	"github.com/inngest/inngest/pkg/api"
+	"github.com/inngest/inngest/pkg/connect/state"
 	"github.com/inngest/inngest/pkg/coreapi/generated"
 	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/execution"
 	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/replay"
 	"github.com/inngest/inngest/pkg/execution/runner"
 	"github.com/inngest/inngest/pkg/history_reader"
 )
@@ -19,8 +21,12 @@ type Resolver struct {
 	Queue         queue.JobQueueReader
 	EventHandler  api.EventHandler
 	Executor      execution.Executor
+	Replays       *replay.Manager
 	ServerKind    string
 
+	// ConnectRouting controls the routing states of connect workers.
+	ConnectRouting state.RoutingStateManager
+
 	// LocalSigningKey is the key used to sign events for self-hosted services.
 	LocalSigningKey string
 
@@ -60,6 +66,16 @@ func (r *Resolver) ConnectV1WorkerConnectionsConnection() generated.ConnectV1Wor
 	return &connectV1workerConnectionResolver{r}
 }
 
+func (r *Resolver) EventSchema() generated.EventSchemaResolver { return &eventSchemaResolver{r} }
+
+func (r *Resolver) QuarantinedEvent() generated.QuarantinedEventResolver {
+	return &quarantinedEventResolver{r}
+}
+
+func (r *Resolver) WebhookSource() generated.WebhookSourceResolver {
+	return &webhookSourceResolver{r}
+}
+
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type eventResolver struct{ *Resolver }
@@ -72,3 +88,6 @@ type connectV1workerConnectionResolver struct{ *Resolver }
 type functionResolver struct{ *Resolver }
 type streamItemResolver struct{ *Resolver }
 type runsV2ConnResolver struct{ *Resolver }
+type eventSchemaResolver struct{ *Resolver }
+type quarantinedEventResolver struct{ *Resolver }
+type webhookSourceResolver struct{ *Resolver }
@@ -0,0 +1,107 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/coreapi/graph/models"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/webhook"
+)
+
+func (r *mutationResolver) CreateWebhookSource(ctx context.Context, input models.WebhookSourceInput) (*cqrs.WebhookSource, error) {
+	now := time.Now().Truncate(time.Millisecond)
+	src := webhookSourceFromInput(input)
+	src.ID = uuid.New()
+	src.CreatedAt = now
+	src.UpdatedAt = now
+
+	if err := webhook.Validate(src); err != nil {
+		return nil, err
+	}
+	if err := r.Data.InsertWebhookSource(ctx, src); err != nil {
+		return nil, err
+	}
+	return &src, nil
+}
+
+func (r *mutationResolver) UpdateWebhookSource(ctx context.Context, id uuid.UUID, input models.WebhookSourceInput) (*cqrs.WebhookSource, error) {
+	existing, err := r.Data.WebhookSource(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("webhook source not found: %w", err)
+	}
+
+	src := webhookSourceFromInput(input)
+	src.ID = existing.ID
+	src.CreatedAt = existing.CreatedAt
+	src.UpdatedAt = time.Now().Truncate(time.Millisecond)
+
+	if err := webhook.Validate(src); err != nil {
+		return nil, err
+	}
+	if err := r.Data.UpdateWebhookSource(ctx, src); err != nil {
+		return nil, err
+	}
+	return &src, nil
+}
+
+func (r *mutationResolver) DeleteWebhookSource(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	if _, err := r.Data.WebhookSource(ctx, id); err != nil {
+		return uuid.Nil, fmt.Errorf("webhook source not found: %w", err)
+	}
+	return id, r.Data.DeleteWebhookSource(ctx, id)
+}
+
+func (qr *queryResolver) WebhookSources(ctx context.Context) ([]*cqrs.WebhookSource, error) {
+	srcs, err := qr.Data.WebhookSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*cqrs.WebhookSource, len(srcs))
+	for i := range srcs {
+		out[i] = &srcs[i]
+	}
+	return out, nil
+}
+
+func (qr *queryResolver) WebhookSource(ctx context.Context, id uuid.UUID) (*cqrs.WebhookSource, error) {
+	return qr.Data.WebhookSource(ctx, id)
+}
+
+func (r *webhookSourceResolver) Path(ctx context.Context, obj *cqrs.WebhookSource) (string, error) {
+	return "/w/" + obj.ID.String(), nil
+}
+
+func webhookSourceFromInput(input models.WebhookSourceInput) cqrs.WebhookSource {
+	src := cqrs.WebhookSource{
+		Name:      input.Name,
+		Transform: input.Transform,
+	}
+	if sig := input.Signature; sig != nil {
+		src.Signature = &cqrs.WebhookSignature{
+			Secret:    sig.Secret,
+			Header:    sig.Header,
+			Algorithm: "sha256",
+			Encoding:  "hex",
+		}
+		if sig.Algorithm != nil {
+			src.Signature.Algorithm = *sig.Algorithm
+		}
+		if sig.Encoding != nil {
+			src.Signature.Encoding = *sig.Encoding
+		}
+		if sig.Prefix != nil {
+			src.Signature.Prefix = *sig.Prefix
+		}
+		if sig.Scheme != nil {
+			src.Signature.Scheme = *sig.Scheme
+		}
+		if sig.Tolerance != nil {
+			src.Signature.Tolerance = *sig.Tolerance
+		}
+	}
+	return src
+}
@@ -0,0 +1,72 @@
+package cqrs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// ArchiveReadWriter reads and writes every historical row in the backing
+// store, allowing data to be exported and imported between stores.
+type ArchiveReadWriter interface {
+	ArchiveReader
+	ArchiveWriter
+}
+
+// ArchiveReader pages through every row of a kind in ID order.  Each call
+// returns up to limit rows with IDs after the given ID;  pass a zero ULID to
+// start from the beginning.
+type ArchiveReader interface {
+	// ArchiveEvents returns events ordered by internal ID.
+	ArchiveEvents(ctx context.Context, after ulid.ULID, limit int) ([]*Event, error)
+	// ArchiveFunctionRuns returns function runs ordered by run ID, including
+	// the status and output of finished runs.
+	ArchiveFunctionRuns(ctx context.Context, after ulid.ULID, limit int) ([]*FunctionRun, error)
+	// ArchiveTraceRuns returns trace runs ordered by run ID.
+	ArchiveTraceRuns(ctx context.Context, after ulid.ULID, limit int) ([]*TraceRun, error)
+	// ArchiveHistory returns function run history ordered by ID.
+	ArchiveHistory(ctx context.Context, after ulid.ULID, limit int) ([]*HistoryRow, error)
+}
+
+type ArchiveWriter interface {
+	// InsertFunctionFinish records the end of a function run.
+	InsertFunctionFinish(ctx context.Context, f FunctionRunFinish) error
+	// InsertHistoryRow writes a history row as stored, returning false if a
+	// row with the same ID already exists.
+	InsertHistoryRow(ctx context.Context, h HistoryRow) (bool, error)
+}
+
+// HistoryRow is a single row of function run history as stored, with JSON
+// columns holding their serialized values.  Unlike history.History, rows are
+// copied without conversion so that archives retain every column.
+type HistoryRow struct {
+	ID                   ulid.ULID
+	CreatedAt            time.Time
+	RunStartedAt         time.Time
+	FunctionID           uuid.UUID
+	FunctionVersion      int64
+	RunID                ulid.ULID
+	EventID              ulid.ULID
+	BatchID              ulid.ULID
+	GroupID              sql.NullString
+	IdempotencyKey       string
+	Type                 string
+	Attempt              int64
+	LatencyMs            sql.NullInt64
+	StepName             sql.NullString
+	StepID               sql.NullString
+	StepType             sql.NullString
+	Url                  sql.NullString
+	CancelRequest        sql.NullString
+	Sleep                sql.NullString
+	WaitForEvent         sql.NullString
+	WaitResult           sql.NullString
+	InvokeFunction       sql.NullString
+	InvokeFunctionResult sql.NullString
+	Result               sql.NullString
+	WorkspaceID          uuid.UUID
+	SkipReason           sql.NullString
+}
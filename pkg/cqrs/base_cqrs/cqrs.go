@@ -1,11 +1,13 @@
 package base_cqrs
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"database/sql"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -454,6 +456,22 @@ func (w wrapper) UpdateFunctionConfig(ctx context.Context, arg cqrs.UpdateFuncti
 	)
 }
 
+func (w wrapper) UpdateFunctionPausedAt(ctx context.Context, arg cqrs.UpdateFunctionPausedAtParams) (*cqrs.Function, error) {
+	params := sqlc.UpdateFunctionPausedAtParams{ID: arg.ID}
+	if arg.PausedAt != nil {
+		params.PausedAt = sql.NullTime{Time: *arg.PausedAt, Valid: true}
+	}
+
+	fn, err := w.q.UpdateFunctionPausedAt(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &cqrs.Function{}
+	err = copier.CopyWithOption(out, fn, copierOpts)
+	return out, err
+}
+
 //
 // Events
 //
@@ -467,9 +485,13 @@ func (w wrapper) InsertEvent(ctx context.Context, e cqrs.Event) error {
 	if err != nil {
 		return err
 	}
+	// Keep the original receive time of imported events.
+	if e.ReceivedAt.IsZero() {
+		e.ReceivedAt = time.Now()
+	}
 	evt := sqlc.InsertEventParams{
 		InternalID: e.ID,
-		ReceivedAt: time.Now(),
+		ReceivedAt: e.ReceivedAt,
 		EventID:    e.EventID,
 		EventName:  e.EventName,
 		EventData:  string(data),
@@ -1023,6 +1045,8 @@ func (w wrapper) GetTraceSpansByRun(ctx context.Context, id cqrs.TraceRunIdentif
 		if err := json.Unmarshal(s.SpanAttributes, &spanAttr); err == nil {
 			span.SpanAttributes = spanAttr
 		}
+		_ = json.Unmarshal(s.Events, &span.Events)
+		_ = json.Unmarshal(s.Links, &span.Links)
 
 		res = append(res, span)
 		seen[ident] = true
@@ -1059,6 +1083,10 @@ func (w wrapper) GetTraceRun(ctx context.Context, id cqrs.TraceRunIdentifier) (*
 		return nil, err
 	}
 
+	return convertTraceRun(run), nil
+}
+
+func convertTraceRun(run *sqlc.TraceRun) *cqrs.TraceRun {
 	start := time.UnixMilli(run.StartedAt)
 	end := time.UnixMilli(run.EndedAt)
 	triggerIDS := strings.Split(string(run.TriggerIds), ",")
@@ -1084,7 +1112,7 @@ func (w wrapper) GetTraceRun(ctx context.Context, id cqrs.TraceRunIdentifier) (*
 		AppID:        run.AppID,
 		FunctionID:   run.FunctionID,
 		TraceID:      string(run.TraceID),
-		RunID:        id.RunID.String(),
+		RunID:        run.RunID.String(),
 		QueuedAt:     time.UnixMilli(run.QueuedAt),
 		StartedAt:    start,
 		EndedAt:      end,
@@ -1099,7 +1127,7 @@ func (w wrapper) GetTraceRun(ctx context.Context, id cqrs.TraceRunIdentifier) (*
 		HasAI:        run.HasAi,
 	}
 
-	return &trun, nil
+	return &trun
 }
 
 func (w wrapper) GetSpanOutput(ctx context.Context, opts cqrs.SpanIdentifier) (*cqrs.SpanOutput, error) {
@@ -2030,6 +2058,769 @@ func (w wrapper) GetWorkerConnections(ctx context.Context, opt cqrs.GetWorkerCon
 	return res, nil
 }
 
+//
+// Dead letters
+//
+
+func (w wrapper) InsertDeadLetter(ctx context.Context, dl cqrs.DeadLetter) error {
+	evtIDs := make([]string, len(dl.EventIDs))
+	for i, id := range dl.EventIDs {
+		evtIDs[i] = id.String()
+	}
+
+	events, err := json.Marshal(dl.Events)
+	if err != nil {
+		return fmt.Errorf("error marshalling dead letter events: %w", err)
+	}
+
+	params := sqlc.InsertDeadLetterParams{
+		ID:              dl.ID,
+		AccountID:       dl.AccountID,
+		WorkspaceID:     dl.WorkspaceID,
+		AppID:           dl.AppID,
+		FunctionID:      dl.FunctionID,
+		FunctionVersion: int64(dl.FunctionVersion),
+		RunID:           dl.RunID,
+		EventIds:        []byte(strings.Join(evtIDs, ",")),
+		Events:          events,
+		Error:           dl.Error,
+		FailedAt:        dl.FailedAt.UnixMilli(),
+	}
+	if dl.StepID != nil {
+		params.StepID = sql.NullString{String: *dl.StepID, Valid: true}
+	}
+	if dl.StepName != nil {
+		params.StepName = sql.NullString{String: *dl.StepName, Valid: true}
+	}
+
+	return w.q.InsertDeadLetter(ctx, params)
+}
+
+func (w wrapper) DeadLetter(ctx context.Context, wsID uuid.UUID, id ulid.ULID) (*cqrs.DeadLetter, error) {
+	obj, err := w.q.GetDeadLetter(ctx, sqlc.GetDeadLetterParams{
+		WorkspaceID: wsID,
+		ID:          id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dl, err := convertDeadLetter(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &dl, nil
+}
+
+func (w wrapper) DeadLetters(ctx context.Context, wsID uuid.UUID, opts *cqrs.DeadLettersOpts) ([]cqrs.DeadLetter, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Cursor == nil {
+		opts.Cursor = &endULID
+	}
+
+	var (
+		rows []*sqlc.DeadLetter
+		err  error
+	)
+
+	if opts.FunctionID == nil {
+		rows, err = w.q.GetDeadLetters(ctx, sqlc.GetDeadLettersParams{
+			WorkspaceID: wsID,
+			Cursor:      *opts.Cursor,
+			Before:      opts.Newest.UnixMilli(),
+			After:       opts.Oldest.UnixMilli(),
+			Limit:       int64(opts.Limit),
+		})
+	} else {
+		rows, err = w.q.GetFunctionDeadLetters(ctx, sqlc.GetFunctionDeadLettersParams{
+			WorkspaceID: wsID,
+			FunctionID:  *opts.FunctionID,
+			Cursor:      *opts.Cursor,
+			Before:      opts.Newest.UnixMilli(),
+			After:       opts.Oldest.UnixMilli(),
+			Limit:       int64(opts.Limit),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]cqrs.DeadLetter, len(rows))
+	for n, row := range rows {
+		if out[n], err = convertDeadLetter(row); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (w wrapper) UpdateDeadLetterRedrive(ctx context.Context, wsID uuid.UUID, id ulid.ULID, runID ulid.ULID) error {
+	return w.q.UpdateDeadLetterRedrive(ctx, sqlc.UpdateDeadLetterRedriveParams{
+		RedrivenAt:   sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		RedriveRunID: &runID,
+		WorkspaceID:  wsID,
+		ID:           id,
+	})
+}
+
+func convertDeadLetter(obj *sqlc.DeadLetter) (cqrs.DeadLetter, error) {
+	evtIDs, err := obj.EventIDs()
+	if err != nil {
+		return cqrs.DeadLetter{}, fmt.Errorf("error parsing dead letter event IDs: %w", err)
+	}
+
+	var events []json.RawMessage
+	if len(obj.Events) > 0 {
+		if err := json.Unmarshal(obj.Events, &events); err != nil {
+			return cqrs.DeadLetter{}, fmt.Errorf("error unmarshalling dead letter events: %w", err)
+		}
+	}
+
+	dl := cqrs.DeadLetter{
+		ID:              obj.ID,
+		AccountID:       obj.AccountID,
+		WorkspaceID:     obj.WorkspaceID,
+		AppID:           obj.AppID,
+		FunctionID:      obj.FunctionID,
+		FunctionVersion: int(obj.FunctionVersion),
+		RunID:           obj.RunID,
+		EventIDs:        evtIDs,
+		Events:          events,
+		Error:           obj.Error,
+		FailedAt:        time.UnixMilli(obj.FailedAt),
+		RedriveRunID:    obj.RedriveRunID,
+	}
+	if obj.StepID.Valid {
+		dl.StepID = &obj.StepID.String
+	}
+	if obj.StepName.Valid {
+		dl.StepName = &obj.StepName.String
+	}
+	if obj.RedrivenAt.Valid {
+		dl.RedrivenAt = ptr.Time(time.UnixMilli(obj.RedrivenAt.Int64))
+	}
+	return dl, nil
+}
+
+//
+// Replays
+//
+
+func (w wrapper) InsertReplay(ctx context.Context, r cqrs.Replay) error {
+	statuses := make([]string, len(r.Statuses))
+	for i, s := range r.Statuses {
+		statuses[i] = s.String()
+	}
+
+	now := time.Now().UnixMilli()
+	return w.q.InsertReplay(ctx, sqlc.InsertReplayParams{
+		ID:          r.ID,
+		AccountID:   r.AccountID,
+		WorkspaceID: r.WorkspaceID,
+		AppID:       r.AppID,
+		FunctionID:  r.FunctionID,
+		Name:        r.Name,
+		FromTime:    r.FromTime.UnixMilli(),
+		ToTime:      r.ToTime.UnixMilli(),
+		Statuses:    strings.Join(statuses, ","),
+		Rate:        int64(r.Rate),
+		Status:      int64(r.Status),
+		TotalRuns:   int64(r.TotalRuns),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+}
+
+func (w wrapper) Replay(ctx context.Context, wsID uuid.UUID, id uuid.UUID) (*cqrs.Replay, error) {
+	obj, err := w.q.GetReplay(ctx, sqlc.GetReplayParams{
+		WorkspaceID: wsID,
+		ID:          id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := convertReplay(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (w wrapper) FunctionReplays(ctx context.Context, wsID uuid.UUID, fnID uuid.UUID) ([]cqrs.Replay, error) {
+	rows, err := w.q.GetFunctionReplays(ctx, sqlc.GetFunctionReplaysParams{
+		WorkspaceID: wsID,
+		FunctionID:  fnID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertReplays(rows)
+}
+
+func (w wrapper) ReplaysByStatus(ctx context.Context, status enums.ReplayStatus) ([]cqrs.Replay, error) {
+	rows, err := w.q.GetReplaysByStatus(ctx, int64(status))
+	if err != nil {
+		return nil, err
+	}
+	return convertReplays(rows)
+}
+
+func (w wrapper) UpdateReplayProgress(ctx context.Context, r cqrs.Replay) error {
+	return w.q.UpdateReplayProgress(ctx, sqlc.UpdateReplayProgressParams{
+		RunCursor:     r.Cursor,
+		ProcessedRuns: int64(r.ProcessedRuns),
+		ScheduledRuns: int64(r.ScheduledRuns),
+		FailedRuns:    int64(r.FailedRuns),
+		UpdatedAt:     time.Now().UnixMilli(),
+		ID:            r.ID,
+	})
+}
+
+func (w wrapper) UpdateReplayStatus(ctx context.Context, wsID uuid.UUID, id uuid.UUID, status enums.ReplayStatus, err *string) error {
+	now := time.Now().UnixMilli()
+	params := sqlc.UpdateReplayStatusParams{
+		Status:      int64(status),
+		UpdatedAt:   now,
+		WorkspaceID: wsID,
+		ID:          id,
+	}
+	if err != nil {
+		params.Error = sql.NullString{String: *err, Valid: true}
+	}
+	if status == enums.ReplayStatusCompleted || status == enums.ReplayStatusFailed {
+		params.EndedAt = sql.NullInt64{Int64: now, Valid: true}
+	}
+	return w.q.UpdateReplayStatus(ctx, params)
+}
+
+func convertReplays(rows []*sqlc.Replay) ([]cqrs.Replay, error) {
+	var err error
+	out := make([]cqrs.Replay, len(rows))
+	for n, row := range rows {
+		if out[n], err = convertReplay(row); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func convertReplay(obj *sqlc.Replay) (cqrs.Replay, error) {
+	var statuses []enums.ReplayRunStatus
+	for _, s := range strings.Split(obj.Statuses, ",") {
+		if s == "" {
+			continue
+		}
+		status, err := enums.ReplayRunStatusString(s)
+		if err != nil {
+			return cqrs.Replay{}, fmt.Errorf("error parsing replay status: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	r := cqrs.Replay{
+		ID:            obj.ID,
+		AccountID:     obj.AccountID,
+		WorkspaceID:   obj.WorkspaceID,
+		AppID:         obj.AppID,
+		FunctionID:    obj.FunctionID,
+		Name:          obj.Name,
+		FromTime:      time.UnixMilli(obj.FromTime),
+		ToTime:        time.UnixMilli(obj.ToTime),
+		Statuses:      statuses,
+		Rate:          int(obj.Rate),
+		Status:        enums.ReplayStatus(obj.Status),
+		Cursor:        obj.RunCursor,
+		TotalRuns:     int(obj.TotalRuns),
+		ProcessedRuns: int(obj.ProcessedRuns),
+		ScheduledRuns: int(obj.ScheduledRuns),
+		FailedRuns:    int(obj.FailedRuns),
+		CreatedAt:     time.UnixMilli(obj.CreatedAt),
+		UpdatedAt:     time.UnixMilli(obj.UpdatedAt),
+	}
+	if obj.Error.Valid {
+		r.Error = &obj.Error.String
+	}
+	if obj.EndedAt.Valid {
+		r.EndedAt = ptr.Time(time.UnixMilli(obj.EndedAt.Int64))
+	}
+	return r, nil
+}
+
+//
+// Event schemas
+//
+
+func (w wrapper) ReplaceEventSchemas(ctx context.Context, appID uuid.UUID, schemas []cqrs.EventSchema) error {
+	if w.tx != nil {
+		return w.replaceEventSchemas(ctx, appID, schemas)
+	}
+
+	tx, err := w.WithTx(ctx)
+	if err != nil {
+		return err
+	}
+	if err := tx.(*wrapper).replaceEventSchemas(ctx, appID, schemas); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (w wrapper) replaceEventSchemas(ctx context.Context, appID uuid.UUID, schemas []cqrs.EventSchema) error {
+	rows, err := w.q.GetEventSchemas(ctx)
+	if err != nil {
+		return fmt.Errorf("error loading event schemas: %w", err)
+	}
+	existing := []cqrs.EventSchema{}
+	for _, s := range convertEventSchemas(rows) {
+		if s.AppID == appID {
+			existing = append(existing, s)
+		}
+	}
+	if equalEventSchemas(existing, schemas) {
+		// Apps re-sync often, so only write schemas when they've changed.
+		return nil
+	}
+
+	if err := w.q.DeleteEventSchemasByApp(ctx, appID); err != nil {
+		return fmt.Errorf("error deleting event schemas: %w", err)
+	}
+
+	now := time.Now()
+	for _, s := range schemas {
+		updatedAt := s.UpdatedAt
+		if updatedAt.IsZero() {
+			updatedAt = now
+		}
+		err := w.q.InsertEventSchema(ctx, sqlc.InsertEventSchemaParams{
+			AppID:     appID,
+			EventName: s.EventName,
+			Source:    s.Source,
+			Schema:    s.Schema,
+			UpdatedAt: updatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("error inserting event schema for %s: %w", s.EventName, err)
+		}
+	}
+	return nil
+}
+
+func (w wrapper) EventSchemas(ctx context.Context) ([]cqrs.EventSchema, error) {
+	rows, err := w.q.GetEventSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertEventSchemas(rows), nil
+}
+
+func (w wrapper) EventSchemasByName(ctx context.Context, name string) ([]cqrs.EventSchema, error) {
+	rows, err := w.q.GetEventSchemasByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return convertEventSchemas(rows), nil
+}
+
+func (w wrapper) InsertQuarantinedEvent(ctx context.Context, evt cqrs.QuarantinedEvent) error {
+	return w.q.InsertQuarantinedEvent(ctx, sqlc.InsertQuarantinedEventParams{
+		ID:         evt.ID,
+		EventName:  evt.EventName,
+		Event:      evt.Event,
+		Error:      evt.Error,
+		ReceivedAt: evt.ReceivedAt.UnixMilli(),
+	})
+}
+
+func (w wrapper) QuarantinedEvents(ctx context.Context, opts cqrs.QuarantinedEventsOpts) ([]cqrs.QuarantinedEvent, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Cursor == nil {
+		opts.Cursor = &endULID
+	}
+
+	rows, err := w.q.GetQuarantinedEvents(ctx, sqlc.GetQuarantinedEventsParams{
+		Cursor: *opts.Cursor,
+		Limit:  int64(opts.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]cqrs.QuarantinedEvent, len(rows))
+	for n, row := range rows {
+		out[n] = cqrs.QuarantinedEvent{
+			ID:         row.ID,
+			EventName:  row.EventName,
+			Event:      row.Event,
+			Error:      row.Error,
+			ReceivedAt: time.UnixMilli(row.ReceivedAt),
+		}
+	}
+	return out, nil
+}
+
+// equalEventSchemas returns whether both sets of schemas register the same
+// schemas for the same event names.
+func equalEventSchemas(a, b []cqrs.EventSchema) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byName := make(map[string]cqrs.EventSchema, len(a))
+	for _, s := range a {
+		byName[s.EventName] = s
+	}
+	for _, s := range b {
+		existing, ok := byName[s.EventName]
+		if !ok || existing.Source != s.Source || !bytes.Equal(existing.Schema, s.Schema) {
+			return false
+		}
+	}
+	return true
+}
+
+func convertEventSchemas(rows []*sqlc.EventSchema) []cqrs.EventSchema {
+	out := make([]cqrs.EventSchema, len(rows))
+	for n, row := range rows {
+		out[n] = cqrs.EventSchema{
+			AppID:     row.AppID,
+			EventName: row.EventName,
+			Source:    row.Source,
+			Schema:    row.Schema,
+			UpdatedAt: time.UnixMilli(row.UpdatedAt),
+		}
+	}
+	return out
+}
+
+//
+// Event keys
+//
+
+func (w wrapper) InsertEventKey(ctx context.Context, key cqrs.EventKey) error {
+	allowed := key.AllowedEvents
+	if allowed == nil {
+		allowed = []string{}
+	}
+	byt, err := json.Marshal(allowed)
+	if err != nil {
+		return fmt.Errorf("error marshalling allowed events: %w", err)
+	}
+
+	params := sqlc.InsertEventKeyParams{
+		ID:            key.ID,
+		Name:          key.Name,
+		KeyHash:       key.Hash,
+		KeyPrefix:     key.Prefix,
+		AllowedEvents: string(byt),
+		CreatedAt:     key.CreatedAt.UnixMilli(),
+	}
+	if key.RateLimit != nil {
+		params.RateLimit = int64(key.RateLimit.Limit)
+		params.RatePeriod = key.RateLimit.Period
+	}
+	if key.ExpiresAt != nil {
+		params.ExpiresAt = sql.NullInt64{Int64: key.ExpiresAt.UnixMilli(), Valid: true}
+	}
+	return w.q.InsertEventKey(ctx, params)
+}
+
+func (w wrapper) EventKeys(ctx context.Context) ([]cqrs.EventKey, error) {
+	rows, err := w.q.GetEventKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]cqrs.EventKey, len(rows))
+	for n, row := range rows {
+		if out[n], err = convertEventKey(row); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (w wrapper) EventKey(ctx context.Context, id uuid.UUID) (*cqrs.EventKey, error) {
+	row, err := w.q.GetEventKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	key, err := convertEventKey(row)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (w wrapper) EventKeyByHash(ctx context.Context, hash string) (*cqrs.EventKey, error) {
+	row, err := w.q.GetEventKeyByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	key, err := convertEventKey(row)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (w wrapper) RevokeEventKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return w.q.RevokeEventKey(ctx, sqlc.RevokeEventKeyParams{
+		RevokedAt: sql.NullInt64{Int64: at.UnixMilli(), Valid: true},
+		ID:        id,
+	})
+}
+
+func (w wrapper) UpdateEventKeyLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return w.q.UpdateEventKeyLastUsed(ctx, sqlc.UpdateEventKeyLastUsedParams{
+		LastUsedAt: sql.NullInt64{Int64: at.UnixMilli(), Valid: true},
+		ID:         id,
+	})
+}
+
+func convertEventKey(row *sqlc.EventKey) (cqrs.EventKey, error) {
+	key := cqrs.EventKey{
+		ID:        row.ID,
+		Name:      row.Name,
+		Hash:      row.KeyHash,
+		Prefix:    row.KeyPrefix,
+		CreatedAt: time.UnixMilli(row.CreatedAt),
+	}
+	if err := json.Unmarshal([]byte(row.AllowedEvents), &key.AllowedEvents); err != nil {
+		return cqrs.EventKey{}, fmt.Errorf("error parsing allowed events: %w", err)
+	}
+	if row.RateLimit > 0 {
+		key.RateLimit = &cqrs.EventKeyRateLimit{
+			Limit:  int(row.RateLimit),
+			Period: row.RatePeriod,
+		}
+	}
+	if row.ExpiresAt.Valid {
+		key.ExpiresAt = ptr.Time(time.UnixMilli(row.ExpiresAt.Int64))
+	}
+	if row.LastUsedAt.Valid {
+		key.LastUsedAt = ptr.Time(time.UnixMilli(row.LastUsedAt.Int64))
+	}
+	if row.RevokedAt.Valid {
+		key.RevokedAt = ptr.Time(time.UnixMilli(row.RevokedAt.Int64))
+	}
+	return key, nil
+}
+
+//
+// Webhook sources
+//
+
+func (w wrapper) InsertWebhookSource(ctx context.Context, src cqrs.WebhookSource) error {
+	sig, err := marshalWebhookSignature(src.Signature)
+	if err != nil {
+		return err
+	}
+	return w.q.InsertWebhookSource(ctx, sqlc.InsertWebhookSourceParams{
+		ID:        src.ID,
+		Name:      src.Name,
+		Transform: src.Transform,
+		Signature: sig,
+		CreatedAt: src.CreatedAt.UnixMilli(),
+		UpdatedAt: src.UpdatedAt.UnixMilli(),
+	})
+}
+
+func (w wrapper) UpdateWebhookSource(ctx context.Context, src cqrs.WebhookSource) error {
+	sig, err := marshalWebhookSignature(src.Signature)
+	if err != nil {
+		return err
+	}
+	return w.q.UpdateWebhookSource(ctx, sqlc.UpdateWebhookSourceParams{
+		Name:      src.Name,
+		Transform: src.Transform,
+		Signature: sig,
+		UpdatedAt: src.UpdatedAt.UnixMilli(),
+		ID:        src.ID,
+	})
+}
+
+func (w wrapper) DeleteWebhookSource(ctx context.Context, id uuid.UUID) error {
+	return w.q.DeleteWebhookSource(ctx, id)
+}
+
+func (w wrapper) WebhookSources(ctx context.Context) ([]cqrs.WebhookSource, error) {
+	rows, err := w.q.GetWebhookSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]cqrs.WebhookSource, len(rows))
+	for n, row := range rows {
+		if out[n], err = convertWebhookSource(row); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (w wrapper) WebhookSource(ctx context.Context, id uuid.UUID) (*cqrs.WebhookSource, error) {
+	row, err := w.q.GetWebhookSource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	src, err := convertWebhookSource(row)
+	if err != nil {
+		return nil, err
+	}
+	return &src, nil
+}
+
+func marshalWebhookSignature(sig *cqrs.WebhookSignature) ([]byte, error) {
+	if sig == nil {
+		return nil, nil
+	}
+	byt, err := json.Marshal(sig)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling webhook signature: %w", err)
+	}
+	return byt, nil
+}
+
+func convertWebhookSource(row *sqlc.WebhookSource) (cqrs.WebhookSource, error) {
+	src := cqrs.WebhookSource{
+		ID:        row.ID,
+		Name:      row.Name,
+		Transform: row.Transform,
+		CreatedAt: time.UnixMilli(row.CreatedAt),
+		UpdatedAt: time.UnixMilli(row.UpdatedAt),
+	}
+	if len(row.Signature) > 0 {
+		src.Signature = &cqrs.WebhookSignature{}
+		if err := json.Unmarshal(row.Signature, src.Signature); err != nil {
+			return cqrs.WebhookSource{}, fmt.Errorf("error parsing webhook signature: %w", err)
+		}
+	}
+	return src, nil
+}
+
+//
+// Archive
+//
+
+func (w wrapper) ArchiveEvents(ctx context.Context, after ulid.ULID, limit int) ([]*cqrs.Event, error) {
+	evts, err := w.q.GetEventsAfter(ctx, sqlc.GetEventsAfterParams{
+		After: after,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*cqrs.Event, len(evts))
+	for n, i := range evts {
+		e := convertEvent(i)
+		res[n] = &e
+	}
+	return res, nil
+}
+
+func (w wrapper) ArchiveFunctionRuns(ctx context.Context, after ulid.ULID, limit int) ([]*cqrs.FunctionRun, error) {
+	runs, err := w.q.GetFunctionRunsAfter(ctx, sqlc.GetFunctionRunsAfterParams{
+		After: after,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return []*cqrs.FunctionRun{}, nil
+	}
+
+	runIDs := make([]ulid.ULID, len(runs))
+	for n, run := range runs {
+		runIDs[n] = run.RunID
+	}
+	finishes, err := w.q.GetFunctionRunFinishesByRunIDs(ctx, runIDs)
+	if err != nil {
+		return nil, err
+	}
+	byRunID := make(map[ulid.ULID]sqlc.FunctionFinish, len(finishes))
+	for _, f := range finishes {
+		byRunID[f.RunID] = *f
+	}
+
+	res := make([]*cqrs.FunctionRun, len(runs))
+	for n, run := range runs {
+		res[n] = toCQRSRun(*run, byRunID[run.RunID])
+	}
+	return res, nil
+}
+
+func (w wrapper) ArchiveTraceRuns(ctx context.Context, after ulid.ULID, limit int) ([]*cqrs.TraceRun, error) {
+	runs, err := w.q.GetTraceRunsAfter(ctx, sqlc.GetTraceRunsAfterParams{
+		After: after,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*cqrs.TraceRun, len(runs))
+	for n, run := range runs {
+		res[n] = convertTraceRun(run)
+	}
+	return res, nil
+}
+
+func (w wrapper) ArchiveHistory(ctx context.Context, after ulid.ULID, limit int) ([]*cqrs.HistoryRow, error) {
+	rows, err := w.q.GetHistoryAfter(ctx, sqlc.GetHistoryAfterParams{
+		After: after,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*cqrs.HistoryRow, len(rows))
+	for n, row := range rows {
+		res[n] = &cqrs.HistoryRow{}
+		if err := copier.Copy(res[n], row); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (w wrapper) InsertHistoryRow(ctx context.Context, h cqrs.HistoryRow) (bool, error) {
+	_, err := w.q.GetHistoryItem(ctx, h.ID)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	params := sqlc.InsertHistoryParams{}
+	if err := copier.Copy(&params, h); err != nil {
+		return false, err
+	}
+	return true, w.q.InsertHistory(ctx, params)
+}
+
+func (w wrapper) InsertFunctionFinish(ctx context.Context, f cqrs.FunctionRunFinish) error {
+	output := "{}"
+	if len(f.Output) > 0 {
+		output = string(f.Output)
+	}
+	return w.q.InsertFunctionFinish(ctx, sqlc.InsertFunctionFinishParams{
+		RunID:              f.RunID,
+		Status:             sql.NullString{String: f.Status.String(), Valid: true},
+		Output:             sql.NullString{String: output, Valid: true},
+		CompletedStepCount: sql.NullInt64{Int64: f.CompletedStepCount, Valid: true},
+		CreatedAt:          sql.NullTime{Time: f.CreatedAt, Valid: true},
+	})
+}
+
 // copyWriter allows running duck-db specific functions as CQRS functions, copying CQRS types to DDB types
 // automatically.
 func copyWriter[
@@ -2054,7 +2845,7 @@ func copyWriter[
 		return out, err
 	}
 
-	err = copier.CopyWithOption(&out, in, copier.Option{DeepCopy: true})
+	err = copier.CopyWithOption(&out, in, copierOpts)
 	return out, err
 }
 
@@ -2070,6 +2861,25 @@ func copyInto[
 	if err != nil {
 		return out, err
 	}
-	err = copier.CopyWithOption(&out, in, copier.Option{DeepCopy: true})
+	err = copier.CopyWithOption(&out, in, copierOpts)
 	return out, err
 }
+
+// copierOpts are used when copying sqlc types into CQRS types.  Null times are
+// copied into nil pointers, as copier otherwise sets pointers to the zero time.
+var copierOpts = copier.Option{
+	DeepCopy: true,
+	Converters: []copier.TypeConverter{
+		{
+			SrcType: sql.NullTime{},
+			DstType: &time.Time{},
+			Fn: func(src any) (any, error) {
+				t := src.(sql.NullTime)
+				if !t.Valid {
+					return (*time.Time)(nil), nil
+				}
+				return &t.Time, nil
+			},
+		},
+	},
+}
@@ -0,0 +1,87 @@
+package base_cqrs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetters(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := NewCQRS(db, "sqlite")
+
+	wsID := uuid.New()
+	fnA, fnB := uuid.New(), uuid.New()
+	stepID := "step-a"
+
+	insert := func(fnID uuid.UUID, failedAt time.Time) cqrs.DeadLetter {
+		evtID := ulid.MustNew(ulid.Now(), rand.Reader)
+		dl := cqrs.DeadLetter{
+			ID:              ulid.MustNew(ulid.Timestamp(failedAt), rand.Reader),
+			AccountID:       uuid.New(),
+			WorkspaceID:     wsID,
+			AppID:           uuid.New(),
+			FunctionID:      fnID,
+			FunctionVersion: 2,
+			RunID:           ulid.MustNew(ulid.Now(), rand.Reader),
+			EventIDs:        []ulid.ULID{evtID},
+			Events:          []json.RawMessage{json.RawMessage(`{"name":"test/event","data":{}}`)},
+			StepID:          &stepID,
+			Error:           "boom",
+			FailedAt:        failedAt.Truncate(time.Millisecond),
+		}
+		require.NoError(t, mgr.InsertDeadLetter(ctx, dl))
+		return dl
+	}
+
+	now := time.Now()
+	first := insert(fnA, now.Add(-2*time.Minute))
+	second := insert(fnB, now.Add(-time.Minute))
+
+	t.Run("lists dead letters newest first", func(t *testing.T) {
+		dls, err := mgr.DeadLetters(ctx, wsID, &cqrs.DeadLettersOpts{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, dls, 2)
+		require.Equal(t, second.ID, dls[0].ID)
+		require.Equal(t, first.ID, dls[1].ID)
+
+		require.Equal(t, first.EventIDs, dls[1].EventIDs)
+		require.JSONEq(t, string(first.Events[0]), string(dls[1].Events[0]))
+		require.Equal(t, stepID, *dls[1].StepID)
+		require.Nil(t, dls[1].StepName)
+		require.Equal(t, first.FailedAt.UnixMilli(), dls[1].FailedAt.UnixMilli())
+	})
+
+	t.Run("filters by function", func(t *testing.T) {
+		dls, err := mgr.DeadLetters(ctx, wsID, &cqrs.DeadLettersOpts{Limit: 10, FunctionID: &fnA})
+		require.NoError(t, err)
+		require.Len(t, dls, 1)
+		require.Equal(t, first.ID, dls[0].ID)
+	})
+
+	t.Run("records redrives", func(t *testing.T) {
+		runID := ulid.MustNew(ulid.Now(), rand.Reader)
+		require.NoError(t, mgr.UpdateDeadLetterRedrive(ctx, wsID, first.ID, runID))
+
+		dl, err := mgr.DeadLetter(ctx, wsID, first.ID)
+		require.NoError(t, err)
+		require.True(t, dl.IsRedriven())
+		require.Equal(t, runID, *dl.RedriveRunID)
+		require.NotNil(t, dl.RedrivenAt)
+	})
+
+	t.Run("scopes to the workspace", func(t *testing.T) {
+		_, err := mgr.DeadLetter(ctx, uuid.New(), first.ID)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,120 @@
+package base_cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := NewCQRS(db, "sqlite")
+
+	appID := uuid.New()
+	schema := func(name, source, typ string) cqrs.EventSchema {
+		return cqrs.EventSchema{
+			EventName: name,
+			Source:    source,
+			Schema:    json.RawMessage(`{"type":"` + typ + `"}`),
+		}
+	}
+
+	require.NoError(t, mgr.ReplaceEventSchemas(ctx, appID, []cqrs.EventSchema{
+		schema("user/created", cqrs.EventSchemaSourceSDK, "object"),
+		schema("user/deleted", cqrs.EventSchemaSourceSDK, "object"),
+	}))
+	require.NoError(t, mgr.ReplaceEventSchemas(ctx, uuid.Nil, []cqrs.EventSchema{
+		schema("user/created", cqrs.EventSchemaSourceConfig, "object"),
+	}))
+
+	t.Run("lists schemas ordered by event name", func(t *testing.T) {
+		all, err := mgr.EventSchemas(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		require.Equal(t, "user/created", all[0].EventName)
+		require.Equal(t, "user/deleted", all[2].EventName)
+	})
+
+	t.Run("loads schemas from every source by name", func(t *testing.T) {
+		byName, err := mgr.EventSchemasByName(ctx, "user/created")
+		require.NoError(t, err)
+		require.Len(t, byName, 2)
+		require.JSONEq(t, `{"type":"object"}`, string(byName[0].Schema))
+	})
+
+	t.Run("replacing unchanged schemas doesn't write", func(t *testing.T) {
+		before, err := mgr.EventSchemasByName(ctx, "user/deleted")
+		require.NoError(t, err)
+
+		<-time.After(2 * time.Millisecond)
+		require.NoError(t, mgr.ReplaceEventSchemas(ctx, appID, []cqrs.EventSchema{
+			schema("user/deleted", cqrs.EventSchemaSourceSDK, "object"),
+			schema("user/created", cqrs.EventSchemaSourceSDK, "object"),
+		}))
+
+		after, err := mgr.EventSchemasByName(ctx, "user/deleted")
+		require.NoError(t, err)
+		require.Equal(t, before[0].UpdatedAt, after[0].UpdatedAt)
+	})
+
+	t.Run("replacing an app's schemas leaves other apps untouched", func(t *testing.T) {
+		require.NoError(t, mgr.ReplaceEventSchemas(ctx, appID, []cqrs.EventSchema{
+			schema("user/updated", cqrs.EventSchemaSourceSDK, "object"),
+		}))
+
+		deleted, err := mgr.EventSchemasByName(ctx, "user/deleted")
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+
+		created, err := mgr.EventSchemasByName(ctx, "user/created")
+		require.NoError(t, err)
+		require.Len(t, created, 1)
+		require.Equal(t, uuid.Nil, created[0].AppID)
+		require.Equal(t, cqrs.EventSchemaSourceConfig, created[0].Source)
+	})
+}
+
+func TestQuarantinedEvents(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := NewCQRS(db, "sqlite")
+
+	now := time.Now().Truncate(time.Millisecond)
+	var ids []ulid.ULID
+	for i := 0; i < 3; i++ {
+		at := now.Add(time.Duration(i) * time.Second)
+		id := ulid.MustNew(ulid.Timestamp(at), ulid.DefaultEntropy())
+		ids = append(ids, id)
+		require.NoError(t, mgr.InsertQuarantinedEvent(ctx, cqrs.QuarantinedEvent{
+			ID:         id,
+			EventName:  "user/created",
+			Event:      json.RawMessage(`{"name":"user/created","data":{}}`),
+			Error:      "invalid",
+			ReceivedAt: at,
+		}))
+	}
+
+	evts, err := mgr.QuarantinedEvents(ctx, cqrs.QuarantinedEventsOpts{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, evts, 2)
+	require.Equal(t, ids[2], evts[0].ID)
+	require.Equal(t, ids[1], evts[1].ID)
+	require.Equal(t, "invalid", evts[0].Error)
+
+	cursor := evts[1].ID
+	evts, err = mgr.QuarantinedEvents(ctx, cqrs.QuarantinedEventsOpts{Limit: 2, Cursor: &cursor})
+	require.NoError(t, err)
+	require.Len(t, evts, 1)
+	require.Equal(t, ids[0], evts[0].ID)
+}
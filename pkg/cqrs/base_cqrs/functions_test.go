@@ -0,0 +1,51 @@
+package base_cqrs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionPausedAt(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := NewCQRS(db, "sqlite")
+
+	fn, err := mgr.InsertFunction(ctx, cqrs.InsertFunctionParams{
+		ID:        uuid.New(),
+		AppID:     uuid.New(),
+		Name:      "test",
+		Slug:      "test",
+		Config:    "{}",
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+	require.False(t, fn.IsPaused())
+
+	pausedAt := time.Now().Truncate(time.Millisecond)
+	paused, err := mgr.UpdateFunctionPausedAt(ctx, cqrs.UpdateFunctionPausedAtParams{
+		ID:       fn.ID,
+		PausedAt: &pausedAt,
+	})
+	require.NoError(t, err)
+	require.True(t, paused.IsPaused())
+
+	found, err := mgr.GetFunctionByInternalUUID(ctx, uuid.New(), fn.ID)
+	require.NoError(t, err)
+	require.True(t, found.IsPaused())
+	require.Equal(t, pausedAt.UnixMilli(), found.PausedAt.UnixMilli())
+
+	unpaused, err := mgr.UpdateFunctionPausedAt(ctx, cqrs.UpdateFunctionPausedAtParams{ID: fn.ID})
+	require.NoError(t, err)
+	require.False(t, unpaused.IsPaused())
+
+	found, err = mgr.GetFunctionByInternalUUID(ctx, uuid.New(), fn.ID)
+	require.NoError(t, err)
+	require.False(t, found.IsPaused())
+}
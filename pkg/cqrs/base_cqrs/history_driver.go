@@ -34,6 +34,7 @@ func (d historyDriver) Write(ctx context.Context, h history.History) (err error)
 		IdempotencyKey:  h.IdempotencyKey,
 		Type:            h.Type,
 		Attempt:         h.Attempt,
+		WorkspaceID:     h.WorkspaceID,
 	}
 	if h.LatencyMS != nil {
 		params.LatencyMs = sql.NullInt64{
@@ -68,6 +69,12 @@ func (d historyDriver) Write(ctx context.Context, h history.History) (err error)
 			String: h.StepType.String(),
 		}
 	}
+	if h.SkipReason != nil {
+		params.SkipReason = sql.NullString{
+			Valid:  true,
+			String: h.SkipReason.String(),
+		}
+	}
 	if h.URL != nil {
 		params.Url = sql.NullString{
 			Valid:  true,
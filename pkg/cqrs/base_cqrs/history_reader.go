@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	sq "github.com/doug-martin/goqu/v9"
+
 	"github.com/google/uuid"
 	"github.com/inngest/inngest/pkg/cqrs"
 	sqlc "github.com/inngest/inngest/pkg/cqrs/base_cqrs/sqlc/sqlite"
@@ -20,12 +23,23 @@ import (
 
 func NewHistoryReader(db *sql.DB, driver string) history_reader.Reader {
 	return &reader{
-		q: NewQueries(db, driver),
+		q:      NewQueries(db, driver),
+		db:     db,
+		driver: driver,
 	}
 }
 
 type reader struct {
-	q sqlc.Querier
+	q      sqlc.Querier
+	db     *sql.DB
+	driver string
+}
+
+func (r *reader) dialect() string {
+	if r.driver == "postgres" {
+		return "postgres"
+	}
+	return "sqlite3"
 }
 
 func (r *reader) CountRuns(
@@ -223,12 +237,238 @@ func (r *reader) GetUsage(
 	return nil, errors.New("not implemented")
 }
 
+// GetReplayRuns returns finished and skipped runs matching the given options,
+// ordered by run ID.
 func (r *reader) GetReplayRuns(ctx context.Context, opts history_reader.GetReplayRunsOpts) ([]history_reader.ReplayRun, error) {
-	return nil, errors.New("not implemented")
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Limit == 0 {
+		opts.Limit = history_reader.DefaultQueryLimit
+	}
+
+	lower, upper := replayRunBounds(opts.LowerTime, opts.UpperTime)
+	cursor := nilULID
+	if opts.Cursor != nil {
+		cursor = *opts.Cursor
+	}
+
+	result := []history_reader.ReplayRun{}
+
+	if len(opts.Statuses) > 0 {
+		statuses := make([]string, len(opts.Statuses))
+		for i, s := range opts.Statuses {
+			statuses[i] = s.String()
+		}
+
+		filter := []sq.Expression{
+			sq.C("run_id").Table("function_runs").Gte(lower[:]),
+			sq.C("run_id").Table("function_runs").Lt(upper[:]),
+			sq.C("run_id").Table("function_runs").Gt(cursor[:]),
+			sq.C("workspace_id").Table("function_runs").Eq(opts.WorkspaceID),
+			sq.C("status").Table("function_finishes").In(statuses),
+		}
+		if opts.WorkflowID != nil {
+			filter = append(filter, sq.C("function_id").Table("function_runs").Eq(*opts.WorkflowID))
+		}
+
+		query, args, err := sq.Dialect(r.dialect()).
+			From("function_runs").
+			Join(sq.T("function_finishes"), sq.On(sq.Ex{"function_finishes.run_id": sq.I("function_runs.run_id")})).
+			Select(
+				sq.C("run_id").Table("function_runs"),
+				sq.C("event_id").Table("function_runs"),
+				sq.C("batch_id").Table("function_runs"),
+				sq.C("function_id").Table("function_runs"),
+				sq.C("cron").Table("function_runs"),
+			).
+			Where(filter...).
+			Order(sq.C("run_id").Table("function_runs").Asc()).
+			Limit(uint(opts.Limit)).
+			Prepared(true).
+			ToSQL()
+		if err != nil {
+			return nil, err
+		}
+
+		runs, err := r.queryReplayRuns(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replay runs: %w", err)
+		}
+		result = append(result, runs...)
+	}
+
+	if len(opts.SkipReasons) > 0 {
+		reasons := make([]string, len(opts.SkipReasons))
+		for i, r := range opts.SkipReasons {
+			reasons[i] = r.String()
+		}
+
+		filter := []sq.Expression{
+			sq.C("run_id").Gte(lower[:]),
+			sq.C("run_id").Lt(upper[:]),
+			sq.C("run_id").Gt(cursor[:]),
+			sq.C("workspace_id").Eq(opts.WorkspaceID),
+			sq.C("type").Eq(enums.HistoryTypeFunctionSkipped.String()),
+			sq.C("skip_reason").In(reasons),
+		}
+		if opts.WorkflowID != nil {
+			filter = append(filter, sq.C("function_id").Eq(*opts.WorkflowID))
+		}
+
+		query, args, err := sq.Dialect(r.dialect()).
+			From("history").
+			Select(
+				sq.C("run_id"),
+				sq.C("event_id"),
+				sq.C("batch_id"),
+				sq.C("function_id"),
+				sq.L("NULL"),
+			).
+			Where(filter...).
+			Order(sq.C("run_id").Asc()).
+			Limit(uint(opts.Limit)).
+			Prepared(true).
+			ToSQL()
+		if err != nil {
+			return nil, err
+		}
+
+		runs, err := r.queryReplayRuns(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get skipped replay runs: %w", err)
+		}
+		result = append(result, runs...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID.Compare(result[j].ID) < 0
+	})
+	if len(result) > opts.Limit {
+		result = result[:opts.Limit]
+	}
+	return result, nil
+}
+
+func (r *reader) queryReplayRuns(ctx context.Context, query string, args ...any) ([]history_reader.ReplayRun, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []history_reader.ReplayRun{}
+	for rows.Next() {
+		var (
+			run     history_reader.ReplayRun
+			batchID ulid.ULID
+			cron    sql.NullString
+		)
+		if err := rows.Scan(&run.ID, &run.EventID, &batchID, &run.WorkflowID, &cron); err != nil {
+			return nil, err
+		}
+		if batchID != nilULID {
+			run.BatchID = &batchID
+		}
+		if cron.Valid && cron.String != "" {
+			run.Cron = &cron.String
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
 }
 
 func (r *reader) CountReplayRuns(ctx context.Context, opts history_reader.CountReplayRunsOpts) (history_reader.ReplayRunCounts, error) {
-	return history_reader.ReplayRunCounts{}, errors.New("not implemented")
+	counts := history_reader.ReplayRunCounts{}
+	if err := opts.Validate(); err != nil {
+		return counts, err
+	}
+
+	lower, upper := replayRunBounds(opts.LowerTime, opts.UpperTime)
+
+	filter := []sq.Expression{
+		sq.C("run_id").Table("function_runs").Gte(lower[:]),
+		sq.C("run_id").Table("function_runs").Lt(upper[:]),
+		sq.C("workspace_id").Table("function_runs").Eq(opts.WorkspaceID),
+	}
+	if opts.WorkflowID != nil {
+		filter = append(filter, sq.C("function_id").Table("function_runs").Eq(*opts.WorkflowID))
+	}
+
+	query, args, err := sq.Dialect(r.dialect()).
+		From("function_runs").
+		Join(sq.T("function_finishes"), sq.On(sq.Ex{"function_finishes.run_id": sq.I("function_runs.run_id")})).
+		Select(sq.C("status").Table("function_finishes"), sq.COUNT(sq.Star())).
+		Where(filter...).
+		GroupBy(sq.C("status").Table("function_finishes")).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return counts, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return counts, fmt.Errorf("failed to count replay runs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return counts, err
+		}
+		switch status {
+		case enums.RunStatusCompleted.String():
+			counts.CompletedCount = count
+		case enums.RunStatusFailed.String():
+			counts.FailedCount = count
+		case enums.RunStatusCancelled.String():
+			counts.CancelledCount = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return counts, err
+	}
+
+	filter = []sq.Expression{
+		sq.C("run_id").Gte(lower[:]),
+		sq.C("run_id").Lt(upper[:]),
+		sq.C("workspace_id").Eq(opts.WorkspaceID),
+		sq.C("type").Eq(enums.HistoryTypeFunctionSkipped.String()),
+		sq.C("skip_reason").Eq(enums.SkipReasonFunctionPaused.String()),
+	}
+	if opts.WorkflowID != nil {
+		filter = append(filter, sq.C("function_id").Eq(*opts.WorkflowID))
+	}
+
+	query, args, err = sq.Dialect(r.dialect()).
+		From("history").
+		Select(sq.COUNT(sq.Star())).
+		Where(filter...).
+		Prepared(true).
+		ToSQL()
+	if err != nil {
+		return counts, err
+	}
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&counts.SkippedPausedCount); err != nil {
+		return counts, fmt.Errorf("failed to count skipped replay runs: %w", err)
+	}
+
+	return counts, nil
+}
+
+// replayRunBounds returns the run IDs bounding runs started within the given
+// times.  Run IDs embed their start time, so filtering on IDs avoids comparing
+// timestamps across databases.
+func replayRunBounds(lowerTime, upperTime time.Time) (ulid.ULID, ulid.ULID) {
+	var lower, upper ulid.ULID
+	_ = lower.SetTime(ulid.Timestamp(lowerTime))
+	_ = upper.SetTime(ulid.Timestamp(upperTime))
+	return lower, upper
 }
 
 func (r *reader) GetActiveRunIDs(
@@ -0,0 +1,19 @@
+CREATE TABLE dead_letters (
+    id BYTEA PRIMARY KEY,
+    account_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    app_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    function_version INT NOT NULL,
+    run_id BYTEA NOT NULL,
+    event_ids BYTEA NOT NULL,
+    events BYTEA NOT NULL,
+    step_id VARCHAR,
+    step_name VARCHAR,
+    error VARCHAR NOT NULL,
+    failed_at BIGINT NOT NULL,
+    redriven_at BIGINT,
+    redrive_run_id BYTEA
+);
+
+CREATE INDEX idx_dead_letters_function_id ON dead_letters (function_id);
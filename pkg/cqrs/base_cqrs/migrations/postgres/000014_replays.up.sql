@@ -0,0 +1,25 @@
+CREATE TABLE replays (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    app_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    name VARCHAR NOT NULL,
+    from_time BIGINT NOT NULL,
+    to_time BIGINT NOT NULL,
+    statuses VARCHAR NOT NULL,
+    rate INT NOT NULL,
+    status INT NOT NULL,
+    run_cursor BYTEA,
+    total_runs INT NOT NULL,
+    processed_runs INT NOT NULL DEFAULT 0,
+    scheduled_runs INT NOT NULL DEFAULT 0,
+    failed_runs INT NOT NULL DEFAULT 0,
+    error VARCHAR,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    ended_at BIGINT
+);
+
+CREATE INDEX idx_replays_function_id ON replays (function_id);
+CREATE INDEX idx_replays_status ON replays (status);
@@ -0,0 +1,2 @@
+ALTER TABLE functions
+DROP COLUMN paused_at;
@@ -0,0 +1,3 @@
+-- Adds a column to record when a function was paused
+ALTER TABLE functions
+ADD COLUMN paused_at TIMESTAMP;
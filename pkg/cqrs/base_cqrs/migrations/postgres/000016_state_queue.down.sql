@@ -0,0 +1,11 @@
+DROP TABLE queue_functions;
+DROP TABLE queue_idempotency;
+DROP TABLE queue_items;
+DROP TABLE pause_consumes;
+DROP TABLE pause_leases;
+DROP TABLE pause_signals;
+DROP TABLE pause_invokes;
+DROP TABLE pauses;
+DROP TABLE run_idempotency;
+DROP TABLE run_steps;
+DROP TABLE run_state;
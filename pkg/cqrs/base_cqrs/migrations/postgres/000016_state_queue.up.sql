@@ -0,0 +1,99 @@
+CREATE TABLE run_state (
+    run_id CHAR(26) PRIMARY KEY,
+    account_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    status INT NOT NULL,
+    metadata TEXT NOT NULL,
+    events TEXT NOT NULL,
+    pending TEXT NOT NULL DEFAULT '[]',
+    state_size BIGINT NOT NULL DEFAULT 0,
+    event_size BIGINT NOT NULL DEFAULT 0,
+    step_count INT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL
+);
+
+CREATE TABLE run_steps (
+    run_id CHAR(26) NOT NULL,
+    step_id VARCHAR NOT NULL,
+    output TEXT,
+    input TEXT,
+    stack_index INT,
+    PRIMARY KEY (run_id, step_id)
+);
+
+CREATE TABLE run_idempotency (
+    function_id UUID NOT NULL,
+    key VARCHAR NOT NULL,
+    run_id VARCHAR NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (function_id, key)
+);
+
+CREATE TABLE pauses (
+    id UUID PRIMARY KEY,
+    workspace_id UUID NOT NULL,
+    run_id CHAR(26) NOT NULL,
+    event VARCHAR NOT NULL DEFAULT '',
+    pause TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_pauses_workspace_event ON pauses (workspace_id, event, created_at);
+CREATE INDEX idx_pauses_run_id ON pauses (run_id);
+
+CREATE TABLE pause_invokes (
+    workspace_id UUID NOT NULL,
+    correlation_id VARCHAR NOT NULL,
+    pause_id UUID NOT NULL,
+    PRIMARY KEY (workspace_id, correlation_id)
+);
+
+CREATE TABLE pause_signals (
+    workspace_id UUID NOT NULL,
+    signal_id VARCHAR NOT NULL,
+    pause_id UUID NOT NULL,
+    PRIMARY KEY (workspace_id, signal_id)
+);
+
+CREATE TABLE pause_leases (
+    pause_id UUID PRIMARY KEY,
+    leased_until BIGINT NOT NULL
+);
+
+CREATE TABLE pause_consumes (
+    run_id CHAR(26) NOT NULL,
+    pause_id UUID NOT NULL,
+    idempotency_key VARCHAR NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (run_id, pause_id)
+);
+
+CREATE TABLE queue_items (
+    id VARCHAR PRIMARY KEY,
+    partition_id VARCHAR NOT NULL,
+    account_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    run_id CHAR(26) NOT NULL,
+    kind VARCHAR NOT NULL,
+    item TEXT NOT NULL,
+    at BIGINT NOT NULL,
+    lease_id CHAR(26),
+    leased_until BIGINT,
+    enqueued_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_queue_items_partition_at ON queue_items (partition_id, at);
+CREATE INDEX idx_queue_items_function_id ON queue_items (function_id, at);
+CREATE INDEX idx_queue_items_run_id ON queue_items (run_id);
+
+CREATE TABLE queue_idempotency (
+    id VARCHAR PRIMARY KEY,
+    expires_at BIGINT NOT NULL
+);
+
+CREATE TABLE queue_functions (
+    function_id UUID PRIMARY KEY,
+    paused BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -0,0 +1,2 @@
+DROP TABLE quarantined_events;
+DROP TABLE event_schemas;
@@ -0,0 +1,18 @@
+CREATE TABLE event_schemas (
+    app_id UUID NOT NULL,
+    event_name VARCHAR NOT NULL,
+    source VARCHAR NOT NULL,
+    schema BYTEA NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (app_id, event_name)
+);
+
+CREATE INDEX idx_event_schemas_event_name ON event_schemas (event_name);
+
+CREATE TABLE quarantined_events (
+    id BYTEA PRIMARY KEY,
+    event_name VARCHAR NOT NULL,
+    event BYTEA NOT NULL,
+    error VARCHAR NOT NULL,
+    received_at BIGINT NOT NULL
+);
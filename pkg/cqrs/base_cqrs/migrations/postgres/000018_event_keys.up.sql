@@ -0,0 +1,13 @@
+CREATE TABLE event_keys (
+    id UUID PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    key_hash VARCHAR NOT NULL UNIQUE,
+    key_prefix VARCHAR NOT NULL,
+    allowed_events VARCHAR NOT NULL,
+    rate_limit INT NOT NULL DEFAULT 0,
+    rate_period VARCHAR NOT NULL DEFAULT '',
+    expires_at BIGINT,
+    last_used_at BIGINT,
+    revoked_at BIGINT,
+    created_at BIGINT NOT NULL
+);
@@ -0,0 +1,8 @@
+CREATE TABLE webhook_sources (
+    id UUID PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    transform VARCHAR NOT NULL,
+    signature BYTEA,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE history DROP COLUMN skip_reason;
+ALTER TABLE history DROP COLUMN workspace_id;
@@ -0,0 +1,4 @@
+-- Records the workspace and skip reason of history rows, such that skipped runs
+-- can be filtered when replaying.
+ALTER TABLE history ADD COLUMN workspace_id CHAR(36);
+ALTER TABLE history ADD COLUMN skip_reason VARCHAR;
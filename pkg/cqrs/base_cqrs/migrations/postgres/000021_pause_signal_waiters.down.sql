@@ -0,0 +1 @@
+DROP TABLE pause_signal_waiters;
@@ -0,0 +1,6 @@
+CREATE TABLE pause_signal_waiters (
+    workspace_id UUID NOT NULL,
+    signal_id VARCHAR NOT NULL,
+    pause_id UUID NOT NULL,
+    PRIMARY KEY (workspace_id, signal_id, pause_id)
+);
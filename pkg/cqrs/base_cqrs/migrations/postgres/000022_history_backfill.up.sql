@@ -0,0 +1,5 @@
+-- History rows written before 000020 have no workspace or skip reason.  OSS only
+-- has the default workspace, and functions were only skipped because they were
+-- paused, so backfill both such that older skipped runs can be replayed.
+UPDATE history SET workspace_id = '00000000-0000-4000-b000-000000000000' WHERE workspace_id IS NULL;
+UPDATE history SET skip_reason = 'FunctionPaused' WHERE type = 'FunctionSkipped' AND skip_reason IS NULL;
@@ -0,0 +1,10 @@
+DROP TABLE event_dedup;
+DROP TABLE rate_limits;
+DROP TABLE cron_next_fires;
+DROP TABLE batch_items;
+DROP TABLE batches;
+DROP TABLE batch_pointers;
+DROP TABLE debounce_leading;
+DROP TABLE debounce_pointers;
+DROP TABLE debounces;
+DROP TABLE queue_singletons;
@@ -0,0 +1,78 @@
+CREATE TABLE queue_singletons (
+    key VARCHAR PRIMARY KEY,
+    run_id CHAR(26) NOT NULL
+);
+
+CREATE INDEX idx_queue_singletons_run_id ON queue_singletons (run_id);
+
+CREATE TABLE debounces (
+    id CHAR(26) PRIMARY KEY,
+    item TEXT NOT NULL
+);
+
+CREATE TABLE debounce_pointers (
+    function_id UUID NOT NULL,
+    key VARCHAR NOT NULL,
+    debounce_id CHAR(26) NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (function_id, key)
+);
+
+CREATE TABLE debounce_leading (
+    function_id UUID NOT NULL,
+    key VARCHAR NOT NULL,
+    timeout_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (function_id, key)
+);
+
+CREATE TABLE batch_pointers (
+    function_id UUID NOT NULL,
+    pointer VARCHAR NOT NULL,
+    batch_id CHAR(26) NOT NULL,
+    PRIMARY KEY (function_id, pointer)
+);
+
+CREATE TABLE batches (
+    id CHAR(26) PRIMARY KEY,
+    function_id UUID NOT NULL,
+    pointer VARCHAR NOT NULL,
+    key VARCHAR NOT NULL,
+    status VARCHAR NOT NULL,
+    size INT NOT NULL DEFAULT 0,
+    bytes BIGINT NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_batches_function_id ON batches (function_id, status);
+
+CREATE TABLE batch_items (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id CHAR(26) NOT NULL,
+    item TEXT NOT NULL
+);
+
+CREATE INDEX idx_batch_items_batch_id ON batch_items (batch_id);
+
+CREATE TABLE cron_next_fires (
+    function_id UUID NOT NULL,
+    field VARCHAR NOT NULL,
+    record TEXT NOT NULL,
+    PRIMARY KEY (function_id, field)
+);
+
+CREATE TABLE rate_limits (
+    key VARCHAR PRIMARY KEY,
+    value BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL
+);
+
+CREATE TABLE event_dedup (
+    workspace_id UUID NOT NULL,
+    event_id VARCHAR NOT NULL,
+    internal_id CHAR(26) NOT NULL,
+    expires_at BIGINT NOT NULL,
+    PRIMARY KEY (workspace_id, event_id)
+);
+
+CREATE INDEX idx_event_dedup_expires_at ON event_dedup (expires_at);
@@ -0,0 +1,19 @@
+CREATE TABLE dead_letters (
+    id CHAR(26) PRIMARY KEY,
+    account_id CHAR(36) NOT NULL,
+    workspace_id CHAR(36) NOT NULL,
+    app_id CHAR(36) NOT NULL,
+    function_id CHAR(36) NOT NULL,
+    function_version INT NOT NULL,
+    run_id CHAR(26) NOT NULL,
+    event_ids BLOB NOT NULL,
+    events BLOB NOT NULL,
+    step_id VARCHAR,
+    step_name VARCHAR,
+    error VARCHAR NOT NULL,
+    failed_at INT NOT NULL,
+    redriven_at INT,
+    redrive_run_id CHAR(26)
+);
+
+CREATE INDEX idx_dead_letters_function_id ON dead_letters (function_id);
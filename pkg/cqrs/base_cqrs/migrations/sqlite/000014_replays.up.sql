@@ -0,0 +1,25 @@
+CREATE TABLE replays (
+    id CHAR(36) PRIMARY KEY,
+    account_id CHAR(36) NOT NULL,
+    workspace_id CHAR(36) NOT NULL,
+    app_id CHAR(36) NOT NULL,
+    function_id CHAR(36) NOT NULL,
+    name VARCHAR NOT NULL,
+    from_time INT NOT NULL,
+    to_time INT NOT NULL,
+    statuses VARCHAR NOT NULL,
+    rate INT NOT NULL,
+    status INT NOT NULL,
+    run_cursor CHAR(26),
+    total_runs INT NOT NULL,
+    processed_runs INT NOT NULL DEFAULT 0,
+    scheduled_runs INT NOT NULL DEFAULT 0,
+    failed_runs INT NOT NULL DEFAULT 0,
+    error VARCHAR,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    ended_at INT
+);
+
+CREATE INDEX idx_replays_function_id ON replays (function_id);
+CREATE INDEX idx_replays_status ON replays (status);
@@ -0,0 +1 @@
+-- Run state and queue tables are only used by Postgres.
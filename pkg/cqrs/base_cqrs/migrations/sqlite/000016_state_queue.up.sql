@@ -0,0 +1,2 @@
+-- Run state and queue tables are only used by Postgres.  This keeps migration
+-- versions in step across dialects.
@@ -0,0 +1,18 @@
+CREATE TABLE event_schemas (
+    app_id CHAR(36) NOT NULL,
+    event_name VARCHAR NOT NULL,
+    source VARCHAR NOT NULL,
+    schema BLOB NOT NULL,
+    updated_at INT NOT NULL,
+    PRIMARY KEY (app_id, event_name)
+);
+
+CREATE INDEX idx_event_schemas_event_name ON event_schemas (event_name);
+
+CREATE TABLE quarantined_events (
+    id CHAR(26) PRIMARY KEY,
+    event_name VARCHAR NOT NULL,
+    event BLOB NOT NULL,
+    error VARCHAR NOT NULL,
+    received_at INT NOT NULL
+);
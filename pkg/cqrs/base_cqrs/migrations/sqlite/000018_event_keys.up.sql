@@ -0,0 +1,13 @@
+CREATE TABLE event_keys (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    key_hash VARCHAR NOT NULL UNIQUE,
+    key_prefix VARCHAR NOT NULL,
+    allowed_events VARCHAR NOT NULL,
+    rate_limit INT NOT NULL DEFAULT 0,
+    rate_period VARCHAR NOT NULL DEFAULT '',
+    expires_at INT,
+    last_used_at INT,
+    revoked_at INT,
+    created_at INT NOT NULL
+);
@@ -0,0 +1,8 @@
+CREATE TABLE webhook_sources (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    transform VARCHAR NOT NULL,
+    signature BLOB,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL
+);
@@ -0,0 +1 @@
+-- Pause tables are only used by Postgres.
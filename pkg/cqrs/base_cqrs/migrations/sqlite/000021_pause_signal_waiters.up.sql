@@ -0,0 +1,2 @@
+-- Pause tables are only used by Postgres.  This keeps migration versions in
+-- step across dialects.
@@ -0,0 +1 @@
+-- The backfilled values can't be told apart from written values, so this is a no-op.
@@ -0,0 +1 @@
+-- Queue dependency tables are only used by Postgres.
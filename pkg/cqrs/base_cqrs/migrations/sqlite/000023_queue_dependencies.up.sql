@@ -0,0 +1,2 @@
+-- Queue dependency tables are only used by Postgres.  This keeps migration
+-- versions in step across dialects.
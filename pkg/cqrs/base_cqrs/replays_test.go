@@ -0,0 +1,259 @@
+package base_cqrs
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/cqrs"
+	sqlc "github.com/inngest/inngest/pkg/cqrs/base_cqrs/sqlc/sqlite"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution/history"
+	"github.com/inngest/inngest/pkg/history_reader"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplays(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := NewCQRS(db, "sqlite")
+
+	wsID, fnID := uuid.New(), uuid.New()
+	now := time.Now().Truncate(time.Millisecond)
+
+	r := cqrs.Replay{
+		ID:          uuid.New(),
+		AccountID:   uuid.New(),
+		WorkspaceID: wsID,
+		AppID:       uuid.New(),
+		FunctionID:  fnID,
+		Name:        "test",
+		FromTime:    now.Add(-time.Hour),
+		ToTime:      now,
+		Statuses:    []enums.ReplayRunStatus{enums.ReplayRunStatusFailed, enums.ReplayRunStatusSkippedPaused},
+		Rate:        cqrs.DefaultReplayRate,
+		Status:      enums.ReplayStatusRunning,
+		TotalRuns:   3,
+	}
+	require.NoError(t, mgr.InsertReplay(ctx, r))
+
+	t.Run("loads replays", func(t *testing.T) {
+		found, err := mgr.Replay(ctx, wsID, r.ID)
+		require.NoError(t, err)
+		require.Equal(t, r.Name, found.Name)
+		require.Equal(t, r.Statuses, found.Statuses)
+		require.Equal(t, r.FromTime.UnixMilli(), found.FromTime.UnixMilli())
+		require.Equal(t, r.ToTime.UnixMilli(), found.ToTime.UnixMilli())
+		require.Equal(t, enums.ReplayStatusRunning, found.Status)
+		require.Nil(t, found.Cursor)
+		require.Nil(t, found.EndedAt)
+
+		replays, err := mgr.FunctionReplays(ctx, wsID, fnID)
+		require.NoError(t, err)
+		require.Len(t, replays, 1)
+
+		_, err = mgr.Replay(ctx, uuid.New(), r.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("stores progress", func(t *testing.T) {
+		cursor := ulid.MustNew(ulid.Now(), rand.Reader)
+		r.Cursor = &cursor
+		r.ProcessedRuns, r.ScheduledRuns, r.FailedRuns = 2, 1, 1
+		require.NoError(t, mgr.UpdateReplayProgress(ctx, r))
+
+		found, err := mgr.Replay(ctx, wsID, r.ID)
+		require.NoError(t, err)
+		require.Equal(t, cursor, *found.Cursor)
+		require.Equal(t, 2, found.ProcessedRuns)
+		require.Equal(t, 1, found.ScheduledRuns)
+		require.Equal(t, 1, found.FailedRuns)
+	})
+
+	t.Run("updates status", func(t *testing.T) {
+		running, err := mgr.ReplaysByStatus(ctx, enums.ReplayStatusRunning)
+		require.NoError(t, err)
+		require.Len(t, running, 1)
+
+		msg := "boom"
+		require.NoError(t, mgr.UpdateReplayStatus(ctx, wsID, r.ID, enums.ReplayStatusFailed, &msg))
+
+		found, err := mgr.Replay(ctx, wsID, r.ID)
+		require.NoError(t, err)
+		require.Equal(t, enums.ReplayStatusFailed, found.Status)
+		require.Equal(t, msg, *found.Error)
+		require.NotNil(t, found.EndedAt)
+
+		running, err = mgr.ReplaysByStatus(ctx, enums.ReplayStatusRunning)
+		require.NoError(t, err)
+		require.Len(t, running, 0)
+	})
+}
+
+func TestGetReplayRuns(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := NewCQRS(db, "sqlite")
+	q := sqlc.New(db)
+	hr := NewHistoryReader(db, "sqlite")
+
+	accountID, wsID, fnID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	finished := func(at time.Time, status enums.RunStatus) ulid.ULID {
+		runID := ulid.MustNew(ulid.Timestamp(at), rand.Reader)
+		require.NoError(t, mgr.InsertFunctionRun(ctx, cqrs.FunctionRun{
+			RunID:        runID,
+			RunStartedAt: at,
+			FunctionID:   fnID,
+			WorkspaceID:  wsID,
+			EventID:      ulid.MustNew(ulid.Timestamp(at), rand.Reader),
+		}))
+		require.NoError(t, q.InsertFunctionFinish(ctx, sqlc.InsertFunctionFinishParams{
+			RunID:              runID,
+			Status:             sql.NullString{String: status.String(), Valid: true},
+			Output:             sql.NullString{String: "{}", Valid: true},
+			CompletedStepCount: sql.NullInt64{Int64: 1, Valid: true},
+			CreatedAt:          sql.NullTime{Time: at, Valid: true},
+		}))
+		return runID
+	}
+
+	skipped := func(at time.Time, wsID uuid.UUID, reason enums.SkipReason) ulid.ULID {
+		runID := ulid.MustNew(ulid.Timestamp(at), rand.Reader)
+		require.NoError(t, mgr.InsertHistory(ctx, history.History{
+			ID:          ulid.MustNew(ulid.Now(), rand.Reader),
+			AccountID:   accountID,
+			WorkspaceID: wsID,
+			CreatedAt:   at,
+			FunctionID:  fnID,
+			RunID:       runID,
+			EventID:     ulid.MustNew(ulid.Timestamp(at), rand.Reader),
+			SkipReason:  &reason,
+			Type:        enums.HistoryTypeFunctionSkipped.String(),
+		}))
+		return runID
+	}
+
+	completed := finished(now.Add(-50*time.Minute), enums.RunStatusCompleted)
+	failed := finished(now.Add(-40*time.Minute), enums.RunStatusFailed)
+	paused := skipped(now.Add(-30*time.Minute), wsID, enums.SkipReasonFunctionPaused)
+	// Singleton skips and skips in other workspaces are never replayed.
+	_ = skipped(now.Add(-25*time.Minute), wsID, enums.SkipReasonSingleton)
+	_ = skipped(now.Add(-25*time.Minute), uuid.New(), enums.SkipReasonFunctionPaused)
+	_ = finished(now.Add(-20*time.Minute), enums.RunStatusCancelled)
+	// Outside of the time range.
+	_ = finished(now.Add(-2*time.Hour), enums.RunStatusFailed)
+
+	opts := history_reader.GetReplayRunsOpts{
+		AccountID:   accountID,
+		WorkspaceID: wsID,
+		WorkflowID:  &fnID,
+		LowerTime:   now.Add(-time.Hour),
+		UpperTime:   now,
+		Statuses:    []enums.RunStatus{enums.RunStatusCompleted, enums.RunStatusFailed},
+		SkipReasons: []enums.SkipReason{enums.SkipReasonFunctionPaused},
+	}
+
+	t.Run("returns matching runs in order", func(t *testing.T) {
+		runs, err := hr.GetReplayRuns(ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, runs, 3)
+		require.Equal(t, completed, runs[0].ID)
+		require.Equal(t, failed, runs[1].ID)
+		require.Equal(t, paused, runs[2].ID)
+		require.Nil(t, runs[0].BatchID)
+		require.Equal(t, fnID, runs[2].WorkflowID)
+	})
+
+	t.Run("paginates using the cursor", func(t *testing.T) {
+		opts := opts
+		opts.Limit = 2
+		runs, err := hr.GetReplayRuns(ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		require.Equal(t, failed, runs[1].ID)
+
+		opts.Cursor = &runs[1].ID
+		runs, err = hr.GetReplayRuns(ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		require.Equal(t, paused, runs[0].ID)
+	})
+
+	t.Run("counts runs", func(t *testing.T) {
+		counts, err := hr.CountReplayRuns(ctx, history_reader.CountReplayRunsOpts{
+			AccountID:   accountID,
+			WorkspaceID: wsID,
+			WorkflowID:  &fnID,
+			LowerTime:   now.Add(-time.Hour),
+			UpperTime:   now,
+		})
+		require.NoError(t, err)
+		require.Equal(t, history_reader.ReplayRunCounts{
+			CompletedCount:     1,
+			FailedCount:        1,
+			CancelledCount:     1,
+			SkippedPausedCount: 1,
+		}, counts)
+	})
+}
+
+func TestHistoryBackfill(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := New(BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := NewCQRS(db, "sqlite")
+	hr := NewHistoryReader(db, "sqlite")
+
+	fnID, now := uuid.New(), time.Now()
+	runID := ulid.MustNew(ulid.Timestamp(now.Add(-time.Minute)), rand.Reader)
+	reason := enums.SkipReasonFunctionPaused
+	require.NoError(t, mgr.InsertHistory(ctx, history.History{
+		ID:          ulid.MustNew(ulid.Now(), rand.Reader),
+		AccountID:   consts.DevServerAccountID,
+		WorkspaceID: consts.DevServerEnvID,
+		CreatedAt:   now,
+		FunctionID:  fnID,
+		RunID:       runID,
+		EventID:     ulid.MustNew(ulid.Now(), rand.Reader),
+		SkipReason:  &reason,
+		Type:        enums.HistoryTypeFunctionSkipped.String(),
+	}))
+
+	// Rows written before the columns were added have neither set.
+	_, err = db.ExecContext(ctx, "UPDATE history SET workspace_id = NULL, skip_reason = NULL")
+	require.NoError(t, err)
+
+	opts := history_reader.GetReplayRunsOpts{
+		AccountID:   consts.DevServerAccountID,
+		WorkspaceID: consts.DevServerEnvID,
+		WorkflowID:  &fnID,
+		LowerTime:   now.Add(-time.Hour),
+		UpperTime:   now,
+		SkipReasons: []enums.SkipReason{enums.SkipReasonFunctionPaused},
+	}
+	runs, err := hr.GetReplayRuns(ctx, opts)
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	backfill, err := FS.ReadFile("migrations/sqlite/000022_history_backfill.up.sql")
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, string(backfill))
+	require.NoError(t, err)
+
+	runs, err = hr.GetReplayRuns(ctx, opts)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, runID, runs[0].ID)
+}
@@ -347,6 +347,20 @@ func (q NormalizedQueries) UpdateFunctionConfig(ctx context.Context, arg sqlc_sq
 	return function.ToSQLite()
 }
 
+func (q NormalizedQueries) UpdateFunctionPausedAt(ctx context.Context, arg sqlc_sqlite.UpdateFunctionPausedAtParams) (*sqlc_sqlite.Function, error) {
+	pgParams := UpdateFunctionPausedAtParams{
+		PausedAt: arg.PausedAt,
+		ID:       arg.ID,
+	}
+
+	function, err := q.db.UpdateFunctionPausedAt(ctx, pgParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return function.ToSQLite()
+}
+
 func (q NormalizedQueries) InsertEvent(ctx context.Context, e sqlc_sqlite.InsertEventParams) error {
 	pgParams := InsertEventParams{
 		InternalID: e.InternalID,
@@ -507,6 +521,23 @@ func (q NormalizedQueries) GetEventsIDbound(ctx context.Context, params sqlc_sql
 	return sqliteEvents, nil
 }
 
+func (q NormalizedQueries) GetEventsAfter(ctx context.Context, params sqlc_sqlite.GetEventsAfterParams) ([]*sqlc_sqlite.Event, error) {
+	events, err := q.db.GetEventsAfter(ctx, GetEventsAfterParams{
+		After: params.After,
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteEvents := make([]*sqlc_sqlite.Event, len(events))
+	for i, event := range events {
+		sqliteEvents[i], _ = event.ToSQLite()
+	}
+
+	return sqliteEvents, nil
+}
+
 func (q NormalizedQueries) InsertFunctionRun(ctx context.Context, e sqlc_sqlite.InsertFunctionRunParams) error {
 	pgParams := InsertFunctionRunParams{
 		RunID:           e.RunID,
@@ -551,6 +582,23 @@ func (q NormalizedQueries) GetFunctionRun(ctx context.Context, id ulid.ULID) (*s
 	return row.ToSQLite()
 }
 
+func (q NormalizedQueries) GetFunctionRunsAfter(ctx context.Context, params sqlc_sqlite.GetFunctionRunsAfterParams) ([]*sqlc_sqlite.FunctionRun, error) {
+	runs, err := q.db.GetFunctionRunsAfter(ctx, GetFunctionRunsAfterParams{
+		After: params.After,
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRuns := make([]*sqlc_sqlite.FunctionRun, len(runs))
+	for i, run := range runs {
+		sqliteRuns[i], _ = run.ToSQLite()
+	}
+
+	return sqliteRuns, nil
+}
+
 func (q NormalizedQueries) GetFunctionRunsTimebound(ctx context.Context, params sqlc_sqlite.GetFunctionRunsTimeboundParams) ([]*sqlc_sqlite.GetFunctionRunsTimeboundRow, error) {
 	pgParams := GetFunctionRunsTimeboundParams{}
 
@@ -613,6 +661,8 @@ func (q NormalizedQueries) InsertHistory(ctx context.Context, h sqlc_sqlite.Inse
 		InvokeFunction:       h.InvokeFunction,
 		InvokeFunctionResult: h.InvokeFunctionResult,
 		Result:               h.Result,
+		WorkspaceID:          h.WorkspaceID,
+		SkipReason:           h.SkipReason,
 	}
 
 	return q.db.InsertHistory(ctx, pgParams)
@@ -710,6 +760,23 @@ func (q NormalizedQueries) GetTraceRun(ctx context.Context, runID ulid.ULID) (*s
 	return traceRun.ToSQLite()
 }
 
+func (q NormalizedQueries) GetTraceRunsAfter(ctx context.Context, params sqlc_sqlite.GetTraceRunsAfterParams) ([]*sqlc_sqlite.TraceRun, error) {
+	runs, err := q.db.GetTraceRunsAfter(ctx, GetTraceRunsAfterParams{
+		After: params.After.String(),
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRuns := make([]*sqlc_sqlite.TraceRun, len(runs))
+	for i, run := range runs {
+		sqliteRuns[i], _ = run.ToSQLite()
+	}
+
+	return sqliteRuns, nil
+}
+
 func (q NormalizedQueries) GetTraceSpanOutput(ctx context.Context, arg sqlc_sqlite.GetTraceSpanOutputParams) ([]*sqlc_sqlite.Trace, error) {
 	pgArg := GetTraceSpanOutputParams{
 		TraceID: arg.TraceID,
@@ -750,6 +817,23 @@ func (q NormalizedQueries) HistoryCountRuns(ctx context.Context) (int64, error)
 	return q.db.HistoryCountRuns(ctx)
 }
 
+func (q NormalizedQueries) GetHistoryAfter(ctx context.Context, params sqlc_sqlite.GetHistoryAfterParams) ([]*sqlc_sqlite.History, error) {
+	history, err := q.db.GetHistoryAfter(ctx, GetHistoryAfterParams{
+		After: params.After,
+		Limit: int32(params.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteHistory := make([]*sqlc_sqlite.History, len(history))
+	for i, h := range history {
+		sqliteHistory[i], _ = h.ToSQLite()
+	}
+
+	return sqliteHistory, nil
+}
+
 func (q NormalizedQueries) GetHistoryItem(ctx context.Context, id ulid.ULID) (*sqlc_sqlite.History, error) {
 	history, err := q.db.GetHistoryItem(ctx, id)
 	if err != nil {
@@ -772,3 +856,390 @@ func (q NormalizedQueries) GetFunctionRuns(ctx context.Context) ([]*sqlc_sqlite.
 
 	return sqliteRows, nil
 }
+
+func (q NormalizedQueries) InsertDeadLetter(ctx context.Context, arg sqlc_sqlite.InsertDeadLetterParams) error {
+	// Keep CodeQL happy
+	if arg.FunctionVersion > math.MaxInt32 || arg.FunctionVersion < math.MinInt32 {
+		return fmt.Errorf("function version must be a valid int32")
+	}
+
+	return q.db.InsertDeadLetter(ctx, InsertDeadLetterParams{
+		ID:              arg.ID,
+		AccountID:       arg.AccountID,
+		WorkspaceID:     arg.WorkspaceID,
+		AppID:           arg.AppID,
+		FunctionID:      arg.FunctionID,
+		FunctionVersion: int32(arg.FunctionVersion),
+		RunID:           arg.RunID,
+		EventIds:        arg.EventIds,
+		Events:          arg.Events,
+		StepID:          arg.StepID,
+		StepName:        arg.StepName,
+		Error:           arg.Error,
+		FailedAt:        arg.FailedAt,
+	})
+}
+
+func (q NormalizedQueries) GetDeadLetter(ctx context.Context, arg sqlc_sqlite.GetDeadLetterParams) (*sqlc_sqlite.DeadLetter, error) {
+	dl, err := q.db.GetDeadLetter(ctx, GetDeadLetterParams{
+		WorkspaceID: arg.WorkspaceID,
+		ID:          arg.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dl.ToSQLite()
+}
+
+func (q NormalizedQueries) GetDeadLetters(ctx context.Context, arg sqlc_sqlite.GetDeadLettersParams) ([]*sqlc_sqlite.DeadLetter, error) {
+	// Keep CodeQL happy
+	if arg.Limit > math.MaxInt32 || arg.Limit < math.MinInt32 {
+		return nil, fmt.Errorf("limit must be a valid int32")
+	}
+
+	rows, err := q.db.GetDeadLetters(ctx, GetDeadLettersParams{
+		WorkspaceID: arg.WorkspaceID,
+		Cursor:      arg.Cursor,
+		Before:      arg.Before,
+		After:       arg.After,
+		Limit:       int32(arg.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.DeadLetter, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) GetFunctionDeadLetters(ctx context.Context, arg sqlc_sqlite.GetFunctionDeadLettersParams) ([]*sqlc_sqlite.DeadLetter, error) {
+	// Keep CodeQL happy
+	if arg.Limit > math.MaxInt32 || arg.Limit < math.MinInt32 {
+		return nil, fmt.Errorf("limit must be a valid int32")
+	}
+
+	rows, err := q.db.GetFunctionDeadLetters(ctx, GetFunctionDeadLettersParams{
+		WorkspaceID: arg.WorkspaceID,
+		FunctionID:  arg.FunctionID,
+		Cursor:      arg.Cursor,
+		Before:      arg.Before,
+		After:       arg.After,
+		Limit:       int32(arg.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.DeadLetter, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) UpdateDeadLetterRedrive(ctx context.Context, arg sqlc_sqlite.UpdateDeadLetterRedriveParams) error {
+	return q.db.UpdateDeadLetterRedrive(ctx, UpdateDeadLetterRedriveParams{
+		RedrivenAt:   arg.RedrivenAt,
+		RedriveRunID: arg.RedriveRunID,
+		WorkspaceID:  arg.WorkspaceID,
+		ID:           arg.ID,
+	})
+}
+
+func (q NormalizedQueries) InsertReplay(ctx context.Context, arg sqlc_sqlite.InsertReplayParams) error {
+	// Keep CodeQL happy
+	for _, n := range []int64{arg.Rate, arg.Status, arg.TotalRuns} {
+		if n > math.MaxInt32 || n < math.MinInt32 {
+			return fmt.Errorf("replay values must be valid int32s")
+		}
+	}
+
+	return q.db.InsertReplay(ctx, InsertReplayParams{
+		ID:          arg.ID,
+		AccountID:   arg.AccountID,
+		WorkspaceID: arg.WorkspaceID,
+		AppID:       arg.AppID,
+		FunctionID:  arg.FunctionID,
+		Name:        arg.Name,
+		FromTime:    arg.FromTime,
+		ToTime:      arg.ToTime,
+		Statuses:    arg.Statuses,
+		Rate:        int32(arg.Rate),
+		Status:      int32(arg.Status),
+		TotalRuns:   int32(arg.TotalRuns),
+		CreatedAt:   arg.CreatedAt,
+		UpdatedAt:   arg.UpdatedAt,
+	})
+}
+
+func (q NormalizedQueries) GetReplay(ctx context.Context, arg sqlc_sqlite.GetReplayParams) (*sqlc_sqlite.Replay, error) {
+	r, err := q.db.GetReplay(ctx, GetReplayParams{
+		WorkspaceID: arg.WorkspaceID,
+		ID:          arg.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ToSQLite()
+}
+
+func (q NormalizedQueries) GetFunctionReplays(ctx context.Context, arg sqlc_sqlite.GetFunctionReplaysParams) ([]*sqlc_sqlite.Replay, error) {
+	rows, err := q.db.GetFunctionReplays(ctx, GetFunctionReplaysParams{
+		WorkspaceID: arg.WorkspaceID,
+		FunctionID:  arg.FunctionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.Replay, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) GetReplaysByStatus(ctx context.Context, status int64) ([]*sqlc_sqlite.Replay, error) {
+	// Keep CodeQL happy
+	if status > math.MaxInt32 || status < math.MinInt32 {
+		return nil, fmt.Errorf("status must be a valid int32")
+	}
+
+	rows, err := q.db.GetReplaysByStatus(ctx, int32(status))
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.Replay, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) UpdateReplayProgress(ctx context.Context, arg sqlc_sqlite.UpdateReplayProgressParams) error {
+	// Keep CodeQL happy
+	for _, n := range []int64{arg.ProcessedRuns, arg.ScheduledRuns, arg.FailedRuns} {
+		if n > math.MaxInt32 || n < math.MinInt32 {
+			return fmt.Errorf("replay run counts must be valid int32s")
+		}
+	}
+
+	return q.db.UpdateReplayProgress(ctx, UpdateReplayProgressParams{
+		RunCursor:     arg.RunCursor,
+		ProcessedRuns: int32(arg.ProcessedRuns),
+		ScheduledRuns: int32(arg.ScheduledRuns),
+		FailedRuns:    int32(arg.FailedRuns),
+		UpdatedAt:     arg.UpdatedAt,
+		ID:            arg.ID,
+	})
+}
+
+func (q NormalizedQueries) UpdateReplayStatus(ctx context.Context, arg sqlc_sqlite.UpdateReplayStatusParams) error {
+	// Keep CodeQL happy
+	if arg.Status > math.MaxInt32 || arg.Status < math.MinInt32 {
+		return fmt.Errorf("status must be a valid int32")
+	}
+
+	return q.db.UpdateReplayStatus(ctx, UpdateReplayStatusParams{
+		Status:      int32(arg.Status),
+		Error:       arg.Error,
+		UpdatedAt:   arg.UpdatedAt,
+		EndedAt:     arg.EndedAt,
+		WorkspaceID: arg.WorkspaceID,
+		ID:          arg.ID,
+	})
+}
+
+func (q NormalizedQueries) InsertEventSchema(ctx context.Context, arg sqlc_sqlite.InsertEventSchemaParams) error {
+	return q.db.InsertEventSchema(ctx, InsertEventSchemaParams{
+		AppID:     arg.AppID,
+		EventName: arg.EventName,
+		Source:    arg.Source,
+		Schema:    arg.Schema,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (q NormalizedQueries) DeleteEventSchemasByApp(ctx context.Context, appID uuid.UUID) error {
+	return q.db.DeleteEventSchemasByApp(ctx, appID)
+}
+
+func (q NormalizedQueries) GetEventSchemas(ctx context.Context) ([]*sqlc_sqlite.EventSchema, error) {
+	rows, err := q.db.GetEventSchemas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.EventSchema, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) GetEventSchemasByName(ctx context.Context, eventName string) ([]*sqlc_sqlite.EventSchema, error) {
+	rows, err := q.db.GetEventSchemasByName(ctx, eventName)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.EventSchema, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) InsertQuarantinedEvent(ctx context.Context, arg sqlc_sqlite.InsertQuarantinedEventParams) error {
+	return q.db.InsertQuarantinedEvent(ctx, InsertQuarantinedEventParams{
+		ID:         arg.ID,
+		EventName:  arg.EventName,
+		Event:      arg.Event,
+		Error:      arg.Error,
+		ReceivedAt: arg.ReceivedAt,
+	})
+}
+
+func (q NormalizedQueries) GetQuarantinedEvents(ctx context.Context, arg sqlc_sqlite.GetQuarantinedEventsParams) ([]*sqlc_sqlite.QuarantinedEvent, error) {
+	// Keep CodeQL happy
+	if arg.Limit > math.MaxInt32 || arg.Limit < math.MinInt32 {
+		return nil, fmt.Errorf("limit must be a valid int32")
+	}
+
+	rows, err := q.db.GetQuarantinedEvents(ctx, GetQuarantinedEventsParams{
+		Cursor: arg.Cursor,
+		Limit:  int32(arg.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.QuarantinedEvent, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) InsertEventKey(ctx context.Context, arg sqlc_sqlite.InsertEventKeyParams) error {
+	// Keep CodeQL happy
+	if arg.RateLimit > math.MaxInt32 || arg.RateLimit < math.MinInt32 {
+		return fmt.Errorf("rate limit must be a valid int32")
+	}
+
+	return q.db.InsertEventKey(ctx, InsertEventKeyParams{
+		ID:            arg.ID,
+		Name:          arg.Name,
+		KeyHash:       arg.KeyHash,
+		KeyPrefix:     arg.KeyPrefix,
+		AllowedEvents: arg.AllowedEvents,
+		RateLimit:     int32(arg.RateLimit),
+		RatePeriod:    arg.RatePeriod,
+		ExpiresAt:     arg.ExpiresAt,
+		CreatedAt:     arg.CreatedAt,
+	})
+}
+
+func (q NormalizedQueries) GetEventKeys(ctx context.Context) ([]*sqlc_sqlite.EventKey, error) {
+	rows, err := q.db.GetEventKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.EventKey, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) GetEventKey(ctx context.Context, id uuid.UUID) (*sqlc_sqlite.EventKey, error) {
+	row, err := q.db.GetEventKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return row.ToSQLite()
+}
+
+func (q NormalizedQueries) GetEventKeyByHash(ctx context.Context, keyHash string) (*sqlc_sqlite.EventKey, error) {
+	row, err := q.db.GetEventKeyByHash(ctx, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	return row.ToSQLite()
+}
+
+func (q NormalizedQueries) RevokeEventKey(ctx context.Context, arg sqlc_sqlite.RevokeEventKeyParams) error {
+	return q.db.RevokeEventKey(ctx, RevokeEventKeyParams{
+		RevokedAt: arg.RevokedAt,
+		ID:        arg.ID,
+	})
+}
+
+func (q NormalizedQueries) UpdateEventKeyLastUsed(ctx context.Context, arg sqlc_sqlite.UpdateEventKeyLastUsedParams) error {
+	return q.db.UpdateEventKeyLastUsed(ctx, UpdateEventKeyLastUsedParams{
+		LastUsedAt: arg.LastUsedAt,
+		ID:         arg.ID,
+	})
+}
+
+func (q NormalizedQueries) InsertWebhookSource(ctx context.Context, arg sqlc_sqlite.InsertWebhookSourceParams) error {
+	return q.db.InsertWebhookSource(ctx, InsertWebhookSourceParams{
+		ID:        arg.ID,
+		Name:      arg.Name,
+		Transform: arg.Transform,
+		Signature: arg.Signature,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	})
+}
+
+func (q NormalizedQueries) GetWebhookSources(ctx context.Context) ([]*sqlc_sqlite.WebhookSource, error) {
+	rows, err := q.db.GetWebhookSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqliteRows := make([]*sqlc_sqlite.WebhookSource, len(rows))
+	for i, row := range rows {
+		sqliteRows[i], _ = row.ToSQLite()
+	}
+
+	return sqliteRows, nil
+}
+
+func (q NormalizedQueries) GetWebhookSource(ctx context.Context, id uuid.UUID) (*sqlc_sqlite.WebhookSource, error) {
+	row, err := q.db.GetWebhookSource(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return row.ToSQLite()
+}
+
+func (q NormalizedQueries) UpdateWebhookSource(ctx context.Context, arg sqlc_sqlite.UpdateWebhookSourceParams) error {
+	return q.db.UpdateWebhookSource(ctx, UpdateWebhookSourceParams{
+		Name:      arg.Name,
+		Transform: arg.Transform,
+		Signature: arg.Signature,
+		UpdatedAt: arg.UpdatedAt,
+		ID:        arg.ID,
+	})
+}
+
+func (q NormalizedQueries) DeleteWebhookSource(ctx context.Context, id uuid.UUID) error {
+	return q.db.DeleteWebhookSource(ctx, id)
+}
@@ -33,6 +33,7 @@ func (f *Function) ToSQLite() (*sqlc.Function, error) {
 		Config:     f.Config,
 		CreatedAt:  f.CreatedAt,
 		ArchivedAt: f.ArchivedAt,
+		PausedAt:   f.PausedAt,
 	}, nil
 }
 
@@ -147,6 +148,8 @@ func (h *History) ToSQLite() (*sqlc.History, error) {
 		InvokeFunction:       h.InvokeFunction,
 		InvokeFunctionResult: h.InvokeFunctionResult,
 		Result:               h.Result,
+		WorkspaceID:          h.WorkspaceID,
+		SkipReason:           h.SkipReason,
 	}, nil
 }
 
@@ -323,3 +326,95 @@ func (wc *WorkerConnection) ToSQLite() (*sqlc.WorkerConnection, error) {
 		Os:               wc.Os,
 	}, nil
 }
+
+func (dl *DeadLetter) ToSQLite() (*sqlc.DeadLetter, error) {
+	return &sqlc.DeadLetter{
+		ID:              dl.ID,
+		AccountID:       dl.AccountID,
+		WorkspaceID:     dl.WorkspaceID,
+		AppID:           dl.AppID,
+		FunctionID:      dl.FunctionID,
+		FunctionVersion: int64(dl.FunctionVersion),
+		RunID:           dl.RunID,
+		EventIds:        dl.EventIds,
+		Events:          dl.Events,
+		StepID:          dl.StepID,
+		StepName:        dl.StepName,
+		Error:           dl.Error,
+		FailedAt:        dl.FailedAt,
+		RedrivenAt:      dl.RedrivenAt,
+		RedriveRunID:    dl.RedriveRunID,
+	}, nil
+}
+
+func (r *Replay) ToSQLite() (*sqlc.Replay, error) {
+	return &sqlc.Replay{
+		ID:            r.ID,
+		AccountID:     r.AccountID,
+		WorkspaceID:   r.WorkspaceID,
+		AppID:         r.AppID,
+		FunctionID:    r.FunctionID,
+		Name:          r.Name,
+		FromTime:      r.FromTime,
+		ToTime:        r.ToTime,
+		Statuses:      r.Statuses,
+		Rate:          int64(r.Rate),
+		Status:        int64(r.Status),
+		RunCursor:     r.RunCursor,
+		TotalRuns:     int64(r.TotalRuns),
+		ProcessedRuns: int64(r.ProcessedRuns),
+		ScheduledRuns: int64(r.ScheduledRuns),
+		FailedRuns:    int64(r.FailedRuns),
+		Error:         r.Error,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+		EndedAt:       r.EndedAt,
+	}, nil
+}
+
+func (es *EventSchema) ToSQLite() (*sqlc.EventSchema, error) {
+	return &sqlc.EventSchema{
+		AppID:     es.AppID,
+		EventName: es.EventName,
+		Source:    es.Source,
+		Schema:    es.Schema,
+		UpdatedAt: es.UpdatedAt,
+	}, nil
+}
+
+func (qe *QuarantinedEvent) ToSQLite() (*sqlc.QuarantinedEvent, error) {
+	return &sqlc.QuarantinedEvent{
+		ID:         qe.ID,
+		EventName:  qe.EventName,
+		Event:      qe.Event,
+		Error:      qe.Error,
+		ReceivedAt: qe.ReceivedAt,
+	}, nil
+}
+
+func (ek *EventKey) ToSQLite() (*sqlc.EventKey, error) {
+	return &sqlc.EventKey{
+		ID:            ek.ID,
+		Name:          ek.Name,
+		KeyHash:       ek.KeyHash,
+		KeyPrefix:     ek.KeyPrefix,
+		AllowedEvents: ek.AllowedEvents,
+		RateLimit:     int64(ek.RateLimit),
+		RatePeriod:    ek.RatePeriod,
+		ExpiresAt:     ek.ExpiresAt,
+		LastUsedAt:    ek.LastUsedAt,
+		RevokedAt:     ek.RevokedAt,
+		CreatedAt:     ek.CreatedAt,
+	}, nil
+}
+
+func (ws *WebhookSource) ToSQLite() (*sqlc.WebhookSource, error) {
+	return &sqlc.WebhookSource{
+		ID:        ws.ID,
+		Name:      ws.Name,
+		Transform: ws.Transform,
+		Signature: ws.Signature,
+		CreatedAt: ws.CreatedAt,
+		UpdatedAt: ws.UpdatedAt,
+	}, nil
+}
@@ -78,6 +78,9 @@ SELECT * FROM functions WHERE slug = $1 AND archived_at IS NULL;
 -- name: UpdateFunctionConfig :one
 UPDATE functions SET config = $1, archived_at = NULL WHERE id = $2 RETURNING *;
 
+-- name: UpdateFunctionPausedAt :one
+UPDATE functions SET paused_at = $1 WHERE id = $2 RETURNING *;
+
 -- name: DeleteFunctionsByAppID :exec
 UPDATE functions SET archived_at = CURRENT_TIMESTAMP WHERE app_id = $1;
 
@@ -127,6 +130,9 @@ FROM function_runs
 LEFT JOIN function_finishes ON function_finishes.run_id = function_runs.run_id
 WHERE function_runs.event_id IN (SELECT UNNEST(sqlc.slice('event_ids')::BYTEA[]));
 
+-- name: GetFunctionRunsAfter :many
+SELECT * FROM function_runs WHERE run_id > @after ORDER BY run_id ASC LIMIT $2;
+
 -- name: GetFunctionRunFinishesByRunIDs :many
 SELECT * FROM function_finishes WHERE run_id IN (sqlc.slice('run_ids'));
 
@@ -158,6 +164,9 @@ SELECT * FROM event_batches WHERE run_id = CAST($1 AS CHAR(26));
 -- name: GetEventBatchesByEventID :many
 SELECT * FROM event_batches WHERE POSITION(CAST($1 AS TEXT) IN CAST(event_ids AS TEXT)) > 0;
 
+-- name: GetEventsAfter :many
+SELECT * FROM events WHERE internal_id > @after ORDER BY internal_id ASC LIMIT $2;
+
 -- name: GetEventsIDbound :many
 SELECT DISTINCT e.*
 FROM events AS e
@@ -186,12 +195,15 @@ SELECT * FROM events WHERE internal_id < $1 AND received_at <= $2 AND received_a
 
 -- name: InsertHistory :exec
 INSERT INTO history
-    (id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, step_type, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result) VALUES
-    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24);
+    (id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, step_type, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, workspace_id, skip_reason) VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26);
 
 -- name: GetHistoryItem :one
 SELECT * FROM history WHERE id = $1;
 
+-- name: GetHistoryAfter :many
+SELECT * FROM history WHERE id > @after ORDER BY id ASC LIMIT $2;
+
 -- name: GetFunctionRunHistory :many
 SELECT * FROM history WHERE run_id = $1 ORDER BY created_at ASC;
 
@@ -239,6 +251,9 @@ ON CONFLICT (run_id) DO UPDATE SET
 -- name: GetTraceRun :one
 SELECT * FROM trace_runs WHERE run_id = sqlc.arg('run_id')::CHAR(26);
 
+-- name: GetTraceRunsAfter :many
+SELECT * FROM trace_runs WHERE run_id > sqlc.arg('after')::CHAR(26) ORDER BY run_id ASC LIMIT $2;
+
 -- name: GetTraceSpans :many
 SELECT * FROM traces WHERE trace_id = sqlc.arg('trace_id') AND run_id = sqlc.arg('run_id')::CHAR(26) ORDER BY timestamp_unix_ms DESC, duration DESC;
 
@@ -351,3 +366,119 @@ DO UPDATE SET
 
 -- name: GetWorkerConnection :one
 SELECT * FROM worker_connections WHERE account_id = sqlc.arg('account_id') AND workspace_id = sqlc.arg('workspace_id') AND id = sqlc.arg('connection_id');
+
+--
+-- Dead letters
+--
+
+-- name: InsertDeadLetter :exec
+INSERT INTO dead_letters
+	(id, account_id, workspace_id, app_id, function_id, function_version, run_id, event_ids, events, step_id, step_name, error, failed_at) VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+
+-- name: GetDeadLetter :one
+SELECT * FROM dead_letters WHERE workspace_id = sqlc.arg('workspace_id') AND id = sqlc.arg('id');
+
+-- name: GetDeadLetters :many
+SELECT * FROM dead_letters WHERE workspace_id = sqlc.arg('workspace_id') AND id < sqlc.arg('cursor') AND failed_at <= sqlc.arg('before') AND failed_at >= sqlc.arg('after') ORDER BY id DESC LIMIT sqlc.arg('limit');
+
+-- name: GetFunctionDeadLetters :many
+SELECT * FROM dead_letters WHERE workspace_id = sqlc.arg('workspace_id') AND function_id = sqlc.arg('function_id') AND id < sqlc.arg('cursor') AND failed_at <= sqlc.arg('before') AND failed_at >= sqlc.arg('after') ORDER BY id DESC LIMIT sqlc.arg('limit');
+
+-- name: UpdateDeadLetterRedrive :exec
+UPDATE dead_letters SET redriven_at = sqlc.arg('redriven_at'), redrive_run_id = sqlc.arg('redrive_run_id') WHERE workspace_id = sqlc.arg('workspace_id') AND id = sqlc.arg('id');
+
+--
+-- Replays
+--
+
+-- name: InsertReplay :exec
+INSERT INTO replays
+	(id, account_id, workspace_id, app_id, function_id, name, from_time, to_time, statuses, rate, status, total_runs, created_at, updated_at) VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);
+
+-- name: GetReplay :one
+SELECT * FROM replays WHERE workspace_id = sqlc.arg('workspace_id') AND id = sqlc.arg('id');
+
+-- name: GetFunctionReplays :many
+SELECT * FROM replays WHERE workspace_id = sqlc.arg('workspace_id') AND function_id = sqlc.arg('function_id') ORDER BY created_at DESC;
+
+-- name: GetReplaysByStatus :many
+SELECT * FROM replays WHERE status = sqlc.arg('status') ORDER BY created_at ASC;
+
+-- name: UpdateReplayProgress :exec
+UPDATE replays SET run_cursor = sqlc.arg('run_cursor'), processed_runs = sqlc.arg('processed_runs'), scheduled_runs = sqlc.arg('scheduled_runs'), failed_runs = sqlc.arg('failed_runs'), updated_at = sqlc.arg('updated_at') WHERE id = sqlc.arg('id');
+
+-- name: UpdateReplayStatus :exec
+UPDATE replays SET status = sqlc.arg('status'), error = sqlc.arg('error'), updated_at = sqlc.arg('updated_at'), ended_at = sqlc.arg('ended_at') WHERE workspace_id = sqlc.arg('workspace_id') AND id = sqlc.arg('id');
+
+--
+-- Event schemas
+--
+
+-- name: InsertEventSchema :exec
+INSERT INTO event_schemas
+	(app_id, event_name, source, schema, updated_at) VALUES
+	($1, $2, $3, $4, $5);
+
+-- name: DeleteEventSchemasByApp :exec
+DELETE FROM event_schemas WHERE app_id = sqlc.arg('app_id');
+
+-- name: GetEventSchemas :many
+SELECT * FROM event_schemas ORDER BY event_name ASC, app_id ASC;
+
+-- name: GetEventSchemasByName :many
+SELECT * FROM event_schemas WHERE event_name = sqlc.arg('event_name') ORDER BY app_id ASC;
+
+-- name: InsertQuarantinedEvent :exec
+INSERT INTO quarantined_events
+	(id, event_name, event, error, received_at) VALUES
+	($1, $2, $3, $4, $5);
+
+-- name: GetQuarantinedEvents :many
+SELECT * FROM quarantined_events WHERE id < sqlc.arg('cursor') ORDER BY id DESC LIMIT sqlc.arg('limit');
+
+--
+-- Event keys
+--
+
+-- name: InsertEventKey :exec
+INSERT INTO event_keys
+	(id, name, key_hash, key_prefix, allowed_events, rate_limit, rate_period, expires_at, created_at) VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetEventKeys :many
+SELECT * FROM event_keys ORDER BY created_at DESC;
+
+-- name: GetEventKey :one
+SELECT * FROM event_keys WHERE id = sqlc.arg('id');
+
+-- name: GetEventKeyByHash :one
+SELECT * FROM event_keys WHERE key_hash = sqlc.arg('key_hash');
+
+-- name: RevokeEventKey :exec
+UPDATE event_keys SET revoked_at = sqlc.arg('revoked_at') WHERE id = sqlc.arg('id') AND revoked_at IS NULL;
+
+-- name: UpdateEventKeyLastUsed :exec
+UPDATE event_keys SET last_used_at = sqlc.arg('last_used_at') WHERE id = sqlc.arg('id');
+
+--
+-- Webhook sources
+--
+
+-- name: InsertWebhookSource :exec
+INSERT INTO webhook_sources
+	(id, name, transform, signature, created_at, updated_at) VALUES
+	($1, $2, $3, $4, $5, $6);
+
+-- name: GetWebhookSources :many
+SELECT * FROM webhook_sources ORDER BY created_at DESC;
+
+-- name: GetWebhookSource :one
+SELECT * FROM webhook_sources WHERE id = sqlc.arg('id');
+
+-- name: UpdateWebhookSource :exec
+UPDATE webhook_sources SET name = sqlc.arg('name'), transform = sqlc.arg('transform'), signature = sqlc.arg('signature'), updated_at = sqlc.arg('updated_at') WHERE id = sqlc.arg('id');
+
+-- name: DeleteWebhookSource :exec
+DELETE FROM webhook_sources WHERE id = sqlc.arg('id');
@@ -25,6 +25,15 @@ func (q *Queries) DeleteApp(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteEventSchemasByApp = `-- name: DeleteEventSchemasByApp :exec
+DELETE FROM event_schemas WHERE app_id = $1
+`
+
+func (q *Queries) DeleteEventSchemasByApp(ctx context.Context, appID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteEventSchemasByApp, appID)
+	return err
+}
+
 const deleteFunctionsByAppID = `-- name: DeleteFunctionsByAppID :exec
 UPDATE functions SET archived_at = CURRENT_TIMESTAMP WHERE app_id = $1
 `
@@ -71,6 +80,15 @@ func (q *Queries) DeleteOldQueueSnapshots(ctx context.Context, limit int32) (int
 	return result.RowsAffected()
 }
 
+const deleteWebhookSource = `-- name: DeleteWebhookSource :exec
+DELETE FROM webhook_sources WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhookSource(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookSource, id)
+	return err
+}
+
 const getAllApps = `-- name: GetAllApps :many
 SELECT id, name, sdk_language, sdk_version, framework, metadata, status, error, checksum, created_at, archived_at, url, method, app_version FROM apps WHERE archived_at IS NULL
 `
@@ -244,7 +262,7 @@ func (q *Queries) GetAppByURL(ctx context.Context, url string) (*App, error) {
 }
 
 const getAppFunctions = `-- name: GetAppFunctions :many
-SELECT id, app_id, name, slug, config, created_at, archived_at FROM functions WHERE app_id = $1 AND archived_at IS NULL
+SELECT id, app_id, name, slug, config, created_at, archived_at, paused_at FROM functions WHERE app_id = $1 AND archived_at IS NULL
 `
 
 func (q *Queries) GetAppFunctions(ctx context.Context, appID uuid.UUID) ([]*Function, error) {
@@ -264,6 +282,7 @@ func (q *Queries) GetAppFunctions(ctx context.Context, appID uuid.UUID) ([]*Func
 			&i.Config,
 			&i.CreatedAt,
 			&i.ArchivedAt,
+			&i.PausedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -279,7 +298,7 @@ func (q *Queries) GetAppFunctions(ctx context.Context, appID uuid.UUID) ([]*Func
 }
 
 const getAppFunctionsBySlug = `-- name: GetAppFunctionsBySlug :many
-SELECT functions.id, functions.app_id, functions.name, functions.slug, functions.config, functions.created_at, functions.archived_at FROM functions JOIN apps ON apps.id = functions.app_id WHERE apps.name = $1 AND functions.archived_at IS NULL
+SELECT functions.id, functions.app_id, functions.name, functions.slug, functions.config, functions.created_at, functions.archived_at, functions.paused_at FROM functions JOIN apps ON apps.id = functions.app_id WHERE apps.name = $1 AND functions.archived_at IS NULL
 `
 
 func (q *Queries) GetAppFunctionsBySlug(ctx context.Context, name string) ([]*Function, error) {
@@ -299,6 +318,7 @@ func (q *Queries) GetAppFunctionsBySlug(ctx context.Context, name string) ([]*Fu
 			&i.Config,
 			&i.CreatedAt,
 			&i.ArchivedAt,
+			&i.PausedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -355,6 +375,95 @@ func (q *Queries) GetApps(ctx context.Context) ([]*App, error) {
 	return items, nil
 }
 
+const getDeadLetter = `-- name: GetDeadLetter :one
+SELECT id, account_id, workspace_id, app_id, function_id, function_version, run_id, event_ids, events, step_id, step_name, error, failed_at, redriven_at, redrive_run_id FROM dead_letters WHERE workspace_id = $1 AND id = $2
+`
+
+type GetDeadLetterParams struct {
+	WorkspaceID uuid.UUID
+	ID          ulid.ULID
+}
+
+func (q *Queries) GetDeadLetter(ctx context.Context, arg GetDeadLetterParams) (*DeadLetter, error) {
+	row := q.db.QueryRowContext(ctx, getDeadLetter, arg.WorkspaceID, arg.ID)
+	var i DeadLetter
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.WorkspaceID,
+		&i.AppID,
+		&i.FunctionID,
+		&i.FunctionVersion,
+		&i.RunID,
+		&i.EventIds,
+		&i.Events,
+		&i.StepID,
+		&i.StepName,
+		&i.Error,
+		&i.FailedAt,
+		&i.RedrivenAt,
+		&i.RedriveRunID,
+	)
+	return &i, err
+}
+
+const getDeadLetters = `-- name: GetDeadLetters :many
+SELECT id, account_id, workspace_id, app_id, function_id, function_version, run_id, event_ids, events, step_id, step_name, error, failed_at, redriven_at, redrive_run_id FROM dead_letters WHERE workspace_id = $1 AND id < $2 AND failed_at <= $3 AND failed_at >= $4 ORDER BY id DESC LIMIT $5
+`
+
+type GetDeadLettersParams struct {
+	WorkspaceID uuid.UUID
+	Cursor      ulid.ULID
+	Before      int64
+	After       int64
+	Limit       int32
+}
+
+func (q *Queries) GetDeadLetters(ctx context.Context, arg GetDeadLettersParams) ([]*DeadLetter, error) {
+	rows, err := q.db.QueryContext(ctx, getDeadLetters,
+		arg.WorkspaceID,
+		arg.Cursor,
+		arg.Before,
+		arg.After,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*DeadLetter
+	for rows.Next() {
+		var i DeadLetter
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.WorkspaceID,
+			&i.AppID,
+			&i.FunctionID,
+			&i.FunctionVersion,
+			&i.RunID,
+			&i.EventIds,
+			&i.Events,
+			&i.StepID,
+			&i.StepName,
+			&i.Error,
+			&i.FailedAt,
+			&i.RedrivenAt,
+			&i.RedriveRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEventBatchByRunID = `-- name: GetEventBatchByRunID :one
 SELECT id, account_id, workspace_id, app_id, workflow_id, run_id, started_at, executed_at, event_ids FROM event_batches WHERE run_id = CAST($1 AS CHAR(26))
 `
@@ -437,6 +546,139 @@ func (q *Queries) GetEventByInternalID(ctx context.Context, internalID ulid.ULID
 	return &i, err
 }
 
+const getEventKey = `-- name: GetEventKey :one
+SELECT id, name, key_hash, key_prefix, allowed_events, rate_limit, rate_period, expires_at, last_used_at, revoked_at, created_at FROM event_keys WHERE id = $1
+`
+
+func (q *Queries) GetEventKey(ctx context.Context, id uuid.UUID) (*EventKey, error) {
+	row := q.db.QueryRowContext(ctx, getEventKey, id)
+	var i EventKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.KeyHash,
+		&i.KeyPrefix,
+		&i.AllowedEvents,
+		&i.RateLimit,
+		&i.RatePeriod,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const getEventKeyByHash = `-- name: GetEventKeyByHash :one
+SELECT id, name, key_hash, key_prefix, allowed_events, rate_limit, rate_period, expires_at, last_used_at, revoked_at, created_at FROM event_keys WHERE key_hash = $1
+`
+
+func (q *Queries) GetEventKeyByHash(ctx context.Context, keyHash string) (*EventKey, error) {
+	row := q.db.QueryRowContext(ctx, getEventKeyByHash, keyHash)
+	var i EventKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.KeyHash,
+		&i.KeyPrefix,
+		&i.AllowedEvents,
+		&i.RateLimit,
+		&i.RatePeriod,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const getEventKeys = `-- name: GetEventKeys :many
+SELECT id, name, key_hash, key_prefix, allowed_events, rate_limit, rate_period, expires_at, last_used_at, revoked_at, created_at FROM event_keys ORDER BY created_at DESC
+`
+
+func (q *Queries) GetEventKeys(ctx context.Context) ([]*EventKey, error) {
+	rows, err := q.db.QueryContext(ctx, getEventKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*EventKey
+	for rows.Next() {
+		var i EventKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.KeyHash,
+			&i.KeyPrefix,
+			&i.AllowedEvents,
+			&i.RateLimit,
+			&i.RatePeriod,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEventsAfter = `-- name: GetEventsAfter :many
+SELECT internal_id, account_id, workspace_id, source, source_id, received_at, event_id, event_name, event_data, event_user, event_v, event_ts FROM events WHERE internal_id > $1 ORDER BY internal_id ASC LIMIT $2
+`
+
+type GetEventsAfterParams struct {
+	After ulid.ULID
+	Limit int32
+}
+
+func (q *Queries) GetEventsAfter(ctx context.Context, arg GetEventsAfterParams) ([]*Event, error) {
+	rows, err := q.db.QueryContext(ctx, getEventsAfter,
+		arg.After,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.InternalID,
+			&i.AccountID,
+			&i.WorkspaceID,
+			&i.Source,
+			&i.SourceID,
+			&i.ReceivedAt,
+			&i.EventID,
+			&i.EventName,
+			&i.EventData,
+			&i.EventUser,
+			&i.EventV,
+			&i.EventTs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEventsByInternalIDs = `-- name: GetEventsByInternalIDs :many
 SELECT internal_id, account_id, workspace_id, source, source_id, received_at, event_id, event_name, event_data, event_user, event_v, event_ts FROM events WHERE internal_id = ANY($1::BYTEA[])
 `
@@ -477,6 +719,72 @@ func (q *Queries) GetEventsByInternalIDs(ctx context.Context, dollar_1 [][]byte)
 	return items, nil
 }
 
+const getEventSchemas = `-- name: GetEventSchemas :many
+SELECT app_id, event_name, source, schema, updated_at FROM event_schemas ORDER BY event_name ASC, app_id ASC
+`
+
+func (q *Queries) GetEventSchemas(ctx context.Context) ([]*EventSchema, error) {
+	rows, err := q.db.QueryContext(ctx, getEventSchemas)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*EventSchema
+	for rows.Next() {
+		var i EventSchema
+		if err := rows.Scan(
+			&i.AppID,
+			&i.EventName,
+			&i.Source,
+			&i.Schema,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEventSchemasByName = `-- name: GetEventSchemasByName :many
+SELECT app_id, event_name, source, schema, updated_at FROM event_schemas WHERE event_name = $1 ORDER BY app_id ASC
+`
+
+func (q *Queries) GetEventSchemasByName(ctx context.Context, eventName string) ([]*EventSchema, error) {
+	rows, err := q.db.QueryContext(ctx, getEventSchemasByName, eventName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*EventSchema
+	for rows.Next() {
+		var i EventSchema
+		if err := rows.Scan(
+			&i.AppID,
+			&i.EventName,
+			&i.Source,
+			&i.Schema,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getEventsIDbound = `-- name: GetEventsIDbound :many
 SELECT DISTINCT e.internal_id, e.account_id, e.workspace_id, e.source, e.source_id, e.received_at, e.event_id, e.event_name, e.event_data, e.event_user, e.event_v, e.event_ts
 FROM events AS e
@@ -541,7 +849,7 @@ func (q *Queries) GetEventsIDbound(ctx context.Context, arg GetEventsIDboundPara
 }
 
 const getFunctionByID = `-- name: GetFunctionByID :one
-SELECT id, app_id, name, slug, config, created_at, archived_at FROM functions WHERE id = $1
+SELECT id, app_id, name, slug, config, created_at, archived_at, paused_at FROM functions WHERE id = $1
 `
 
 func (q *Queries) GetFunctionByID(ctx context.Context, id uuid.UUID) (*Function, error) {
@@ -555,12 +863,13 @@ func (q *Queries) GetFunctionByID(ctx context.Context, id uuid.UUID) (*Function,
 		&i.Config,
 		&i.CreatedAt,
 		&i.ArchivedAt,
+		&i.PausedAt,
 	)
 	return &i, err
 }
 
 const getFunctionBySlug = `-- name: GetFunctionBySlug :one
-SELECT id, app_id, name, slug, config, created_at, archived_at FROM functions WHERE slug = $1 AND archived_at IS NULL
+SELECT id, app_id, name, slug, config, created_at, archived_at, paused_at FROM functions WHERE slug = $1 AND archived_at IS NULL
 `
 
 func (q *Queries) GetFunctionBySlug(ctx context.Context, slug string) (*Function, error) {
@@ -574,10 +883,126 @@ func (q *Queries) GetFunctionBySlug(ctx context.Context, slug string) (*Function
 		&i.Config,
 		&i.CreatedAt,
 		&i.ArchivedAt,
+		&i.PausedAt,
 	)
 	return &i, err
 }
 
+const getFunctionDeadLetters = `-- name: GetFunctionDeadLetters :many
+SELECT id, account_id, workspace_id, app_id, function_id, function_version, run_id, event_ids, events, step_id, step_name, error, failed_at, redriven_at, redrive_run_id FROM dead_letters WHERE workspace_id = $1 AND function_id = $2 AND id < $3 AND failed_at <= $4 AND failed_at >= $5 ORDER BY id DESC LIMIT $6
+`
+
+type GetFunctionDeadLettersParams struct {
+	WorkspaceID uuid.UUID
+	FunctionID  uuid.UUID
+	Cursor      ulid.ULID
+	Before      int64
+	After       int64
+	Limit       int32
+}
+
+func (q *Queries) GetFunctionDeadLetters(ctx context.Context, arg GetFunctionDeadLettersParams) ([]*DeadLetter, error) {
+	rows, err := q.db.QueryContext(ctx, getFunctionDeadLetters,
+		arg.WorkspaceID,
+		arg.FunctionID,
+		arg.Cursor,
+		arg.Before,
+		arg.After,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*DeadLetter
+	for rows.Next() {
+		var i DeadLetter
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.WorkspaceID,
+			&i.AppID,
+			&i.FunctionID,
+			&i.FunctionVersion,
+			&i.RunID,
+			&i.EventIds,
+			&i.Events,
+			&i.StepID,
+			&i.StepName,
+			&i.Error,
+			&i.FailedAt,
+			&i.RedrivenAt,
+			&i.RedriveRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFunctionReplays = `-- name: GetFunctionReplays :many
+SELECT id, account_id, workspace_id, app_id, function_id, name, from_time, to_time, statuses, rate, status, run_cursor, total_runs, processed_runs, scheduled_runs, failed_runs, error, created_at, updated_at, ended_at FROM replays WHERE workspace_id = $1 AND function_id = $2 ORDER BY created_at DESC
+`
+
+type GetFunctionReplaysParams struct {
+	WorkspaceID uuid.UUID
+	FunctionID  uuid.UUID
+}
+
+func (q *Queries) GetFunctionReplays(ctx context.Context, arg GetFunctionReplaysParams) ([]*Replay, error) {
+	rows, err := q.db.QueryContext(ctx, getFunctionReplays,
+		arg.WorkspaceID,
+		arg.FunctionID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Replay
+	for rows.Next() {
+		var i Replay
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.WorkspaceID,
+			&i.AppID,
+			&i.FunctionID,
+			&i.Name,
+			&i.FromTime,
+			&i.ToTime,
+			&i.Statuses,
+			&i.Rate,
+			&i.Status,
+			&i.RunCursor,
+			&i.TotalRuns,
+			&i.ProcessedRuns,
+			&i.ScheduledRuns,
+			&i.FailedRuns,
+			&i.Error,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFunctionRun = `-- name: GetFunctionRun :one
 SELECT function_runs.run_id, function_runs.run_started_at, function_runs.function_id, function_runs.function_version, function_runs.trigger_type, function_runs.event_id, function_runs.batch_id, function_runs.original_run_id, function_runs.cron, function_finishes.run_id, function_finishes.status, function_finishes.output, function_finishes.completed_step_count, function_finishes.created_at
   FROM function_runs
@@ -656,7 +1081,7 @@ func (q *Queries) GetFunctionRunFinishesByRunIDs(ctx context.Context, runIds []u
 }
 
 const getFunctionRunHistory = `-- name: GetFunctionRunHistory :many
-SELECT id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, step_type FROM history WHERE run_id = $1 ORDER BY created_at ASC
+SELECT id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, step_type, workspace_id, skip_reason FROM history WHERE run_id = $1 ORDER BY created_at ASC
 `
 
 func (q *Queries) GetFunctionRunHistory(ctx context.Context, runID ulid.ULID) ([]*History, error) {
@@ -693,6 +1118,8 @@ func (q *Queries) GetFunctionRunHistory(ctx context.Context, runID ulid.ULID) ([
 			&i.InvokeFunctionResult,
 			&i.Result,
 			&i.StepType,
+			&i.WorkspaceID,
+			&i.SkipReason,
 		); err != nil {
 			return nil, err
 		}
@@ -755,6 +1182,51 @@ func (q *Queries) GetFunctionRuns(ctx context.Context) ([]*GetFunctionRunsRow, e
 	return items, nil
 }
 
+const getFunctionRunsAfter = `-- name: GetFunctionRunsAfter :many
+SELECT run_id, run_started_at, function_id, function_version, trigger_type, event_id, batch_id, original_run_id, cron FROM function_runs WHERE run_id > $1 ORDER BY run_id ASC LIMIT $2
+`
+
+type GetFunctionRunsAfterParams struct {
+	After ulid.ULID
+	Limit int32
+}
+
+func (q *Queries) GetFunctionRunsAfter(ctx context.Context, arg GetFunctionRunsAfterParams) ([]*FunctionRun, error) {
+	rows, err := q.db.QueryContext(ctx, getFunctionRunsAfter,
+		arg.After,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*FunctionRun
+	for rows.Next() {
+		var i FunctionRun
+		if err := rows.Scan(
+			&i.RunID,
+			&i.RunStartedAt,
+			&i.FunctionID,
+			&i.FunctionVersion,
+			&i.TriggerType,
+			&i.EventID,
+			&i.BatchID,
+			&i.OriginalRunID,
+			&i.Cron,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFunctionRunsFromEvents = `-- name: GetFunctionRunsFromEvents :many
 SELECT function_runs.run_id, function_runs.run_started_at, function_runs.function_id, function_runs.function_version, function_runs.trigger_type, function_runs.event_id, function_runs.batch_id, function_runs.original_run_id, function_runs.cron,
     COALESCE(function_finishes.status, '') AS finish_status,
@@ -869,7 +1341,7 @@ func (q *Queries) GetFunctionRunsTimebound(ctx context.Context, arg GetFunctionR
 }
 
 const getFunctions = `-- name: GetFunctions :many
-SELECT functions.id, functions.app_id, functions.name, functions.slug, functions.config, functions.created_at, functions.archived_at
+SELECT functions.id, functions.app_id, functions.name, functions.slug, functions.config, functions.created_at, functions.archived_at, functions.paused_at
 FROM functions
 JOIN apps ON apps.id = functions.app_id
 WHERE functions.archived_at IS NULL
@@ -893,6 +1365,69 @@ func (q *Queries) GetFunctions(ctx context.Context) ([]*Function, error) {
 			&i.Config,
 			&i.CreatedAt,
 			&i.ArchivedAt,
+			&i.PausedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHistoryAfter = `-- name: GetHistoryAfter :many
+SELECT id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, step_type, workspace_id, skip_reason FROM history WHERE id > $1 ORDER BY id ASC LIMIT $2
+`
+
+type GetHistoryAfterParams struct {
+	After ulid.ULID
+	Limit int32
+}
+
+func (q *Queries) GetHistoryAfter(ctx context.Context, arg GetHistoryAfterParams) ([]*History, error) {
+	rows, err := q.db.QueryContext(ctx, getHistoryAfter,
+		arg.After,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*History
+	for rows.Next() {
+		var i History
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.RunStartedAt,
+			&i.FunctionID,
+			&i.FunctionVersion,
+			&i.RunID,
+			&i.EventID,
+			&i.BatchID,
+			&i.GroupID,
+			&i.IdempotencyKey,
+			&i.Type,
+			&i.Attempt,
+			&i.LatencyMs,
+			&i.StepName,
+			&i.StepID,
+			&i.Url,
+			&i.CancelRequest,
+			&i.Sleep,
+			&i.WaitForEvent,
+			&i.WaitResult,
+			&i.InvokeFunction,
+			&i.InvokeFunctionResult,
+			&i.Result,
+			&i.StepType,
+			&i.WorkspaceID,
+			&i.SkipReason,
 		); err != nil {
 			return nil, err
 		}
@@ -908,7 +1443,7 @@ func (q *Queries) GetFunctions(ctx context.Context) ([]*Function, error) {
 }
 
 const getHistoryItem = `-- name: GetHistoryItem :one
-SELECT id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, step_type FROM history WHERE id = $1
+SELECT id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, step_type, workspace_id, skip_reason FROM history WHERE id = $1
 `
 
 func (q *Queries) GetHistoryItem(ctx context.Context, id ulid.ULID) (*History, error) {
@@ -939,6 +1474,8 @@ func (q *Queries) GetHistoryItem(ctx context.Context, id ulid.ULID) (*History, e
 		&i.InvokeFunctionResult,
 		&i.Result,
 		&i.StepType,
+		&i.WorkspaceID,
+		&i.SkipReason,
 	)
 	return &i, err
 }
@@ -980,6 +1517,47 @@ func (q *Queries) GetLatestQueueSnapshotChunks(ctx context.Context) ([]*GetLates
 	return items, nil
 }
 
+const getQuarantinedEvents = `-- name: GetQuarantinedEvents :many
+SELECT id, event_name, event, error, received_at FROM quarantined_events WHERE id < $1 ORDER BY id DESC LIMIT $2
+`
+
+type GetQuarantinedEventsParams struct {
+	Cursor ulid.ULID
+	Limit  int32
+}
+
+func (q *Queries) GetQuarantinedEvents(ctx context.Context, arg GetQuarantinedEventsParams) ([]*QuarantinedEvent, error) {
+	rows, err := q.db.QueryContext(ctx, getQuarantinedEvents,
+		arg.Cursor,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*QuarantinedEvent
+	for rows.Next() {
+		var i QuarantinedEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventName,
+			&i.Event,
+			&i.Error,
+			&i.ReceivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getQueueSnapshotChunks = `-- name: GetQueueSnapshotChunks :many
 
 
@@ -1018,6 +1596,91 @@ func (q *Queries) GetQueueSnapshotChunks(ctx context.Context, snapshotID string)
 	return items, nil
 }
 
+const getReplay = `-- name: GetReplay :one
+SELECT id, account_id, workspace_id, app_id, function_id, name, from_time, to_time, statuses, rate, status, run_cursor, total_runs, processed_runs, scheduled_runs, failed_runs, error, created_at, updated_at, ended_at FROM replays WHERE workspace_id = $1 AND id = $2
+`
+
+type GetReplayParams struct {
+	WorkspaceID uuid.UUID
+	ID          uuid.UUID
+}
+
+func (q *Queries) GetReplay(ctx context.Context, arg GetReplayParams) (*Replay, error) {
+	row := q.db.QueryRowContext(ctx, getReplay, arg.WorkspaceID, arg.ID)
+	var i Replay
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.WorkspaceID,
+		&i.AppID,
+		&i.FunctionID,
+		&i.Name,
+		&i.FromTime,
+		&i.ToTime,
+		&i.Statuses,
+		&i.Rate,
+		&i.Status,
+		&i.RunCursor,
+		&i.TotalRuns,
+		&i.ProcessedRuns,
+		&i.ScheduledRuns,
+		&i.FailedRuns,
+		&i.Error,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.EndedAt,
+	)
+	return &i, err
+}
+
+const getReplaysByStatus = `-- name: GetReplaysByStatus :many
+SELECT id, account_id, workspace_id, app_id, function_id, name, from_time, to_time, statuses, rate, status, run_cursor, total_runs, processed_runs, scheduled_runs, failed_runs, error, created_at, updated_at, ended_at FROM replays WHERE status = $1 ORDER BY created_at ASC
+`
+
+func (q *Queries) GetReplaysByStatus(ctx context.Context, status int32) ([]*Replay, error) {
+	rows, err := q.db.QueryContext(ctx, getReplaysByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Replay
+	for rows.Next() {
+		var i Replay
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.WorkspaceID,
+			&i.AppID,
+			&i.FunctionID,
+			&i.Name,
+			&i.FromTime,
+			&i.ToTime,
+			&i.Statuses,
+			&i.Rate,
+			&i.Status,
+			&i.RunCursor,
+			&i.TotalRuns,
+			&i.ProcessedRuns,
+			&i.ScheduledRuns,
+			&i.FailedRuns,
+			&i.Error,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTraceRun = `-- name: GetTraceRun :one
 SELECT run_id, account_id, workspace_id, app_id, function_id, trace_id, queued_at, started_at, ended_at, status, source_id, trigger_ids, output, is_debounce, batch_id, cron_schedule, has_ai FROM trace_runs WHERE run_id = $1::CHAR(26)
 `
@@ -1047,6 +1710,59 @@ func (q *Queries) GetTraceRun(ctx context.Context, runID string) (*TraceRun, err
 	return &i, err
 }
 
+const getTraceRunsAfter = `-- name: GetTraceRunsAfter :many
+SELECT run_id, account_id, workspace_id, app_id, function_id, trace_id, queued_at, started_at, ended_at, status, source_id, trigger_ids, output, is_debounce, batch_id, cron_schedule, has_ai FROM trace_runs WHERE run_id > $1::CHAR(26) ORDER BY run_id ASC LIMIT $2
+`
+
+type GetTraceRunsAfterParams struct {
+	After string
+	Limit int32
+}
+
+func (q *Queries) GetTraceRunsAfter(ctx context.Context, arg GetTraceRunsAfterParams) ([]*TraceRun, error) {
+	rows, err := q.db.QueryContext(ctx, getTraceRunsAfter,
+		arg.After,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*TraceRun
+	for rows.Next() {
+		var i TraceRun
+		if err := rows.Scan(
+			&i.RunID,
+			&i.AccountID,
+			&i.WorkspaceID,
+			&i.AppID,
+			&i.FunctionID,
+			&i.TraceID,
+			&i.QueuedAt,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.Status,
+			&i.SourceID,
+			&i.TriggerIds,
+			&i.Output,
+			&i.IsDebounce,
+			&i.BatchID,
+			&i.CronSchedule,
+			&i.HasAi,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTraceSpanOutput = `-- name: GetTraceSpanOutput :many
 SELECT timestamp, timestamp_unix_ms, trace_id, span_id, parent_span_id, trace_state, span_name, span_kind, service_name, resource_attributes, scope_name, scope_version, span_attributes, duration, status_code, status_message, events, links, run_id FROM traces WHERE trace_id = $1 AND span_id = $2 ORDER BY timestamp_unix_ms DESC, duration DESC
 `
@@ -1151,6 +1867,58 @@ func (q *Queries) GetTraceSpans(ctx context.Context, arg GetTraceSpansParams) ([
 	return items, nil
 }
 
+const getWebhookSource = `-- name: GetWebhookSource :one
+SELECT id, name, transform, signature, created_at, updated_at FROM webhook_sources WHERE id = $1
+`
+
+func (q *Queries) GetWebhookSource(ctx context.Context, id uuid.UUID) (*WebhookSource, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookSource, id)
+	var i WebhookSource
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Transform,
+		&i.Signature,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const getWebhookSources = `-- name: GetWebhookSources :many
+SELECT id, name, transform, signature, created_at, updated_at FROM webhook_sources ORDER BY created_at DESC
+`
+
+func (q *Queries) GetWebhookSources(ctx context.Context) ([]*WebhookSource, error) {
+	rows, err := q.db.QueryContext(ctx, getWebhookSources)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*WebhookSource
+	for rows.Next() {
+		var i WebhookSource
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Transform,
+			&i.Signature,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkerConnection = `-- name: GetWorkerConnection :one
 SELECT account_id, workspace_id, app_name, app_id, id, gateway_id, instance_id, status, worker_ip, connected_at, last_heartbeat_at, disconnected_at, recorded_at, inserted_at, disconnect_reason, group_hash, sdk_lang, sdk_version, sdk_platform, sync_id, app_version, function_count, cpu_cores, mem_bytes, os FROM worker_connections WHERE account_id = $1 AND workspace_id = $2 AND id = $3
 `
@@ -1205,6 +1973,47 @@ func (q *Queries) HistoryCountRuns(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const insertDeadLetter = `-- name: InsertDeadLetter :exec
+INSERT INTO dead_letters
+	(id, account_id, workspace_id, app_id, function_id, function_version, run_id, event_ids, events, step_id, step_name, error, failed_at) VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+`
+
+type InsertDeadLetterParams struct {
+	ID              ulid.ULID
+	AccountID       uuid.UUID
+	WorkspaceID     uuid.UUID
+	AppID           uuid.UUID
+	FunctionID      uuid.UUID
+	FunctionVersion int32
+	RunID           ulid.ULID
+	EventIds        []byte
+	Events          []byte
+	StepID          sql.NullString
+	StepName        sql.NullString
+	Error           string
+	FailedAt        int64
+}
+
+func (q *Queries) InsertDeadLetter(ctx context.Context, arg InsertDeadLetterParams) error {
+	_, err := q.db.ExecContext(ctx, insertDeadLetter,
+		arg.ID,
+		arg.AccountID,
+		arg.WorkspaceID,
+		arg.AppID,
+		arg.FunctionID,
+		arg.FunctionVersion,
+		arg.RunID,
+		arg.EventIds,
+		arg.Events,
+		arg.StepID,
+		arg.StepName,
+		arg.Error,
+		arg.FailedAt,
+	)
+	return err
+}
+
 const insertEvent = `-- name: InsertEvent :exec
 
 
@@ -1272,12 +2081,70 @@ func (q *Queries) InsertEventBatch(ctx context.Context, arg InsertEventBatchPara
 	return err
 }
 
+const insertEventKey = `-- name: InsertEventKey :exec
+INSERT INTO event_keys
+	(id, name, key_hash, key_prefix, allowed_events, rate_limit, rate_period, expires_at, created_at) VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+type InsertEventKeyParams struct {
+	ID            uuid.UUID
+	Name          string
+	KeyHash       string
+	KeyPrefix     string
+	AllowedEvents string
+	RateLimit     int32
+	RatePeriod    string
+	ExpiresAt     sql.NullInt64
+	CreatedAt     int64
+}
+
+func (q *Queries) InsertEventKey(ctx context.Context, arg InsertEventKeyParams) error {
+	_, err := q.db.ExecContext(ctx, insertEventKey,
+		arg.ID,
+		arg.Name,
+		arg.KeyHash,
+		arg.KeyPrefix,
+		arg.AllowedEvents,
+		arg.RateLimit,
+		arg.RatePeriod,
+		arg.ExpiresAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertEventSchema = `-- name: InsertEventSchema :exec
+INSERT INTO event_schemas
+	(app_id, event_name, source, schema, updated_at) VALUES
+	($1, $2, $3, $4, $5)
+`
+
+type InsertEventSchemaParams struct {
+	AppID     uuid.UUID
+	EventName string
+	Source    string
+	Schema    []byte
+	UpdatedAt int64
+}
+
+func (q *Queries) InsertEventSchema(ctx context.Context, arg InsertEventSchemaParams) error {
+	_, err := q.db.ExecContext(ctx, insertEventSchema,
+		arg.AppID,
+		arg.EventName,
+		arg.Source,
+		arg.Schema,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
 const insertFunction = `-- name: InsertFunction :one
 
 
 INSERT INTO functions
     (id, app_id, name, slug, config, created_at) VALUES
-    ($1, $2, $3, $4, $5, $6) RETURNING id, app_id, name, slug, config, created_at, archived_at
+    ($1, $2, $3, $4, $5, $6) RETURNING id, app_id, name, slug, config, created_at, archived_at, paused_at
 `
 
 type InsertFunctionParams struct {
@@ -1308,6 +2175,7 @@ func (q *Queries) InsertFunction(ctx context.Context, arg InsertFunctionParams)
 		&i.Config,
 		&i.CreatedAt,
 		&i.ArchivedAt,
+		&i.PausedAt,
 	)
 	return &i, err
 }
@@ -1377,8 +2245,8 @@ const insertHistory = `-- name: InsertHistory :exec
 
 
 INSERT INTO history
-    (id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, step_type, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result) VALUES
-    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
+    (id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, step_type, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, workspace_id, skip_reason) VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
 `
 
 type InsertHistoryParams struct {
@@ -1406,6 +2274,8 @@ type InsertHistoryParams struct {
 	InvokeFunction       sql.NullString
 	InvokeFunctionResult sql.NullString
 	Result               sql.NullString
+	WorkspaceID          uuid.UUID
+	SkipReason           sql.NullString
 }
 
 // History
@@ -1435,6 +2305,33 @@ func (q *Queries) InsertHistory(ctx context.Context, arg InsertHistoryParams) er
 		arg.InvokeFunction,
 		arg.InvokeFunctionResult,
 		arg.Result,
+		arg.WorkspaceID,
+		arg.SkipReason,
+	)
+	return err
+}
+
+const insertQuarantinedEvent = `-- name: InsertQuarantinedEvent :exec
+INSERT INTO quarantined_events
+	(id, event_name, event, error, received_at) VALUES
+	($1, $2, $3, $4, $5)
+`
+
+type InsertQuarantinedEventParams struct {
+	ID         ulid.ULID
+	EventName  string
+	Event      []byte
+	Error      string
+	ReceivedAt int64
+}
+
+func (q *Queries) InsertQuarantinedEvent(ctx context.Context, arg InsertQuarantinedEventParams) error {
+	_, err := q.db.ExecContext(ctx, insertQuarantinedEvent,
+		arg.ID,
+		arg.EventName,
+		arg.Event,
+		arg.Error,
+		arg.ReceivedAt,
 	)
 	return err
 }
@@ -1456,6 +2353,49 @@ func (q *Queries) InsertQueueSnapshotChunk(ctx context.Context, arg InsertQueueS
 	return err
 }
 
+const insertReplay = `-- name: InsertReplay :exec
+INSERT INTO replays
+	(id, account_id, workspace_id, app_id, function_id, name, from_time, to_time, statuses, rate, status, total_runs, created_at, updated_at) VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+`
+
+type InsertReplayParams struct {
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	WorkspaceID uuid.UUID
+	AppID       uuid.UUID
+	FunctionID  uuid.UUID
+	Name        string
+	FromTime    int64
+	ToTime      int64
+	Statuses    string
+	Rate        int32
+	Status      int32
+	TotalRuns   int32
+	CreatedAt   int64
+	UpdatedAt   int64
+}
+
+func (q *Queries) InsertReplay(ctx context.Context, arg InsertReplayParams) error {
+	_, err := q.db.ExecContext(ctx, insertReplay,
+		arg.ID,
+		arg.AccountID,
+		arg.WorkspaceID,
+		arg.AppID,
+		arg.FunctionID,
+		arg.Name,
+		arg.FromTime,
+		arg.ToTime,
+		arg.Statuses,
+		arg.Rate,
+		arg.Status,
+		arg.TotalRuns,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
 const insertTrace = `-- name: InsertTrace :exec
 
 
@@ -1583,6 +2523,33 @@ func (q *Queries) InsertTraceRun(ctx context.Context, arg InsertTraceRunParams)
 	return err
 }
 
+const insertWebhookSource = `-- name: InsertWebhookSource :exec
+INSERT INTO webhook_sources
+	(id, name, transform, signature, created_at, updated_at) VALUES
+	($1, $2, $3, $4, $5, $6)
+`
+
+type InsertWebhookSourceParams struct {
+	ID        uuid.UUID
+	Name      string
+	Transform string
+	Signature []byte
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+func (q *Queries) InsertWebhookSource(ctx context.Context, arg InsertWebhookSourceParams) error {
+	_, err := q.db.ExecContext(ctx, insertWebhookSource,
+		arg.ID,
+		arg.Name,
+		arg.Transform,
+		arg.Signature,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
 const insertWorkerConnection = `-- name: InsertWorkerConnection :exec
 
 INSERT INTO worker_connections (
@@ -1710,6 +2677,23 @@ func (q *Queries) InsertWorkerConnection(ctx context.Context, arg InsertWorkerCo
 	return err
 }
 
+const revokeEventKey = `-- name: RevokeEventKey :exec
+UPDATE event_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL
+`
+
+type RevokeEventKeyParams struct {
+	RevokedAt sql.NullInt64
+	ID        uuid.UUID
+}
+
+func (q *Queries) RevokeEventKey(ctx context.Context, arg RevokeEventKeyParams) error {
+	_, err := q.db.ExecContext(ctx, revokeEventKey,
+		arg.RevokedAt,
+		arg.ID,
+	)
+	return err
+}
+
 const updateAppError = `-- name: UpdateAppError :one
 UPDATE apps SET error = $1 WHERE id = $2 RETURNING id, name, sdk_language, sdk_version, framework, metadata, status, error, checksum, created_at, archived_at, url, method, app_version
 `
@@ -1772,8 +2756,46 @@ func (q *Queries) UpdateAppURL(ctx context.Context, arg UpdateAppURLParams) (*Ap
 	return &i, err
 }
 
+const updateDeadLetterRedrive = `-- name: UpdateDeadLetterRedrive :exec
+UPDATE dead_letters SET redriven_at = $1, redrive_run_id = $2 WHERE workspace_id = $3 AND id = $4
+`
+
+type UpdateDeadLetterRedriveParams struct {
+	RedrivenAt   sql.NullInt64
+	RedriveRunID *ulid.ULID
+	WorkspaceID  uuid.UUID
+	ID           ulid.ULID
+}
+
+func (q *Queries) UpdateDeadLetterRedrive(ctx context.Context, arg UpdateDeadLetterRedriveParams) error {
+	_, err := q.db.ExecContext(ctx, updateDeadLetterRedrive,
+		arg.RedrivenAt,
+		arg.RedriveRunID,
+		arg.WorkspaceID,
+		arg.ID,
+	)
+	return err
+}
+
+const updateEventKeyLastUsed = `-- name: UpdateEventKeyLastUsed :exec
+UPDATE event_keys SET last_used_at = $1 WHERE id = $2
+`
+
+type UpdateEventKeyLastUsedParams struct {
+	LastUsedAt sql.NullInt64
+	ID         uuid.UUID
+}
+
+func (q *Queries) UpdateEventKeyLastUsed(ctx context.Context, arg UpdateEventKeyLastUsedParams) error {
+	_, err := q.db.ExecContext(ctx, updateEventKeyLastUsed,
+		arg.LastUsedAt,
+		arg.ID,
+	)
+	return err
+}
+
 const updateFunctionConfig = `-- name: UpdateFunctionConfig :one
-UPDATE functions SET config = $1, archived_at = NULL WHERE id = $2 RETURNING id, app_id, name, slug, config, created_at, archived_at
+UPDATE functions SET config = $1, archived_at = NULL WHERE id = $2 RETURNING id, app_id, name, slug, config, created_at, archived_at, paused_at
 `
 
 type UpdateFunctionConfigParams struct {
@@ -1792,10 +2814,109 @@ func (q *Queries) UpdateFunctionConfig(ctx context.Context, arg UpdateFunctionCo
 		&i.Config,
 		&i.CreatedAt,
 		&i.ArchivedAt,
+		&i.PausedAt,
+	)
+	return &i, err
+}
+
+const updateFunctionPausedAt = `-- name: UpdateFunctionPausedAt :one
+UPDATE functions SET paused_at = $1 WHERE id = $2 RETURNING id, app_id, name, slug, config, created_at, archived_at, paused_at
+`
+
+type UpdateFunctionPausedAtParams struct {
+	PausedAt sql.NullTime
+	ID       uuid.UUID
+}
+
+func (q *Queries) UpdateFunctionPausedAt(ctx context.Context, arg UpdateFunctionPausedAtParams) (*Function, error) {
+	row := q.db.QueryRowContext(ctx, updateFunctionPausedAt, arg.PausedAt, arg.ID)
+	var i Function
+	err := row.Scan(
+		&i.ID,
+		&i.AppID,
+		&i.Name,
+		&i.Slug,
+		&i.Config,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.PausedAt,
 	)
 	return &i, err
 }
 
+const updateReplayProgress = `-- name: UpdateReplayProgress :exec
+UPDATE replays SET run_cursor = $1, processed_runs = $2, scheduled_runs = $3, failed_runs = $4, updated_at = $5 WHERE id = $6
+`
+
+type UpdateReplayProgressParams struct {
+	RunCursor     *ulid.ULID
+	ProcessedRuns int32
+	ScheduledRuns int32
+	FailedRuns    int32
+	UpdatedAt     int64
+	ID            uuid.UUID
+}
+
+func (q *Queries) UpdateReplayProgress(ctx context.Context, arg UpdateReplayProgressParams) error {
+	_, err := q.db.ExecContext(ctx, updateReplayProgress,
+		arg.RunCursor,
+		arg.ProcessedRuns,
+		arg.ScheduledRuns,
+		arg.FailedRuns,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const updateReplayStatus = `-- name: UpdateReplayStatus :exec
+UPDATE replays SET status = $1, error = $2, updated_at = $3, ended_at = $4 WHERE workspace_id = $5 AND id = $6
+`
+
+type UpdateReplayStatusParams struct {
+	Status      int32
+	Error       sql.NullString
+	UpdatedAt   int64
+	EndedAt     sql.NullInt64
+	WorkspaceID uuid.UUID
+	ID          uuid.UUID
+}
+
+func (q *Queries) UpdateReplayStatus(ctx context.Context, arg UpdateReplayStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateReplayStatus,
+		arg.Status,
+		arg.Error,
+		arg.UpdatedAt,
+		arg.EndedAt,
+		arg.WorkspaceID,
+		arg.ID,
+	)
+	return err
+}
+
+const updateWebhookSource = `-- name: UpdateWebhookSource :exec
+UPDATE webhook_sources SET name = $1, transform = $2, signature = $3, updated_at = $4 WHERE id = $5
+`
+
+type UpdateWebhookSourceParams struct {
+	Name      string
+	Transform string
+	Signature []byte
+	UpdatedAt int64
+	ID        uuid.UUID
+}
+
+func (q *Queries) UpdateWebhookSource(ctx context.Context, arg UpdateWebhookSourceParams) error {
+	_, err := q.db.ExecContext(ctx, updateWebhookSource,
+		arg.Name,
+		arg.Transform,
+		arg.Signature,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
 const upsertApp = `-- name: UpsertApp :one
 INSERT INTO apps (id, name, sdk_language, sdk_version, framework, metadata, status, error, checksum, url, method, app_version)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
@@ -24,7 +24,8 @@ CREATE TABLE functions (
 	slug VARCHAR NOT NULL,
 	config VARCHAR NOT NULL,
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-	archived_at TIMESTAMP
+	archived_at TIMESTAMP,
+	paused_at TIMESTAMP
 );
 
 -- XXX: This does not conform to the cloud.  It only includes basic fields.
@@ -89,7 +90,9 @@ CREATE TABLE history (
 	invoke_function VARCHAR,
 	invoke_function_result VARCHAR,
 	result VARCHAR,
-	step_type VARCHAR
+	step_type VARCHAR,
+	workspace_id CHAR(36),
+	skip_reason VARCHAR
 );
 
 CREATE TABLE event_batches (
@@ -191,3 +194,84 @@ CREATE TABLE worker_connections (
 
     PRIMARY KEY(id, app_name)
 );
+
+CREATE TABLE dead_letters (
+    id BYTEA PRIMARY KEY,
+    account_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    app_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    function_version INT NOT NULL,
+    run_id BYTEA NOT NULL,
+    event_ids BYTEA NOT NULL,
+    events BYTEA NOT NULL,
+    step_id VARCHAR,
+    step_name VARCHAR,
+    error VARCHAR NOT NULL,
+    failed_at BIGINT NOT NULL,
+    redriven_at BIGINT,
+    redrive_run_id BYTEA
+);
+
+CREATE TABLE replays (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL,
+    workspace_id UUID NOT NULL,
+    app_id UUID NOT NULL,
+    function_id UUID NOT NULL,
+    name VARCHAR NOT NULL,
+    from_time BIGINT NOT NULL,
+    to_time BIGINT NOT NULL,
+    statuses VARCHAR NOT NULL,
+    rate INT NOT NULL,
+    status INT NOT NULL,
+    run_cursor BYTEA,
+    total_runs INT NOT NULL,
+    processed_runs INT NOT NULL DEFAULT 0,
+    scheduled_runs INT NOT NULL DEFAULT 0,
+    failed_runs INT NOT NULL DEFAULT 0,
+    error VARCHAR,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    ended_at BIGINT
+);
+
+CREATE TABLE event_schemas (
+    app_id UUID NOT NULL,
+    event_name VARCHAR NOT NULL,
+    source VARCHAR NOT NULL,
+    schema BYTEA NOT NULL,
+    updated_at BIGINT NOT NULL,
+    PRIMARY KEY (app_id, event_name)
+);
+
+CREATE TABLE quarantined_events (
+    id BYTEA PRIMARY KEY,
+    event_name VARCHAR NOT NULL,
+    event BYTEA NOT NULL,
+    error VARCHAR NOT NULL,
+    received_at BIGINT NOT NULL
+);
+
+CREATE TABLE event_keys (
+    id UUID PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    key_hash VARCHAR NOT NULL UNIQUE,
+    key_prefix VARCHAR NOT NULL,
+    allowed_events VARCHAR NOT NULL,
+    rate_limit INT NOT NULL DEFAULT 0,
+    rate_period VARCHAR NOT NULL DEFAULT '',
+    expires_at BIGINT,
+    last_used_at BIGINT,
+    revoked_at BIGINT,
+    created_at BIGINT NOT NULL
+);
+
+CREATE TABLE webhook_sources (
+    id UUID PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    transform VARCHAR NOT NULL,
+    signature BYTEA,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL
+);
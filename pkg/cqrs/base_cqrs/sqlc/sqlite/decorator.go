@@ -21,3 +21,23 @@ func (e EventBatch) EventIDs() ([]ulid.ULID, error) {
 
 	return ids, nil
 }
+
+// EventIDs convert the blob data to a list of ULIDs
+func (d DeadLetter) EventIDs() ([]ulid.ULID, error) {
+	if len(d.EventIds) == 0 {
+		return nil, nil
+	}
+
+	strids := strings.Split(string(d.EventIds), ",")
+	ids := make([]ulid.ULID, len(strids))
+
+	for i, sid := range strids {
+		id, err := ulid.Parse(sid)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
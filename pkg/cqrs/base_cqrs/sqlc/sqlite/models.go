@@ -29,6 +29,24 @@ type App struct {
 	AppVersion  sql.NullString
 }
 
+type DeadLetter struct {
+	ID              ulid.ULID
+	AccountID       uuid.UUID
+	WorkspaceID     uuid.UUID
+	AppID           uuid.UUID
+	FunctionID      uuid.UUID
+	FunctionVersion int64
+	RunID           ulid.ULID
+	EventIds        []byte
+	Events          []byte
+	StepID          sql.NullString
+	StepName        sql.NullString
+	Error           string
+	FailedAt        int64
+	RedrivenAt      sql.NullInt64
+	RedriveRunID    *ulid.ULID
+}
+
 type Event struct {
 	InternalID  ulid.ULID
 	AccountID   interface{}
@@ -56,6 +74,28 @@ type EventBatch struct {
 	EventIds    []byte
 }
 
+type EventKey struct {
+	ID            uuid.UUID
+	Name          string
+	KeyHash       string
+	KeyPrefix     string
+	AllowedEvents string
+	RateLimit     int64
+	RatePeriod    string
+	ExpiresAt     sql.NullInt64
+	LastUsedAt    sql.NullInt64
+	RevokedAt     sql.NullInt64
+	CreatedAt     int64
+}
+
+type EventSchema struct {
+	AppID     uuid.UUID
+	EventName string
+	Source    string
+	Schema    []byte
+	UpdatedAt int64
+}
+
 type Function struct {
 	ID         uuid.UUID
 	AppID      uuid.UUID
@@ -64,6 +104,7 @@ type Function struct {
 	Config     string
 	CreatedAt  time.Time
 	ArchivedAt sql.NullTime
+	PausedAt   sql.NullTime
 }
 
 type FunctionFinish struct {
@@ -112,6 +153,16 @@ type History struct {
 	InvokeFunction       sql.NullString
 	InvokeFunctionResult sql.NullString
 	Result               sql.NullString
+	WorkspaceID          uuid.UUID
+	SkipReason           sql.NullString
+}
+
+type QuarantinedEvent struct {
+	ID         ulid.ULID
+	EventName  string
+	Event      []byte
+	Error      string
+	ReceivedAt int64
 }
 
 type QueueSnapshotChunk struct {
@@ -120,6 +171,29 @@ type QueueSnapshotChunk struct {
 	Data       []byte
 }
 
+type Replay struct {
+	ID            uuid.UUID
+	AccountID     uuid.UUID
+	WorkspaceID   uuid.UUID
+	AppID         uuid.UUID
+	FunctionID    uuid.UUID
+	Name          string
+	FromTime      int64
+	ToTime        int64
+	Statuses      string
+	Rate          int64
+	Status        int64
+	RunCursor     *ulid.ULID
+	TotalRuns     int64
+	ProcessedRuns int64
+	ScheduledRuns int64
+	FailedRuns    int64
+	Error         sql.NullString
+	CreatedAt     int64
+	UpdatedAt     int64
+	EndedAt       sql.NullInt64
+}
+
 type Trace struct {
 	Timestamp          time.Time
 	TimestampUnixMs    int64
@@ -162,6 +236,15 @@ type TraceRun struct {
 	HasAi        bool
 }
 
+type WebhookSource struct {
+	ID        uuid.UUID
+	Name      string
+	Transform string
+	Signature []byte
+	CreatedAt int64
+	UpdatedAt int64
+}
+
 type WorkerConnection struct {
 	AccountID        uuid.UUID
 	WorkspaceID      uuid.UUID
@@ -13,9 +13,11 @@ import (
 
 type Querier interface {
 	DeleteApp(ctx context.Context, id uuid.UUID) error
+	DeleteEventSchemasByApp(ctx context.Context, appID uuid.UUID) error
 	DeleteFunctionsByAppID(ctx context.Context, appID uuid.UUID) error
 	DeleteFunctionsByIDs(ctx context.Context, ids []uuid.UUID) error
 	DeleteOldQueueSnapshots(ctx context.Context, limit int64) (int64, error)
+	DeleteWebhookSource(ctx context.Context, id uuid.UUID) error
 	GetAllApps(ctx context.Context) ([]*App, error)
 	GetApp(ctx context.Context, id uuid.UUID) (*App, error)
 	GetAppByChecksum(ctx context.Context, checksum string) (*App, error)
@@ -25,31 +27,50 @@ type Querier interface {
 	GetAppFunctions(ctx context.Context, appID uuid.UUID) ([]*Function, error)
 	GetAppFunctionsBySlug(ctx context.Context, name string) ([]*Function, error)
 	GetApps(ctx context.Context) ([]*App, error)
+	GetDeadLetter(ctx context.Context, arg GetDeadLetterParams) (*DeadLetter, error)
+	GetDeadLetters(ctx context.Context, arg GetDeadLettersParams) ([]*DeadLetter, error)
 	GetEventBatchByRunID(ctx context.Context, runID ulid.ULID) (*EventBatch, error)
 	GetEventBatchesByEventID(ctx context.Context, instr string) ([]*EventBatch, error)
 	GetEventByInternalID(ctx context.Context, internalID ulid.ULID) (*Event, error)
+	GetEventKey(ctx context.Context, id uuid.UUID) (*EventKey, error)
+	GetEventKeyByHash(ctx context.Context, keyHash string) (*EventKey, error)
+	GetEventKeys(ctx context.Context) ([]*EventKey, error)
+	GetEventsAfter(ctx context.Context, arg GetEventsAfterParams) ([]*Event, error)
 	GetEventsByInternalIDs(ctx context.Context, ids []ulid.ULID) ([]*Event, error)
+	GetEventSchemas(ctx context.Context) ([]*EventSchema, error)
+	GetEventSchemasByName(ctx context.Context, eventName string) ([]*EventSchema, error)
 	GetEventsIDbound(ctx context.Context, arg GetEventsIDboundParams) ([]*Event, error)
 	GetFunctionByID(ctx context.Context, id uuid.UUID) (*Function, error)
 	GetFunctionBySlug(ctx context.Context, slug string) (*Function, error)
+	GetFunctionDeadLetters(ctx context.Context, arg GetFunctionDeadLettersParams) ([]*DeadLetter, error)
+	GetFunctionReplays(ctx context.Context, arg GetFunctionReplaysParams) ([]*Replay, error)
 	GetFunctionRun(ctx context.Context, runID ulid.ULID) (*GetFunctionRunRow, error)
 	GetFunctionRunFinishesByRunIDs(ctx context.Context, runIds []ulid.ULID) ([]*FunctionFinish, error)
 	GetFunctionRunHistory(ctx context.Context, runID ulid.ULID) ([]*History, error)
 	GetFunctionRuns(ctx context.Context) ([]*GetFunctionRunsRow, error)
+	GetFunctionRunsAfter(ctx context.Context, arg GetFunctionRunsAfterParams) ([]*FunctionRun, error)
 	GetFunctionRunsFromEvents(ctx context.Context, eventIds []ulid.ULID) ([]*GetFunctionRunsFromEventsRow, error)
 	GetFunctionRunsTimebound(ctx context.Context, arg GetFunctionRunsTimeboundParams) ([]*GetFunctionRunsTimeboundRow, error)
 	GetFunctions(ctx context.Context) ([]*Function, error)
+	GetHistoryAfter(ctx context.Context, arg GetHistoryAfterParams) ([]*History, error)
 	GetHistoryItem(ctx context.Context, id ulid.ULID) (*History, error)
 	GetLatestQueueSnapshotChunks(ctx context.Context) ([]*GetLatestQueueSnapshotChunksRow, error)
 	//
 	// Queue snapshots
 	//
+	GetQuarantinedEvents(ctx context.Context, arg GetQuarantinedEventsParams) ([]*QuarantinedEvent, error)
 	GetQueueSnapshotChunks(ctx context.Context, snapshotID interface{}) ([]*GetQueueSnapshotChunksRow, error)
+	GetReplay(ctx context.Context, arg GetReplayParams) (*Replay, error)
+	GetReplaysByStatus(ctx context.Context, status int64) ([]*Replay, error)
 	GetTraceRun(ctx context.Context, runID ulid.ULID) (*TraceRun, error)
+	GetTraceRunsAfter(ctx context.Context, arg GetTraceRunsAfterParams) ([]*TraceRun, error)
 	GetTraceSpanOutput(ctx context.Context, arg GetTraceSpanOutputParams) ([]*Trace, error)
 	GetTraceSpans(ctx context.Context, arg GetTraceSpansParams) ([]*Trace, error)
+	GetWebhookSource(ctx context.Context, id uuid.UUID) (*WebhookSource, error)
+	GetWebhookSources(ctx context.Context) ([]*WebhookSource, error)
 	GetWorkerConnection(ctx context.Context, arg GetWorkerConnectionParams) (*WorkerConnection, error)
 	HistoryCountRuns(ctx context.Context) (int64, error)
+	InsertDeadLetter(ctx context.Context, arg InsertDeadLetterParams) error
 	//
 	// Events
 	//
@@ -59,6 +80,8 @@ type Querier interface {
 	// functions
 	//
 	// note - this is very basic right now.
+	InsertEventKey(ctx context.Context, arg InsertEventKeyParams) error
+	InsertEventSchema(ctx context.Context, arg InsertEventSchemaParams) error
 	InsertFunction(ctx context.Context, arg InsertFunctionParams) (*Function, error)
 	InsertFunctionFinish(ctx context.Context, arg InsertFunctionFinishParams) error
 	//
@@ -69,7 +92,9 @@ type Querier interface {
 	// History
 	//
 	InsertHistory(ctx context.Context, arg InsertHistoryParams) error
+	InsertQuarantinedEvent(ctx context.Context, arg InsertQuarantinedEventParams) error
 	InsertQueueSnapshotChunk(ctx context.Context, arg InsertQueueSnapshotChunkParams) error
+	InsertReplay(ctx context.Context, arg InsertReplayParams) error
 	//
 	// Traces
 	//
@@ -78,10 +103,18 @@ type Querier interface {
 	//
 	// Worker Connections
 	//
+	InsertWebhookSource(ctx context.Context, arg InsertWebhookSourceParams) error
 	InsertWorkerConnection(ctx context.Context, arg InsertWorkerConnectionParams) error
+	RevokeEventKey(ctx context.Context, arg RevokeEventKeyParams) error
 	UpdateAppError(ctx context.Context, arg UpdateAppErrorParams) (*App, error)
 	UpdateAppURL(ctx context.Context, arg UpdateAppURLParams) (*App, error)
+	UpdateDeadLetterRedrive(ctx context.Context, arg UpdateDeadLetterRedriveParams) error
+	UpdateEventKeyLastUsed(ctx context.Context, arg UpdateEventKeyLastUsedParams) error
 	UpdateFunctionConfig(ctx context.Context, arg UpdateFunctionConfigParams) (*Function, error)
+	UpdateFunctionPausedAt(ctx context.Context, arg UpdateFunctionPausedAtParams) (*Function, error)
+	UpdateReplayProgress(ctx context.Context, arg UpdateReplayProgressParams) error
+	UpdateReplayStatus(ctx context.Context, arg UpdateReplayStatusParams) error
+	UpdateWebhookSource(ctx context.Context, arg UpdateWebhookSourceParams) error
 	UpsertApp(ctx context.Context, arg UpsertAppParams) (*App, error)
 	WorkspaceEvents(ctx context.Context, arg WorkspaceEventsParams) ([]*Event, error)
 	WorkspaceNamedEvents(ctx context.Context, arg WorkspaceNamedEventsParams) ([]*Event, error)
@@ -79,6 +79,9 @@ SELECT * FROM functions WHERE slug = ? AND archived_at IS NULL;
 -- name: UpdateFunctionConfig :one
 UPDATE functions SET config = ?, archived_at = NULL WHERE id = ? RETURNING *;
 
+-- name: UpdateFunctionPausedAt :one
+UPDATE functions SET paused_at = ? WHERE id = ? RETURNING *;
+
 -- name: DeleteFunctionsByAppID :exec
 UPDATE functions SET archived_at = datetime('now') WHERE app_id = ?;
 
@@ -121,6 +124,9 @@ SELECT sqlc.embed(function_runs), sqlc.embed(function_finishes) FROM function_ru
 LEFT JOIN function_finishes ON function_finishes.run_id = function_runs.run_id
 WHERE function_runs.event_id IN (sqlc.slice('event_ids'));
 
+-- name: GetFunctionRunsAfter :many
+SELECT * FROM function_runs WHERE run_id > @after ORDER BY run_id ASC LIMIT ?;
+
 -- name: GetFunctionRunFinishesByRunIDs :many
 SELECT * FROM function_finishes WHERE run_id IN (sqlc.slice('run_ids'));
 
@@ -150,6 +156,9 @@ SELECT * FROM event_batches WHERE run_id = ?;
 -- name: GetEventBatchesByEventID :many
 SELECT * FROM event_batches WHERE INSTR(CAST(event_ids AS TEXT), ?) > 0;
 
+-- name: GetEventsAfter :many
+SELECT * FROM events WHERE internal_id > @after ORDER BY internal_id ASC LIMIT ?;
+
 -- name: GetEventsIDbound :many
 SELECT DISTINCT e.*
 FROM events AS e
@@ -182,12 +191,15 @@ SELECT * FROM events WHERE internal_id < @cursor AND received_at <= @before AND
 
 -- name: InsertHistory :exec
 INSERT INTO history
-	(id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, step_type, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result) VALUES
-	(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	(id, created_at, run_started_at, function_id, function_version, run_id, event_id, batch_id, group_id, idempotency_key, type, attempt, latency_ms, step_name, step_id, step_type, url, cancel_request, sleep, wait_for_event, wait_result, invoke_function, invoke_function_result, result, workspace_id, skip_reason) VALUES
+	(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetHistoryItem :one
 SELECT * FROM history WHERE id = ?;
 
+-- name: GetHistoryAfter :many
+SELECT * FROM history WHERE id > @after ORDER BY id ASC LIMIT ?;
+
 -- name: GetFunctionRunHistory :many
 SELECT * FROM history WHERE run_id = ? ORDER BY created_at ASC;
 
@@ -237,6 +249,9 @@ DO UPDATE SET
 -- name: GetTraceRun :one
 SELECT * FROM trace_runs WHERE run_id = @run_id;
 
+-- name: GetTraceRunsAfter :many
+SELECT * FROM trace_runs WHERE run_id > @after ORDER BY run_id ASC LIMIT ?;
+
 -- name: GetTraceSpans :many
 SELECT * FROM traces WHERE trace_id = @trace_id AND run_id = @run_id ORDER BY timestamp_unix_ms DESC, duration DESC;
 
@@ -323,3 +338,119 @@ DO UPDATE SET
 
 -- name: GetWorkerConnection :one
 SELECT * FROM worker_connections WHERE account_id = @account_id AND workspace_id = @workspace_id AND id = @connection_id;
+
+--
+-- Dead letters
+--
+
+-- name: InsertDeadLetter :exec
+INSERT INTO dead_letters
+	(id, account_id, workspace_id, app_id, function_id, function_version, run_id, event_ids, events, step_id, step_name, error, failed_at) VALUES
+	(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetDeadLetter :one
+SELECT * FROM dead_letters WHERE workspace_id = @workspace_id AND id = @id;
+
+-- name: GetDeadLetters :many
+SELECT * FROM dead_letters WHERE workspace_id = @workspace_id AND id < @cursor AND failed_at <= @before AND failed_at >= @after ORDER BY id DESC LIMIT ?;
+
+-- name: GetFunctionDeadLetters :many
+SELECT * FROM dead_letters WHERE workspace_id = @workspace_id AND function_id = @function_id AND id < @cursor AND failed_at <= @before AND failed_at >= @after ORDER BY id DESC LIMIT ?;
+
+-- name: UpdateDeadLetterRedrive :exec
+UPDATE dead_letters SET redriven_at = @redriven_at, redrive_run_id = @redrive_run_id WHERE workspace_id = @workspace_id AND id = @id;
+
+--
+-- Replays
+--
+
+-- name: InsertReplay :exec
+INSERT INTO replays
+	(id, account_id, workspace_id, app_id, function_id, name, from_time, to_time, statuses, rate, status, total_runs, created_at, updated_at) VALUES
+	(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetReplay :one
+SELECT * FROM replays WHERE workspace_id = @workspace_id AND id = @id;
+
+-- name: GetFunctionReplays :many
+SELECT * FROM replays WHERE workspace_id = @workspace_id AND function_id = @function_id ORDER BY created_at DESC;
+
+-- name: GetReplaysByStatus :many
+SELECT * FROM replays WHERE status = @status ORDER BY created_at ASC;
+
+-- name: UpdateReplayProgress :exec
+UPDATE replays SET run_cursor = @run_cursor, processed_runs = @processed_runs, scheduled_runs = @scheduled_runs, failed_runs = @failed_runs, updated_at = @updated_at WHERE id = @id;
+
+-- name: UpdateReplayStatus :exec
+UPDATE replays SET status = @status, error = @error, updated_at = @updated_at, ended_at = @ended_at WHERE workspace_id = @workspace_id AND id = @id;
+
+--
+-- Event schemas
+--
+
+-- name: InsertEventSchema :exec
+INSERT INTO event_schemas
+	(app_id, event_name, source, schema, updated_at) VALUES
+	(?, ?, ?, ?, ?);
+
+-- name: DeleteEventSchemasByApp :exec
+DELETE FROM event_schemas WHERE app_id = @app_id;
+
+-- name: GetEventSchemas :many
+SELECT * FROM event_schemas ORDER BY event_name ASC, app_id ASC;
+
+-- name: GetEventSchemasByName :many
+SELECT * FROM event_schemas WHERE event_name = @event_name ORDER BY app_id ASC;
+
+-- name: InsertQuarantinedEvent :exec
+INSERT INTO quarantined_events
+	(id, event_name, event, error, received_at) VALUES
+	(?, ?, ?, ?, ?);
+
+-- name: GetQuarantinedEvents :many
+SELECT * FROM quarantined_events WHERE id < @cursor ORDER BY id DESC LIMIT @limit;
+
+--
+-- Event keys
+--
+
+-- name: InsertEventKey :exec
+INSERT INTO event_keys
+	(id, name, key_hash, key_prefix, allowed_events, rate_limit, rate_period, expires_at, created_at) VALUES
+	(?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetEventKeys :many
+SELECT * FROM event_keys ORDER BY created_at DESC;
+
+-- name: GetEventKey :one
+SELECT * FROM event_keys WHERE id = @id;
+
+-- name: GetEventKeyByHash :one
+SELECT * FROM event_keys WHERE key_hash = @key_hash;
+
+-- name: RevokeEventKey :exec
+UPDATE event_keys SET revoked_at = @revoked_at WHERE id = @id AND revoked_at IS NULL;
+
+-- name: UpdateEventKeyLastUsed :exec
+UPDATE event_keys SET last_used_at = @last_used_at WHERE id = @id;
+
+--
+-- Webhook sources
+--
+
+-- name: InsertWebhookSource :exec
+INSERT INTO webhook_sources
+	(id, name, transform, signature, created_at, updated_at) VALUES
+	(?, ?, ?, ?, ?, ?);
+
+-- name: GetWebhookSources :many
+SELECT * FROM webhook_sources ORDER BY created_at DESC;
+
+-- name: GetWebhookSource :one
+SELECT * FROM webhook_sources WHERE id = @id;
+
+-- name: UpdateWebhookSource :exec
+UPDATE webhook_sources SET name = @name, transform = @transform, signature = @signature, updated_at = @updated_at WHERE id = @id;
+
+-- name: DeleteWebhookSource :exec
+DELETE FROM webhook_sources WHERE id = @id;
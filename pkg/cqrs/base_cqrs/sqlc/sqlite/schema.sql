@@ -37,7 +37,8 @@ CREATE TABLE functions (
 	slug VARCHAR NOT NULL,
 	config VARCHAR NOT NULL,
 	created_at TIMESTAMP NOT NULL,
-	archived_at TIMESTAMP
+	archived_at TIMESTAMP,
+	paused_at TIMESTAMP
 );
 
 CREATE TABLE function_runs (
@@ -86,7 +87,9 @@ CREATE TABLE history (
 	wait_result VARCHAR,
 	invoke_function VARCHAR,
 	invoke_function_result VARCHAR,
-	result VARCHAR
+	result VARCHAR,
+	workspace_id UUID,
+	skip_reason VARCHAR
 );
 
 CREATE TABLE event_batches (
@@ -188,3 +191,84 @@ CREATE TABLE worker_connections (
 
     PRIMARY KEY(id, app_name)
 );
+
+CREATE TABLE dead_letters (
+    id CHAR(26) PRIMARY KEY,
+    account_id CHAR(36) NOT NULL,
+    workspace_id CHAR(36) NOT NULL,
+    app_id CHAR(36) NOT NULL,
+    function_id CHAR(36) NOT NULL,
+    function_version INT NOT NULL,
+    run_id CHAR(26) NOT NULL,
+    event_ids BLOB NOT NULL,
+    events BLOB NOT NULL,
+    step_id VARCHAR,
+    step_name VARCHAR,
+    error VARCHAR NOT NULL,
+    failed_at INT NOT NULL,
+    redriven_at INT,
+    redrive_run_id CHAR(26)
+);
+
+CREATE TABLE replays (
+    id CHAR(36) PRIMARY KEY,
+    account_id CHAR(36) NOT NULL,
+    workspace_id CHAR(36) NOT NULL,
+    app_id CHAR(36) NOT NULL,
+    function_id CHAR(36) NOT NULL,
+    name VARCHAR NOT NULL,
+    from_time INT NOT NULL,
+    to_time INT NOT NULL,
+    statuses VARCHAR NOT NULL,
+    rate INT NOT NULL,
+    status INT NOT NULL,
+    run_cursor CHAR(26),
+    total_runs INT NOT NULL,
+    processed_runs INT NOT NULL DEFAULT 0,
+    scheduled_runs INT NOT NULL DEFAULT 0,
+    failed_runs INT NOT NULL DEFAULT 0,
+    error VARCHAR,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    ended_at INT
+);
+
+CREATE TABLE event_schemas (
+    app_id CHAR(36) NOT NULL,
+    event_name VARCHAR NOT NULL,
+    source VARCHAR NOT NULL,
+    schema BLOB NOT NULL,
+    updated_at INT NOT NULL,
+    PRIMARY KEY (app_id, event_name)
+);
+
+CREATE TABLE quarantined_events (
+    id CHAR(26) PRIMARY KEY,
+    event_name VARCHAR NOT NULL,
+    event BLOB NOT NULL,
+    error VARCHAR NOT NULL,
+    received_at INT NOT NULL
+);
+
+CREATE TABLE event_keys (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    key_hash VARCHAR NOT NULL UNIQUE,
+    key_prefix VARCHAR NOT NULL,
+    allowed_events VARCHAR NOT NULL,
+    rate_limit INT NOT NULL DEFAULT 0,
+    rate_period VARCHAR NOT NULL DEFAULT '',
+    expires_at INT,
+    last_used_at INT,
+    revoked_at INT,
+    created_at INT NOT NULL
+);
+
+CREATE TABLE webhook_sources (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    transform VARCHAR NOT NULL,
+    signature BLOB,
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL
+);
@@ -1,6 +1,8 @@
 package base_cqrs
 
 import (
+	"database/sql"
+
 	sqlc "github.com/inngest/inngest/pkg/cqrs/base_cqrs/sqlc/sqlite"
 	"github.com/inngest/inngest/pkg/execution/history"
 	"github.com/jinzhu/copier"
@@ -11,6 +13,9 @@ func convertHistoryToWriter(h history.History) (*sqlc.InsertHistoryParams, error
 	if err := copier.CopyWithOption(&to, h, copier.Option{DeepCopy: true}); err != nil {
 		return nil, err
 	}
+	if h.SkipReason != nil {
+		to.SkipReason = sql.NullString{String: h.SkipReason.String(), Valid: true}
+	}
 
 	return &to, nil
 }
@@ -36,6 +36,22 @@ type Manager interface {
 	// Connection history
 	ConnectionHistoryReadWriter
 
+	// Dead letters for permanently failed runs
+	DeadLetterReadWriter
+
+	// Bulk replays of historical runs
+	ReplayReadWriter
+
+	// Event schemas validated at ingest
+	EventSchemaReadWriter
+	// Event keys used to send events
+	EventKeyReadWriter
+	// Webhook sources transforming HTTP payloads into events
+	WebhookSourceReadWriter
+
+	// Paged access to historical rows for exports and imports
+	ArchiveReadWriter
+
 	// Scoped allows creating a new manager using a transaction.
 	WithTx(ctx context.Context) (TxManager, error)
 }
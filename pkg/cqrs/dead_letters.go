@@ -0,0 +1,99 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// MaxDeadLetters is the maximum number of dead letters that can be loaded at once.
+	MaxDeadLetters = 100
+)
+
+type DeadLetterReadWriter interface {
+	DeadLetterReader
+	DeadLetterWriter
+}
+
+// DeadLetterReader loads dead letters from a backing store.
+type DeadLetterReader interface {
+	// DeadLetters returns dead letters for a workspace in reverse chronological order.
+	DeadLetters(ctx context.Context, wsID uuid.UUID, opts *DeadLettersOpts) ([]DeadLetter, error)
+	// DeadLetter returns a single dead letter by ID.
+	DeadLetter(ctx context.Context, wsID uuid.UUID, id ulid.ULID) (*DeadLetter, error)
+}
+
+type DeadLetterWriter interface {
+	// InsertDeadLetter writes a dead letter to the backing store.
+	InsertDeadLetter(ctx context.Context, dl DeadLetter) error
+	// UpdateDeadLetterRedrive records the run scheduled when redriving a dead letter.
+	UpdateDeadLetterRedrive(ctx context.Context, wsID uuid.UUID, id ulid.ULID, runID ulid.ULID) error
+}
+
+// DeadLetter represents a function run which permanently failed after exhausting
+// all retries, storing everything required to schedule the run again.
+type DeadLetter struct {
+	ID          ulid.ULID `json:"id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	WorkspaceID uuid.UUID `json:"environment_id"`
+	AppID       uuid.UUID `json:"app_id"`
+	// FunctionID represents the function's internal ID.
+	FunctionID      uuid.UUID `json:"function_internal_id"`
+	FunctionVersion int       `json:"function_version"`
+	// RunID is the ID of the failed run.
+	RunID ulid.ULID `json:"run_id"`
+	// EventIDs are the internal IDs of the events which triggered the run.
+	EventIDs []ulid.ULID `json:"event_ids"`
+	// Events are the events which triggered the run, in the same order as EventIDs.
+	Events []json.RawMessage `json:"events"`
+	// StepID and StepName represent the step which last errored, if any.
+	StepID   *string `json:"step_id,omitempty"`
+	StepName *string `json:"step_name,omitempty"`
+	// Error is the last error returned by the run.
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+	// RedrivenAt and RedriveRunID are set once the dead letter is redriven.
+	RedrivenAt   *time.Time `json:"redriven_at,omitempty"`
+	RedriveRunID *ulid.ULID `json:"redrive_run_id,omitempty"`
+}
+
+// IsRedriven returns whether the dead letter has been redriven.
+func (d DeadLetter) IsRedriven() bool {
+	return d.RedriveRunID != nil
+}
+
+type DeadLettersOpts struct {
+	Cursor *ulid.ULID
+	Limit  int
+	// FunctionID filters dead letters to a given function.
+	FunctionID *uuid.UUID
+	// Newest represents the newest failure time to load dead letters from.
+	Newest time.Time
+	// Oldest represents the oldest failure time to load dead letters from.
+	Oldest time.Time
+}
+
+func (o *DeadLettersOpts) Validate() error {
+	if o.Limit < 1 {
+		return fmt.Errorf("limit must be positive")
+	}
+	if o.Limit > MaxDeadLetters {
+		return fmt.Errorf("limit must be less than %d", MaxDeadLetters)
+	}
+	if o.Newest.IsZero() {
+		o.Newest = time.Now()
+	}
+	if o.Oldest.IsZero() {
+		// Default to the dead letter retention period.
+		o.Oldest = time.Now().Add(-1 * DeadLetterRetention)
+	}
+	return nil
+}
+
+// DeadLetterRetention is the default period of time to list dead letters for.
+var DeadLetterRetention = 7 * 24 * time.Hour
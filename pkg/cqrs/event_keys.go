@@ -0,0 +1,62 @@
+package cqrs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventKeyReadWriter interface {
+	EventKeyReader
+	EventKeyWriter
+}
+
+// EventKeyReader loads event keys from a backing store.
+type EventKeyReader interface {
+	// EventKeys returns all event keys, including revoked keys, newest first.
+	EventKeys(ctx context.Context) ([]EventKey, error)
+	// EventKey returns a single event key by ID.
+	EventKey(ctx context.Context, id uuid.UUID) (*EventKey, error)
+	// EventKeyByHash returns the event key with the given hashed secret.
+	EventKeyByHash(ctx context.Context, hash string) (*EventKey, error)
+}
+
+type EventKeyWriter interface {
+	// InsertEventKey stores a new event key.
+	InsertEventKey(ctx context.Context, key EventKey) error
+	// RevokeEventKey revokes an event key, preventing it from being used to
+	// send events.  Revoking an already revoked key is a no-op.
+	RevokeEventKey(ctx context.Context, id uuid.UUID, at time.Time) error
+	// UpdateEventKeyLastUsed records the time an event key was last used.
+	UpdateEventKeyLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// EventKey is a key used to send events, scoped to a set of event names and
+// an optional ingest rate limit.
+type EventKey struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Hash is the SHA-256 hash of the key's secret.  Secrets are never stored.
+	Hash string `json:"-"`
+	// Prefix is the first few characters of the key's secret, used to
+	// identify keys without exposing the secret.
+	Prefix string `json:"prefix"`
+	// AllowedEvents is a list of event name globs which may be sent using
+	// this key, eg. "user/*".  An empty list allows all events.
+	AllowedEvents []string `json:"allowed_events"`
+	// RateLimit optionally limits the rate at which events may be sent using
+	// this key.
+	RateLimit  *EventKeyRateLimit `json:"rate_limit,omitempty"`
+	ExpiresAt  *time.Time         `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time         `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// EventKeyRateLimit limits the number of events sent using a key within a
+// period, eg. 100 events per "1m".
+type EventKeyRateLimit struct {
+	Limit  int    `json:"limit"`
+	Period string `json:"period"`
+}
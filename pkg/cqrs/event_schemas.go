@@ -0,0 +1,83 @@
+package cqrs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// EventSchemaSourceSDK is the source for schemas registered by apps when syncing.
+	EventSchemaSourceSDK = "sdk"
+	// EventSchemaSourceConfig is the source for schemas loaded from config files.
+	EventSchemaSourceConfig = "config"
+
+	// MaxQuarantinedEvents is the maximum number of quarantined events that can be
+	// loaded at once.
+	MaxQuarantinedEvents = 100
+)
+
+type EventSchemaReadWriter interface {
+	EventSchemaReader
+	EventSchemaWriter
+}
+
+// EventSchemaReader loads event schemas and quarantined events from a backing store.
+type EventSchemaReader interface {
+	// EventSchemas returns all registered event schemas, ordered by event name.
+	EventSchemas(ctx context.Context) ([]EventSchema, error)
+	// EventSchemasByName returns all schemas registered for the given event name.
+	EventSchemasByName(ctx context.Context, name string) ([]EventSchema, error)
+	// QuarantinedEvents returns events which failed schema validation in reverse
+	// chronological order.
+	QuarantinedEvents(ctx context.Context, opts QuarantinedEventsOpts) ([]QuarantinedEvent, error)
+}
+
+type EventSchemaWriter interface {
+	// ReplaceEventSchemas replaces all schemas for the given app ID.  Schemas loaded
+	// from config are stored using uuid.Nil as the app ID.
+	ReplaceEventSchemas(ctx context.Context, appID uuid.UUID, schemas []EventSchema) error
+	// InsertQuarantinedEvent stores an event which failed schema validation.
+	InsertQuarantinedEvent(ctx context.Context, evt QuarantinedEvent) error
+}
+
+// EventSchema is a JSON Schema which the data of every event with the given name
+// must conform to.
+type EventSchema struct {
+	// AppID is the app which registered the schema, or uuid.Nil for schemas
+	// loaded from config.
+	AppID     uuid.UUID       `json:"app_id"`
+	EventName string          `json:"event_name"`
+	Source    string          `json:"source"`
+	Schema    json.RawMessage `json:"schema"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// QuarantinedEvent is an event which was held at ingest because it failed schema
+// validation.  Quarantined events never trigger functions.
+type QuarantinedEvent struct {
+	ID         ulid.ULID       `json:"id"`
+	EventName  string          `json:"event_name"`
+	Event      json.RawMessage `json:"event"`
+	Error      string          `json:"error"`
+	ReceivedAt time.Time       `json:"received_at"`
+}
+
+type QuarantinedEventsOpts struct {
+	Cursor *ulid.ULID
+	Limit  int
+}
+
+func (o *QuarantinedEventsOpts) Validate() error {
+	if o.Limit < 1 {
+		return fmt.Errorf("limit must be positive")
+	}
+	if o.Limit > MaxQuarantinedEvents {
+		return fmt.Errorf("limit must be less than %d", MaxQuarantinedEvents)
+	}
+	return nil
+}
@@ -16,6 +16,14 @@ type Function struct {
 	Name      string          `json:"name"`
 	Config    json.RawMessage `json:"config"`
 	CreatedAt time.Time       `json:"created_at"`
+	// PausedAt is the time the function was paused, or nil if the function
+	// isn't paused.  Runs for paused functions are skipped.
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+}
+
+// IsPaused returns whether the function is currently paused.
+func (f Function) IsPaused() bool {
+	return f.PausedAt != nil
 }
 
 func (f Function) InngestFunction() (*inngest.Function, error) {
@@ -59,6 +67,8 @@ type DevFunctionReader interface {
 }
 
 type DevFunctionWriter interface {
+	FunctionPauseWriter
+
 	InsertFunction(ctx context.Context, params InsertFunctionParams) (*Function, error)
 	UpdateFunctionConfig(ctx context.Context, arg UpdateFunctionConfigParams) (*Function, error)
 	// DeleteFunctionsByAppID deletes all functions for a specific app.
@@ -67,6 +77,13 @@ type DevFunctionWriter interface {
 	DeleteFunctionsByIDs(ctx context.Context, ids []uuid.UUID) error
 }
 
+// FunctionPauseWriter pauses and unpauses functions.
+type FunctionPauseWriter interface {
+	// UpdateFunctionPausedAt pauses or unpauses a function.  A nil PausedAt
+	// unpauses the function.
+	UpdateFunctionPausedAt(ctx context.Context, arg UpdateFunctionPausedAtParams) (*Function, error)
+}
+
 type InsertFunctionParams struct {
 	ID        uuid.UUID
 	AppID     uuid.UUID
@@ -80,3 +97,8 @@ type UpdateFunctionConfigParams struct {
 	Config string
 	ID     uuid.UUID
 }
+
+type UpdateFunctionPausedAtParams struct {
+	PausedAt *time.Time
+	ID       uuid.UUID
+}
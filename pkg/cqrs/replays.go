@@ -0,0 +1,119 @@
+package cqrs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// MaxReplayRate is the maximum number of runs a replay can schedule per second.
+	MaxReplayRate = 1000
+	// DefaultReplayRate is the number of runs a replay schedules per second if
+	// no rate is specified.
+	DefaultReplayRate = 10
+)
+
+type ReplayReadWriter interface {
+	ReplayReader
+	ReplayWriter
+}
+
+// ReplayReader loads replays from a backing store.
+type ReplayReader interface {
+	// Replay returns a single replay by ID.
+	Replay(ctx context.Context, wsID uuid.UUID, id uuid.UUID) (*Replay, error)
+	// FunctionReplays returns a function's replays in reverse chronological order.
+	FunctionReplays(ctx context.Context, wsID uuid.UUID, fnID uuid.UUID) ([]Replay, error)
+	// ReplaysByStatus returns replays across every workspace with the given
+	// status, oldest first.
+	ReplaysByStatus(ctx context.Context, status enums.ReplayStatus) ([]Replay, error)
+}
+
+type ReplayWriter interface {
+	// InsertReplay writes a new replay to the backing store.
+	InsertReplay(ctx context.Context, r Replay) error
+	// UpdateReplayProgress stores the progress of a replay.  This never
+	// changes the replay's status.
+	UpdateReplayProgress(ctx context.Context, r Replay) error
+	// UpdateReplayStatus changes the status of a replay, storing the given
+	// error if the replay failed.
+	UpdateReplayStatus(ctx context.Context, wsID uuid.UUID, id uuid.UUID, status enums.ReplayStatus, err *string) error
+}
+
+// Replay represents a bulk replay of a function's historical runs, scheduling
+// new runs using each original run's events.
+type Replay struct {
+	ID          uuid.UUID `json:"id"`
+	AccountID   uuid.UUID `json:"account_id"`
+	WorkspaceID uuid.UUID `json:"environment_id"`
+	AppID       uuid.UUID `json:"app_id"`
+	// FunctionID represents the function's internal ID.
+	FunctionID uuid.UUID `json:"function_internal_id"`
+	Name       string    `json:"name"`
+	// FromTime and ToTime bound the start time of the runs to replay.
+	FromTime time.Time `json:"from_time"`
+	ToTime   time.Time `json:"to_time"`
+	// Statuses are the statuses of the runs to replay.
+	Statuses []enums.ReplayRunStatus `json:"statuses"`
+	// Rate is the maximum number of runs scheduled per second.
+	Rate   int                `json:"rate"`
+	Status enums.ReplayStatus `json:"status"`
+	// Cursor is the ID of the last run processed.
+	Cursor *ulid.ULID `json:"cursor,omitempty"`
+	// TotalRuns is the number of runs matching the replay when it was created.
+	TotalRuns int `json:"total_runs"`
+	// ProcessedRuns is the number of runs processed so far, which is the sum of
+	// ScheduledRuns and FailedRuns.
+	ProcessedRuns int `json:"processed_runs"`
+	ScheduledRuns int `json:"scheduled_runs"`
+	FailedRuns    int `json:"failed_runs"`
+	// Error is set if the replay failed.
+	Error     *string    `json:"error,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// RunStatuses returns the run statuses and skip reasons selected by the replay,
+// expanding enums.ReplayRunStatusAll.
+func (r Replay) RunStatuses() ([]enums.RunStatus, []enums.SkipReason) {
+	var (
+		statuses []enums.RunStatus
+		reasons  []enums.SkipReason
+	)
+	for _, s := range r.Statuses {
+		switch s {
+		case enums.ReplayRunStatusAll:
+			return enums.ReplayableFunctionRunStatuses(), enums.ReplayableSkipReasons()
+		case enums.ReplayRunStatusSkippedPaused:
+			reasons = append(reasons, enums.SkipReasonFunctionPaused)
+		default:
+			statuses = append(statuses, enums.RunStatus(s))
+		}
+	}
+	return statuses, reasons
+}
+
+func (r Replay) Validate() error {
+	if r.FunctionID == uuid.Nil {
+		return fmt.Errorf("function ID must be provided")
+	}
+	if r.FromTime.IsZero() || r.ToTime.IsZero() {
+		return fmt.Errorf("from and to times must be provided")
+	}
+	if !r.ToTime.After(r.FromTime) {
+		return fmt.Errorf("to time must be after from time")
+	}
+	if len(r.Statuses) == 0 {
+		return fmt.Errorf("at least one status must be provided")
+	}
+	if r.Rate < 1 || r.Rate > MaxReplayRate {
+		return fmt.Errorf("rate must be between 1 and %d", MaxReplayRate)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cqrs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookSourceReadWriter interface {
+	WebhookSourceReader
+	WebhookSourceWriter
+}
+
+// WebhookSourceReader loads webhook sources from a backing store.
+type WebhookSourceReader interface {
+	// WebhookSources returns all webhook sources, newest first.
+	WebhookSources(ctx context.Context) ([]WebhookSource, error)
+	// WebhookSource returns a single webhook source by ID.
+	WebhookSource(ctx context.Context, id uuid.UUID) (*WebhookSource, error)
+}
+
+type WebhookSourceWriter interface {
+	InsertWebhookSource(ctx context.Context, src WebhookSource) error
+	// UpdateWebhookSource updates a source's name, transform, and signature.
+	UpdateWebhookSource(ctx context.Context, src WebhookSource) error
+	DeleteWebhookSource(ctx context.Context, id uuid.UUID) error
+}
+
+// WebhookSource receives arbitrary HTTP payloads at /w/{id}, transforming
+// each request into one or more events.
+type WebhookSource struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Transform is a CEL expression which maps the incoming request's
+	// headers, query, and body into an event or a list of events.
+	Transform string `json:"transform"`
+	// Signature, if set, verifies an HMAC signature of each request's body.
+	Signature *WebhookSignature `json:"signature,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// WebhookSignature configures HMAC verification of webhook request bodies,
+// eg. GitHub's "X-Hub-Signature-256: sha256=<hex>" header.
+type WebhookSignature struct {
+	// Secret is the shared secret used to sign requests.
+	Secret string `json:"secret"`
+	// Header is the request header containing the signature.
+	Header string `json:"header"`
+	// Algorithm is the hash used for the HMAC: "sha1", "sha256", or "sha512".
+	Algorithm string `json:"algorithm"`
+	// Encoding is the encoding of the signature: "hex" or "base64".
+	Encoding string `json:"encoding"`
+	// Prefix is an optional prefix stripped from the header value, eg. "sha256=".
+	Prefix string `json:"prefix,omitempty"`
+	// Scheme is how requests are signed.  By default the body is signed.  The
+	// "timestamped" scheme signs "<timestamp>.<body>" and expects a header of
+	// the form "t=<unix seconds>,v1=<signature>", as sent by Stripe.
+	Scheme string `json:"scheme,omitempty"`
+	// Tolerance is the maximum age in seconds of a timestamped signature,
+	// defaulting to 5 minutes.
+	Tolerance int `json:"tolerance,omitempty"`
+}
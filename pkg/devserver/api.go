@@ -21,6 +21,7 @@ import (
 	"github.com/inngest/inngest/pkg/api/tel"
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/eventschema"
 	"github.com/inngest/inngest/pkg/headers"
 	"github.com/inngest/inngest/pkg/inngest"
 	"github.com/inngest/inngest/pkg/inngest/version"
@@ -142,7 +143,7 @@ func (a devapi) Info(w http.ResponseWriter, r *http.Request) {
 		Handlers:            a.devserver.handlers,
 		IsSingleNodeService: a.devserver.IsSingleNodeService(),
 		IsMissingSigningKey: a.devserver.Opts.RequireKeys && !a.devserver.HasSigningKey(),
-		IsMissingEventKeys:  a.devserver.Opts.RequireKeys && !a.devserver.HasEventKeys(),
+		IsMissingEventKeys:  a.devserver.Opts.RequireKeys && !a.devserver.HasEventKeys(r.Context()),
 		Features:            features,
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -295,6 +296,13 @@ func (a devapi) register(ctx context.Context, r sdk.RegisterRequest) (*sync.Repl
 	if err != nil && err != sdk.ErrNoFunctions {
 		return nil, publicerr.Wrap(err, 400, "At least one function is invalid")
 	}
+	if a.devserver.Opts.FunctionValidator != nil {
+		for _, fn := range funcs {
+			if err = a.devserver.Opts.FunctionValidator(*fn); err != nil {
+				return nil, publicerr.Wrap(err, 400, "At least one function is unsupported")
+			}
+		}
+	}
 
 	// For each function,
 	for _, fn := range funcs {
@@ -335,6 +343,15 @@ func (a devapi) register(ctx context.Context, r sdk.RegisterRequest) (*sync.Repl
 		}
 	}
 
+	// Replace any event schemas registered by the app.
+	schemas, err := eventschema.Parse(r.EventSchemas, cqrs.EventSchemaSourceSDK)
+	if err != nil {
+		return nil, publicerr.Wrap(err, 400, "At least one event schema is invalid")
+	}
+	if err = tx.ReplaceEventSchemas(ctx, appID, schemas); err != nil {
+		return nil, publicerr.Wrap(err, 500, "Error saving event schemas")
+	}
+
 	reply := &sync.Reply{
 		OK:       true,
 		Modified: true,
@@ -34,8 +34,14 @@ import (
 	"github.com/inngest/inngest/pkg/deploy"
 	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventdedup"
+	"github.com/inngest/inngest/pkg/eventkey"
+	"github.com/inngest/inngest/pkg/eventroute"
+	"github.com/inngest/inngest/pkg/eventschema"
 	"github.com/inngest/inngest/pkg/execution"
 	"github.com/inngest/inngest/pkg/execution/batch"
+	"github.com/inngest/inngest/pkg/execution/cron"
+	"github.com/inngest/inngest/pkg/execution/deadletter"
 	"github.com/inngest/inngest/pkg/execution/debounce"
 	"github.com/inngest/inngest/pkg/execution/driver"
 	"github.com/inngest/inngest/pkg/execution/driver/httpdriver"
@@ -44,7 +50,9 @@ import (
 	"github.com/inngest/inngest/pkg/execution/queue"
 	"github.com/inngest/inngest/pkg/execution/ratelimit"
 	"github.com/inngest/inngest/pkg/execution/realtime"
+	"github.com/inngest/inngest/pkg/execution/replay"
 	"github.com/inngest/inngest/pkg/execution/runner"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
 	"github.com/inngest/inngest/pkg/execution/singleton"
 	"github.com/inngest/inngest/pkg/execution/state"
 	"github.com/inngest/inngest/pkg/execution/state/redis_state"
@@ -53,6 +61,7 @@ import (
 	"github.com/inngest/inngest/pkg/expressions/expragg"
 	"github.com/inngest/inngest/pkg/history_drivers/memory_reader"
 	"github.com/inngest/inngest/pkg/history_drivers/memory_writer"
+	"github.com/inngest/inngest/pkg/inngest"
 	"github.com/inngest/inngest/pkg/logger"
 	"github.com/inngest/inngest/pkg/pubsub"
 	"github.com/inngest/inngest/pkg/run"
@@ -101,6 +110,43 @@ type StartOpts struct {
 
 	ConnectGatewayPort int    `json:"connectGatewayPort"`
 	ConnectGatewayHost string `json:"connectGatewayHost"`
+
+	// CronCatchUp defines how cron ticks missed while the server was down are
+	// handled.
+	CronCatchUp enums.CronCatchUp `json:"cron-catch-up"`
+
+	// EventSchemas is an optional path to a JSON file mapping event names to
+	// JSON Schemas which events are validated against at ingest.
+	EventSchemas string `json:"event-schemas"`
+	// EventSchemaMode determines how events failing schema validation are
+	// handled at ingest.
+	EventSchemaMode eventschema.Mode `json:"event-schema-mode"`
+
+	// EventDedupWindow is the window in which events sent with the same ID
+	// are deduplicated.  When enabled, events reusing the ID of an event sent
+	// within the window are acknowledged with the original event's internal ID
+	// but are not published, so functions won't run for them.  This is zero,
+	// disabling deduplication, by default.
+	EventDedupWindow time.Duration `json:"event-dedup-window"`
+
+	// EventScheduleMinDelay is the minimum time an event's timestamp must be
+	// in the future for the event to be held until that time.  Events with
+	// earlier timestamps are published immediately, tolerating clock skew
+	// between senders and the server.
+	EventScheduleMinDelay time.Duration `json:"event-schedule-min-delay"`
+
+	// RealtimeRoutes is an optional path to a JSON file of routes which publish
+	// matching events to realtime channels as they're delivered.
+	RealtimeRoutes string `json:"realtime-routes"`
+
+	// PriorityFactorMin and PriorityFactorMax limit the run priority factors of
+	// functions, in seconds.  When both are zero, only the limits in consts apply.
+	PriorityFactorMin int64 `json:"priority-factor-min"`
+	PriorityFactorMax int64 `json:"priority-factor-max"`
+
+	// FunctionValidator, if set, rejects syncs containing functions which the
+	// server's backends can't run.
+	FunctionValidator func(fn inngest.Function) error `json:"-"`
 }
 
 // Create and start a new dev server.  The dev server is used during (surprise surprise)
@@ -137,6 +183,16 @@ func start(ctx context.Context, opts StartOpts) error {
 	// Initialize the devserver
 	dbDriver := "sqlite"
 	dbcqrs := base_cqrs.NewCQRS(db, dbDriver)
+	if err := eventschema.LoadConfig(ctx, dbcqrs, opts.EventSchemas); err != nil {
+		return fmt.Errorf("error loading event schemas: %w", err)
+	}
+	var routes *eventroute.Router
+	if opts.RealtimeRoutes != "" {
+		var err error
+		if routes, err = eventroute.LoadFile(ctx, opts.RealtimeRoutes); err != nil {
+			return fmt.Errorf("error loading realtime routes: %w", err)
+		}
+	}
 	hd := base_cqrs.NewHistoryDriver(db, dbDriver)
 	loader := dbcqrs.(state.FunctionLoader)
 
@@ -190,8 +246,11 @@ func start(ctx context.Context, opts StartOpts) error {
 	}
 	smv2 := redis_state.MustRunServiceV2(sm)
 
-	// Create a new broadcaster which lets us broadcast realtime messages.
-	broadcaster := realtime.NewInProcessBroadcaster()
+	// Create a new broadcaster which lets us broadcast realtime messages, retaining
+	// recent messages so that reconnecting subscribers can catch up.
+	broadcaster := realtime.NewInProcessBroadcaster(
+		realtime.WithHistory(realtime.DefaultHistorySize, realtime.DefaultHistoryTTL),
+	)
 
 	runMode := redis_state.QueueRunMode{
 		Sequential:    true,
@@ -318,6 +377,13 @@ func start(ctx context.Context, opts StartOpts) error {
 	debouncer := debounce.NewRedisDebouncer(unshardedClient.Debounce(), queueShard, rq)
 
 	sn := singleton.New(ctx, queueShard.RedisClient)
+	crons := cron.NewRedisCronManager(queueShard.RedisClient, rq, cron.WithLogger(l), cron.WithCatchUp(opts.CronCatchUp))
+	scheduler := scheduledevent.NewRedisScheduler(
+		queueShard,
+		rq,
+		scheduledevent.WithLogger(l),
+		scheduledevent.WithMinDelay(opts.EventScheduleMinDelay),
+	)
 
 	conditionalTracer := itrace.NewConditionalTracer(itrace.ConnectTracer(), itrace.AlwaysTrace)
 
@@ -395,6 +461,7 @@ func start(ctx context.Context, opts StartOpts) error {
 				EventTopic: opts.Config.EventStream.Service.Concrete.TopicName(),
 			},
 			run.NewTraceLifecycleListener(nil),
+			deadletter.NewLifecycleListener(dbcqrs),
 		),
 		executor.WithStepLimits(func(id sv2.ID) int {
 			if override, hasOverride := stepLimitOverrides[id.FunctionID.String()]; hasOverride {
@@ -414,6 +481,7 @@ func start(ctx context.Context, opts StartOpts) error {
 		}),
 		executor.WithInvokeFailHandler(getInvokeFailHandler(ctx, pb, opts.Config.EventStream.Service.Concrete.TopicName())),
 		executor.WithSendingEventHandler(getSendingEventHandler(ctx, pb, opts.Config.EventStream.Service.Concrete.TopicName())),
+		executor.WithPriorityFactorRange(executor.StaticPriorityFactorRange(opts.PriorityFactorMin, opts.PriorityFactorMax)),
 		executor.WithDebouncer(debouncer),
 		executor.WithSingletonManager(sn),
 		executor.WithBatcher(batcher),
@@ -434,6 +502,7 @@ func start(ctx context.Context, opts StartOpts) error {
 		executor.WithServiceExecutor(exec),
 		executor.WithServiceBatcher(batcher),
 		executor.WithServiceDebouncer(debouncer),
+		executor.WithServiceCronManager(crons),
 		executor.WithServiceLogger(l),
 	)
 
@@ -448,7 +517,9 @@ func start(ctx context.Context, opts StartOpts) error {
 		runner.WithTracker(t),
 		runner.WithRateLimiter(rl),
 		runner.WithBatchManager(batcher),
+		runner.WithCronManager(crons),
 		runner.WithPublisher(pb),
+		runner.WithRealtimeRoutes(routes, broadcaster),
 		runner.WithLogger(l),
 	)
 
@@ -459,6 +530,10 @@ func start(ctx context.Context, opts StartOpts) error {
 	ds.State = sm
 	ds.Queue = rq
 	ds.Executor = exec
+	ds.EventKeys = eventkey.NewAuthorizer(opts.EventKeys, dbcqrs, rl)
+
+	replays := replay.NewManager(ds.Data, base_cqrs.NewHistoryReader(db, dbDriver), ds.Data, ds.Data, ds.Executor, replay.WithLogger(l))
+
 	// start the API
 	// Create a new API endpoint which hosts SDK-related functionality for
 	// registering functions.
@@ -470,16 +545,21 @@ func start(ctx context.Context, opts StartOpts) error {
 		caching := apiv1.NewCacheMiddleware(cache)
 
 		apiv1.AddRoutes(r, apiv1.Opts{
-			CachingMiddleware:  caching,
-			EventReader:        ds.Data,
-			FunctionReader:     ds.Data,
-			FunctionRunReader:  ds.Data,
-			JobQueueReader:     ds.Queue.(queue.JobQueueReader),
-			Executor:           ds.Executor,
-			QueueShardSelector: shardSelector,
-			Broadcaster:        broadcaster,
-			RealtimeJWTSecret:  consts.DevServerRealtimeJWTSecret,
-			TraceReader:        ds.Data,
+			CachingMiddleware:    caching,
+			EventReader:          ds.Data,
+			FunctionReader:       ds.Data,
+			FunctionRunReader:    ds.Data,
+			JobQueueReader:       ds.Queue.(queue.JobQueueReader),
+			Executor:             ds.Executor,
+			QueueShardSelector:   shardSelector,
+			Broadcaster:          broadcaster,
+			RealtimeJWTSecret:    consts.DevServerRealtimeJWTSecret,
+			TraceReader:          ds.Data,
+			DeadLetterReadWriter: ds.Data,
+			FunctionPauseWriter:  ds.Data,
+			Replays:              replays,
+			EventScheduler:       scheduler,
+			Batcher:              batcher,
 		})
 	})
 
@@ -505,11 +585,13 @@ func start(ctx context.Context, opts StartOpts) error {
 		EventHandler:  ds.HandleEvent,
 		Executor:      ds.Executor,
 		HistoryReader: memory_reader.NewReader(),
+		Replays:       replays,
 		ConnectOpts: connectv0.Opts{
 			GroupManager:               connectionManager,
 			ConnectManager:             connectionManager,
 			ConnectResponseNotifier:    apiConnectProxy,
 			ConnectRequestStateManager: connectionManager,
+			RoutingManager:             connectionManager,
 			Signer:                     auth.NewJWTSessionTokenSigner(consts.DevServerConnectJwtSecret),
 			RequestAuther:              ds,
 			ConnectGatewayRetriever:    ds,
@@ -574,13 +656,22 @@ func start(ctx context.Context, opts StartOpts) error {
 	}
 
 	ds.Apiservice = api.NewService(api.APIServiceOptions{
-		Config:         ds.Opts.Config,
-		Mounts:         mounts,
-		LocalEventKeys: opts.EventKeys,
-		Logger:         l,
+		Config:             ds.Opts.Config,
+		Mounts:             mounts,
+		LocalEventKeys:     opts.EventKeys,
+		Logger:             l,
+		EventValidator:     eventschema.NewValidator(dbcqrs),
+		EventSchemaMode:    opts.EventSchemaMode,
+		Quarantine:         dbcqrs,
+		EventKeys:          dbcqrs,
+		RateLimiter:        rl,
+		EventKeyAuthorizer: ds.EventKeys,
+		WebhookSources:     dbcqrs,
+		EventScheduler:     scheduler,
+		Deduplicator:       eventdedup.NewRedisDeduplicator(unshardedRc, "{dedup}:", eventdedup.StaticWindow(opts.EventDedupWindow)),
 	})
 
-	return service.StartAll(ctx, ds, runner, executorSvc, ds.Apiservice, connGateway)
+	return service.StartAll(ctx, ds, runner, executorSvc, ds.Apiservice, connGateway, replays)
 }
 
 func createInmemoryRedis(ctx context.Context, tick time.Duration) (rueidis.Client, *miniredis.Miniredis, error) {
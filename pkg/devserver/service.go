@@ -22,6 +22,7 @@ import (
 	"github.com/inngest/inngest/pkg/devserver/discovery"
 	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventkey"
 	"github.com/inngest/inngest/pkg/execution"
 	"github.com/inngest/inngest/pkg/execution/history"
 	"github.com/inngest/inngest/pkg/execution/queue"
@@ -61,6 +62,7 @@ func NewService(opts StartOpts, runner runner.Runner, data cqrs.Manager, pb pubs
 		stepLimitOverrides:      stepLimitOverrides,
 		stateSizeLimitOverrides: stateSizeLimitOverrides,
 		redisClient:             rc,
+		EventKeys:               eventkey.NewAuthorizer(opts.EventKeys, data, nil),
 		historyWriter:           hw,
 		singleNodeServiceOpts:   snso,
 		log:                     logger.StdlibLogger(context.Background()),
@@ -82,11 +84,14 @@ type devserver struct {
 	stateSizeLimitOverrides map[string]int
 
 	// Runner stores the Runner
-	Runner      runner.Runner
-	Tracker     *runner.Tracker
-	State       state.Manager
-	Queue       queue.Queue
-	Executor    execution.Executor
+	Runner   runner.Runner
+	Tracker  *runner.Tracker
+	State    state.Manager
+	Queue    queue.Queue
+	Executor execution.Executor
+	// EventKeys authorizes event keys.  It's shared with the event API so
+	// that both use the same cache of whether keys are configured.
+	EventKeys   eventkey.Authorizer
 	publisher   pubsub.Publisher
 	redisClient rueidis.Client
 
@@ -139,8 +144,10 @@ func (d *devserver) HasSigningKey() bool {
 	return d.Opts.SigningKey != nil && *d.Opts.SigningKey != ""
 }
 
-func (d *devserver) HasEventKeys() bool {
-	return len(d.Opts.EventKeys) > 0
+// HasEventKeys returns whether any static or active scoped event keys exist.
+func (d *devserver) HasEventKeys(ctx context.Context) bool {
+	ok, _ := d.EventKeys.Configured(ctx)
+	return ok
 }
 
 func (d *devserver) Pre(ctx context.Context) error {
@@ -201,7 +208,7 @@ func (d *devserver) Run(ctx context.Context) error {
 				if !d.HasSigningKey() {
 					fmt.Println(cli.WarningStyle.Render("\tWARNING: No signing key provided. Syncs and runs will not function.\n\t\t Add a signing key with a flag, environment variable, or config file.\n"))
 				}
-				if !d.HasEventKeys() {
+				if !d.HasEventKeys(ctx) {
 					fmt.Println(cli.WarningStyle.Render("\tWARNING: No event keys provided. Events will not be accepted.\n\t\t Add event keys with a flag, environment variable, or config file.\n"))
 				}
 			}
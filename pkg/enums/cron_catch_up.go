@@ -0,0 +1,16 @@
+//go:generate go run github.com/dmarkham/enumer -trimprefix=CronCatchUp -type=CronCatchUp -transform=snake -json -text
+
+package enums
+
+// CronCatchUp determines how cron ticks missed while the server was down are
+// handled once scheduling resumes.
+type CronCatchUp int
+
+const (
+	// CronCatchUpSkip drops every missed tick and resumes at the next scheduled tick.
+	CronCatchUpSkip CronCatchUp = iota
+	// CronCatchUpOnce fires the most recent missed tick once, dropping older ticks.
+	CronCatchUpOnce
+	// CronCatchUpAll fires every missed tick in order.
+	CronCatchUpAll
+)
@@ -0,0 +1,14 @@
+//go:generate go run github.com/dmarkham/enumer -trimprefix=DebounceMode -type=DebounceMode -transform=snake -json -text -gqlgen
+
+package enums
+
+type DebounceMode int
+
+const (
+	// DebounceModeTrailing runs the function with the last event received once the
+	// debounce period passes without any new events.
+	DebounceModeTrailing DebounceMode = iota
+	// DebounceModeLeading runs the function immediately with the first event, then
+	// skips any further events until the debounce period passes without any new events.
+	DebounceModeLeading
+)
@@ -2,4 +2,7 @@ package enums
 
 type QueueShardKind string
 
-const QueueShardKindRedis QueueShardKind = "redis"
+const (
+	QueueShardKindRedis    QueueShardKind = "redis"
+	QueueShardKindPostgres QueueShardKind = "postgres"
+)
@@ -0,0 +1,17 @@
+//go:generate go run github.com/dmarkham/enumer -trimprefix=ReplayStatus -type=ReplayStatus -json -text -gqlgen
+
+package enums
+
+// ReplayStatus represents the status of a bulk replay of historical runs.
+type ReplayStatus int
+
+const (
+	// ReplayStatusRunning replays are actively scheduling runs.
+	ReplayStatusRunning ReplayStatus = iota
+	// ReplayStatusPaused replays stop scheduling runs until they're resumed.
+	ReplayStatusPaused
+	// ReplayStatusCompleted replays have processed every matching run.
+	ReplayStatusCompleted
+	// ReplayStatusFailed replays stopped due to an unrecoverable error.
+	ReplayStatusFailed
+)
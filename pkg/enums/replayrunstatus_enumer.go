@@ -10,17 +10,27 @@ import (
 	"strings"
 )
 
-const _ReplayRunStatusName = "All"
-
-var _ReplayRunStatusIndex = [...]uint8{0, 3}
+const (
+	_ReplayRunStatusName_0      = "AllCompletedFailedCancelled"
+	_ReplayRunStatusLowerName_0 = "allcompletedfailedcancelled"
+	_ReplayRunStatusName_1      = "SkippedPaused"
+	_ReplayRunStatusLowerName_1 = "skippedpaused"
+)
 
-const _ReplayRunStatusLowerName = "all"
+var (
+	_ReplayRunStatusIndex_0 = [...]uint8{0, 3, 12, 18, 27}
+	_ReplayRunStatusIndex_1 = [...]uint8{0, 13}
+)
 
 func (i ReplayRunStatus) String() string {
-	if i < 0 || i >= ReplayRunStatus(len(_ReplayRunStatusIndex)-1) {
+	switch {
+	case 0 <= i && i <= 3:
+		return _ReplayRunStatusName_0[_ReplayRunStatusIndex_0[i]:_ReplayRunStatusIndex_0[i+1]]
+	case i == 11:
+		return _ReplayRunStatusName_1
+	default:
 		return fmt.Sprintf("ReplayRunStatus(%d)", i)
 	}
-	return _ReplayRunStatusName[_ReplayRunStatusIndex[i]:_ReplayRunStatusIndex[i+1]]
 }
 
 // An "invalid array index" compiler error signifies that the constant values have changed.
@@ -28,17 +38,33 @@ func (i ReplayRunStatus) String() string {
 func _ReplayRunStatusNoOp() {
 	var x [1]struct{}
 	_ = x[ReplayRunStatusAll-(0)]
+	_ = x[ReplayRunStatusCompleted-(1)]
+	_ = x[ReplayRunStatusFailed-(2)]
+	_ = x[ReplayRunStatusCancelled-(3)]
+	_ = x[ReplayRunStatusSkippedPaused-(11)]
 }
 
-var _ReplayRunStatusValues = []ReplayRunStatus{ReplayRunStatusAll}
+var _ReplayRunStatusValues = []ReplayRunStatus{ReplayRunStatusAll, ReplayRunStatusCompleted, ReplayRunStatusFailed, ReplayRunStatusCancelled, ReplayRunStatusSkippedPaused}
 
 var _ReplayRunStatusNameToValueMap = map[string]ReplayRunStatus{
-	_ReplayRunStatusName[0:3]:      ReplayRunStatusAll,
-	_ReplayRunStatusLowerName[0:3]: ReplayRunStatusAll,
+	_ReplayRunStatusName_0[0:3]:        ReplayRunStatusAll,
+	_ReplayRunStatusLowerName_0[0:3]:   ReplayRunStatusAll,
+	_ReplayRunStatusName_0[3:12]:       ReplayRunStatusCompleted,
+	_ReplayRunStatusLowerName_0[3:12]:  ReplayRunStatusCompleted,
+	_ReplayRunStatusName_0[12:18]:      ReplayRunStatusFailed,
+	_ReplayRunStatusLowerName_0[12:18]: ReplayRunStatusFailed,
+	_ReplayRunStatusName_0[18:27]:      ReplayRunStatusCancelled,
+	_ReplayRunStatusLowerName_0[18:27]: ReplayRunStatusCancelled,
+	_ReplayRunStatusName_1[0:13]:       ReplayRunStatusSkippedPaused,
+	_ReplayRunStatusLowerName_1[0:13]:  ReplayRunStatusSkippedPaused,
 }
 
 var _ReplayRunStatusNames = []string{
-	_ReplayRunStatusName[0:3],
+	_ReplayRunStatusName_0[0:3],
+	_ReplayRunStatusName_0[3:12],
+	_ReplayRunStatusName_0[12:18],
+	_ReplayRunStatusName_0[18:27],
+	_ReplayRunStatusName_1[0:13],
 }
 
 // ReplayRunStatusString retrieves an enum value from the enum constants string name.
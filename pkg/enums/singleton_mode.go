@@ -7,4 +7,6 @@ type SingletonMode int
 const (
 	// SingletonModeSkip skips the new run if another singleton instance is already in progress.
 	SingletonModeSkip SingletonMode = iota
+	// SingletonModeCancel cancels the in-progress singleton instance and schedules the new run.
+	SingletonModeCancel
 )
@@ -29,6 +29,9 @@ const (
 	// API.  Note that invoking functions still sends an event in the usual manner.
 	InvokeFnName = InternalNamePrefix + "function.invoked"
 	FnCronName   = InternalNamePrefix + "scheduled.timer"
+	// SignalSendName is the event name used to deliver signals to runs waiting
+	// via `waitForSignal`, for producers which can only send events.
+	SignalSendName = InternalNamePrefix + "signal.send"
 )
 
 var (
@@ -217,6 +220,39 @@ func (e Event) IsInvokeEvent() bool {
 	return e.Name == InvokeFnName
 }
 
+// IsSignalEvent returns true if the event delivers a signal to a waiting run.
+func (e Event) IsSignalEvent() bool {
+	return e.Name == SignalSendName
+}
+
+// SignalSendData represents the data within a SignalSendName event.
+type SignalSendData struct {
+	// Signal is the signal ID to resume.
+	Signal string `json:"signal"`
+	// Data is the payload returned to the waiting step.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// SignalData returns the signal data for a SignalSendName event, or nil if the
+// event is not a signal event.
+func (e Event) SignalData() (*SignalSendData, error) {
+	if !e.IsSignalEvent() {
+		return nil, nil
+	}
+	byt, err := json.Marshal(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	data := &SignalSendData{}
+	if err := json.Unmarshal(byt, data); err != nil {
+		return nil, err
+	}
+	if data.Signal == "" {
+		return nil, fmt.Errorf("signal is required")
+	}
+	return data, nil
+}
+
 // InngestMetadata represents metadata for an event that is used to invoke a
 // function. Note that this metadata is not present on all functions. For
 // accessing an event's correlation ID, prefer using `Event.CorrelationID()`.
@@ -152,3 +152,24 @@ func TestInternalIDSeedFromString(t *testing.T) {
 		r.Nil(seed)
 	})
 }
+
+func TestSignalData(t *testing.T) {
+	evt := Event{Name: "user/thing", Data: map[string]any{"signal": "foo"}}
+	data, err := evt.SignalData()
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	evt = Event{
+		Name: SignalSendName,
+		Data: map[string]any{"signal": "foo", "data": map[string]any{"ok": true}},
+	}
+	require.True(t, evt.IsSignalEvent())
+	data, err = evt.SignalData()
+	require.NoError(t, err)
+	require.Equal(t, "foo", data.Signal)
+	require.JSONEq(t, `{"ok":true}`, string(data.Data))
+
+	evt = Event{Name: SignalSendName, Data: map[string]any{}}
+	_, err = evt.SignalData()
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+// Package eventdedup deduplicates ingested events by their user-supplied ID
+// within a per-workspace window, ensuring that retried sends are only
+// published once.
+package eventdedup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+)
+
+const (
+	// DefaultWindow is the window used by the deduplicators when no
+	// WindowFunc is given.  Deduplication is opt-in: the dev server and
+	// self-hosted server don't enable it unless a window is configured.
+	DefaultWindow = 24 * time.Hour
+
+	// claimScript atomically records an event's internal ID, returning the
+	// previously recorded internal ID if the event ID has already been
+	// claimed within the window.
+	claimScript = `
+local existing = redis.call('get', KEYS[1])
+if existing then
+  return existing
+end
+redis.call('set', KEYS[1], ARGV[1], 'PX', ARGV[2])
+return ""
+`
+)
+
+// Deduplicator records ingested event IDs, reporting duplicates.
+type Deduplicator interface {
+	// Claim records the internal ID for the given workspace's event ID.  If
+	// the event ID was already claimed within the window, the original
+	// internal ID is returned along with true.
+	Claim(ctx context.Context, wsID uuid.UUID, eventID string, internalID ulid.ULID) (ulid.ULID, bool, error)
+	// Release removes a claim, allowing the event ID to be sent again, eg.
+	// if publishing the claimed event fails.
+	Release(ctx context.Context, wsID uuid.UUID, eventID string) error
+}
+
+// WindowFunc returns the deduplication window for a workspace.  A window of
+// zero or less disables deduplication for the workspace.
+type WindowFunc func(ctx context.Context, wsID uuid.UUID) time.Duration
+
+// StaticWindow returns a WindowFunc which uses the same window for every
+// workspace.
+func StaticWindow(d time.Duration) WindowFunc {
+	return func(ctx context.Context, wsID uuid.UUID) time.Duration {
+		return d
+	}
+}
+
+// NewRedisDeduplicator returns a Deduplicator which stores claims in redis,
+// expiring each claim after the workspace's window.
+func NewRedisDeduplicator(r rueidis.Client, prefix string, window WindowFunc) Deduplicator {
+	if window == nil {
+		window = StaticWindow(DefaultWindow)
+	}
+	return &redisDeduplicator{
+		r:      r,
+		prefix: prefix,
+		window: window,
+		claim:  rueidis.NewLuaScript(claimScript),
+	}
+}
+
+type redisDeduplicator struct {
+	r      rueidis.Client
+	prefix string
+	window WindowFunc
+	claim  *rueidis.Lua
+}
+
+func (d *redisDeduplicator) key(wsID uuid.UUID, eventID string) string {
+	return fmt.Sprintf("%s%s:%s", d.prefix, wsID, eventID)
+}
+
+func (d *redisDeduplicator) Claim(ctx context.Context, wsID uuid.UUID, eventID string, internalID ulid.ULID) (ulid.ULID, bool, error) {
+	window := d.window(ctx, wsID)
+	if window <= 0 || eventID == "" {
+		return internalID, false, nil
+	}
+
+	existing, err := d.claim.Exec(
+		ctx,
+		d.r,
+		[]string{d.key(wsID, eventID)},
+		[]string{internalID.String(), fmt.Sprintf("%d", window.Milliseconds())},
+	).ToString()
+	if err != nil {
+		return internalID, false, fmt.Errorf("error claiming event id: %w", err)
+	}
+	if existing == "" {
+		return internalID, false, nil
+	}
+
+	id, err := ulid.Parse(existing)
+	if err != nil {
+		return internalID, false, fmt.Errorf("invalid claimed internal id: %w", err)
+	}
+	return id, true, nil
+}
+
+func (d *redisDeduplicator) Release(ctx context.Context, wsID uuid.UUID, eventID string) error {
+	if eventID == "" {
+		return nil
+	}
+	cmd := d.r.B().Del().Key(d.key(wsID, eventID)).Build()
+	if err := d.r.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("error releasing event id: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package eventdedup
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisDeduplicator(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	wsA, wsB := uuid.New(), uuid.New()
+	d := NewRedisDeduplicator(rc, "{dedup}:", func(ctx context.Context, wsID uuid.UUID) time.Duration {
+		if wsID == wsB {
+			return 0
+		}
+		return time.Hour
+	})
+
+	first, second := ulid.Make(), ulid.Make()
+
+	t.Run("first claim is not a duplicate", func(t *testing.T) {
+		id, dup, err := d.Claim(ctx, wsA, "evt-1", first)
+		require.NoError(t, err)
+		require.False(t, dup)
+		require.Equal(t, first, id)
+	})
+
+	t.Run("repeated claims return the original id", func(t *testing.T) {
+		id, dup, err := d.Claim(ctx, wsA, "evt-1", second)
+		require.NoError(t, err)
+		require.True(t, dup)
+		require.Equal(t, first, id)
+	})
+
+	t.Run("events without ids are never duplicates", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, dup, err := d.Claim(ctx, wsA, "", second)
+			require.NoError(t, err)
+			require.False(t, dup)
+		}
+	})
+
+	t.Run("workspaces without a window are not deduplicated", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, dup, err := d.Claim(ctx, wsB, "evt-1", second)
+			require.NoError(t, err)
+			require.False(t, dup)
+		}
+	})
+
+	t.Run("released claims may be claimed again", func(t *testing.T) {
+		require.NoError(t, d.Release(ctx, wsA, "evt-1"))
+		id, dup, err := d.Claim(ctx, wsA, "evt-1", second)
+		require.NoError(t, err)
+		require.False(t, dup)
+		require.Equal(t, second, id)
+	})
+
+	t.Run("claims expire after the window", func(t *testing.T) {
+		r.FastForward(time.Hour + time.Second)
+		_, dup, err := d.Claim(ctx, wsA, "evt-1", first)
+		require.NoError(t, err)
+		require.False(t, dup)
+	})
+}
+
+func TestPostgresDeduplicator(t *testing.T) {
+	uri := os.Getenv("POSTGRES_URI")
+	if uri == "" {
+		t.Skip("POSTGRES_URI not set")
+	}
+
+	ctx := context.Background()
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{PostgresURI: uri})
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("TRUNCATE TABLE event_dedup")
+	require.NoError(t, err)
+
+	ws := uuid.New()
+	d := NewPostgresDeduplicator(db, StaticWindow(time.Second))
+
+	first, second := ulid.Make(), ulid.Make()
+
+	t.Run("repeated claims return the original id", func(t *testing.T) {
+		_, dup, err := d.Claim(ctx, ws, "evt-1", first)
+		require.NoError(t, err)
+		require.False(t, dup)
+
+		id, dup, err := d.Claim(ctx, ws, "evt-1", second)
+		require.NoError(t, err)
+		require.True(t, dup)
+		require.Equal(t, first, id)
+	})
+
+	t.Run("expired claims are deleted", func(t *testing.T) {
+		<-time.After(1100 * time.Millisecond)
+
+		// Force the next claim to sweep.
+		d.(*postgresDeduplicator).lastSweep.Store(0)
+		_, dup, err := d.Claim(ctx, ws, "evt-2", second)
+		require.NoError(t, err)
+		require.False(t, dup)
+
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM event_dedup WHERE event_id = 'evt-1'`).Scan(&count))
+		require.Equal(t, 0, count)
+	})
+}
@@ -0,0 +1,109 @@
+package eventdedup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+)
+
+// pgNowMillis is the database's current time in milliseconds.
+const pgNowMillis = `(EXTRACT(EPOCH FROM clock_timestamp()) * 1000)::BIGINT`
+
+// sweepInterval is how often expired claims are deleted from Postgres.
+const sweepInterval = time.Minute
+
+// NewPostgresDeduplicator returns a Deduplicator which stores claims in
+// Postgres, expiring each claim after the workspace's window.  Expired claims
+// are deleted while claiming, at most once per sweepInterval.
+func NewPostgresDeduplicator(db *sql.DB, window WindowFunc) Deduplicator {
+	if window == nil {
+		window = StaticWindow(DefaultWindow)
+	}
+	return &postgresDeduplicator{
+		db:     db,
+		window: window,
+	}
+}
+
+type postgresDeduplicator struct {
+	db     *sql.DB
+	window WindowFunc
+
+	// lastSweep is the time expired claims were last deleted, in milliseconds.
+	lastSweep atomic.Int64
+}
+
+// sweep deletes expired claims if they haven't been deleted within the sweep
+// interval.
+func (d *postgresDeduplicator) sweep(ctx context.Context) {
+	now := time.Now()
+	last := d.lastSweep.Load()
+	if now.Sub(time.UnixMilli(last)) < sweepInterval || !d.lastSweep.CompareAndSwap(last, now.UnixMilli()) {
+		return
+	}
+	if _, err := d.db.ExecContext(ctx, `DELETE FROM event_dedup WHERE expires_at <= `+pgNowMillis); err != nil {
+		logger.StdlibLogger(ctx).Warn("error deleting expired event ids", "error", err)
+	}
+}
+
+func (d *postgresDeduplicator) Claim(ctx context.Context, wsID uuid.UUID, eventID string, internalID ulid.ULID) (ulid.ULID, bool, error) {
+	window := d.window(ctx, wsID)
+	if window <= 0 || eventID == "" {
+		return internalID, false, nil
+	}
+
+	d.sweep(ctx)
+
+	// Claim the event ID, replacing any expired claim.  No rows are returned
+	// if the event ID is already claimed within the window.
+	var claimed string
+	err := d.db.QueryRowContext(ctx, `
+		INSERT INTO event_dedup (workspace_id, event_id, internal_id, expires_at) VALUES ($1, $2, $3, `+pgNowMillis+` + $4)
+		ON CONFLICT (workspace_id, event_id) DO UPDATE SET internal_id = EXCLUDED.internal_id, expires_at = EXCLUDED.expires_at
+		WHERE event_dedup.expires_at <= `+pgNowMillis+`
+		RETURNING internal_id`,
+		wsID, eventID, internalID.String(), window.Milliseconds(),
+	).Scan(&claimed)
+	if err == nil {
+		return internalID, false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return internalID, false, fmt.Errorf("error claiming event id: %w", err)
+	}
+
+	var existing string
+	err = d.db.QueryRowContext(ctx,
+		`SELECT internal_id FROM event_dedup WHERE workspace_id = $1 AND event_id = $2`,
+		wsID, eventID,
+	).Scan(&existing)
+	if err != nil {
+		return internalID, false, fmt.Errorf("error loading claimed event id: %w", err)
+	}
+
+	id, err := ulid.Parse(existing)
+	if err != nil {
+		return internalID, false, fmt.Errorf("invalid claimed internal id: %w", err)
+	}
+	return id, true, nil
+}
+
+func (d *postgresDeduplicator) Release(ctx context.Context, wsID uuid.UUID, eventID string) error {
+	if eventID == "" {
+		return nil
+	}
+	_, err := d.db.ExecContext(ctx,
+		`DELETE FROM event_dedup WHERE workspace_id = $1 AND event_id = $2`,
+		wsID, eventID,
+	)
+	if err != nil {
+		return fmt.Errorf("error releasing event id: %w", err)
+	}
+	return nil
+}
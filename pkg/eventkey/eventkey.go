@@ -0,0 +1,305 @@
+// Package eventkey creates and authorizes event keys.  Event keys are either
+// static keys passed via config, which may send any event, or keys stored in
+// the cqrs store, which are scoped to a list of event name globs and an
+// optional ingest rate limit.  Rate limits count requests, not events.
+package eventkey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/execution/ratelimit"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/xhit/go-str2duration/v2"
+)
+
+const (
+	// prefixLength is the number of characters of a key's secret stored in
+	// plain text, used to identify keys.
+	prefixLength = 8
+
+	// lastUsedInterval is the minimum interval between writes of a key's
+	// last used timestamp, ensuring that we don't write on every request.
+	lastUsedInterval = time.Minute
+	// configuredInterval is how long the result of Configured is cached, ensuring
+	// that requests with unknown keys don't load every key.
+	configuredInterval = 10 * time.Second
+)
+
+var (
+	ErrNotFound        = fmt.Errorf("event key not found")
+	ErrRevoked         = fmt.Errorf("event key has been revoked")
+	ErrExpired         = fmt.Errorf("event key has expired")
+	ErrEventNotAllowed = fmt.Errorf("event key is not allowed to send this event")
+	ErrRateLimited     = fmt.Errorf("event key rate limit exceeded")
+)
+
+// Hash returns the hash of an event key's secret, used to store and look up
+// keys without storing secrets.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateOpts configures a new event key.
+type CreateOpts struct {
+	Name          string
+	AllowedEvents []string
+	RateLimit     *cqrs.EventKeyRateLimit
+	ExpiresAt     *time.Time
+}
+
+func (c CreateOpts) Validate() error {
+	var err error
+	if c.Name == "" {
+		err = errors.Join(err, fmt.Errorf("event key name is required"))
+	}
+	for _, g := range c.AllowedEvents {
+		if strings.TrimSpace(g) == "" {
+			err = errors.Join(err, fmt.Errorf("allowed event globs must not be empty"))
+		}
+	}
+	if c.RateLimit != nil {
+		if c.RateLimit.Limit < 1 {
+			err = errors.Join(err, fmt.Errorf("rate limit must be positive"))
+		}
+		if _, perr := str2duration.ParseDuration(c.RateLimit.Period); perr != nil {
+			err = errors.Join(err, fmt.Errorf("invalid rate limit period: %w", perr))
+		}
+	}
+	if c.ExpiresAt != nil && !c.ExpiresAt.After(time.Now()) {
+		err = errors.Join(err, fmt.Errorf("expiry must be in the future"))
+	}
+	return err
+}
+
+// Create generates and stores a new event key, returning the stored key and
+// its secret.  The secret is only available at creation.
+func Create(ctx context.Context, w cqrs.EventKeyWriter, opts CreateOpts) (*cqrs.EventKey, string, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	byt := make([]byte, 32)
+	if _, err := rand.Read(byt); err != nil {
+		return nil, "", fmt.Errorf("error generating event key: %w", err)
+	}
+	secret := hex.EncodeToString(byt)
+
+	key := cqrs.EventKey{
+		ID:            uuid.New(),
+		Name:          opts.Name,
+		Hash:          Hash(secret),
+		Prefix:        secret[:prefixLength],
+		AllowedEvents: opts.AllowedEvents,
+		RateLimit:     opts.RateLimit,
+		ExpiresAt:     opts.ExpiresAt,
+		CreatedAt:     time.Now().Truncate(time.Millisecond),
+	}
+	if err := w.InsertEventKey(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("error storing event key: %w", err)
+	}
+	return &key, secret, nil
+}
+
+// Authorizer authorizes event keys used to send events.
+type Authorizer interface {
+	// Authorize returns the scope of the given key, or ErrNotFound, ErrRevoked,
+	// or ErrExpired if the key may not be used.
+	Authorize(ctx context.Context, key string) (*Scope, error)
+	// Configured returns whether any usable event keys exist.  Once a key is
+	// configured, unknown keys are rejected:  creating a single scoped key,
+	// even in the dev server, stops arbitrary keys from sending events.
+	Configured(ctx context.Context) (bool, error)
+}
+
+// NewAuthorizer returns an Authorizer which allows the given static keys to
+// send any event, and loads scoped keys from the given store.  The store and
+// rate limiter may be nil.
+//
+// Whether keys are configured is cached for up to 10 seconds, so creating the
+// first key or revoking the last key may take that long to apply to unknown
+// keys.
+func NewAuthorizer(static []string, store cqrs.EventKeyReadWriter, rl ratelimit.RateLimiter) Authorizer {
+	return &authorizer{
+		static: static,
+		store:  store,
+		rl:     rl,
+	}
+}
+
+type authorizer struct {
+	static []string
+	store  cqrs.EventKeyReadWriter
+	rl     ratelimit.RateLimiter
+
+	// lastUsed stores the last time each key's last used timestamp was
+	// written, by key ID.
+	lastUsed sync.Map
+
+	// lock guards the cached result of Configured.
+	lock         sync.Mutex
+	configured   bool
+	configuredAt time.Time
+}
+
+func (a *authorizer) Authorize(ctx context.Context, key string) (*Scope, error) {
+	for _, k := range a.static {
+		if k == key {
+			return &Scope{}, nil
+		}
+	}
+	if a.store == nil {
+		return nil, ErrNotFound
+	}
+
+	ek, err := a.store.EventKeyByHash(ctx, Hash(key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading event key: %w", err)
+	}
+
+	now := time.Now()
+	if ek.RevokedAt != nil {
+		return nil, ErrRevoked
+	}
+	if ek.ExpiresAt != nil && !ek.ExpiresAt.After(now) {
+		return nil, ErrExpired
+	}
+
+	scope, err := newScope(ek, a.rl)
+	if err != nil {
+		return nil, err
+	}
+
+	if last, ok := a.lastUsed.Load(ek.ID); !ok || now.Sub(last.(time.Time)) >= lastUsedInterval {
+		a.lastUsed.Store(ek.ID, now)
+		if err := a.store.UpdateEventKeyLastUsed(ctx, ek.ID, now); err != nil {
+			return nil, fmt.Errorf("error updating event key: %w", err)
+		}
+	}
+
+	return scope, nil
+}
+
+func (a *authorizer) Configured(ctx context.Context) (bool, error) {
+	if len(a.static) > 0 {
+		return true, nil
+	}
+	if a.store == nil {
+		return false, nil
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := time.Now()
+	if !a.configuredAt.IsZero() && now.Sub(a.configuredAt) < configuredInterval {
+		return a.configured, nil
+	}
+
+	keys, err := a.store.EventKeys(ctx)
+	if err != nil {
+		return false, err
+	}
+	configured := false
+	for _, k := range keys {
+		if k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now)) {
+			configured = true
+			break
+		}
+	}
+
+	a.configured, a.configuredAt = configured, now
+	return configured, nil
+}
+
+// Scope restricts the events which may be sent using a key.  The zero value
+// allows any event to be sent.
+type Scope struct {
+	key     *cqrs.EventKey
+	globs   []*regexp.Regexp
+	limiter ratelimit.RateLimiter
+}
+
+func newScope(key *cqrs.EventKey, rl ratelimit.RateLimiter) (*Scope, error) {
+	s := &Scope{key: key, limiter: rl}
+	for _, g := range key.AllowedEvents {
+		re, err := CompileGlob(g)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed event glob %q: %w", g, err)
+		}
+		s.globs = append(s.globs, re)
+	}
+	return s, nil
+}
+
+// Key returns the stored event key, or nil for static keys.
+func (s Scope) Key() *cqrs.EventKey {
+	return s.key
+}
+
+// Allow returns ErrEventNotAllowed if the given event name doesn't match the
+// key's allowed events.
+func (s Scope) Allow(ctx context.Context, name string) error {
+	if !s.Allows(name) {
+		return fmt.Errorf("%w: %s", ErrEventNotAllowed, name)
+	}
+	return nil
+}
+
+// RateLimit returns ErrRateLimited if the key's rate limit has been exceeded.
+// Each call counts as a single request towards the key's rate limit, regardless
+// of how many events the request contains.
+func (s Scope) RateLimit(ctx context.Context) error {
+	if s.key == nil || s.key.RateLimit == nil || s.limiter == nil {
+		return nil
+	}
+	limited, _, err := s.limiter.RateLimit(ctx, "eventkey-"+s.key.ID.String(), inngest.RateLimit{
+		Limit:  uint(s.key.RateLimit.Limit),
+		Period: s.key.RateLimit.Period,
+	})
+	if err != nil {
+		return fmt.Errorf("error checking event key rate limit: %w", err)
+	}
+	if limited {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// Allows returns whether the given event name matches the key's allowed events.
+func (s Scope) Allows(name string) bool {
+	if len(s.globs) == 0 {
+		return true
+	}
+	for _, re := range s.globs {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompileGlob compiles an event name glob, where "*" matches any sequence of
+// characters, including "/".
+func CompileGlob(glob string) (*regexp.Regexp, error) {
+	parts := strings.Split(glob, "*")
+	for i, p := range parts {
+		parts[i] = regexp.QuoteMeta(p)
+	}
+	return regexp.Compile("^" + strings.Join(parts, ".*") + "$")
+}
@@ -0,0 +1,163 @@
+package eventkey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/stretchr/testify/require"
+)
+
+type countingLimiter struct {
+	counts map[string]uint
+}
+
+func (c *countingLimiter) RateLimit(ctx context.Context, key string, rl inngest.RateLimit) (bool, time.Duration, error) {
+	c.counts[key]++
+	if c.counts[key] > rl.Limit {
+		return true, time.Second, nil
+	}
+	return false, -1, nil
+}
+
+func newStore(t *testing.T) cqrs.Manager {
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	return base_cqrs.NewCQRS(db, "sqlite")
+}
+
+func TestCreateValidation(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	_, _, err := Create(ctx, store, CreateOpts{})
+	require.ErrorContains(t, err, "name is required")
+
+	_, _, err = Create(ctx, store, CreateOpts{
+		Name:      "bad",
+		RateLimit: &cqrs.EventKeyRateLimit{Limit: 10, Period: "soon"},
+	})
+	require.ErrorContains(t, err, "invalid rate limit period")
+
+	past := time.Now().Add(-time.Minute)
+	_, _, err = Create(ctx, store, CreateOpts{Name: "expired", ExpiresAt: &past})
+	require.ErrorContains(t, err, "expiry must be in the future")
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t)
+	rl := &countingLimiter{counts: map[string]uint{}}
+	a := NewAuthorizer([]string{"static"}, store, rl)
+
+	key, secret, err := Create(ctx, store, CreateOpts{
+		Name:          "billing",
+		AllowedEvents: []string{"billing/*", "user/created"},
+		RateLimit:     &cqrs.EventKeyRateLimit{Limit: 2, Period: "1m"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, secret[:prefixLength], key.Prefix)
+	require.Equal(t, Hash(secret), key.Hash)
+
+	t.Run("static keys may send any event", func(t *testing.T) {
+		scope, err := a.Authorize(ctx, "static")
+		require.NoError(t, err)
+		require.Nil(t, scope.Key())
+		require.NoError(t, scope.Allow(ctx, "anything/at.all"))
+	})
+
+	t.Run("unknown keys are not found", func(t *testing.T) {
+		_, err := a.Authorize(ctx, "nope")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("scoped keys match globs and rate limits", func(t *testing.T) {
+		scope, err := a.Authorize(ctx, secret)
+		require.NoError(t, err)
+		require.Equal(t, key.ID, scope.Key().ID)
+
+		require.True(t, scope.Allows("billing/invoice.paid"))
+		require.True(t, scope.Allows("user/created"))
+		require.False(t, scope.Allows("user/deleted"))
+		require.False(t, scope.Allows("xbilling/invoice.paid"))
+
+		require.ErrorIs(t, scope.Allow(ctx, "user/deleted"), ErrEventNotAllowed)
+		require.NoError(t, scope.Allow(ctx, "billing/a"))
+		require.NoError(t, scope.Allow(ctx, "billing/b"))
+		require.NoError(t, scope.Allow(ctx, "billing/c"), "events don't count towards rate limits")
+
+		require.NoError(t, scope.RateLimit(ctx))
+		require.NoError(t, scope.RateLimit(ctx))
+		require.ErrorIs(t, scope.RateLimit(ctx), ErrRateLimited)
+
+		loaded, err := store.EventKey(ctx, key.ID)
+		require.NoError(t, err)
+		require.NotNil(t, loaded.LastUsedAt)
+		require.Equal(t, []string{"billing/*", "user/created"}, loaded.AllowedEvents)
+		require.Equal(t, key.RateLimit, loaded.RateLimit)
+	})
+
+	t.Run("revoked keys are rejected", func(t *testing.T) {
+		require.NoError(t, store.RevokeEventKey(ctx, key.ID, time.Now()))
+		_, err := a.Authorize(ctx, secret)
+		require.ErrorIs(t, err, ErrRevoked)
+	})
+
+	t.Run("expired keys are rejected", func(t *testing.T) {
+		exp := time.Now().Add(50 * time.Millisecond)
+		_, secret, err := Create(ctx, store, CreateOpts{Name: "short", ExpiresAt: &exp})
+		require.NoError(t, err)
+
+		_, err = a.Authorize(ctx, secret)
+		require.NoError(t, err)
+
+		<-time.After(100 * time.Millisecond)
+		_, err = a.Authorize(ctx, secret)
+		require.ErrorIs(t, err, ErrExpired)
+	})
+}
+
+func TestConfigured(t *testing.T) {
+	ctx := context.Background()
+	store := newStore(t)
+
+	ok, err := NewAuthorizer(nil, store, nil).Configured(ctx)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = NewAuthorizer([]string{"static"}, store, nil).Configured(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	key, _, err := Create(ctx, store, CreateOpts{Name: "scoped"})
+	require.NoError(t, err)
+	ok, err = NewAuthorizer(nil, store, nil).Configured(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, store.RevokeEventKey(ctx, key.ID, time.Now()))
+	ok, err = NewAuthorizer(nil, store, nil).Configured(ctx)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	t.Run("caches whether keys are configured", func(t *testing.T) {
+		a := NewAuthorizer(nil, store, nil)
+		ok, err := a.Configured(ctx)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		_, _, err = Create(ctx, store, CreateOpts{Name: "cached"})
+		require.NoError(t, err)
+		ok, err = a.Configured(ctx)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		a.(*authorizer).configuredAt = time.Now().Add(-configuredInterval)
+		ok, err = a.Configured(ctx)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+}
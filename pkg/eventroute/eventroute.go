@@ -0,0 +1,174 @@
+// Package eventroute publishes delivered events to realtime channels, using
+// routing rules which match events by name and expression.  This allows
+// subscribers such as browsers to react to events without a function
+// publishing them.
+package eventroute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventkey"
+	"github.com/inngest/inngest/pkg/execution/realtime"
+	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/inngest/inngest/pkg/expressions"
+	"github.com/inngest/inngest/pkg/logger"
+)
+
+// Route maps matching events to a realtime channel and topic.
+type Route struct {
+	// Event is the event name glob to match, where "*" matches any sequence
+	// of characters, eg. "order/*".
+	Event string `json:"event"`
+	// If is an optional boolean expression which events must match, eg.
+	// "event.data.total > 100".
+	If string `json:"if,omitempty"`
+	// Channel is the channel to publish matching events to.  Exactly one of
+	// Channel and ChannelExpression must be set.
+	Channel string `json:"channel,omitempty"`
+	// ChannelExpression is an expression evaluated against the event to
+	// determine the channel, eg. "'user:' + event.data.user_id".
+	ChannelExpression string `json:"channelExpression,omitempty"`
+	// Topic is the topic to publish matching events to.  This defaults to
+	// the event name.
+	Topic string `json:"topic,omitempty"`
+}
+
+// Validate returns an error if the route is invalid.
+func (r Route) Validate(ctx context.Context) error {
+	var err error
+	if r.Event == "" {
+		err = errors.Join(err, fmt.Errorf("event is required"))
+	}
+	if (r.Channel == "") == (r.ChannelExpression == "") {
+		err = errors.Join(err, fmt.Errorf("exactly one of channel or channelExpression is required"))
+	}
+	if r.If != "" {
+		if verr := expressions.Validate(ctx, nil, r.If); verr != nil {
+			err = errors.Join(err, fmt.Errorf("invalid if expression: %w", verr))
+		}
+	}
+	if r.ChannelExpression != "" {
+		if verr := expressions.Validate(ctx, nil, r.ChannelExpression); verr != nil {
+			err = errors.Join(err, fmt.Errorf("invalid channel expression: %w", verr))
+		}
+	}
+	return err
+}
+
+// Router publishes events matching its routes to realtime subscribers.
+type Router struct {
+	routes []route
+}
+
+type route struct {
+	Route
+	glob *regexp.Regexp
+}
+
+// New validates the given routes, returning a Router which publishes events
+// matching any route.
+func New(ctx context.Context, routes []Route) (*Router, error) {
+	var errs error
+	r := &Router{routes: make([]route, 0, len(routes))}
+	for n, rt := range routes {
+		if err := rt.Validate(ctx); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid route %d: %w", n, err))
+			continue
+		}
+		glob, err := eventkey.CompileGlob(rt.Event)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid route %d: invalid event glob: %w", n, err))
+			continue
+		}
+		r.routes = append(r.routes, route{Route: rt, glob: glob})
+	}
+	return r, errs
+}
+
+// LoadFile loads routes from a JSON file containing a list of routes, eg.
+// [{"event": "order/*", "channelExpression": "'user:' + event.data.user_id"}].
+func LoadFile(ctx context.Context, path string) (*Router, error) {
+	byt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading realtime routes file: %w", err)
+	}
+	routes := []Route{}
+	if err := json.Unmarshal(byt, &routes); err != nil {
+		return nil, fmt.Errorf("error parsing realtime routes file: %w", err)
+	}
+	return New(ctx, routes)
+}
+
+// Messages returns the realtime messages for each route matching the event.
+// Routes whose expressions fail to evaluate are skipped.
+func (r *Router) Messages(ctx context.Context, envID uuid.UUID, evt event.Event) []realtime.Message {
+	if r == nil {
+		return nil
+	}
+
+	var (
+		data = map[string]any{"event": evt.Map()}
+		msgs = []realtime.Message{}
+		l    = logger.StdlibLogger(ctx)
+	)
+	for _, rt := range r.routes {
+		if !rt.glob.MatchString(evt.Name) {
+			continue
+		}
+
+		if rt.If != "" {
+			ok, _, err := expressions.EvaluateBoolean(ctx, rt.If, data)
+			if err != nil {
+				l.Warn("error evaluating realtime route", "error", err, "event", evt.Name, "route", rt.Event)
+				continue
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		channel := rt.Channel
+		if rt.ChannelExpression != "" {
+			val, _, err := expressions.Evaluate(ctx, rt.ChannelExpression, data)
+			if err != nil {
+				l.Warn("error evaluating realtime route channel", "error", err, "event", evt.Name, "route", rt.Event)
+				continue
+			}
+			str, ok := val.(string)
+			if !ok || str == "" {
+				l.Warn("realtime route channel must be a string", "event", evt.Name, "route", rt.Event, "channel", val)
+				continue
+			}
+			channel = str
+		}
+
+		topic := rt.Topic
+		if topic == "" {
+			topic = evt.Name
+		}
+
+		msg := streamingtypes.NewMessage(streamingtypes.MessageKindEvent, evt)
+		msg.EnvID = envID
+		msg.Channel = channel
+		msg.Topic = topic
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// Publish publishes the event to the channel and topic of each matching route.
+func (r *Router) Publish(ctx context.Context, p realtime.Publisher, envID uuid.UUID, evt event.Event) {
+	if r == nil || p == nil {
+		return
+	}
+	for _, msg := range r.Messages(ctx, envID, evt) {
+		p.Publish(ctx, msg)
+	}
+}
@@ -0,0 +1,134 @@
+package eventroute
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution/realtime"
+	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouterMessages(t *testing.T) {
+	ctx := context.Background()
+	envID := uuid.New()
+
+	r, err := New(ctx, []Route{
+		{
+			Event:             "order/*",
+			ChannelExpression: "'user:' + event.data.user_id",
+		},
+		{
+			Event:   "order/created",
+			If:      "event.data.total > 100",
+			Channel: "large-orders",
+			Topic:   "created",
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("matches globs and expressions", func(t *testing.T) {
+		evt := event.Event{
+			Name: "order/created",
+			Data: map[string]any{"user_id": "u_1", "total": 120},
+		}
+		msgs := r.Messages(ctx, envID, evt)
+		require.Len(t, msgs, 2)
+
+		require.Equal(t, streamingtypes.MessageKindEvent, msgs[0].Kind)
+		require.Equal(t, envID, msgs[0].EnvID)
+		require.Equal(t, "user:u_1", msgs[0].Channel)
+		require.Equal(t, "order/created", msgs[0].Topic)
+
+		require.Equal(t, "large-orders", msgs[1].Channel)
+		require.Equal(t, "created", msgs[1].Topic)
+
+		published := event.Event{}
+		require.NoError(t, json.Unmarshal(msgs[0].Data, &published))
+		require.Equal(t, evt.Name, published.Name)
+		require.Equal(t, "u_1", published.Data["user_id"])
+	})
+
+	t.Run("skips unmatched expressions", func(t *testing.T) {
+		msgs := r.Messages(ctx, envID, event.Event{
+			Name: "order/created",
+			Data: map[string]any{"user_id": "u_1", "total": 10},
+		})
+		require.Len(t, msgs, 1)
+		require.Equal(t, "user:u_1", msgs[0].Channel)
+	})
+
+	t.Run("skips channels which aren't strings", func(t *testing.T) {
+		r, err := New(ctx, []Route{{Event: "order/*", ChannelExpression: "event.data.total"}})
+		require.NoError(t, err)
+		msgs := r.Messages(ctx, envID, event.Event{
+			Name: "order/cancelled",
+			Data: map[string]any{"total": 10},
+		})
+		require.Empty(t, msgs)
+	})
+
+	t.Run("skips unmatched events", func(t *testing.T) {
+		msgs := r.Messages(ctx, envID, event.Event{
+			Name: "user/created",
+			Data: map[string]any{"user_id": "u_1"},
+		})
+		require.Empty(t, msgs)
+	})
+
+	t.Run("publishes", func(t *testing.T) {
+		b := realtime.NewInProcessBroadcaster()
+		received := []realtime.Message{}
+		sub := realtime.NewInmemorySubscription(uuid.New(), func(m realtime.Message) error {
+			received = append(received, m)
+			return nil
+		})
+		err := b.Subscribe(ctx, sub, []realtime.Topic{{
+			Kind:    streamingtypes.TopicKindRun,
+			EnvID:   envID,
+			Channel: "user:u_1",
+			Name:    "order/created",
+		}})
+		require.NoError(t, err)
+
+		r.Publish(ctx, b, envID, event.Event{
+			Name: "order/created",
+			Data: map[string]any{"user_id": "u_1", "total": 10},
+		})
+		require.Len(t, received, 1)
+		require.Equal(t, streamingtypes.MessageKindEvent, received[0].Kind)
+	})
+}
+
+func TestNewInvalidRoutes(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := New(ctx, []Route{
+		{Channel: "a"},
+		{Event: "a"},
+		{Event: "a", Channel: "a", ChannelExpression: "'a'"},
+		{Event: "a", Channel: "a", If: "event.data.total >"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid route 0: event is required")
+	require.Contains(t, err.Error(), "invalid route 1: exactly one of channel or channelExpression is required")
+	require.Contains(t, err.Error(), "invalid route 2: exactly one of channel or channelExpression is required")
+	require.Contains(t, err.Error(), "invalid route 3: invalid if expression")
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "routes.json")
+	err := os.WriteFile(path, []byte(`[{"event": "order/*", "channel": "orders"}]`), 0o600)
+	require.NoError(t, err)
+
+	r, err := LoadFile(context.Background(), path)
+	require.NoError(t, err)
+	msgs := r.Messages(context.Background(), uuid.New(), event.Event{Name: "order/created"})
+	require.Len(t, msgs, 1)
+	require.Equal(t, "orders", msgs[0].Channel)
+}
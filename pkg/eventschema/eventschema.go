@@ -0,0 +1,273 @@
+// Package eventschema validates incoming events against JSON Schemas registered
+// per event name, either by apps when syncing or from config files.
+package eventschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Mode determines how events which fail validation are handled at ingest.
+type Mode string
+
+const (
+	// ModeReject rejects events which fail validation.  Rejected events are
+	// not stored and never trigger functions.  Other events sent in the same
+	// request are still published.
+	ModeReject Mode = "reject"
+	// ModeQuarantine stores events which fail validation for inspection,
+	// without triggering functions.
+	ModeQuarantine Mode = "quarantine"
+)
+
+// ParseMode parses a validation mode, defaulting to ModeReject if empty.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(strings.ToLower(s)) {
+	case "", ModeReject:
+		return ModeReject, nil
+	case ModeQuarantine:
+		return ModeQuarantine, nil
+	default:
+		return "", fmt.Errorf("invalid event schema mode: %s", s)
+	}
+}
+
+// ValidationError is returned when an event's data doesn't conform to the
+// schemas registered for the event's name.
+type ValidationError struct {
+	EventName string
+	Errors    []string
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("event %s failed schema validation: %s", v.EventName, strings.Join(v.Errors, "; "))
+}
+
+// Compile compiles a JSON Schema, returning an error if the schema is invalid.
+// Only local references (eg. "#/definitions/foo") are allowed, ensuring that
+// schemas never load remote or file-based references.
+func Compile(schema json.RawMessage) (*gojsonschema.Schema, error) {
+	var doc any
+	if err := json.Unmarshal(schema, &doc); err != nil {
+		return nil, fmt.Errorf("schema is not valid JSON: %w", err)
+	}
+	if _, ok := doc.(map[string]any); !ok {
+		return nil, fmt.Errorf("schema must be a JSON object")
+	}
+	if err := checkRefs(doc); err != nil {
+		return nil, err
+	}
+	return gojsonschema.NewSchema(gojsonschema.NewGoLoader(doc))
+}
+
+func checkRefs(v any) error {
+	switch typ := v.(type) {
+	case map[string]any:
+		for k, val := range typ {
+			if ref, ok := val.(string); ok && k == "$ref" && !strings.HasPrefix(ref, "#") {
+				return fmt.Errorf("schema references must be local: %s", ref)
+			}
+			if err := checkRefs(val); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, val := range typ {
+			if err := checkRefs(val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Validator validates events against the schemas registered for their name.
+type Validator interface {
+	// Validate returns a ValidationError if the event's data doesn't conform
+	// to every schema registered for the event's name.  Events without a
+	// registered schema are always valid.
+	Validate(ctx context.Context, evt event.Event) error
+}
+
+// DefaultRefreshInterval is how often a validator reloads schemas from its reader.
+const DefaultRefreshInterval = 5 * time.Second
+
+// NewValidator returns a Validator which loads schemas from the given reader.
+// Schemas are cached in memory and reloaded every DefaultRefreshInterval, so
+// newly synced schemas apply to events received after the next refresh.
+func NewValidator(r cqrs.EventSchemaReader) Validator {
+	return &validator{r: r, refresh: DefaultRefreshInterval}
+}
+
+type validator struct {
+	r       cqrs.EventSchemaReader
+	refresh time.Duration
+
+	// lock guards the cached schemas below.
+	lock sync.RWMutex
+	// schemas holds compiled schemas by event name.
+	schemas  map[string][]*gojsonschema.Schema
+	loadedAt time.Time
+}
+
+func (v *validator) Validate(ctx context.Context, evt event.Event) error {
+	schemas, err := v.load(ctx, evt.Name)
+	if err != nil {
+		return err
+	}
+	if len(schemas) == 0 {
+		return nil
+	}
+
+	data := evt.Data
+	if data == nil {
+		data = map[string]any{}
+	}
+	doc := gojsonschema.NewGoLoader(data)
+
+	verr := ValidationError{EventName: evt.Name}
+	for _, compiled := range schemas {
+		res, err := compiled.Validate(doc)
+		if err != nil {
+			return fmt.Errorf("error validating event: %w", err)
+		}
+		for _, re := range res.Errors() {
+			verr.Errors = append(verr.Errors, re.String())
+		}
+	}
+
+	if len(verr.Errors) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// load returns the compiled schemas for the given event name, reloading every
+// schema from the reader if the cache is older than the refresh interval.
+// Stored schemas which no longer compile are logged and skipped so that a
+// single bad schema doesn't fail every ingested event.
+func (v *validator) load(ctx context.Context, name string) ([]*gojsonschema.Schema, error) {
+	v.lock.RLock()
+	if v.schemas != nil && time.Since(v.loadedAt) < v.refresh {
+		defer v.lock.RUnlock()
+		return v.schemas[name], nil
+	}
+	v.lock.RUnlock()
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	// Another caller may have reloaded the cache while we waited for the lock.
+	if v.schemas != nil && time.Since(v.loadedAt) < v.refresh {
+		return v.schemas[name], nil
+	}
+
+	all, err := v.r.EventSchemas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading event schemas: %w", err)
+	}
+
+	schemas := map[string][]*gojsonschema.Schema{}
+	for _, s := range all {
+		compiled, err := Compile(s.Schema)
+		if err != nil {
+			logger.StdlibLogger(ctx).Error("error compiling event schema",
+				"error", err,
+				"event_name", s.EventName,
+				"source", s.Source,
+			)
+			continue
+		}
+		schemas[s.EventName] = append(schemas[s.EventName], compiled)
+	}
+
+	v.schemas = schemas
+	v.loadedAt = time.Now()
+	return schemas[name], nil
+}
+
+// Parse compiles each schema in the given map of event names to JSON Schemas,
+// returning schemas ready to be stored.
+func Parse(schemas map[string]json.RawMessage, source string) ([]cqrs.EventSchema, error) {
+	var errs error
+	out := make([]cqrs.EventSchema, 0, len(schemas))
+	for name, schema := range schemas {
+		if name == "" {
+			errs = errors.Join(errs, fmt.Errorf("event schema names must not be empty"))
+			continue
+		}
+		if _, err := Compile(schema); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid schema for event %s: %w", name, err))
+			continue
+		}
+		out = append(out, cqrs.EventSchema{
+			EventName: name,
+			Source:    source,
+			Schema:    schema,
+		})
+	}
+	return out, errs
+}
+
+// LoadFile loads event schemas from a JSON file which maps event names to JSON
+// Schemas, eg. {"user/signed.up": {"type": "object", ...}}.
+func LoadFile(path string) ([]cqrs.EventSchema, error) {
+	byt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading event schema file: %w", err)
+	}
+	schemas := map[string]json.RawMessage{}
+	if err := json.Unmarshal(byt, &schemas); err != nil {
+		return nil, fmt.Errorf("error parsing event schema file: %w", err)
+	}
+	return Parse(schemas, cqrs.EventSchemaSourceConfig)
+}
+
+// LoadConfig replaces all config-sourced schemas with those in the given file.
+// If path is empty, any previously loaded config schemas are removed.
+func LoadConfig(ctx context.Context, w cqrs.EventSchemaWriter, path string) error {
+	var schemas []cqrs.EventSchema
+	if path != "" {
+		var err error
+		if schemas, err = LoadFile(path); err != nil {
+			return err
+		}
+	}
+	return w.ReplaceEventSchemas(ctx, uuid.Nil, schemas)
+}
+
+// Quarantine stores an event which failed validation, returning the ID of the
+// quarantined event.
+func Quarantine(ctx context.Context, w cqrs.EventSchemaWriter, evt event.Event, verr error) (string, error) {
+	byt, err := json.Marshal(evt)
+	if err != nil {
+		return "", fmt.Errorf("error marshalling quarantined event: %w", err)
+	}
+
+	now := time.Now()
+	id := ulid.MustNew(ulid.Timestamp(now), ulid.DefaultEntropy())
+	err = w.InsertQuarantinedEvent(ctx, cqrs.QuarantinedEvent{
+		ID:         id,
+		EventName:  evt.Name,
+		Event:      byt,
+		Error:      verr.Error(),
+		ReceivedAt: now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error quarantining event: %w", err)
+	}
+	return id.String(), nil
+}
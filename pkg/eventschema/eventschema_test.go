@@ -0,0 +1,119 @@
+package eventschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReader struct {
+	cqrs.EventSchemaReader
+	schemas []cqrs.EventSchema
+	loads   int
+}
+
+func (f *fakeReader) EventSchemas(ctx context.Context) ([]cqrs.EventSchema, error) {
+	f.loads++
+	return f.schemas, nil
+}
+
+func TestCompile(t *testing.T) {
+	_, err := Compile(json.RawMessage(`{"type":"object","properties":{"id":{"$ref":"#/definitions/id"}},"definitions":{"id":{"type":"string"}}}`))
+	require.NoError(t, err)
+
+	_, err = Compile(json.RawMessage(`{"properties":{"id":{"$ref":"https://example.com/id.json"}}}`))
+	require.ErrorContains(t, err, "must be local")
+
+	_, err = Compile(json.RawMessage(`{"allOf":[{"$ref":"file:///etc/passwd"}]}`))
+	require.ErrorContains(t, err, "must be local")
+
+	_, err = Compile(json.RawMessage(`[]`))
+	require.Error(t, err)
+
+	_, err = Compile(json.RawMessage(`{`))
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	ctx := context.Background()
+	r := &fakeReader{schemas: []cqrs.EventSchema{
+		{EventName: "user/created", Schema: json.RawMessage(`{"type":"object","required":["id"],"properties":{"id":{"type":"string"}}}`)},
+		{EventName: "user/created", Schema: json.RawMessage(`{"type":"object","properties":{"age":{"type":"integer"}}}`)},
+	}}
+	v := NewValidator(r)
+
+	require.NoError(t, v.Validate(ctx, event.Event{Name: "other/event", Data: map[string]any{"id": 1}}))
+	require.NoError(t, v.Validate(ctx, event.Event{Name: "user/created", Data: map[string]any{"id": "u_1", "age": 30}}))
+
+	err := v.Validate(ctx, event.Event{Name: "user/created", Data: map[string]any{"age": "old"}})
+	verr := ValidationError{}
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, "user/created", verr.EventName)
+	require.Len(t, verr.Errors, 2, "errors from every schema should be reported")
+
+	err = v.Validate(ctx, event.Event{Name: "user/created"})
+	require.ErrorAs(t, err, &verr)
+
+	require.Equal(t, 1, r.loads, "schemas should be loaded once and cached")
+
+	t.Run("reloads schemas after the refresh interval", func(t *testing.T) {
+		v.(*validator).loadedAt = time.Now().Add(-DefaultRefreshInterval)
+		r.schemas = nil
+		require.NoError(t, v.Validate(ctx, event.Event{Name: "user/created"}))
+		require.Equal(t, 2, r.loads)
+	})
+}
+
+func TestValidateSkipsUncompilableSchemas(t *testing.T) {
+	ctx := context.Background()
+	r := &fakeReader{schemas: []cqrs.EventSchema{
+		{EventName: "user/created", Schema: json.RawMessage(`{"$ref":"https://example.com/user.json"}`)},
+		{EventName: "user/created", Schema: json.RawMessage(`{"type":"object","required":["id"]}`)},
+	}}
+	v := NewValidator(r)
+
+	require.NoError(t, v.Validate(ctx, event.Event{Name: "user/created", Data: map[string]any{"id": "u_1"}}))
+
+	err := v.Validate(ctx, event.Event{Name: "user/created"})
+	verr := ValidationError{}
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Errors, 1)
+
+	require.Equal(t, 1, r.loads, "compiled schemas should be cached")
+}
+
+func TestParseMode(t *testing.T) {
+	m, err := ParseMode("")
+	require.NoError(t, err)
+	require.Equal(t, ModeReject, m)
+
+	m, err = ParseMode("Quarantine")
+	require.NoError(t, err)
+	require.Equal(t, ModeQuarantine, m)
+
+	_, err = ParseMode("drop")
+	require.Error(t, err)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schemas.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"user/created":{"type":"object"}}`), 0o600))
+
+	schemas, err := LoadFile(path)
+	require.NoError(t, err)
+	require.Len(t, schemas, 1)
+	require.Equal(t, "user/created", schemas[0].EventName)
+	require.Equal(t, cqrs.EventSchemaSourceConfig, schemas[0].Source)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"user/created":{"$ref":"http://example.com"}}`), 0o600))
+	_, err = LoadFile(path)
+	require.ErrorContains(t, err, "user/created")
+}
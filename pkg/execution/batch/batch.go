@@ -6,12 +6,22 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/expressions"
 	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/logger"
 	"github.com/oklog/ulid/v2"
 )
 
+const (
+	defaultBatchSizeLimit = 10 * 1024 * 1024 // 10MiB
+)
+
 // BatchManager represents an implementation-agnostic event batching, running functions
 // only when either the specified buffer is full or the specified time it up.
 //
@@ -34,8 +44,34 @@ type BatchManager interface {
 	Append(ctx context.Context, bi BatchItem, fn inngest.Function) (*BatchAppendResult, error)
 	RetrieveItems(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) ([]BatchItem, error)
 	StartExecution(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID, batchPointer string) (string, error)
+	// ResetExecution reverts an open batch started with StartExecution back to
+	// pending, eg. if scheduling the batch's run failed, such that the batch
+	// may be started again.
+	ResetExecution(ctx context.Context, functionId uuid.UUID, b OpenBatch) error
 	ScheduleExecution(ctx context.Context, opts ScheduleBatchOpts) error
 	DeleteKeys(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) error
+	// OpenBatches returns the function's batches which have not yet started.
+	OpenBatches(ctx context.Context, functionId uuid.UUID) ([]OpenBatch, error)
+	// DiscardBatch drops an open batch's events without running the function.
+	DiscardBatch(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) error
+}
+
+var (
+	ErrBatchNotFound = fmt.Errorf("batch not found")
+	ErrBatchStarted  = fmt.Errorf("batch has already started")
+)
+
+// OpenBatch represents a batch which is appending events and has not yet started.
+type OpenBatch struct {
+	ID ulid.ULID `json:"id"`
+	// Key is the evaluated batch key, or "default" if the function has no key.
+	Key string `json:"key"`
+	// Pointer is the key of the batch pointer, used when starting the batch.
+	Pointer string `json:"-"`
+	// Size is the number of events in the batch.
+	Size int `json:"size"`
+	// CreatedAt is the time the first event was appended to the batch.
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // BatchItem represents the item that are being batched.
@@ -93,3 +129,110 @@ type ScheduleBatchPayload struct {
 	FunctionVersion            int        `json:"fnV"`
 	DeprecatedFunctionPausedAt *time.Time `json:"fpAt,omitempty"` // deprecated
 }
+
+type BatchManagerOpt func(o *options)
+
+func WithBatchSizeLimit(l int) BatchManagerOpt {
+	return func(o *options) {
+		o.sizeLimit = l
+	}
+}
+
+func WithLogger(l logger.Logger) BatchManagerOpt {
+	return func(o *options) {
+		o.log = l
+	}
+}
+
+// options holds the configuration shared by each BatchManager implementation.
+type options struct {
+	// sizeLimit is the size limit that a batch can have
+	sizeLimit int
+	log       logger.Logger
+}
+
+func newOptions(opts []BatchManagerOpt) options {
+	o := options{
+		sizeLimit: defaultBatchSizeLimit,
+		log:       logger.StdlibLogger(context.Background()),
+	}
+	for _, apply := range opts {
+		apply(&o)
+	}
+	return o
+}
+
+func (o options) batchKey(ctx context.Context, evt event.Event, fn inngest.Function) (string, error) {
+	if fn.EventBatch.Key == nil {
+		return "default", nil
+	}
+
+	out, _, err := expressions.Evaluate(ctx, *fn.EventBatch.Key, map[string]any{"event": evt.Map()})
+	if err != nil {
+		o.log.Error("error evaluating batch key expression",
+			"error", err,
+			"expression", *fn.EventBatch.Key,
+			"event", evt.Map(),
+		)
+		return fn.ID.String(), fmt.Errorf("invalid expression: %w", err)
+	}
+	if str, ok := out.(string); ok {
+		return str, nil
+	}
+	return fmt.Sprintf("%v", out), nil
+}
+
+// shouldFlush returns whether the event matches the batch's flushIf expression.
+// Errors evaluating the expression never flush the batch.
+func (o options) shouldFlush(ctx context.Context, evt event.Event, fn inngest.Function) bool {
+	if fn.EventBatch.FlushIf == nil || *fn.EventBatch.FlushIf == "" {
+		return false
+	}
+
+	ok, _, err := expressions.EvaluateBoolean(ctx, *fn.EventBatch.FlushIf, map[string]any{"event": evt.Map()})
+	if err != nil {
+		o.log.Error("error evaluating batch flushIf expression",
+			"error", err,
+			"expression", *fn.EventBatch.FlushIf,
+			"event", evt.Map(),
+		)
+		return false
+	}
+	return ok
+}
+
+// scheduleExecution enqueues a job to start the batch at the given time.
+// Scheduling the same batch more than once is a no-op.
+func scheduleExecution(ctx context.Context, q redis_state.QueueManager, l logger.Logger, opts ScheduleBatchOpts) error {
+	jobID := opts.JobID()
+	maxAttempts := consts.MaxRetries + 1
+
+	queueName := queue.KindScheduleBatch
+	err := q.Enqueue(ctx, queue.Item{
+		JobID:       &jobID,
+		GroupID:     uuid.New().String(),
+		WorkspaceID: opts.WorkspaceID,
+		Kind:        queue.KindScheduleBatch,
+		Identifier: state.Identifier{
+			WorkflowID:      opts.FunctionID,
+			WorkflowVersion: opts.FunctionVersion,
+			Key:             fmt.Sprintf("batchschedule:%s", opts.BatchID),
+			AccountID:       opts.AccountID,
+			WorkspaceID:     opts.WorkspaceID,
+			AppID:           opts.AppID,
+		},
+		Attempt:     0,
+		MaxAttempts: &maxAttempts,
+		Payload:     opts.ScheduleBatchPayload,
+		QueueName:   &queueName,
+	}, opts.At, queue.EnqueueOpts{})
+	if err == redis_state.ErrQueueItemExists {
+		l.Debug("queue item already exists for scheduled batch", "job_id", jobID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error enqueueing batch scheduler: %v", err)
+	}
+
+	return nil
+}
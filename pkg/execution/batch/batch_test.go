@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"github.com/inngest/inngest/pkg/event"
 	"github.com/inngest/inngest/pkg/execution/state/redis_state"
 	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/util"
 	"github.com/oklog/ulid/v2"
 	"github.com/redis/rueidis"
 	"github.com/stretchr/testify/require"
@@ -28,7 +30,7 @@ func TestBatchSizeLimit(t *testing.T) {
 
 	bc := redis_state.NewBatchClient(rc, redis_state.QueueDefaultKey)
 	// make the size limit crazy small (10 bytes) for verification purposes
-	bm := NewRedisBatchManager(bc, nil, WithRedisBatchSizeLimit(10))
+	bm := NewRedisBatchManager(bc, nil, WithBatchSizeLimit(10))
 
 	accountId := uuid.New()
 	fnId := uuid.New()
@@ -98,7 +100,8 @@ func TestBatchCleanup(t *testing.T) {
 	require.True(t, r.Exists(bc.KeyGenerator().Batch(context.Background(), fnId, ulid.MustParse(res.BatchID))))
 	require.True(t, r.Exists(bc.KeyGenerator().BatchMetadata(context.Background(), fnId, ulid.MustParse(res.BatchID))))
 	require.True(t, r.Exists(bc.KeyGenerator().BatchPointer(context.Background(), fnId)))
-	require.Equal(t, 3, len(r.Keys()))
+	require.True(t, r.Exists(bc.KeyGenerator().BatchIndex(context.Background(), fnId)))
+	require.Equal(t, 4, len(r.Keys()))
 
 	err = bm.DeleteKeys(context.Background(), fnId, ulid.MustParse(res.BatchID))
 	require.NoError(t, err)
@@ -108,3 +111,163 @@ func TestBatchCleanup(t *testing.T) {
 	require.True(t, r.Exists(bc.KeyGenerator().BatchPointer(context.Background(), fnId)))
 	require.Equal(t, 1, len(r.Keys()))
 }
+
+func TestBatchFlushIf(t *testing.T) {
+	r := miniredis.RunT(t)
+
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	bc := redis_state.NewBatchClient(rc, redis_state.QueueDefaultKey)
+	bm := NewRedisBatchManager(bc, nil)
+
+	ctx := context.Background()
+	fnId := uuid.New()
+	fn := inngest.Function{
+		ID: fnId,
+		EventBatch: &inngest.EventBatchConfig{
+			MaxSize: 10,
+			Timeout: "60s",
+			FlushIf: util.StrPtr("event.data.final == true"),
+		},
+	}
+
+	add := func(final bool) *BatchAppendResult {
+		res, err := bm.Append(ctx, BatchItem{
+			FunctionID: fnId,
+			EventID:    ulid.MustNew(ulid.Now(), rand.Reader),
+			Event: event.Event{
+				Name: "test/event",
+				Data: map[string]any{"final": final},
+			},
+		}, fn)
+		require.NoError(t, err)
+		return res
+	}
+
+	first := add(false)
+	require.Equal(t, enums.BatchNew, first.Status)
+	require.Equal(t, enums.BatchAppend, add(false).Status)
+
+	flushed := add(true)
+	require.Equal(t, enums.BatchFull, flushed.Status)
+	require.Equal(t, first.BatchID, flushed.BatchID)
+
+	items, err := bm.RetrieveItems(ctx, fnId, ulid.MustParse(flushed.BatchID))
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	// Flushed batches are no longer open, and new events start a new batch.
+	open, err := bm.OpenBatches(ctx, fnId)
+	require.NoError(t, err)
+	require.Empty(t, open)
+
+	next := add(false)
+	require.Equal(t, enums.BatchNew, next.Status)
+	require.NotEqual(t, first.BatchID, next.BatchID)
+}
+
+func TestOpenBatches(t *testing.T) {
+	r := miniredis.RunT(t)
+
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	bc := redis_state.NewBatchClient(rc, redis_state.QueueDefaultKey)
+	bm := NewRedisBatchManager(bc, nil)
+
+	ctx := context.Background()
+	fnId := uuid.New()
+	fn := inngest.Function{
+		ID: fnId,
+		EventBatch: &inngest.EventBatchConfig{
+			Key:     util.StrPtr("event.data.user"),
+			MaxSize: 10,
+			Timeout: "60s",
+		},
+	}
+
+	add := func(user string) *BatchAppendResult {
+		res, err := bm.Append(ctx, BatchItem{
+			FunctionID: fnId,
+			EventID:    ulid.MustNew(ulid.Now(), rand.Reader),
+			Event: event.Event{
+				Name: "test/event",
+				Data: map[string]any{"user": user},
+			},
+		}, fn)
+		require.NoError(t, err)
+		return res
+	}
+
+	a := add("a")
+	add("a")
+	b := add("b")
+
+	open, err := bm.OpenBatches(ctx, fnId)
+	require.NoError(t, err)
+	require.Len(t, open, 2)
+	sizes := map[string]int{}
+	for _, batch := range open {
+		sizes[batch.Key] = batch.Size
+		require.WithinDuration(t, time.Now(), batch.CreatedAt, 5*time.Second)
+	}
+	require.Equal(t, map[string]int{"a": 2, "b": 1}, sizes)
+
+	t.Run("started batches are not open", func(t *testing.T) {
+		status, err := bm.StartExecution(ctx, fnId, ulid.MustParse(a.BatchID), a.BatchPointerKey)
+		require.NoError(t, err)
+		require.Equal(t, enums.BatchStatusReady.String(), status)
+
+		open, err := bm.OpenBatches(ctx, fnId)
+		require.NoError(t, err)
+		require.Len(t, open, 1)
+		require.Equal(t, b.BatchID, open[0].ID.String())
+
+		require.ErrorIs(t, bm.DiscardBatch(ctx, fnId, ulid.MustParse(a.BatchID)), ErrBatchNotFound)
+	})
+
+	t.Run("reset batches are open", func(t *testing.T) {
+		var started OpenBatch
+		for _, batch := range open {
+			if batch.ID.String() == a.BatchID {
+				started = batch
+			}
+		}
+		require.NoError(t, bm.ResetExecution(ctx, fnId, started))
+
+		reopened, err := bm.OpenBatches(ctx, fnId)
+		require.NoError(t, err)
+		require.Len(t, reopened, 2)
+
+		// The batch can be started again.
+		status, err := bm.StartExecution(ctx, fnId, started.ID, started.Pointer)
+		require.NoError(t, err)
+		require.Equal(t, enums.BatchStatusReady.String(), status)
+	})
+
+	t.Run("discarded batches are dropped", func(t *testing.T) {
+		batchID := ulid.MustParse(b.BatchID)
+		require.NoError(t, bm.DiscardBatch(ctx, fnId, batchID))
+		require.False(t, r.Exists(bc.KeyGenerator().Batch(ctx, fnId, batchID)))
+
+		open, err := bm.OpenBatches(ctx, fnId)
+		require.NoError(t, err)
+		require.Empty(t, open)
+
+		// New events for the key start a new batch.
+		next := add("b")
+		require.Equal(t, enums.BatchNew, next.Status)
+		require.NotEqual(t, b.BatchID, next.BatchID)
+
+		require.ErrorIs(t, bm.DiscardBatch(ctx, fnId, batchID), ErrBatchNotFound)
+	})
+}
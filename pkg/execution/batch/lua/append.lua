@@ -3,6 +3,7 @@
 --
 
 local batchPointerKey = KEYS[1]      -- key to the batch pointer
+local batchIndexKey = KEYS[2]        -- hash of the function's open batches
 
 local batchLimit = tonumber(ARGV[1]) -- max size configured for this batch
 local event = ARGV[2]                -- event to be appended to the batch
@@ -12,6 +13,9 @@ local prefix = ARGV[4]               -- the prefix used for redis
 local batchStatusAppending = ARGV[5]
 local batchStatusStarted = ARGV[6]
 local batchSizeLimit = tonumber(ARGV[7])
+local flush = tonumber(ARGV[8]) == 1  -- whether the event matched the batch's flushIf expression
+local batchKeyValue = ARGV[9]         -- the evaluated batch key, stored in the index
+local currentTime = tonumber(ARGV[10]) -- in ms
 
 -- helper functions
 -- $include(helpers.lua)
@@ -52,17 +56,19 @@ local len = redis.call("RPUSH", batchKey, event)
 if len == 1 then
     -- newly started batch
     resp = { status = "new", batchID = batchID, batchPointerKey = batchPointerKey }
+    redis.call("HSET", batchIndexKey, batchID, cjson.encode({ key = batchKeyValue, pointer = batchPointerKey, at = currentTime }))
 end
 
 local size = redis.call("MEMORY", "USAGE", batchKey)
--- if batch is full
-if len >= batchLimit or size >= batchSizeLimit then
+-- if batch is full, or the event asks to flush the batch
+if len >= batchLimit or size >= batchSizeLimit or flush then
   if not is_status_empty(batchMetadataKey) then
     set_batch_status(batchMetadataKey, batchStatusStarted)
   end
 
   -- change poiner so following ops don't append to this batch anymore
   update_pointer(batchPointerKey, newULID)
+  redis.call("HDEL", batchIndexKey, batchID)
 
   -- Check if the batch size limit is reached, this inevitably will go over a little bit
   -- but that should be fine consider there's a cap on the size of an event
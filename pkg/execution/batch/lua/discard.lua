@@ -0,0 +1,36 @@
+--
+-- Discards an open batch, dropping its events without running the function.
+--
+-- Return values:
+--   0: Discarded
+--   1: Already started
+--  -1: Not found
+--
+local batchKey = KEYS[1]         -- key for the batch's events
+local batchMetadataKey = KEYS[2] -- key for batch metadata
+local batchPointerKey = KEYS[3]  -- key for pointer
+local batchIndexKey = KEYS[4]    -- hash of the function's open batches
+
+local batchStatusStarted = ARGV[1]
+local batchID = ARGV[2]    -- the ULID of the batch being discarded
+local newBatchID = ARGV[3] -- the ULID for a new batch
+
+-- $include(helpers.lua)
+
+if redis.call("HEXISTS", batchIndexKey, batchID) == 0 then
+  return -1
+end
+redis.call("HDEL", batchIndexKey, batchID)
+
+if get_batch_status(batchMetadataKey) == batchStatusStarted then
+  return 1
+end
+
+-- Ensure new events are appended to a new batch.
+if redis.call("GET", batchPointerKey) == batchID then
+  update_pointer(batchPointerKey, newBatchID)
+end
+
+redis.call("DEL", batchKey, batchMetadataKey)
+
+return 0
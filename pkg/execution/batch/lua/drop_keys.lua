@@ -1,11 +1,14 @@
 ---
---- Deletes the provided keys
+--- Deletes the batch's keys and removes the batch from the index of open batches
 ---
 
-for i, key in ipairs(KEYS) do
-  if i > 0 then
-    redis.call("DEL", key)
-  end
-end
+local batchKey = KEYS[1]         -- key for the batch's events
+local batchMetadataKey = KEYS[2] -- key for batch metadata
+local batchIndexKey = KEYS[3]    -- hash of the function's open batches
+
+local batchID = ARGV[1]
+
+redis.call("DEL", batchKey, batchMetadataKey)
+redis.call("HDEL", batchIndexKey, batchID)
 
 return 0
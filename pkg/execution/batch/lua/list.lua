@@ -0,0 +1,26 @@
+--
+-- Lists a function's open batches, removing any stale entries from the index.
+--
+-- Returns a flat list of batch ID, batch details and batch size triples.
+--
+
+local batchIndexKey = KEYS[1] -- hash of the function's open batches
+
+local prefix = ARGV[1]        -- the prefix used for redis
+
+local result = {}
+local entries = redis.call("HGETALL", batchIndexKey)
+for i = 1, #entries, 2 do
+  local batchID = entries[i]
+  -- NOTE: this needs to be identical to the Batch key in the queue key generator
+  local len = redis.call("LLEN", string.format("%s:batches:%s", prefix, batchID))
+  if len == 0 then
+    redis.call("HDEL", batchIndexKey, batchID)
+  else
+    table.insert(result, batchID)
+    table.insert(result, entries[i + 1])
+    table.insert(result, len)
+  end
+end
+
+return result
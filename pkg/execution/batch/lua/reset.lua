@@ -0,0 +1,27 @@
+--
+-- Reverts a started batch to pending, re-opening it after its run failed to be
+-- scheduled.  The batch pointer isn't reverted:  events appended since the
+-- batch started belong to a new batch.
+--
+-- Return values:
+--   0: Reset
+--   1: Not started
+--
+local batchMetadataKey = KEYS[1] -- key for batch metadata
+local batchIndexKey = KEYS[2]    -- hash of the function's open batches
+
+local batchStatusPending = ARGV[1]
+local batchStatusStarted = ARGV[2]
+local batchID = ARGV[3]    -- the ULID of the batch being reset
+local indexEntry = ARGV[4] -- the batch's entry within the index of open batches
+
+-- $include(helpers.lua)
+
+if get_batch_status(batchMetadataKey) ~= batchStatusStarted then
+  return 1
+end
+
+set_batch_status(batchMetadataKey, batchStatusPending)
+redis.call("HSET", batchIndexKey, batchID, indexEntry)
+
+return 0
@@ -0,0 +1,362 @@
+package batch
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution/state/postgres_state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+)
+
+// NewPostgresBatchManager returns a BatchManager which stores batches in
+// Postgres.  Appends to the same batch are serialized using a transaction-level
+// advisory lock on the batch's pointer.
+func NewPostgresBatchManager(db *sql.DB, q redis_state.QueueManager, opts ...BatchManagerOpt) BatchManager {
+	return postgresBatchManager{
+		options: newOptions(opts),
+		db:      db,
+		q:       q,
+	}
+}
+
+type postgresBatchManager struct {
+	options
+
+	db *sql.DB
+	q  redis_state.QueueManager
+}
+
+// batchPointer returns the pointer for the function's current batch along with
+// the evaluated batch key.  Both are "default" for functions without a key.
+func (m postgresBatchManager) batchPointer(ctx context.Context, fn inngest.Function, evt event.Event) (string, string, error) {
+	if fn.EventBatch.Key == nil {
+		return "default", "default", nil
+	}
+
+	batchKey, err := m.batchKey(ctx, evt, fn)
+	if err != nil {
+		return "", "", fmt.Errorf("could not retrieve batch key: %w", err)
+	}
+
+	hashedBatchKey := sha256.Sum256([]byte(batchKey))
+	return base64.StdEncoding.EncodeToString(hashedBatchKey[:]), batchKey, nil
+}
+
+// lockPointer holds a transaction-level advisory lock on the given batch pointer.
+func lockPointer(ctx context.Context, tx *sql.Tx, functionId uuid.UUID, pointer string) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, functionId.String()+":"+pointer)
+	if err != nil {
+		return fmt.Errorf("error locking batch pointer: %w", err)
+	}
+	return nil
+}
+
+// movePointer points the given batch pointer to a new batch, if it currently
+// points to batchID.
+func movePointer(ctx context.Context, tx *sql.Tx, functionId uuid.UUID, pointer string, batchID string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE batch_pointers SET batch_id = $4
+		WHERE function_id = $1 AND pointer = $2 AND batch_id = $3`,
+		functionId, pointer, batchID, ulid.Make().String(),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating batch pointer: %w", err)
+	}
+	return nil
+}
+
+// Append adds an item to the function's current batch, creating the batch if
+// necessary.  Batches which are full, exceed the size limit, or receive an event
+// matching the batch's flushIf expression are started immediately.
+func (m postgresBatchManager) Append(ctx context.Context, bi BatchItem, fn inngest.Function) (*BatchAppendResult, error) {
+	config := fn.EventBatch
+	if config == nil {
+		return nil, fmt.Errorf("no batch config found for for function: %s", fn.Slug)
+	}
+
+	pointer, batchKey, err := m.batchPointer(ctx, fn, bi.Event)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve batch pointer: %w", err)
+	}
+
+	byt, err := json.Marshal(bi)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling batch item: %w", err)
+	}
+	flush := m.shouldFlush(ctx, bi.Event, fn)
+
+	result := &BatchAppendResult{Status: enums.BatchAppend, BatchPointerKey: pointer}
+	err = postgres_state.WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		if err := lockPointer(ctx, tx, fn.ID, pointer); err != nil {
+			return err
+		}
+
+		var batchID string
+		err := tx.QueryRowContext(ctx,
+			`SELECT batch_id FROM batch_pointers WHERE function_id = $1 AND pointer = $2`,
+			fn.ID, pointer,
+		).Scan(&batchID)
+		if errors.Is(err, sql.ErrNoRows) {
+			batchID = ulid.Make().String()
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO batch_pointers (function_id, pointer, batch_id) VALUES ($1, $2, $3)`,
+				fn.ID, pointer, batchID,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("error loading batch pointer: %w", err)
+		}
+		result.BatchID = batchID
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO batches (id, function_id, pointer, key, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (id) DO NOTHING`,
+			batchID, fn.ID, pointer, batchKey, enums.BatchStatusPending.String(), time.Now().UnixMilli(),
+		)
+		if err != nil {
+			return fmt.Errorf("error creating batch: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO batch_items (batch_id, item) VALUES ($1, $2)`,
+			batchID, string(byt),
+		); err != nil {
+			return fmt.Errorf("error appending batch item: %w", err)
+		}
+
+		var (
+			size  int
+			bytes int64
+		)
+		err = tx.QueryRowContext(ctx,
+			`UPDATE batches SET size = size + 1, bytes = bytes + $2 WHERE id = $1 RETURNING size, bytes`,
+			batchID, len(byt),
+		).Scan(&size, &bytes)
+		if err != nil {
+			return fmt.Errorf("error updating batch: %w", err)
+		}
+
+		if size == 1 {
+			result.Status = enums.BatchNew
+		}
+
+		if size >= config.MaxSize || bytes >= int64(m.sizeLimit) || flush {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE batches SET status = $2 WHERE id = $1`,
+				batchID, enums.BatchStatusStarted.String(),
+			); err != nil {
+				return fmt.Errorf("error starting batch: %w", err)
+			}
+			// Ensure that following appends create a new batch.
+			if err := movePointer(ctx, tx, fn.ID, pointer, batchID); err != nil {
+				return err
+			}
+
+			result.Status = enums.BatchFull
+			if bytes >= int64(m.sizeLimit) {
+				result.Status = enums.BatchMaxSize
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to append event: '%s' to a batch: %w", bi.EventID, err)
+	}
+	return result, nil
+}
+
+// RetrieveItems retrieve the data associated with the specified batch.
+func (m postgresBatchManager) RetrieveItems(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) ([]BatchItem, error) {
+	empty := make([]BatchItem, 0)
+
+	rows, err := m.db.QueryContext(ctx,
+		`SELECT item FROM batch_items WHERE batch_id = $1 ORDER BY id`,
+		batchID.String(),
+	)
+	if err != nil {
+		return empty, fmt.Errorf("failed to retrieve list of events for batch '%s': %w", batchID, err)
+	}
+	defer rows.Close()
+
+	items := []BatchItem{}
+	for rows.Next() {
+		var byt string
+		if err := rows.Scan(&byt); err != nil {
+			return empty, err
+		}
+		item := BatchItem{}
+		if err := json.Unmarshal([]byte(byt), &item); err != nil {
+			return empty, fmt.Errorf("failed to decode item for batch '%s': %w", batchID, err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return empty, err
+	}
+	return items, nil
+}
+
+// StartExecution sets the status to `started`
+// If it has already started, don't do anything
+func (m postgresBatchManager) StartExecution(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID, batchPointer string) (string, error) {
+	status := enums.BatchStatusAbsent.String()
+	err := postgres_state.WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		if err := lockPointer(ctx, tx, functionId, batchPointer); err != nil {
+			return err
+		}
+
+		var current string
+		err := tx.QueryRowContext(ctx,
+			`SELECT status FROM batches WHERE id = $1 FOR UPDATE`,
+			batchID.String(),
+		).Scan(&current)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if current == enums.BatchStatusStarted.String() {
+			status = current
+			return nil
+		}
+
+		if err := movePointer(ctx, tx, functionId, batchPointer, batchID.String()); err != nil {
+			return err
+		}
+		if current == "" {
+			// The batch is gone somehow.
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE batches SET status = $2 WHERE id = $1`,
+			batchID.String(), enums.BatchStatusStarted.String(),
+		); err != nil {
+			return err
+		}
+		status = enums.BatchStatusReady.String()
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start batch execution: %w", err)
+	}
+	return status, nil
+}
+
+// ResetExecution reverts an open batch started with StartExecution back to
+// pending.  Resetting a batch which hasn't started is a no-op.
+func (m postgresBatchManager) ResetExecution(ctx context.Context, functionId uuid.UUID, batch OpenBatch) error {
+	_, err := m.db.ExecContext(ctx,
+		`UPDATE batches SET status = $3 WHERE id = $1 AND status = $2`,
+		batch.ID.String(), enums.BatchStatusStarted.String(), enums.BatchStatusPending.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reset batch '%s': %w", batch.ID, err)
+	}
+	return nil
+}
+
+// ScheduleExecution enqueues a job to run the batch job after the specified duration.
+func (m postgresBatchManager) ScheduleExecution(ctx context.Context, opts ScheduleBatchOpts) error {
+	return scheduleExecution(ctx, m.q, m.log, opts)
+}
+
+// DeleteKeys drops the batch and its items.
+func (m postgresBatchManager) DeleteKeys(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) error {
+	err := postgres_state.WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM batch_items WHERE batch_id = $1`, batchID.String()); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM batches WHERE id = $1`, batchID.String())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete batch '%s': %w", batchID, err)
+	}
+	return nil
+}
+
+// OpenBatches returns the function's batches which have not yet started.
+func (m postgresBatchManager) OpenBatches(ctx context.Context, functionId uuid.UUID) ([]OpenBatch, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT id, key, pointer, size, created_at FROM batches
+		WHERE function_id = $1 AND status = $2 AND size > 0
+		ORDER BY created_at`,
+		functionId, enums.BatchStatusPending.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batches: %w", err)
+	}
+	defer rows.Close()
+
+	batches := []OpenBatch{}
+	for rows.Next() {
+		var (
+			id        string
+			b         OpenBatch
+			createdAt int64
+		)
+		if err := rows.Scan(&id, &b.Key, &b.Pointer, &b.Size, &createdAt); err != nil {
+			return nil, err
+		}
+		if b.ID, err = ulid.Parse(id); err != nil {
+			continue
+		}
+		b.CreatedAt = time.UnixMilli(createdAt)
+		batches = append(batches, b)
+	}
+	return batches, rows.Err()
+}
+
+// DiscardBatch drops an open batch's events without running the function.  Any
+// job scheduled to start the batch finds the batch absent and is skipped.
+func (m postgresBatchManager) DiscardBatch(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) error {
+	return postgres_state.WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		var pointer, status string
+		err := tx.QueryRowContext(ctx,
+			`SELECT pointer, status FROM batches WHERE id = $1 AND function_id = $2`,
+			batchID.String(), functionId,
+		).Scan(&pointer, &status)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrBatchNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load batch '%s': %w", batchID, err)
+		}
+
+		if err := lockPointer(ctx, tx, functionId, pointer); err != nil {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx,
+			`SELECT status FROM batches WHERE id = $1 FOR UPDATE`,
+			batchID.String(),
+		).Scan(&status); err != nil {
+			return fmt.Errorf("failed to load batch '%s': %w", batchID, err)
+		}
+		if status == enums.BatchStatusStarted.String() {
+			return ErrBatchStarted
+		}
+
+		// Ensure new events are appended to a new batch.
+		if err := movePointer(ctx, tx, functionId, pointer, batchID.String()); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM batch_items WHERE batch_id = $1`, batchID.String()); err != nil {
+			return fmt.Errorf("failed to discard batch '%s': %w", batchID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM batches WHERE id = $1`, batchID.String()); err != nil {
+			return fmt.Errorf("failed to discard batch '%s': %w", batchID, err)
+		}
+		return nil
+	})
+}
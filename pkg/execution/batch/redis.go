@@ -7,81 +7,31 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/event"
-	"github.com/inngest/inngest/pkg/execution/queue"
-	"github.com/inngest/inngest/pkg/execution/state"
 	"github.com/inngest/inngest/pkg/execution/state/redis_state"
-	"github.com/inngest/inngest/pkg/expressions"
 	"github.com/inngest/inngest/pkg/inngest"
-	"github.com/inngest/inngest/pkg/logger"
 	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
 )
 
-const (
-	defaultBatchSizeLimit = 10 * 1024 * 1024 // 10MiB
-)
-
-type RedisBatchManagerOpt func(m *redisBatchManager)
-
-func WithRedisBatchSizeLimit(l int) RedisBatchManagerOpt {
-	return func(m *redisBatchManager) {
-		m.sizeLimit = l
-	}
-}
-
-func WithLogger(l logger.Logger) RedisBatchManagerOpt {
-	return func(m *redisBatchManager) {
-		m.log = l
+func NewRedisBatchManager(b *redis_state.BatchClient, q redis_state.QueueManager, opts ...BatchManagerOpt) BatchManager {
+	return redisBatchManager{
+		options: newOptions(opts),
+		b:       b,
+		q:       q,
 	}
 }
 
-func NewRedisBatchManager(b *redis_state.BatchClient, q redis_state.QueueManager, opts ...RedisBatchManagerOpt) BatchManager {
-	manager := redisBatchManager{
-		b:         b,
-		q:         q,
-		sizeLimit: defaultBatchSizeLimit,
-		log:       logger.StdlibLogger(context.Background()),
-	}
-
-	for _, apply := range opts {
-		apply(&manager)
-	}
-
-	return manager
-}
-
 type redisBatchManager struct {
+	options
+
 	b *redis_state.BatchClient
 	q redis_state.QueueManager
-
-	// sizeLimit is the size limit that a batch can have
-	sizeLimit int
-	log       logger.Logger
-}
-
-func (b redisBatchManager) batchKey(ctx context.Context, evt event.Event, fn inngest.Function) (string, error) {
-	if fn.EventBatch.Key == nil {
-		return "default", nil
-	}
-
-	out, _, err := expressions.Evaluate(ctx, *fn.EventBatch.Key, map[string]any{"event": evt.Map()})
-	if err != nil {
-		b.log.Error("error evaluating batch key expression",
-			"error", err,
-			"expression", *fn.EventBatch.Key,
-			"event", evt.Map(),
-		)
-		return fn.ID.String(), fmt.Errorf("invalid expression: %w", err)
-	}
-	if str, ok := out.(string); ok {
-		return str, nil
-	}
-	return fmt.Sprintf("%v", out), nil
 }
 
 func (b redisBatchManager) batchPointer(ctx context.Context, fn inngest.Function, evt event.Event) (string, error) {
@@ -109,7 +59,7 @@ func (b redisBatchManager) batchPointer(ctx context.Context, fn inngest.Function
 //     Schedule a job to start the batch execution after the provided `timeout`. The scheduled job actually
 //     executes or not depends on the batch state at the time.
 //
-//  2. Batch is full
+//  2. Batch is full, or the item matches the batch's flushIf expression
 //     Starts the batch job immediately and update the status.
 //
 //  3. Neither #1 or #2
@@ -126,9 +76,16 @@ func (b redisBatchManager) Append(ctx context.Context, bi BatchItem, fn inngest.
 		return nil, fmt.Errorf("could not retrieve batch pointer: %w", err)
 	}
 
+	batchKey := "default"
+	if config.Key != nil {
+		// The key has already been evaluated successfully by batchPointer.
+		batchKey, _ = b.batchKey(ctx, bi.Event, fn)
+	}
+
 	// script keys
 	keys := []string{
 		batchPointer,
+		b.b.KeyGenerator().BatchIndex(ctx, bi.FunctionID),
 	}
 
 	// script args
@@ -142,6 +99,9 @@ func (b redisBatchManager) Append(ctx context.Context, bi BatchItem, fn inngest.
 		enums.BatchStatusPending,
 		enums.BatchStatusStarted,
 		b.sizeLimit,
+		b.shouldFlush(ctx, bi.Event, fn),
+		batchKey,
+		time.Now().UnixMilli(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error preparing batch: %w", err)
@@ -197,10 +157,12 @@ func (b redisBatchManager) StartExecution(ctx context.Context, functionId uuid.U
 	keys := []string{
 		b.b.KeyGenerator().BatchMetadata(ctx, functionId, batchID),
 		batchPointer,
+		b.b.KeyGenerator().BatchIndex(ctx, functionId),
 	}
 	args := []string{
 		enums.BatchStatusStarted.String(),
 		ulid.Make().String(),
+		batchID.String(),
 	}
 
 	status, err := retriableScripts["start"].Exec(
@@ -228,49 +190,53 @@ func (b redisBatchManager) StartExecution(ctx context.Context, functionId uuid.U
 	}
 }
 
-// ScheduleExecution enqueues a job to run the batch job after the specified duration.
-func (b redisBatchManager) ScheduleExecution(ctx context.Context, opts ScheduleBatchOpts) error {
-	jobID := opts.JobID()
-	maxAttempts := consts.MaxRetries + 1
-
-	queueName := queue.KindScheduleBatch
-	err := b.q.Enqueue(ctx, queue.Item{
-		JobID:       &jobID,
-		GroupID:     uuid.New().String(),
-		WorkspaceID: opts.WorkspaceID,
-		Kind:        queue.KindScheduleBatch,
-		Identifier: state.Identifier{
-			WorkflowID:      opts.FunctionID,
-			WorkflowVersion: opts.FunctionVersion,
-			Key:             fmt.Sprintf("batchschedule:%s", opts.BatchID),
-			AccountID:       opts.AccountID,
-			WorkspaceID:     opts.WorkspaceID,
-			AppID:           opts.AppID,
-		},
-		Attempt:     0,
-		MaxAttempts: &maxAttempts,
-		Payload:     opts.ScheduleBatchPayload,
-		QueueName:   &queueName,
-	}, opts.At, queue.EnqueueOpts{})
-	if err == redis_state.ErrQueueItemExists {
-		b.log.Debug("queue item already exists for scheduled batch", "job_id", jobID)
-		return nil
-	}
+// ResetExecution reverts an open batch started with StartExecution back to
+// pending, re-adding it to the function's open batches.  Resetting a batch which
+// hasn't started is a no-op.
+func (b redisBatchManager) ResetExecution(ctx context.Context, functionId uuid.UUID, batch OpenBatch) error {
+	entry, err := json.Marshal(batchIndexEntry{
+		Key:     batch.Key,
+		Pointer: batch.Pointer,
+		At:      batch.CreatedAt.UnixMilli(),
+	})
 	if err != nil {
-		return fmt.Errorf("error enqueueing batch scheduler: %v", err)
+		return fmt.Errorf("failed to encode batch '%s': %w", batch.ID, err)
 	}
 
+	err = retriableScripts["reset"].Exec(
+		ctx,
+		b.b.Client(),
+		[]string{
+			b.b.KeyGenerator().BatchMetadata(ctx, functionId, batch.ID),
+			b.b.KeyGenerator().BatchIndex(ctx, functionId),
+		},
+		[]string{
+			enums.BatchStatusPending.String(),
+			enums.BatchStatusStarted.String(),
+			batch.ID.String(),
+			string(entry),
+		},
+	).Error()
+	if err != nil {
+		return fmt.Errorf("failed to reset batch '%s': %w", batch.ID, err)
+	}
 	return nil
 }
 
+// ScheduleExecution enqueues a job to run the batch job after the specified duration.
+func (b redisBatchManager) ScheduleExecution(ctx context.Context, opts ScheduleBatchOpts) error {
+	return scheduleExecution(ctx, b.q, b.log, opts)
+}
+
 // DeleteKeys drops keys related to the provided batchID.
 func (b redisBatchManager) DeleteKeys(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) error {
 	keys := []string{
 		b.b.KeyGenerator().Batch(ctx, functionId, batchID),
 		b.b.KeyGenerator().BatchMetadata(ctx, functionId, batchID),
+		b.b.KeyGenerator().BatchIndex(ctx, functionId),
 	}
 
-	args, err := redis_state.StrSlice([]any{})
+	args, err := redis_state.StrSlice([]any{batchID})
 	if err != nil {
 		return fmt.Errorf("error constructing batch deletion: %w", err)
 	}
@@ -286,3 +252,103 @@ func (b redisBatchManager) DeleteKeys(ctx context.Context, functionId uuid.UUID,
 
 	return nil
 }
+
+// OpenBatches returns the function's batches which have not yet started.
+func (b redisBatchManager) OpenBatches(ctx context.Context, functionId uuid.UUID) ([]OpenBatch, error) {
+	out, err := retriableScripts["list"].Exec(
+		ctx,
+		b.b.Client(),
+		[]string{b.b.KeyGenerator().BatchIndex(ctx, functionId)},
+		[]string{b.b.KeyGenerator().QueuePrefix(ctx, functionId)},
+	).ToArray()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batches: %w", err)
+	}
+
+	batches := make([]OpenBatch, 0, len(out)/3)
+	for i := 0; i+2 < len(out); i += 3 {
+		id, _ := out[i].ToString()
+		details, _ := out[i+1].ToString()
+		size, _ := out[i+2].AsInt64()
+
+		batchID, err := ulid.Parse(id)
+		if err != nil {
+			continue
+		}
+		entry := batchIndexEntry{}
+		if err := json.Unmarshal([]byte(details), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode batch '%s': %w", id, err)
+		}
+
+		batches = append(batches, OpenBatch{
+			ID:        batchID,
+			Key:       entry.Key,
+			Pointer:   entry.Pointer,
+			Size:      int(size),
+			CreatedAt: time.UnixMilli(entry.At),
+		})
+	}
+
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].CreatedAt.Before(batches[j].CreatedAt)
+	})
+	return batches, nil
+}
+
+// DiscardBatch drops an open batch's events without running the function.  Any
+// job scheduled to start the batch finds the batch absent and is skipped.
+func (b redisBatchManager) DiscardBatch(ctx context.Context, functionId uuid.UUID, batchID ulid.ULID) error {
+	kg := b.b.KeyGenerator()
+
+	// Find the batch's pointer from the index, which is required to ensure that
+	// new events aren't appended to the discarded batch.
+	details, err := b.b.Client().Do(ctx, func(client rueidis.Client) rueidis.Completed {
+		return client.B().Hget().Key(kg.BatchIndex(ctx, functionId)).Field(batchID.String()).Build()
+	}).ToString()
+	if rueidis.IsRedisNil(err) {
+		return ErrBatchNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load batch '%s': %w", batchID, err)
+	}
+	entry := batchIndexEntry{}
+	if err := json.Unmarshal([]byte(details), &entry); err != nil {
+		return fmt.Errorf("failed to decode batch '%s': %w", batchID, err)
+	}
+
+	status, err := retriableScripts["discard"].Exec(
+		ctx,
+		b.b.Client(),
+		[]string{
+			kg.Batch(ctx, functionId, batchID),
+			kg.BatchMetadata(ctx, functionId, batchID),
+			entry.Pointer,
+			kg.BatchIndex(ctx, functionId),
+		},
+		[]string{
+			enums.BatchStatusStarted.String(),
+			batchID.String(),
+			ulid.Make().String(),
+		},
+	).AsInt64()
+	if err != nil {
+		return fmt.Errorf("failed to discard batch '%s': %w", batchID, err)
+	}
+
+	switch status {
+	case -1:
+		return ErrBatchNotFound
+	case 1:
+		return ErrBatchStarted
+	default:
+		return nil
+	}
+}
+
+// batchIndexEntry is the batch detail stored within the index of open batches.
+type batchIndexEntry struct {
+	Key     string `json:"key"`
+	Pointer string `json:"pointer"`
+	// At is the time the batch was created, in unix milliseconds.
+	At int64 `json:"at"`
+}
@@ -0,0 +1,176 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/util"
+	"github.com/robfig/cron/v3"
+)
+
+// MissedTickThreshold is the delay after which a tick is considered missed, eg.
+// because every runner was down when it was due.  Ticks processed within this
+// threshold always fire, regardless of the catch-up policy.
+var MissedTickThreshold = time.Minute
+
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// The general strategy for crons:
+//
+//  1. Each runner syncs every cron trigger on startup and on app sync.  Syncing
+//     creates a next-fire record for the function version if one doesn't exist,
+//     then enqueues a queue item for the record's next tick.  Queue items use a
+//     deterministic job ID per tick, so only one item exists regardless of how
+//     many runners sync.
+//  2. When a tick's queue item is processed, the next tick is recorded and
+//     enqueued before the function is scheduled.  This continues the chain
+//     even if scheduling fails and the item is retried.
+//  3. Ticks processed late are handled according to the catch-up policy.
+
+// CronManager schedules cron triggers as durable queue items.
+type CronManager interface {
+	// Sync ensures that the given cron trigger has a next-fire record and a
+	// queue item for its next tick.  This is idempotent and safe to call from
+	// many runners at once.
+	Sync(ctx context.Context, ci CronItem) error
+	// Next determines whether the tick in the given payload should fire, given
+	// the catch-up policy, then records and enqueues the tick which follows it.
+	Next(ctx context.Context, p CronPayload) (bool, error)
+	// Remove deletes the next-fire record for a cron trigger that no longer
+	// exists, eg. after its function was updated or deleted.
+	Remove(ctx context.Context, ci CronItem) error
+}
+
+// CronItem identifies a single cron trigger for a function version.
+type CronItem struct {
+	// AccountID represents the account for the cron
+	AccountID uuid.UUID `json:"aID"`
+	// WorkspaceID represents the workspace for the cron
+	WorkspaceID uuid.UUID `json:"wsID"`
+	// AppID represents the app for the cron
+	AppID uuid.UUID `json:"appID"`
+	// FunctionID represents the function ID that this cron is for.
+	FunctionID uuid.UUID `json:"fnID"`
+	// FunctionVersion represents the version of the function that the cron
+	// was scheduled for.
+	FunctionVersion int `json:"fnV"`
+	// Expression is the cron expression, as defined in the function's trigger.
+	Expression string `json:"expr"`
+	// Timezone is the timezone of the cron expression, if specified.
+	Timezone string `json:"tz,omitempty"`
+	// Jitter is the maximum delay added to each tick, if specified.
+	Jitter time.Duration `json:"jitter,omitempty"`
+}
+
+// NewCronItem returns the CronItem for the given function's cron trigger.
+func NewCronItem(accountID, workspaceID, appID uuid.UUID, fn inngest.Function, t inngest.CronTrigger) CronItem {
+	return CronItem{
+		AccountID:       accountID,
+		WorkspaceID:     workspaceID,
+		AppID:           appID,
+		FunctionID:      fn.ID,
+		FunctionVersion: fn.FunctionVersion,
+		Expression:      t.Cron,
+		Timezone:        t.Timezone(),
+		Jitter:          t.JitterDuration(),
+	}
+}
+
+// Offset returns the delay added to each tick when jitter is enabled.  The
+// offset is stable for each function and expression, so that every tick of a
+// cron is delayed by the same amount.
+func (c CronItem) Offset() time.Duration {
+	if c.Jitter <= 0 {
+		return 0
+	}
+	sum := xxhash.Sum64String(c.FunctionID.String() + c.Expression)
+	return time.Duration(sum % uint64(c.Jitter)).Truncate(time.Millisecond)
+}
+
+// field returns the field used to store the next-fire record for this cron
+// within the function's next-fire hash.
+func (c CronItem) field() string {
+	return fmt.Sprintf("%d:%s", c.FunctionVersion, util.XXHash(c.Expression))
+}
+
+// CronPayload represents the data stored within the queue's payload for a
+// single tick.
+type CronPayload struct {
+	CronItem
+	// At is the time of the tick, in unix milliseconds.
+	At int64 `json:"at"`
+}
+
+// Time returns the scheduled time of the tick.
+func (p CronPayload) Time() time.Time {
+	return time.UnixMilli(p.At)
+}
+
+// FireAt returns the time at which the tick fires, including jitter.
+func (p CronPayload) FireAt() time.Time {
+	return p.Time().Add(p.Offset())
+}
+
+// JobID returns the deterministic queue job ID for the tick, ensuring that each
+// tick is only ever enqueued once.
+func (p CronPayload) JobID() string {
+	return fmt.Sprintf("cron:%s:%s:%d", p.FunctionID, p.field(), p.At)
+}
+
+// Event returns the cron event which triggers the function for this tick,
+// fired at the given time.  The event ID is derived from the tick so that it
+// acts as an idempotency key.
+func (p CronPayload) Event(firedAt time.Time) event.Event {
+	tick := p.Time()
+	data := map[string]any{
+		"cron":         p.Expression,
+		"scheduled_at": tick.UTC().Format(time.RFC3339Nano),
+		"fired_at":     firedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if p.Timezone != "" {
+		data["timezone"] = p.Timezone
+	}
+	if p.Jitter > 0 {
+		data["jitter"] = p.Jitter.String()
+	}
+	return event.Event{
+		Data:      data,
+		ID:        tick.UTC().Format(time.RFC3339),
+		Name:      event.FnCronName,
+		Timestamp: firedAt.UnixMilli(),
+	}
+}
+
+// Plan returns whether the tick at the given time should fire, along with the
+// time of the next tick to enqueue, following the given catch-up policy.
+func Plan(s cron.Schedule, tick, now time.Time, policy enums.CronCatchUp) (bool, time.Time) {
+	next := s.Next(tick)
+	if now.Sub(tick) <= MissedTickThreshold {
+		return true, next
+	}
+
+	switch policy {
+	case enums.CronCatchUpAll:
+		// Fire every missed tick in order.  The next tick may also be in the past,
+		// in which case it's enqueued immediately.
+		return true, next
+	case enums.CronCatchUpOnce:
+		// Only fire the most recent missed tick.
+		if next.After(now) {
+			return true, next
+		}
+		latest := next
+		for n := s.Next(latest); !n.After(now); n = s.Next(latest) {
+			latest = n
+		}
+		return false, latest
+	default:
+		return false, s.Next(now)
+	}
+}
@@ -0,0 +1,288 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/jonboulle/clockwork"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	sched, err := Parse("*/10 * * * *")
+	require.NoError(t, err)
+
+	tick := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		policy   enums.CronCatchUp
+		fire     bool
+		expected time.Time
+	}{
+		{
+			name:     "on time fires regardless of policy",
+			now:      tick.Add(5 * time.Second),
+			policy:   enums.CronCatchUpSkip,
+			fire:     true,
+			expected: tick.Add(10 * time.Minute),
+		},
+		{
+			name:     "missed tick is skipped",
+			now:      tick.Add(35 * time.Minute),
+			policy:   enums.CronCatchUpSkip,
+			fire:     false,
+			expected: tick.Add(40 * time.Minute),
+		},
+		{
+			name:     "missed ticks all fire in order",
+			now:      tick.Add(35 * time.Minute),
+			policy:   enums.CronCatchUpAll,
+			fire:     true,
+			expected: tick.Add(10 * time.Minute),
+		},
+		{
+			name:     "catch up once skips to the latest missed tick",
+			now:      tick.Add(35 * time.Minute),
+			policy:   enums.CronCatchUpOnce,
+			fire:     false,
+			expected: tick.Add(30 * time.Minute),
+		},
+		{
+			name:     "catch up once fires the latest missed tick",
+			now:      tick.Add(5 * time.Minute),
+			policy:   enums.CronCatchUpOnce,
+			fire:     true,
+			expected: tick.Add(10 * time.Minute),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fire, next := Plan(sched, tick, test.now, test.policy)
+			require.Equal(t, test.fire, fire)
+			require.Equal(t, test.expected, next.UTC())
+		})
+	}
+}
+
+func TestParseTimezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("expressions without a timezone use UTC", func(t *testing.T) {
+		sched, err := Parse("0 9 * * *")
+		require.NoError(t, err)
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, berlin)
+		require.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), sched.Next(from).UTC())
+	})
+
+	for _, prefix := range []string{"TZ=", "CRON_TZ="} {
+		t.Run("expressions with a "+prefix+" prefix use the timezone", func(t *testing.T) {
+			sched, err := Parse(prefix + "Europe/Berlin 0 9 * * *")
+			require.NoError(t, err)
+			from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			require.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, berlin), sched.Next(from).In(berlin))
+		})
+	}
+
+	t.Run("ticks skipped when clocks go forward fire after the transition", func(t *testing.T) {
+		// Clocks go from 02:00 to 03:00 on 2024-03-31.
+		sched, err := Parse("TZ=Europe/Berlin 30 2 * * *")
+		require.NoError(t, err)
+
+		from := time.Date(2024, 3, 30, 2, 30, 0, 0, berlin)
+		next := sched.Next(from)
+		require.Equal(t, time.Date(2024, 3, 31, 3, 0, 0, 0, berlin), next.In(berlin))
+		require.Equal(t, time.Date(2024, 4, 1, 2, 30, 0, 0, berlin), sched.Next(next).In(berlin))
+	})
+
+	t.Run("ticks repeated when clocks go back fire once", func(t *testing.T) {
+		// Clocks go from 03:00 back to 02:00 on 2024-10-27.
+		sched, err := Parse("TZ=Europe/Berlin 30 2 * * *")
+		require.NoError(t, err)
+
+		from := time.Date(2024, 10, 26, 2, 30, 0, 0, berlin)
+		next := sched.Next(from)
+		require.Equal(t, time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC), next.UTC())
+		require.Equal(t, time.Date(2024, 10, 28, 2, 30, 0, 0, berlin), sched.Next(next).In(berlin))
+	})
+
+	t.Run("hourly schedules run every elapsed hour", func(t *testing.T) {
+		sched, err := Parse("TZ=Europe/Berlin 0 * * * *")
+		require.NoError(t, err)
+
+		from := time.Date(2024, 10, 27, 0, 0, 0, 0, time.UTC)
+		next := sched.Next(from)
+		require.Equal(t, from.Add(time.Hour), next.UTC())
+		require.Equal(t, from.Add(2*time.Hour), sched.Next(next).UTC())
+	})
+}
+
+func TestJitter(t *testing.T) {
+	ci := CronItem{
+		FunctionID: uuid.New(),
+		Expression: "0 * * * *",
+		Jitter:     30 * time.Second,
+	}
+
+	offset := ci.Offset()
+	require.GreaterOrEqual(t, offset, time.Duration(0))
+	require.Less(t, offset, ci.Jitter)
+	require.Equal(t, offset, ci.Offset())
+
+	tick := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := CronPayload{CronItem: ci, At: tick.UnixMilli()}
+	require.Equal(t, tick.Add(offset), p.FireAt().UTC())
+
+	firedAt := tick.Add(offset + 15*time.Millisecond)
+	evt := p.Event(firedAt)
+	require.Equal(t, tick.Format(time.RFC3339), evt.ID)
+	require.Equal(t, tick.Format(time.RFC3339Nano), evt.Data["scheduled_at"])
+	require.Equal(t, firedAt.Format(time.RFC3339Nano), evt.Data["fired_at"])
+	require.Equal(t, "30s", evt.Data["jitter"])
+	require.Equal(t, firedAt.UnixMilli(), evt.Timestamp)
+
+	require.Zero(t, CronItem{FunctionID: ci.FunctionID, Expression: ci.Expression}.Offset())
+}
+
+func TestRedisCronManager(t *testing.T) {
+	r := miniredis.RunT(t)
+
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	unshardedClient := redis_state.NewUnshardedClient(rc, redis_state.StateDefaultKey, redis_state.QueueDefaultKey)
+	shard := redis_state.QueueShard{Name: consts.DefaultQueueShardName, RedisClient: unshardedClient.Queue(), Kind: string(enums.QueueShardKindRedis)}
+
+	q := redis_state.NewQueue(
+		shard,
+		redis_state.WithQueueShardClients(
+			map[string]redis_state.QueueShard{
+				shard.Name: shard,
+			},
+		),
+		redis_state.WithShardSelector(func(ctx context.Context, accountId uuid.UUID, queueName *string) (redis_state.QueueShard, error) {
+			return shard, nil
+		}),
+		redis_state.WithKindToQueueMapping(map[string]string{
+			queue.KindCron: queue.KindCron,
+		}),
+	)
+
+	clock := clockwork.NewFakeClockAt(time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC))
+	mgr := NewRedisCronManager(shard.RedisClient, q, WithClock(clock))
+
+	ctx := context.Background()
+	kg := shard.RedisClient.KeyGenerator()
+
+	ci := CronItem{
+		AccountID:       uuid.New(),
+		WorkspaceID:     uuid.New(),
+		AppID:           uuid.New(),
+		FunctionID:      uuid.New(),
+		FunctionVersion: 1,
+		Expression:      "* * * * *",
+	}
+
+	loadRecord := func(t *testing.T) nextFire {
+		val := r.HGet(kg.CronNextFire(ci.FunctionID), ci.field())
+		require.NotEmpty(t, val)
+		rec := nextFire{}
+		require.NoError(t, json.Unmarshal([]byte(val), &rec))
+		return rec
+	}
+
+	first := time.Date(2024, 1, 1, 12, 1, 0, 0, time.UTC)
+
+	t.Run("sync is idempotent", func(t *testing.T) {
+		require.NoError(t, mgr.Sync(ctx, ci))
+
+		// Syncing again, from another runner and later on, must not move the
+		// schedule or enqueue a second item.
+		clock.Advance(10 * time.Second)
+		require.NoError(t, mgr.Sync(ctx, ci))
+
+		keys, err := r.HKeys(kg.QueueItem())
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+
+		require.Equal(t, first.UnixMilli(), loadRecord(t).Next)
+	})
+
+	t.Run("next enqueues the following tick", func(t *testing.T) {
+		clock.Advance(20 * time.Second)
+
+		fire, err := mgr.Next(ctx, CronPayload{CronItem: ci, At: first.UnixMilli()})
+		require.NoError(t, err)
+		require.True(t, fire)
+
+		keys, err := r.HKeys(kg.QueueItem())
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		rec := loadRecord(t)
+		require.Equal(t, first.Add(time.Minute).UnixMilli(), rec.Next)
+		require.Equal(t, first.UnixMilli(), rec.Last)
+
+		// Retrying the same tick is a no-op.
+		_, err = mgr.Next(ctx, CronPayload{CronItem: ci, At: first.UnixMilli()})
+		require.NoError(t, err)
+		keys, err = r.HKeys(kg.QueueItem())
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+	})
+
+	t.Run("retries processed late reuse the first plan", func(t *testing.T) {
+		tick := first.Add(time.Minute)
+		clock.Advance(time.Minute)
+
+		fire, err := mgr.Next(ctx, CronPayload{CronItem: ci, At: tick.UnixMilli()})
+		require.NoError(t, err)
+		require.True(t, fire)
+
+		// Scheduling failed and the item is retried after the missed tick
+		// threshold.  The tick must still fire, and the next tick must not move.
+		clock.Advance(2 * MissedTickThreshold)
+		fire, err = mgr.Next(ctx, CronPayload{CronItem: ci, At: tick.UnixMilli()})
+		require.NoError(t, err)
+		require.True(t, fire)
+
+		rec := loadRecord(t)
+		require.Equal(t, tick.Add(time.Minute).UnixMilli(), rec.Next)
+		require.Equal(t, tick.UnixMilli(), rec.Last)
+
+		keys, err := r.HKeys(kg.QueueItem())
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+	})
+
+	t.Run("missed ticks are skipped by default", func(t *testing.T) {
+		clock.Advance(time.Hour)
+
+		tick := first.Add(2 * time.Minute)
+		fire, err := mgr.Next(ctx, CronPayload{CronItem: ci, At: tick.UnixMilli()})
+		require.NoError(t, err)
+		require.False(t, fire)
+
+		require.Equal(t, clock.Now().Truncate(time.Minute).Add(time.Minute).UnixMilli(), loadRecord(t).Next)
+	})
+
+	t.Run("remove deletes the record", func(t *testing.T) {
+		require.NoError(t, mgr.Remove(ctx, ci))
+		require.Empty(t, r.HGet(kg.CronNextFire(ci.FunctionID), ci.field()))
+	})
+}
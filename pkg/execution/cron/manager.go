@@ -0,0 +1,199 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/jonboulle/clockwork"
+)
+
+type CronManagerOpt func(m *cronManager)
+
+// WithCatchUp sets the policy used for ticks which were missed, eg. because all
+// runners were down.  Defaults to enums.CronCatchUpSkip.
+func WithCatchUp(p enums.CronCatchUp) CronManagerOpt {
+	return func(m *cronManager) {
+		m.catchUp = p
+	}
+}
+
+func WithLogger(l logger.Logger) CronManagerOpt {
+	return func(m *cronManager) {
+		m.log = l
+	}
+}
+
+func WithClock(c clockwork.Clock) CronManagerOpt {
+	return func(m *cronManager) {
+		m.c = c
+	}
+}
+
+// store persists the next-fire record for each cron trigger.
+type store interface {
+	// create stores the record if the cron has none, returning whether the
+	// record was created.
+	create(ctx context.Context, ci CronItem, rec nextFire) (bool, error)
+	// load returns the cron's record, or nil if the record doesn't exist.
+	load(ctx context.Context, ci CronItem) (*nextFire, error)
+	// save stores the cron's record, overwriting any existing record.
+	save(ctx context.Context, ci CronItem, rec nextFire) error
+	// remove deletes the cron's record.
+	remove(ctx context.Context, ci CronItem) error
+}
+
+func newCronManager(s store, q redis_state.QueueManager, opts ...CronManagerOpt) CronManager {
+	manager := &cronManager{
+		c:       clockwork.NewRealClock(),
+		s:       s,
+		q:       q,
+		catchUp: enums.CronCatchUpSkip,
+		log:     logger.StdlibLogger(context.Background()),
+	}
+
+	for _, apply := range opts {
+		apply(manager)
+	}
+
+	return manager
+}
+
+type cronManager struct {
+	c clockwork.Clock
+	s store
+	q redis_state.QueueManager
+
+	catchUp enums.CronCatchUp
+	log     logger.Logger
+}
+
+// nextFire is the record stored for each cron trigger.
+type nextFire struct {
+	// Next is the time of the next tick which has been enqueued, in unix
+	// milliseconds.
+	Next int64 `json:"next"`
+	// Last is the time of the last tick which was processed, in unix
+	// milliseconds.
+	Last int64 `json:"last,omitempty"`
+	// Fire is whether the last tick fired.
+	Fire bool `json:"fire,omitempty"`
+}
+
+func (m *cronManager) Sync(ctx context.Context, ci CronItem) error {
+	sched, err := Parse(ci.Expression)
+	if err != nil {
+		return fmt.Errorf("error parsing cron expression '%s': %w", ci.Expression, err)
+	}
+
+	rec := nextFire{Next: sched.Next(m.c.Now()).UnixMilli()}
+
+	// Only create the record if it doesn't exist, so that syncing never moves an
+	// existing schedule.
+	created, err := m.s.create(ctx, ci, rec)
+	if err != nil {
+		return err
+	}
+	if !created {
+		existing, err := m.s.load(ctx, ci)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			rec = *existing
+		}
+	}
+
+	return m.enqueue(ctx, CronPayload{CronItem: ci, At: rec.Next})
+}
+
+func (m *cronManager) Next(ctx context.Context, p CronPayload) (bool, error) {
+	sched, err := Parse(p.Expression)
+	if err != nil {
+		return false, fmt.Errorf("error parsing cron expression '%s': %w", p.Expression, err)
+	}
+
+	// Retries of a tick reuse the plan recorded when the tick was first
+	// processed.  Planning again could skip the tick or move the next tick if
+	// the retry is processed after the missed tick threshold.
+	rec, err := m.s.load(ctx, p.CronItem)
+	if err != nil {
+		return false, err
+	}
+	if rec == nil || rec.Last != p.At {
+		// Jitter delays when the tick fires, so offset the current time to
+		// check whether the tick was missed.
+		fire, next := Plan(sched, p.Time(), m.c.Now().Add(-p.Offset()), m.catchUp)
+		rec = &nextFire{Next: next.UnixMilli(), Last: p.At, Fire: fire}
+		if err := m.s.save(ctx, p.CronItem, *rec); err != nil {
+			return false, err
+		}
+	}
+	fire, next := rec.Fire, time.UnixMilli(rec.Next)
+
+	// Enqueue the next tick after recording it.  If enqueueing fails the item
+	// is retried and enqueues the recorded tick, and the deterministic job ID
+	// prevents the next tick from being enqueued twice.
+	if err := m.enqueue(ctx, CronPayload{CronItem: p.CronItem, At: rec.Next}); err != nil {
+		return false, err
+	}
+
+	if !fire {
+		m.log.Debug("skipping missed cron tick",
+			"function_id", p.FunctionID,
+			"expression", p.Expression,
+			"tick", p.Time(),
+			"next", next,
+			"catch_up", m.catchUp.String(),
+		)
+	}
+
+	return fire, nil
+}
+
+func (m *cronManager) Remove(ctx context.Context, ci CronItem) error {
+	return m.s.remove(ctx, ci)
+}
+
+// enqueue enqueues the queue item for the given tick.  Each tick has a
+// deterministic job ID, so enqueueing the same tick more than once is a no-op.
+func (m *cronManager) enqueue(ctx context.Context, p CronPayload) error {
+	jobID := p.JobID()
+	maxAttempts := consts.MaxRetries + 1
+
+	queueName := queue.KindCron
+	err := m.q.Enqueue(ctx, queue.Item{
+		JobID:       &jobID,
+		GroupID:     uuid.New().String(),
+		WorkspaceID: p.WorkspaceID,
+		Kind:        queue.KindCron,
+		Identifier: state.Identifier{
+			WorkflowID:      p.FunctionID,
+			WorkflowVersion: p.FunctionVersion,
+			Key:             fmt.Sprintf("cron:%s:%d", p.field(), p.At),
+			AccountID:       p.AccountID,
+			WorkspaceID:     p.WorkspaceID,
+			AppID:           p.AppID,
+		},
+		Attempt:     0,
+		MaxAttempts: &maxAttempts,
+		Payload:     p,
+		QueueName:   &queueName,
+	}, p.FireAt(), queue.EnqueueOpts{})
+	if err == redis_state.ErrQueueItemExists {
+		m.log.Debug("queue item already exists for cron tick", "job_id", jobID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error enqueueing cron tick: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+)
+
+// NewPostgresCronManager returns a CronManager which stores next-fire records in
+// the cron_next_fires table.
+func NewPostgresCronManager(db *sql.DB, q redis_state.QueueManager, opts ...CronManagerOpt) CronManager {
+	return newCronManager(&postgresStore{db: db}, q, opts...)
+}
+
+type postgresStore struct {
+	db *sql.DB
+}
+
+func (s *postgresStore) create(ctx context.Context, ci CronItem, rec nextFire) (bool, error) {
+	byt, err := json.Marshal(rec)
+	if err != nil {
+		return false, fmt.Errorf("error marshalling cron record: %w", err)
+	}
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO cron_next_fires (function_id, field, record) VALUES ($1, $2, $3)
+		ON CONFLICT (function_id, field) DO NOTHING`,
+		ci.FunctionID, ci.field(), string(byt),
+	)
+	if err != nil {
+		return false, fmt.Errorf("error creating cron record: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (s *postgresStore) load(ctx context.Context, ci CronItem) (*nextFire, error) {
+	var byt string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT record FROM cron_next_fires WHERE function_id = $1 AND field = $2`,
+		ci.FunctionID, ci.field(),
+	).Scan(&byt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading cron record: %w", err)
+	}
+
+	rec := &nextFire{}
+	if err := json.Unmarshal([]byte(byt), rec); err != nil {
+		return nil, fmt.Errorf("error unmarshalling cron record: %w", err)
+	}
+	return rec, nil
+}
+
+func (s *postgresStore) save(ctx context.Context, ci CronItem, rec nextFire) error {
+	byt, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("error marshalling cron record: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO cron_next_fires (function_id, field, record) VALUES ($1, $2, $3)
+		ON CONFLICT (function_id, field) DO UPDATE SET record = EXCLUDED.record`,
+		ci.FunctionID, ci.field(), string(byt),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating cron record: %w", err)
+	}
+	return nil
+}
+
+func (s *postgresStore) remove(ctx context.Context, ci CronItem) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM cron_next_fires WHERE function_id = $1 AND field = $2`,
+		ci.FunctionID, ci.field(),
+	)
+	if err != nil {
+		return fmt.Errorf("error removing cron record: %w", err)
+	}
+	return nil
+}
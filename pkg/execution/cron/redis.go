@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/redis/rueidis"
+)
+
+// NewRedisCronManager returns a CronManager which stores next-fire records in
+// redis.
+func NewRedisCronManager(c *redis_state.QueueClient, q redis_state.QueueManager, opts ...CronManagerOpt) CronManager {
+	return newCronManager(&redisStore{r: c}, q, opts...)
+}
+
+type redisStore struct {
+	r *redis_state.QueueClient
+}
+
+func (s *redisStore) create(ctx context.Context, ci CronItem, rec nextFire) (bool, error) {
+	byt, err := json.Marshal(rec)
+	if err != nil {
+		return false, fmt.Errorf("error marshalling cron record: %w", err)
+	}
+
+	client := s.r.Client()
+	key := s.r.KeyGenerator().CronNextFire(ci.FunctionID)
+	created, err := client.Do(ctx, client.B().Hsetnx().Key(key).Field(ci.field()).Value(string(byt)).Build()).AsBool()
+	if err != nil {
+		return false, fmt.Errorf("error creating cron record: %w", err)
+	}
+	return created, nil
+}
+
+func (s *redisStore) load(ctx context.Context, ci CronItem) (*nextFire, error) {
+	client := s.r.Client()
+	key := s.r.KeyGenerator().CronNextFire(ci.FunctionID)
+	byt, err := client.Do(ctx, client.B().Hget().Key(key).Field(ci.field()).Build()).AsBytes()
+	if rueidis.IsRedisNil(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading cron record: %w", err)
+	}
+
+	rec := &nextFire{}
+	if err := json.Unmarshal(byt, rec); err != nil {
+		return nil, fmt.Errorf("error unmarshalling cron record: %w", err)
+	}
+	return rec, nil
+}
+
+func (s *redisStore) save(ctx context.Context, ci CronItem, rec nextFire) error {
+	byt, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("error marshalling cron record: %w", err)
+	}
+
+	client := s.r.Client()
+	key := s.r.KeyGenerator().CronNextFire(ci.FunctionID)
+	if err := client.Do(ctx, client.B().Hset().Key(key).FieldValue().FieldValue(ci.field(), string(byt)).Build()).Error(); err != nil {
+		return fmt.Errorf("error updating cron record: %w", err)
+	}
+	return nil
+}
+
+func (s *redisStore) remove(ctx context.Context, ci CronItem) error {
+	client := s.r.Client()
+	key := s.r.KeyGenerator().CronNextFire(ci.FunctionID)
+	if err := client.Do(ctx, client.B().Hdel().Key(key).Field(ci.field()).Build()).Error(); err != nil {
+		return fmt.Errorf("error removing cron record: %w", err)
+	}
+	return nil
+}
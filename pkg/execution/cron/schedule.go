@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// allHours is the hour bitmask of a schedule which runs every hour.
+const allHours = 1<<24 - 1
+
+// Parse parses the given cron expression.  Expressions may be prefixed with
+// "TZ=" or "CRON_TZ=" to run in a specific timezone, and are otherwise
+// evaluated in UTC.
+//
+// Schedules which run at specific hours in a timezone with daylight saving time
+// follow the same rules as Vixie cron:  ticks within the skipped hour when
+// clocks go forward fire as soon as the hour is skipped, and ticks within the
+// repeated hour when clocks go back only fire once.  Schedules which run every
+// hour are unaffected, as they run once per elapsed hour.
+func Parse(expr string) (cron.Schedule, error) {
+	sched, err := parser.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, ok := sched.(*cron.SpecSchedule)
+	if !ok {
+		return sched, nil
+	}
+	if spec.Location == time.Local {
+		spec.Location = time.UTC
+	}
+	if spec.Location == time.UTC || spec.Hour&allHours == allHours {
+		return spec, nil
+	}
+	return dstSchedule{spec: spec}, nil
+}
+
+// dstSchedule wraps a schedule with fixed hours, handling daylight saving time
+// transitions in the schedule's timezone.
+type dstSchedule struct {
+	spec *cron.SpecSchedule
+}
+
+func (s dstSchedule) Next(t time.Time) time.Time {
+	n := s.spec.Next(t)
+	for !n.IsZero() && s.repeated(n) {
+		n = s.spec.Next(n)
+	}
+	if n.IsZero() {
+		return n
+	}
+	if at, ok := s.skipped(t, n); ok {
+		return at.In(t.Location())
+	}
+	return n
+}
+
+// repeated returns whether the wall clock time of n already occurred earlier,
+// because clocks went back.
+func (s dstSchedule) repeated(n time.Time) bool {
+	_, off := n.In(s.spec.Location).Zone()
+	_, prev := n.Add(-24 * time.Hour).In(s.spec.Location).Zone()
+	if prev <= off {
+		return false
+	}
+
+	earlier := n.Add(-time.Duration(prev-off) * time.Second).In(s.spec.Location)
+	wall := n.In(s.spec.Location)
+	return earlier.Day() == wall.Day() && earlier.Hour() == wall.Hour() && earlier.Minute() == wall.Minute()
+}
+
+// skipped returns the time at which clocks went forward between t and n if the
+// schedule has a tick within the skipped wall clock times.
+func (s dstSchedule) skipped(t, n time.Time) (time.Time, bool) {
+	loc := s.spec.Location
+	_, before := t.In(loc).Zone()
+	_, after := n.In(loc).Zone()
+	if after <= before {
+		return time.Time{}, false
+	}
+
+	// Find the transition, which always happens on a minute boundary.
+	lo, hi := t, n
+	for hi.Sub(lo) > time.Minute {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, off := mid.In(loc).Zone(); off == before {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	transition := hi.Truncate(time.Minute)
+	if _, off := transition.In(loc).Zone(); off == before {
+		transition = hi
+	}
+
+	// Evaluate the schedule against the skipped wall clock times, using UTC as a
+	// stand-in for the wall clock.
+	start := transition.Add(time.Duration(before) * time.Second).In(time.UTC)
+	start = time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+	end := start.Add(time.Duration(after-before) * time.Second)
+
+	wall := *s.spec
+	wall.Location = time.UTC
+	if m := wall.Next(start.Add(-time.Second)); !m.IsZero() && m.Before(end) && transition.After(t) {
+		return transition, true
+	}
+	return time.Time{}, false
+}
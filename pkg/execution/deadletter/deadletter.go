@@ -0,0 +1,233 @@
+package deadletter
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution"
+	"github.com/inngest/inngest/pkg/execution/executor"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	statev1 "github.com/inngest/inngest/pkg/execution/state"
+	statev2 "github.com/inngest/inngest/pkg/execution/state/v2"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+)
+
+var (
+	// ErrAlreadyRedriven is returned when redriving a dead letter which has
+	// already been redriven.
+	ErrAlreadyRedriven = fmt.Errorf("dead letter has already been redriven")
+)
+
+// NewLifecycleListener returns a lifecycle listener which records a dead
+// letter for every function run that permanently fails.
+func NewLifecycleListener(w cqrs.DeadLetterWriter) execution.LifecycleListener {
+	return lifecycle{w: w}
+}
+
+type lifecycle struct {
+	execution.NoopLifecyceListener
+
+	w cqrs.DeadLetterWriter
+}
+
+func (l lifecycle) OnFunctionFinished(
+	ctx context.Context,
+	md statev2.Metadata,
+	_ queue.Item,
+	events []json.RawMessage,
+	resp statev1.DriverResponse,
+) {
+	if resp.Err == nil {
+		return
+	}
+
+	dl := cqrs.DeadLetter{
+		ID:              ulid.MustNew(ulid.Now(), rand.Reader),
+		AccountID:       md.ID.Tenant.AccountID,
+		WorkspaceID:     md.ID.Tenant.EnvID,
+		AppID:           md.ID.Tenant.AppID,
+		FunctionID:      md.ID.FunctionID,
+		FunctionVersion: md.Config.FunctionVersion,
+		RunID:           md.ID.RunID,
+		EventIDs:        md.Config.EventIDs,
+		Events:          events,
+		Error:           resp.Error(),
+		FailedAt:        time.Now(),
+	}
+	if resp.Step.ID != "" {
+		dl.StepID = &resp.Step.ID
+	}
+	if resp.Step.Name != "" {
+		dl.StepName = &resp.Step.Name
+	}
+
+	if err := l.w.InsertDeadLetter(ctx, dl); err != nil {
+		logger.StdlibLogger(ctx).Error(
+			"error recording dead letter",
+			"error", err,
+			"run_id", md.ID.RunID,
+			"function_id", md.ID.FunctionID,
+		)
+	}
+}
+
+// Filter selects the dead letters to redrive in bulk.
+type Filter struct {
+	// FunctionID only redrives dead letters for the given function.
+	FunctionID *uuid.UUID `json:"function_id,omitempty"`
+	// FailedAfter only redrives runs which failed after the given time.
+	FailedAfter time.Time `json:"failed_after,omitempty"`
+	// FailedBefore only redrives runs which failed before the given time.
+	FailedBefore time.Time `json:"failed_before,omitempty"`
+}
+
+// Redriver schedules new runs for dead letters.
+type Redriver struct {
+	DeadLetters cqrs.DeadLetterReadWriter
+	Functions   cqrs.FunctionReader
+	// Runs finds the run scheduled by a previous attempt to redrive a dead
+	// letter.
+	Runs     cqrs.APIV1FunctionRunReader
+	Executor execution.Executor
+}
+
+// Redrive schedules a new run using the events of the given dead letter,
+// returning the new run's ID.  Each dead letter can only be redriven once.
+func (r Redriver) Redrive(ctx context.Context, dl cqrs.DeadLetter) (ulid.ULID, error) {
+	if dl.IsRedriven() {
+		return ulid.ULID{}, ErrAlreadyRedriven
+	}
+	if len(dl.Events) != len(dl.EventIDs) {
+		return ulid.ULID{}, fmt.Errorf("dead letter has %d events and %d event IDs", len(dl.Events), len(dl.EventIDs))
+	}
+
+	fnCQRS, err := r.Functions.GetFunctionByInternalUUID(ctx, dl.WorkspaceID, dl.FunctionID)
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("error loading function: %w", err)
+	}
+	fn, err := fnCQRS.InngestFunction()
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("error loading function: %w", err)
+	}
+
+	evts := make([]event.TrackedEvent, len(dl.Events))
+	for i, byt := range dl.Events {
+		evt, err := event.NewEvent(byt)
+		if err != nil {
+			return ulid.ULID{}, fmt.Errorf("error unmarshalling dead letter event: %w", err)
+		}
+		evts[i] = event.NewOSSTrackedEventWithID(*evt, dl.EventIDs[i])
+	}
+
+	// Use the dead letter's ID as the idempotency key so that concurrent
+	// redrives of the same dead letter only schedule a single run.
+	key := dl.ID.String()
+	md, err := r.Executor.Schedule(ctx, execution.ScheduleRequest{
+		Function:       *fn,
+		AccountID:      dl.AccountID,
+		WorkspaceID:    dl.WorkspaceID,
+		AppID:          dl.AppID,
+		Events:         evts,
+		OriginalRunID:  &dl.RunID,
+		IdempotencyKey: &key,
+	})
+
+	var runID ulid.ULID
+	switch {
+	case err == nil:
+		runID = md.ID.RunID
+	case errors.Is(err, statev1.ErrIdentifierExists) || errors.Is(err, executor.ErrFunctionSkippedIdempotency):
+		// A previous redrive scheduled the run but failed to record it, so
+		// record the existing run instead.
+		if runID, err = r.redriven(ctx, dl); err != nil {
+			return ulid.ULID{}, err
+		}
+	default:
+		return ulid.ULID{}, err
+	}
+
+	if err := r.DeadLetters.UpdateDeadLetterRedrive(ctx, dl.WorkspaceID, dl.ID, runID); err != nil {
+		return runID, fmt.Errorf("error updating dead letter: %w", err)
+	}
+	return runID, nil
+}
+
+// redriven returns the ID of the run previously scheduled for the dead letter.
+func (r Redriver) redriven(ctx context.Context, dl cqrs.DeadLetter) (ulid.ULID, error) {
+	if r.Runs == nil {
+		return ulid.ULID{}, fmt.Errorf("dead letter was already redriven but its run can't be loaded")
+	}
+
+	runs, err := r.Runs.GetFunctionRunsFromEvents(ctx, dl.AccountID, dl.WorkspaceID, dl.EventIDs)
+	if err != nil {
+		return ulid.ULID{}, fmt.Errorf("error loading redriven run: %w", err)
+	}
+
+	var found *ulid.ULID
+	for _, run := range runs {
+		if run.FunctionID != dl.FunctionID || run.OriginalRunID == nil || *run.OriginalRunID != dl.RunID {
+			continue
+		}
+		// Use the latest run, in case the failed run was also rerun.
+		if found == nil || run.RunID.Compare(*found) > 0 {
+			found = &run.RunID
+		}
+	}
+	if found == nil {
+		return ulid.ULID{}, fmt.Errorf("dead letter was already redriven but its run was not found")
+	}
+	return *found, nil
+}
+
+// RedriveResult is the result of redriving a single dead letter in bulk.
+type RedriveResult struct {
+	ID    ulid.ULID  `json:"id"`
+	RunID *ulid.ULID `json:"run_id,omitempty"`
+	Error string     `json:"error,omitempty"`
+}
+
+// RedriveMany redrives every dead letter in the workspace which matches the
+// given filter and hasn't already been redriven.
+func (r Redriver) RedriveMany(ctx context.Context, wsID uuid.UUID, f Filter) ([]RedriveResult, error) {
+	opts := &cqrs.DeadLettersOpts{
+		Limit:      cqrs.MaxDeadLetters,
+		FunctionID: f.FunctionID,
+		Newest:     f.FailedBefore,
+		Oldest:     f.FailedAfter,
+	}
+
+	results := []RedriveResult{}
+	for {
+		page, err := r.DeadLetters.DeadLetters(ctx, wsID, opts)
+		if err != nil {
+			return results, err
+		}
+
+		for _, dl := range page {
+			if dl.IsRedriven() {
+				continue
+			}
+			res := RedriveResult{ID: dl.ID}
+			runID, err := r.Redrive(ctx, dl)
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				res.RunID = &runID
+			}
+			results = append(results, res)
+		}
+
+		if len(page) < opts.Limit {
+			return results, nil
+		}
+		opts.Cursor = &page[len(page)-1].ID
+	}
+}
@@ -0,0 +1,111 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/execution"
+	"github.com/inngest/inngest/pkg/execution/state"
+	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type redriveStore struct {
+	cqrs.DeadLetterReadWriter
+
+	// failUpdates is the number of updates which fail before succeeding.
+	failUpdates int
+	redriven    map[ulid.ULID]ulid.ULID
+}
+
+func (s *redriveStore) UpdateDeadLetterRedrive(ctx context.Context, wsID uuid.UUID, id ulid.ULID, runID ulid.ULID) error {
+	if s.failUpdates > 0 {
+		s.failUpdates--
+		return errors.New("update failed")
+	}
+	s.redriven[id] = runID
+	return nil
+}
+
+type redriveFunctions struct {
+	cqrs.FunctionReader
+}
+
+func (redriveFunctions) GetFunctionByInternalUUID(ctx context.Context, wsID uuid.UUID, fnID uuid.UUID) (*cqrs.Function, error) {
+	config := fmt.Sprintf(`{"id":%q,"name":"fn"}`, fnID)
+	return &cqrs.Function{ID: fnID, Config: json.RawMessage(config)}, nil
+}
+
+// redriveExecutor schedules runs idempotently, recording every scheduled run
+// in runs.
+type redriveExecutor struct {
+	execution.Executor
+
+	keys map[string]bool
+	runs []*cqrs.FunctionRun
+}
+
+func (e *redriveExecutor) Schedule(ctx context.Context, req execution.ScheduleRequest) (*sv2.Metadata, error) {
+	if e.keys[*req.IdempotencyKey] {
+		return nil, state.ErrIdentifierExists
+	}
+	e.keys[*req.IdempotencyKey] = true
+
+	runID := ulid.Make()
+	e.runs = append(e.runs, &cqrs.FunctionRun{
+		RunID:         runID,
+		FunctionID:    req.Function.ID,
+		OriginalRunID: req.OriginalRunID,
+	})
+	return &sv2.Metadata{ID: sv2.ID{RunID: runID}}, nil
+}
+
+func (e *redriveExecutor) GetFunctionRunsFromEvents(ctx context.Context, accountID uuid.UUID, workspaceID uuid.UUID, eventIDs []ulid.ULID) ([]*cqrs.FunctionRun, error) {
+	return e.runs, nil
+}
+
+func (e *redriveExecutor) GetFunctionRun(ctx context.Context, accountID uuid.UUID, workspaceID uuid.UUID, id ulid.ULID) (*cqrs.FunctionRun, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRedriveRetriesAfterUpdateFailure(t *testing.T) {
+	ctx := context.Background()
+
+	store := &redriveStore{failUpdates: 1, redriven: map[ulid.ULID]ulid.ULID{}}
+	exec := &redriveExecutor{keys: map[string]bool{}}
+	r := Redriver{
+		DeadLetters: store,
+		Functions:   redriveFunctions{},
+		Runs:        exec,
+		Executor:    exec,
+	}
+
+	dl := cqrs.DeadLetter{
+		ID:          ulid.Make(),
+		WorkspaceID: uuid.New(),
+		FunctionID:  uuid.New(),
+		RunID:       ulid.Make(),
+		EventIDs:    []ulid.ULID{ulid.Make()},
+		Events:      []json.RawMessage{json.RawMessage(`{"name":"test/event","data":{}}`)},
+	}
+	// Runs of other functions triggered by the same events are ignored.
+	exec.runs = append(exec.runs, &cqrs.FunctionRun{RunID: ulid.Make(), FunctionID: uuid.New(), OriginalRunID: &dl.RunID})
+
+	// The run is scheduled, but recording the redrive fails.
+	_, err := r.Redrive(ctx, dl)
+	require.ErrorContains(t, err, "update failed")
+	require.Len(t, exec.runs, 2)
+
+	// Retrying records the existing run instead of failing on the idempotency key.
+	runID, err := r.Redrive(ctx, dl)
+	require.NoError(t, err)
+	require.Len(t, exec.runs, 2)
+	require.Equal(t, exec.runs[1].RunID, runID)
+	require.Equal(t, runID, store.redriven[dl.ID])
+}
@@ -147,6 +147,10 @@ type Debouncer interface {
 	GetDebounceItem(ctx context.Context, debounceID ulid.ULID, accountID uuid.UUID) (*DebounceItem, error)
 	DeleteDebounceItem(ctx context.Context, debounceID ulid.ULID, d DebounceItem, accountID uuid.UUID) error
 	StartExecution(ctx context.Context, d DebounceItem, fn inngest.Function, debounceID ulid.ULID) error
+	// Leading records an event for a function using leading-edge debouncing,
+	// returning true if the function should run immediately with the event, or
+	// false if the event is within the current debounce window and is skipped.
+	Leading(ctx context.Context, d DebounceItem, fn inngest.Function) (bool, error)
 }
 
 func NewRedisDebouncer(primaryDebounceClient *redis_state.DebounceClient, primaryQueueShard redis_state.QueueShard, primaryQueueManager redis_state.QueueManager) Debouncer {
@@ -401,7 +405,7 @@ func (d debouncer) GetDebounceItem(ctx context.Context, debounceID ulid.ULID, ac
 
 // StartExecution swaps out the underlying pointer of the debounce
 func (d debouncer) StartExecution(ctx context.Context, di DebounceItem, fn inngest.Function, debounceID ulid.ULID) error {
-	dkey, err := d.debounceKey(ctx, di, fn)
+	dkey, err := debounceKey(ctx, di, fn)
 	if err != nil {
 		return err
 	}
@@ -515,6 +519,63 @@ func (d debouncer) Debounce(ctx context.Context, di DebounceItem, fn inngest.Fun
 	return d.debounce(ctx, di, fn, ttl, 0, shouldMigrate)
 }
 
+// Leading records an event for a function using leading-edge debouncing.  The
+// first event opens a window which is extended by the debounce period with every
+// skipped event, up to the debounce's timeout.  Once the window closes, the next
+// event runs the function.
+func (d debouncer) Leading(ctx context.Context, di DebounceItem, fn inngest.Function) (bool, error) {
+	if fn.Debounce == nil {
+		return false, fmt.Errorf("fn has no debounce config")
+	}
+	ttl, err := str2duration.ParseDuration(fn.Debounce.Period)
+	if err != nil {
+		return false, fmt.Errorf("invalid debounce duration: %w", err)
+	}
+	var timeout time.Duration
+	if t := fn.Debounce.TimeoutDuration(); t != nil {
+		timeout = *t
+	}
+
+	key, err := debounceKey(ctx, di, fn)
+	if err != nil {
+		return false, err
+	}
+
+	shouldMigrate := d.shouldMigrate(ctx, di.AccountID)
+	client := d.client(shouldMigrate)
+	if client == nil {
+		return false, fmt.Errorf("client did not return DebounceClient")
+	}
+
+	out, err := scripts["leadingDebounce"].Exec(
+		ctx,
+		client.Client(),
+		[]string{client.KeyGenerator().DebounceLeading(ctx, fn.ID, key)},
+		[]string{
+			strconv.Itoa(int(ttl.Milliseconds())),
+			strconv.Itoa(int(d.c.Now().UnixMilli())),
+			strconv.Itoa(int(timeout.Milliseconds())),
+		},
+	).AsInt64()
+	if err != nil {
+		return false, fmt.Errorf("error updating leading debounce: %w", err)
+	}
+
+	op := "leading_skipped"
+	if out == 1 {
+		op = "leading_started"
+	}
+	metrics.IncrQueueDebounceOperationCounter(ctx, metrics.CounterOpt{
+		PkgName: pkgName,
+		Tags: map[string]any{
+			"op":          op,
+			"queue_shard": d.queueShard(shouldMigrate).Name,
+		},
+	})
+
+	return out == 1, nil
+}
+
 func (d debouncer) debounce(ctx context.Context, di DebounceItem, fn inngest.Function, ttl time.Duration, n int, shouldMigrate bool) error {
 	newDebounceID := ulid.MustNew(ulid.Timestamp(d.c.Now()), rand.Reader)
 	var foundDebounce bool
@@ -523,7 +584,7 @@ func (d debouncer) debounce(ctx context.Context, di DebounceItem, fn inngest.Fun
 		"fn_id", di.FunctionID.String(),
 		"evt_id", di.EventID.String(),
 		"ttl", ttl,
-		"timeout", fn.Debounce.Timeout,
+		"timeout", fn.Debounce.TimeoutDuration(),
 		"primary_shard_name", d.primaryQueueShard.Name,
 		"secondary_shard_name", d.secondaryQueueShard.Name,
 	)
@@ -642,7 +703,7 @@ func (d debouncer) debounce(ctx context.Context, di DebounceItem, fn inngest.Fun
 	return err
 }
 
-func (d debouncer) queueItem(ctx context.Context, di DebounceItem, debounceID ulid.ULID) queue.Item {
+func queueItem(ctx context.Context, di DebounceItem, debounceID ulid.ULID) queue.Item {
 	maxAttempts := consts.MaxRetries + 1
 
 	jobID := debounceID.String()
@@ -666,7 +727,7 @@ func (d debouncer) queueItem(ctx context.Context, di DebounceItem, debounceID ul
 func (d debouncer) newDebounce(ctx context.Context, di DebounceItem, fn inngest.Function, ttl time.Duration, shouldMigrate bool, newDebounceID ulid.ULID) (*ulid.ULID, error) {
 	now := d.c.Now()
 
-	key, err := d.debounceKey(ctx, di, fn)
+	key, err := debounceKey(ctx, di, fn)
 	if err != nil {
 		return nil, err
 	}
@@ -722,7 +783,7 @@ func (d debouncer) newDebounce(ctx context.Context, di DebounceItem, fn inngest.
 		// attempt to start a debounce during the debounce's expiry (race conditions), and the extra
 		// second lets an updateDebounce call on TTL 0 finish, as the buffer is the updateDebounce
 		// deadline.
-		qi := d.queueItem(ctx, di, newDebounceID)
+		qi := queueItem(ctx, di, newDebounceID)
 
 		queueManager := d.queueManager(shouldMigrate)
 		if queueManager == nil {
@@ -766,7 +827,7 @@ func (d debouncer) prepareMigration(ctx context.Context, di DebounceItem, fn inn
 	now := d.c.Now()
 	fakeDebounceID := ulid.MustNew(ulid.Timestamp(now), rand.Reader)
 
-	key, err := d.debounceKey(ctx, di, fn)
+	key, err := debounceKey(ctx, di, fn)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -837,7 +898,7 @@ func (d debouncer) prepareMigration(ctx context.Context, di DebounceItem, fn inn
 func (d debouncer) updateDebounce(ctx context.Context, di DebounceItem, fn inngest.Function, ttl time.Duration, debounceID ulid.ULID, shouldMigrate bool) error {
 	now := d.c.Now()
 
-	key, err := d.debounceKey(ctx, di, fn)
+	key, err := debounceKey(ctx, di, fn)
 	if err != nil {
 		return err
 	}
@@ -901,7 +962,7 @@ func (d debouncer) updateDebounce(ctx context.Context, di DebounceItem, fn innge
 	case -3:
 		// The queue item is not found with the debounceID
 		// enqueue a new item
-		qi := d.queueItem(ctx, di, debounceID)
+		qi := queueItem(ctx, di, debounceID)
 
 		return queueManager.Enqueue(ctx, qi, now.Add(ttl).Add(buffer).Add(time.Second), queue.EnqueueOpts{
 			// Debounce timeout items must live on the same Redis instance as the state.
@@ -941,7 +1002,7 @@ func (d debouncer) updateDebounce(ctx context.Context, di DebounceItem, fn innge
 	}
 }
 
-func (d debouncer) debounceKey(ctx context.Context, evt event.TrackedEvent, fn inngest.Function) (string, error) {
+func debounceKey(ctx context.Context, evt event.TrackedEvent, fn inngest.Function) (string, error) {
 	if fn.Debounce.Key == nil {
 		return fn.ID.String(), nil
 	}
@@ -1807,3 +1807,253 @@ func TestDebounceExecutionShouldNotRaceMigration(t *testing.T) {
 		require.False(t, unshardedCluster.Exists(unshardedDebounceClient.KeyGenerator().DebounceMigrating(ctx)))
 	})
 }
+
+// TestDebounceMaxWait ensures that a continuous stream of events can't extend a
+// debounce past its maxWait.
+func TestDebounceMaxWait(t *testing.T) {
+	unshardedCluster := miniredis.RunT(t)
+
+	unshardedRc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{unshardedCluster.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	unshardedClient := redis_state.NewUnshardedClient(unshardedRc, redis_state.StateDefaultKey, redis_state.QueueDefaultKey)
+	debounceClient := unshardedClient.Debounce()
+
+	defaultQueueShard := redis_state.QueueShard{Name: consts.DefaultQueueShardName, RedisClient: unshardedClient.Queue(), Kind: string(enums.QueueShardKindRedis)}
+
+	q := redis_state.NewQueue(
+		defaultQueueShard,
+		redis_state.WithQueueShardClients(
+			map[string]redis_state.QueueShard{
+				defaultQueueShard.Name: defaultQueueShard,
+			},
+		),
+		redis_state.WithShardSelector(func(ctx context.Context, accountId uuid.UUID, queueName *string) (redis_state.QueueShard, error) {
+			return defaultQueueShard, nil
+		}),
+		redis_state.WithKindToQueueMapping(map[string]string{
+			queue.KindDebounce: queue.KindDebounce,
+		}),
+	)
+
+	fakeClock := clockwork.NewFakeClock()
+
+	redisDebouncer := NewRedisDebouncer(debounceClient, defaultQueueShard, q).(debouncer)
+	redisDebouncer.c = fakeClock
+
+	ctx := context.Background()
+	accountId, workspaceId, appId, functionId := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	fn := inngest.Function{
+		ID: functionId,
+		Debounce: &inngest.Debounce{
+			Period:  "10s",
+			MaxWait: util.StrPtr("25s"),
+		},
+	}
+
+	evt0Time := fakeClock.Now()
+	keyPtr := debounceClient.KeyGenerator().DebouncePointer(ctx, functionId, functionId.String())
+	kg := defaultQueueShard.RedisClient.KeyGenerator()
+
+	send := func(t *testing.T) {
+		eventTime := fakeClock.Now()
+		eventId := ulid.MustNew(ulid.Timestamp(eventTime), rand.Reader)
+		err := redisDebouncer.Debounce(ctx, DebounceItem{
+			AccountID:   accountId,
+			WorkspaceID: workspaceId,
+			AppID:       appId,
+			FunctionID:  functionId,
+			EventID:     eventId,
+			Event: event.Event{
+				Name:      "test-data",
+				ID:        eventId.String(),
+				Timestamp: eventTime.UnixMilli(),
+			},
+		}, fn)
+		require.NoError(t, err)
+	}
+
+	queueScore := func(t *testing.T) int64 {
+		queueItemIds, err := unshardedCluster.HKeys(kg.QueueItem())
+		require.NoError(t, err)
+		require.Len(t, queueItemIds, 1)
+		score, err := unshardedCluster.ZScore(kg.PartitionQueueSet(enums.PartitionTypeDefault, queue.KindDebounce, ""), queueItemIds[0])
+		require.NoError(t, err)
+		return int64(score)
+	}
+
+	advance := func(d time.Duration) {
+		unshardedCluster.FastForward(d)
+		fakeClock.Advance(d)
+	}
+
+	send(t)
+	require.Equal(t, 10*time.Second, unshardedCluster.TTL(keyPtr))
+
+	debounceIds, err := unshardedCluster.HKeys(debounceClient.KeyGenerator().Debounce(ctx))
+	require.NoError(t, err)
+	require.Len(t, debounceIds, 1)
+
+	var di DebounceItem
+	err = json.Unmarshal([]byte(unshardedCluster.HGet(debounceClient.KeyGenerator().Debounce(ctx), debounceIds[0])), &di)
+	require.NoError(t, err)
+	require.Equal(t, evt0Time.Add(25*time.Second).UnixMilli(), di.Timeout)
+
+	// Events within the max wait extend the debounce by the full period.
+	advance(8 * time.Second)
+	send(t)
+	require.Equal(t, 10*time.Second, unshardedCluster.TTL(keyPtr))
+
+	// Events which would extend the debounce past the max wait are capped.
+	advance(8 * time.Second)
+	send(t)
+	require.Equal(t, 9*time.Second, unshardedCluster.TTL(keyPtr))
+	require.Equal(t, evt0Time.Add(25*time.Second).Add(buffer).Add(time.Second).UnixMilli(), queueScore(t))
+
+	advance(8 * time.Second)
+	send(t)
+	require.Equal(t, 1*time.Second, unshardedCluster.TTL(keyPtr))
+	require.Equal(t, evt0Time.Add(25*time.Second).Add(buffer).Add(time.Second).UnixMilli(), queueScore(t))
+}
+
+// TestDebounceLeading ensures that leading debounces run on the first event and
+// skip further events within the window.
+func TestDebounceLeading(t *testing.T) {
+	unshardedCluster := miniredis.RunT(t)
+
+	unshardedRc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{unshardedCluster.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	unshardedClient := redis_state.NewUnshardedClient(unshardedRc, redis_state.StateDefaultKey, redis_state.QueueDefaultKey)
+	debounceClient := unshardedClient.Debounce()
+
+	defaultQueueShard := redis_state.QueueShard{Name: consts.DefaultQueueShardName, RedisClient: unshardedClient.Queue(), Kind: string(enums.QueueShardKindRedis)}
+	q := redis_state.NewQueue(defaultQueueShard)
+
+	fakeClock := clockwork.NewFakeClock()
+
+	redisDebouncer := NewRedisDebouncer(debounceClient, defaultQueueShard, q).(debouncer)
+	redisDebouncer.c = fakeClock
+
+	ctx := context.Background()
+	accountId, functionId := uuid.New(), uuid.New()
+
+	advance := func(d time.Duration) {
+		unshardedCluster.FastForward(d)
+		fakeClock.Advance(d)
+	}
+
+	item := func(userID string) DebounceItem {
+		eventId := ulid.MustNew(ulid.Timestamp(fakeClock.Now()), rand.Reader)
+		return DebounceItem{
+			AccountID:  accountId,
+			FunctionID: functionId,
+			EventID:    eventId,
+			Event: event.Event{
+				Name:      "test-data",
+				ID:        eventId.String(),
+				Data:      map[string]any{"user": userID},
+				Timestamp: fakeClock.Now().UnixMilli(),
+			},
+		}
+	}
+
+	t.Run("events within the window are skipped", func(t *testing.T) {
+		fn := inngest.Function{
+			ID: functionId,
+			Debounce: &inngest.Debounce{
+				Period: "10s",
+				Mode:   enums.DebounceModeLeading,
+			},
+		}
+		keyLeading := debounceClient.KeyGenerator().DebounceLeading(ctx, functionId, functionId.String())
+
+		start, err := redisDebouncer.Leading(ctx, item("a"), fn)
+		require.NoError(t, err)
+		require.True(t, start)
+		require.Equal(t, 10*time.Second, unshardedCluster.TTL(keyLeading))
+
+		// Each skipped event extends the window.
+		for i := 0; i < 3; i++ {
+			advance(6 * time.Second)
+			start, err = redisDebouncer.Leading(ctx, item("a"), fn)
+			require.NoError(t, err)
+			require.False(t, start)
+			require.Equal(t, 10*time.Second, unshardedCluster.TTL(keyLeading))
+		}
+
+		// No debounce items or queue items are created.
+		require.False(t, unshardedCluster.Exists(debounceClient.KeyGenerator().Debounce(ctx)))
+
+		advance(10 * time.Second)
+		start, err = redisDebouncer.Leading(ctx, item("a"), fn)
+		require.NoError(t, err)
+		require.True(t, start)
+	})
+
+	t.Run("windows are scoped by key", func(t *testing.T) {
+		unshardedCluster.FlushAll()
+
+		fn := inngest.Function{
+			ID: functionId,
+			Debounce: &inngest.Debounce{
+				Key:    util.StrPtr("event.data.user"),
+				Period: "10s",
+				Mode:   enums.DebounceModeLeading,
+			},
+		}
+
+		for _, user := range []string{"a", "b"} {
+			start, err := redisDebouncer.Leading(ctx, item(user), fn)
+			require.NoError(t, err)
+			require.True(t, start)
+		}
+		start, err := redisDebouncer.Leading(ctx, item("a"), fn)
+		require.NoError(t, err)
+		require.False(t, start)
+	})
+
+	t.Run("timeout closes the window", func(t *testing.T) {
+		unshardedCluster.FlushAll()
+
+		fn := inngest.Function{
+			ID: functionId,
+			Debounce: &inngest.Debounce{
+				Period:  "10s",
+				Timeout: util.StrPtr("15s"),
+				Mode:    enums.DebounceModeLeading,
+			},
+		}
+		keyLeading := debounceClient.KeyGenerator().DebounceLeading(ctx, functionId, functionId.String())
+
+		start, err := redisDebouncer.Leading(ctx, item("a"), fn)
+		require.NoError(t, err)
+		require.True(t, start)
+
+		advance(6 * time.Second)
+		start, err = redisDebouncer.Leading(ctx, item("a"), fn)
+		require.NoError(t, err)
+		require.False(t, start)
+		require.Equal(t, 9*time.Second, unshardedCluster.TTL(keyLeading))
+
+		advance(6 * time.Second)
+		start, err = redisDebouncer.Leading(ctx, item("a"), fn)
+		require.NoError(t, err)
+		require.False(t, start)
+		require.Equal(t, 3*time.Second, unshardedCluster.TTL(keyLeading))
+
+		// The continuous stream of events runs again once the timeout passes.
+		advance(3 * time.Second)
+		start, err = redisDebouncer.Leading(ctx, item("a"), fn)
+		require.NoError(t, err)
+		require.True(t, start)
+		require.Equal(t, 10*time.Second, unshardedCluster.TTL(keyLeading))
+	})
+}
@@ -0,0 +1,52 @@
+--[[
+
+Records an event within a leading-edge debounce window.  If no window exists
+the event opens a new window and should run the function immediately.  Otherwise
+the event is skipped and the window is extended by the debounce period, up to the
+window's timeout.
+
+Return values:
+- 1: No window existed;  run the function.
+- 0: The event is within an existing window and is skipped.
+]]--
+
+local keyLeading = KEYS[1] -- fn -> leading debounce window
+
+local period      = tonumber(ARGV[1]) -- debounce period, in ms
+local currentTime = tonumber(ARGV[2]) -- in ms
+local timeout     = tonumber(ARGV[3]) -- max window length in ms, or 0 if unset
+
+-- open_window starts a new window, storing the time the window must close by if
+-- there's a timeout.
+local function open_window()
+	local max = 0
+	local ttl = period
+	if timeout > 0 then
+		max = currentTime + timeout
+		if ttl > timeout then
+			ttl = timeout
+		end
+	end
+	redis.call("SET", keyLeading, max, "PX", ttl)
+	return 1
+end
+
+local existing = redis.call("GET", keyLeading)
+if existing == nil or existing == false then
+	return open_window()
+end
+
+-- Extend the window, ensuring that we never extend past the window's timeout so
+-- that a continuous stream of events runs the function at least once per timeout.
+local max = tonumber(existing)
+local ttl = period
+if max > 0 and currentTime + ttl > max then
+	ttl = max - currentTime
+	if ttl <= 0 then
+		-- The window has reached its timeout;  start a new window.
+		return open_window()
+	end
+end
+
+redis.call("SET", keyLeading, existing, "PX", ttl)
+return 0
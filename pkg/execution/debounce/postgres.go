@@ -0,0 +1,456 @@
+package debounce
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state/postgres_state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/inngest/inngest/pkg/telemetry/metrics"
+	"github.com/jonboulle/clockwork"
+	"github.com/oklog/ulid/v2"
+	"github.com/xhit/go-str2duration/v2"
+)
+
+// NewPostgresDebouncer returns a Debouncer which stores debounces in Postgres,
+// for use with the postgres queue.  Debounces for the same function and key are
+// serialized using a transaction-level advisory lock.  Updating a debounce locks
+// its queue item within the same transaction, so that a debounce is never
+// updated once its timeout job is in progress.
+func NewPostgresDebouncer(db *sql.DB, queueShard redis_state.QueueShard, q redis_state.QueueManager) Debouncer {
+	return postgresDebouncer{
+		c:          clockwork.NewRealClock(),
+		db:         db,
+		queueShard: queueShard,
+		q:          q,
+	}
+}
+
+type postgresDebouncer struct {
+	c          clockwork.Clock
+	db         *sql.DB
+	queueShard redis_state.QueueShard
+	q          redis_state.QueueManager
+}
+
+// lockKey holds a transaction-level advisory lock on the given function's
+// debounce key.
+func lockKey(ctx context.Context, tx *sql.Tx, fnID uuid.UUID, key string) error {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "debounce:"+fnID.String()+":"+key)
+	if err != nil {
+		return fmt.Errorf("error locking debounce: %w", err)
+	}
+	return nil
+}
+
+// setDebounce stores the debounce and points the function's debounce key to it
+// until expires.
+func setDebounce(ctx context.Context, tx *sql.Tx, fnID uuid.UUID, key string, debounceID ulid.ULID, di DebounceItem, expires time.Time) error {
+	byt, err := json.Marshal(di)
+	if err != nil {
+		return fmt.Errorf("error marshalling debounce: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO debounce_pointers (function_id, key, debounce_id, expires_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (function_id, key) DO UPDATE SET debounce_id = EXCLUDED.debounce_id, expires_at = EXCLUDED.expires_at`,
+		fnID, key, debounceID.String(), expires.UnixMilli(),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO debounces (id, item) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET item = EXCLUDED.item`,
+		debounceID.String(), string(byt),
+	)
+	return err
+}
+
+// DeleteDebounceItem removes a debounce.
+func (d postgresDebouncer) DeleteDebounceItem(ctx context.Context, debounceID ulid.ULID, di DebounceItem, accountID uuid.UUID) error {
+	if _, err := d.db.ExecContext(ctx, `DELETE FROM debounces WHERE id = $1`, debounceID.String()); err != nil {
+		return fmt.Errorf("could not delete debounce item: %w", err)
+	}
+	return nil
+}
+
+// GetDebounceItem returns a DebounceItem given a debounce ID.
+func (d postgresDebouncer) GetDebounceItem(ctx context.Context, debounceID ulid.ULID, accountID uuid.UUID) (*DebounceItem, error) {
+	var byt string
+	err := d.db.QueryRowContext(ctx, `SELECT item FROM debounces WHERE id = $1`, debounceID.String()).Scan(&byt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDebounceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading debounce item: %w", err)
+	}
+
+	di := &DebounceItem{}
+	if err := json.Unmarshal([]byte(byt), di); err != nil {
+		return nil, fmt.Errorf("error unmarshalling debounce item: %w", err)
+	}
+	return di, nil
+}
+
+// StartExecution swaps out the underlying pointer of the debounce, so that new
+// events create a new debounce.
+func (d postgresDebouncer) StartExecution(ctx context.Context, di DebounceItem, fn inngest.Function, debounceID ulid.ULID) error {
+	key, err := debounceKey(ctx, di, fn)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.ExecContext(ctx, `
+		UPDATE debounce_pointers SET debounce_id = $4
+		WHERE function_id = $1 AND key = $2 AND debounce_id = $3`,
+		fn.ID, key, debounceID.String(), ulid.MustNew(ulid.Now(), rand.Reader).String(),
+	)
+	if err != nil {
+		return fmt.Errorf("error starting debounce: %w", err)
+	}
+
+	metrics.IncrQueueDebounceOperationCounter(ctx, metrics.CounterOpt{
+		PkgName: pkgName,
+		Tags: map[string]any{
+			"op":          "start",
+			"queue_shard": d.queueShard.Name,
+			"status":      "started",
+		},
+	})
+	return nil
+}
+
+// Debounce debounces a given function with the given DebounceItem.
+func (d postgresDebouncer) Debounce(ctx context.Context, di DebounceItem, fn inngest.Function) error {
+	if fn.Debounce == nil {
+		return fmt.Errorf("fn has no debounce config")
+	}
+	ttl, err := str2duration.ParseDuration(fn.Debounce.Period)
+	if err != nil {
+		return fmt.Errorf("invalid debounce duration: %w", err)
+	}
+	return d.debounce(ctx, di, fn, ttl, 0)
+}
+
+// Leading records an event for a function using leading-edge debouncing.  The
+// first event opens a window which is extended by the debounce period with every
+// skipped event, up to the debounce's timeout.  Once the window closes, the next
+// event runs the function.
+func (d postgresDebouncer) Leading(ctx context.Context, di DebounceItem, fn inngest.Function) (bool, error) {
+	if fn.Debounce == nil {
+		return false, fmt.Errorf("fn has no debounce config")
+	}
+	period, err := str2duration.ParseDuration(fn.Debounce.Period)
+	if err != nil {
+		return false, fmt.Errorf("invalid debounce duration: %w", err)
+	}
+	var timeout time.Duration
+	if t := fn.Debounce.TimeoutDuration(); t != nil {
+		timeout = *t
+	}
+
+	key, err := debounceKey(ctx, di, fn)
+	if err != nil {
+		return false, err
+	}
+
+	now := d.c.Now()
+	started := false
+	err = postgres_state.WithTx(ctx, d.db, func(tx *sql.Tx) error {
+		if err := lockKey(ctx, tx, fn.ID, "leading:"+key); err != nil {
+			return err
+		}
+
+		// openWindow starts a new window, storing the time the window must close
+		// by if there's a timeout.
+		openWindow := func() error {
+			started = true
+			var timeoutAt int64
+			ttl := period
+			if timeout > 0 {
+				timeoutAt = now.Add(timeout).UnixMilli()
+				ttl = min(ttl, timeout)
+			}
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO debounce_leading (function_id, key, timeout_at, expires_at) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (function_id, key) DO UPDATE SET timeout_at = EXCLUDED.timeout_at, expires_at = EXCLUDED.expires_at`,
+				fn.ID, key, timeoutAt, now.Add(ttl).UnixMilli(),
+			)
+			return err
+		}
+
+		var timeoutAt int64
+		err := tx.QueryRowContext(ctx, `
+			SELECT timeout_at FROM debounce_leading
+			WHERE function_id = $1 AND key = $2 AND expires_at > $3`,
+			fn.ID, key, now.UnixMilli(),
+		).Scan(&timeoutAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return openWindow()
+		}
+		if err != nil {
+			return err
+		}
+
+		// Extend the window, ensuring that we never extend past the window's
+		// timeout so that a continuous stream of events runs the function at
+		// least once per timeout.
+		expires := now.Add(period).UnixMilli()
+		if timeoutAt > 0 && expires > timeoutAt {
+			if timeoutAt <= now.UnixMilli() {
+				// The window has reached its timeout;  start a new window.
+				return openWindow()
+			}
+			expires = timeoutAt
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE debounce_leading SET expires_at = $3 WHERE function_id = $1 AND key = $2`,
+			fn.ID, key, expires,
+		)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("error updating leading debounce: %w", err)
+	}
+
+	op := "leading_skipped"
+	if started {
+		op = "leading_started"
+	}
+	metrics.IncrQueueDebounceOperationCounter(ctx, metrics.CounterOpt{
+		PkgName: pkgName,
+		Tags: map[string]any{
+			"op":          op,
+			"queue_shard": d.queueShard.Name,
+		},
+	})
+
+	return started, nil
+}
+
+func (d postgresDebouncer) debounce(ctx context.Context, di DebounceItem, fn inngest.Function, ttl time.Duration, n int) error {
+	// Call new debounce immediately.  If this returns ErrDebounceExists then
+	// update the debounce.
+	existingDebounceID, err := d.newDebounce(ctx, di, fn, ttl)
+	if err == nil {
+		return nil
+	}
+	if err != ErrDebounceExists {
+		return err
+	}
+
+	// A debounce must already exist for this fn.  Update it.
+	err = d.updateDebounce(ctx, di, fn, ttl, *existingDebounceID)
+	if errors.Is(err, context.DeadlineExceeded) || err == ErrDebounceInProgress {
+		if n == 5 {
+			logger.StdlibLogger(ctx).Error("unable to update debounce", "error", err, "fn_id", di.FunctionID.String())
+			// Only recurse 5 times.
+			return fmt.Errorf("unable to update debounce: %w", err)
+		}
+		// Re-invoke this to see if we need to extend the debounce or continue,
+		// waiting for the in-progress debounce to have started.
+		<-time.After(750 * time.Millisecond)
+		return d.debounce(ctx, di, fn, ttl, n+1)
+	}
+
+	return err
+}
+
+func (d postgresDebouncer) newDebounce(ctx context.Context, di DebounceItem, fn inngest.Function, ttl time.Duration) (*ulid.ULID, error) {
+	now := d.c.Now()
+
+	key, err := debounceKey(ctx, di, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure we set the debounce's max lifetime.
+	if timeout := fn.Debounce.TimeoutDuration(); timeout != nil {
+		di.Timeout = now.Add(*timeout).UnixMilli()
+		// In case the timeout is shorter than the debounce period, adjust the ttl.
+		if untilTimeout := time.UnixMilli(di.Timeout).Sub(now); ttl > untilTimeout {
+			ttl = untilTimeout.Round(time.Second)
+		}
+		if ttl <= 0 {
+			ttl = time.Second
+		}
+	}
+
+	newDebounceID := ulid.MustNew(ulid.Timestamp(now), rand.Reader)
+
+	var existingID *ulid.ULID
+	err = postgres_state.WithTx(ctx, d.db, func(tx *sql.Tx) error {
+		if err := lockKey(ctx, tx, fn.ID, key); err != nil {
+			return err
+		}
+
+		var id string
+		err := tx.QueryRowContext(ctx, `
+			SELECT debounce_id FROM debounce_pointers
+			WHERE function_id = $1 AND key = $2 AND expires_at > $3`,
+			fn.ID, key, now.UnixMilli(),
+		).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return setDebounce(ctx, tx, fn.ID, key, newDebounceID, di, now.Add(ttl))
+		}
+		if err != nil {
+			return err
+		}
+
+		// A debounce for this function exists.
+		existing, err := ulid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("invalid debounce ID: %w", err)
+		}
+		existingID = &existing
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating debounce: %w", err)
+	}
+	if existingID != nil {
+		return existingID, ErrDebounceExists
+	}
+
+	// Enqueue the debounce job with extra buffer.  This ensures that we never
+	// attempt to start a debounce during the debounce's expiry.
+	err = d.q.Enqueue(ctx, queueItem(ctx, di, newDebounceID), now.Add(ttl).Add(buffer).Add(time.Second), queue.EnqueueOpts{})
+	if err != nil {
+		return &newDebounceID, fmt.Errorf("error enqueueing debounce job: %w", err)
+	}
+
+	metrics.IncrQueueDebounceOperationCounter(ctx, metrics.CounterOpt{
+		PkgName: pkgName,
+		Tags: map[string]any{
+			"op":          "created",
+			"queue_shard": d.queueShard.Name,
+		},
+	})
+
+	return &newDebounceID, nil
+}
+
+// updateDebounce updates the currently pending debounce to point to the new event ID.  It pushes
+// out the debounce's TTL, and re-enqueues the job to initialize fns from the debounce.
+func (d postgresDebouncer) updateDebounce(ctx context.Context, di DebounceItem, fn inngest.Function, ttl time.Duration, debounceID ulid.ULID) error {
+	now := d.c.Now()
+
+	key, err := debounceKey(ctx, di, fn)
+	if err != nil {
+		return err
+	}
+
+	// NOTE: This function has a deadline to complete.  If this fn doesn't complete within the deadline,
+	// we must check if the debounce expired and re-attempt the entire thing, allowing us to either
+	// update or create a new debounce depending on the current time.
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var (
+		// missing is true if the debounce's queue item doesn't exist.
+		missing bool
+		// outOfOrder is true if the debounce stores a newer event.
+		outOfOrder bool
+	)
+	err = postgres_state.WithTx(ctx, d.db, func(tx *sql.Tx) error {
+		if err := lockKey(ctx, tx, fn.ID, key); err != nil {
+			return err
+		}
+
+		// Lock the debounce's queue item, ensuring that it can't be leased until
+		// the debounce is updated.
+		var leasedUntil sql.NullInt64
+		err := tx.QueryRowContext(ctx,
+			`SELECT leased_until FROM queue_items WHERE id = $1 FOR UPDATE`,
+			queue.HashID(ctx, debounceID.String()),
+		).Scan(&leasedUntil)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The queue item was not found.  Store the debounce and enqueue a
+			// new item.
+			missing = true
+			return setDebounce(ctx, tx, fn.ID, key, debounceID, di, now.Add(ttl))
+		}
+		if err != nil {
+			return err
+		}
+		if leasedUntil.Valid && leasedUntil.Int64 > now.UnixMilli() {
+			return ErrDebounceInProgress
+		}
+
+		existing := DebounceItem{}
+		var byt string
+		err = tx.QueryRowContext(ctx, `SELECT item FROM debounces WHERE id = $1`, debounceID.String()).Scan(&byt)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal([]byte(byt), &existing); err != nil {
+				return fmt.Errorf("error unmarshalling debounce item: %w", err)
+			}
+		}
+
+		if existing.Event.Timestamp > di.Event.Timestamp {
+			// The stored event occurs after the event we're updating, so do nothing.
+			outOfOrder = true
+			return nil
+		}
+
+		// Respect the max timeout for the debounce.  We don't want to keep pushing
+		// a debounce out indefinitely.
+		if existing.Timeout > 0 {
+			if now.Add(ttl).UnixMilli() > existing.Timeout {
+				ttl = time.Duration((existing.Timeout-now.UnixMilli())/1000) * time.Second
+				if ttl <= 0 {
+					// Ensure we always use a minimum.
+					ttl = time.Second
+				}
+			}
+			di.Timeout = existing.Timeout
+		}
+
+		return setDebounce(ctx, tx, fn.ID, key, debounceID, di, now.Add(ttl))
+	})
+	if err == ErrDebounceInProgress {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("error updating debounce: %w", err)
+	}
+
+	switch {
+	case outOfOrder:
+		return nil
+	case missing:
+		return d.q.Enqueue(ctx, queueItem(ctx, di, debounceID), now.Add(ttl).Add(buffer).Add(time.Second), queue.EnqueueOpts{})
+	}
+
+	err = d.q.RequeueByJobID(ctx, d.queueShard, debounceID.String(), now.Add(ttl).Add(buffer).Add(time.Second))
+	if err == redis_state.ErrQueueItemAlreadyLeased {
+		logger.StdlibLogger(ctx).Warn(ErrDebounceInProgress.Error(), "error", err)
+		// This is in progress.
+		return ErrDebounceInProgress
+	}
+	if err != nil {
+		return fmt.Errorf("error requeueing debounce job '%s': %w", debounceID, err)
+	}
+
+	metrics.IncrQueueDebounceOperationCounter(ctx, metrics.CounterOpt{
+		PkgName: pkgName,
+		Tags: map[string]any{
+			"op":          "updated",
+			"queue_shard": d.queueShard.Name,
+		},
+	})
+
+	return nil
+}
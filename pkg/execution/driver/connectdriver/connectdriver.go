@@ -155,7 +155,19 @@ func ProxyRequest(ctx, traceCtx context.Context, forwarder pubsub.RequestForward
 		opts.SpanID = spanID.String()
 	}
 
+	// Enforce the step's timeout, if set, while waiting for the worker.
+	parent := ctx
+	stepTimeout := r.Step.TimeoutDuration()
+	if stepTimeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *stepTimeout)
+		defer cancel()
+	}
+
 	resp, err := do(ctx, traceCtx, forwarder, opts)
+	if err != nil && stepTimeout != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil {
+		return httpdriver.StepTimeoutResponse(r, state.StepTimeoutError{Timeout: *stepTimeout})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,67 @@
+package connectdriver
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/inngest/inngest/pkg/connect/pubsub"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution/driver/httpdriver"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
+	"github.com/inngest/inngest/pkg/inngest"
+	connectpb "github.com/inngest/inngest/proto/gen/connect/v1"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingForwarder never receives a response, returning once the request's
+// context is done.
+type blockingForwarder struct{}
+
+func (blockingForwarder) Proxy(ctx, traceCtx context.Context, opts pubsub.ProxyOpts) (*connectpb.SDKResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestProxyRequestStepTimeout(t *testing.T) {
+	ctx := context.Background()
+	timeout := "100ms"
+	jobID := "job"
+	item := queue.Item{JobID: &jobID}
+	id := sv2.ID{RunID: ulid.Make()}
+
+	t.Run("planned steps fail with a step error", func(t *testing.T) {
+		dr, err := ProxyRequest(ctx, ctx, blockingForwarder{}, id, item, httpdriver.Request{
+			URL:  url.URL{Scheme: "ws", Host: "connect"},
+			Step: inngest.Step{ID: "step", Timeout: &timeout},
+			Edge: inngest.Edge{
+				Incoming:                  "step",
+				IncomingGeneratorStep:     "hashed",
+				IncomingGeneratorStepName: "slow",
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, dr.Generator, 1)
+		require.Equal(t, enums.OpcodeStepError, dr.Generator[0].Op)
+		require.Equal(t, state.StepTimeoutErrorName, dr.Generator[0].Error.Name)
+		require.Equal(t, state.StepTimeoutErrorName, dr.UserError.Name)
+	})
+
+	t.Run("unplanned requests fail the attempt", func(t *testing.T) {
+		dr, err := ProxyRequest(ctx, ctx, blockingForwarder{}, id, item, httpdriver.Request{
+			URL:  url.URL{Scheme: "ws", Host: "connect"},
+			Step: inngest.Step{ID: "step", Timeout: &timeout},
+			Edge: inngest.Edge{Incoming: "step"},
+		})
+		var timeoutErr state.StepTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.Equal(t, 100*time.Millisecond, timeoutErr.Timeout)
+		require.NotNil(t, dr.Err)
+		require.Equal(t, state.StepTimeoutErrorName, dr.UserError.Name)
+		require.Nil(t, dr.Generator)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/inngest/go-httpstat"
 	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/execution/driver"
 	"github.com/inngest/inngest/pkg/execution/queue"
 	"github.com/inngest/inngest/pkg/execution/state"
@@ -172,6 +173,11 @@ func DoRequest(ctx context.Context, c util.HTTPDoer, r Request) (*state.DriverRe
 	}
 
 	resp, tracking, err := do(ctx, c, r)
+	var timeoutErr state.StepTimeoutError
+	if errors.As(err, &timeoutErr) {
+		dr, err := StepTimeoutResponse(r, timeoutErr)
+		return dr, tracking, err
+	}
 	if err != nil {
 		return nil, tracking, err
 	}
@@ -180,6 +186,43 @@ func DoRequest(ctx context.Context, c util.HTTPDoer, r Request) (*state.DriverRe
 	return dr, tracking, err
 }
 
+// StepTimeoutResponse returns the driver response for a request which exceeded
+// the step's timeout.  Planned steps fail with an OpcodeStepError so that the
+// executor retries them as per the function's retry policy;  other requests
+// can't be attributed to a single step and fail the attempt as a whole, returning
+// an error wrapping the StepTimeoutError.
+//
+// In both cases the response's UserError is the StepTimeoutError, allowing step
+// timeouts to be told apart from other failures.
+func StepTimeoutResponse(r Request, timeoutErr state.StepTimeoutError) (*state.DriverResponse, error) {
+	dr := &state.DriverResponse{
+		Step:      r.Step,
+		Duration:  timeoutErr.Timeout,
+		UserError: timeoutErr.UserError(),
+	}
+
+	if r.Edge.IncomingGeneratorStep == "" {
+		err := fmt.Errorf("error executing request: %w", timeoutErr)
+		dr.SetError(err)
+		return dr, err
+	}
+
+	name := r.Edge.IncomingGeneratorStepName
+	dr.StatusCode = http.StatusPartialContent
+	dr.Step.ID = r.Edge.IncomingGeneratorStep
+	dr.Step.Name = name
+	dr.Generator = []*state.GeneratorOpcode{
+		{
+			Op:          enums.OpcodeStepError,
+			ID:          r.Edge.IncomingGeneratorStep,
+			Name:        name,
+			DisplayName: &name,
+			Error:       timeoutErr.UserError(),
+		},
+	}
+	return dr, nil
+}
+
 func HandleHttpResponse(ctx context.Context, r Request, resp *Response) (*state.DriverResponse, error) {
 	l := logger.StdlibLogger(ctx)
 
@@ -298,7 +341,15 @@ func do(ctx context.Context, c util.HTTPDoer, r Request) (*Response, *httpstat.R
 		c = defaultClient
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, consts.MaxFunctionTimeout)
+	// Steps may specify a shorter timeout than the max function timeout.
+	parent := ctx
+	timeout := consts.MaxFunctionTimeout
+	stepTimeout := r.Step.TimeoutDuration()
+	if stepTimeout != nil && *stepTimeout < timeout {
+		timeout = *stepTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL.String(), bytes.NewBuffer(r.Input))
@@ -334,6 +385,12 @@ func do(ctx context.Context, c util.HTTPDoer, r Request) (*Response, *httpstat.R
 	resp, byt, dur, err := ExecuteRequest(ctx, c, req)
 	tracking.End(time.Now())
 
+	// Only surface a step timeout if our own deadline fired, rather than the
+	// caller's context.
+	if err != nil && stepTimeout != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && parent.Err() == nil {
+		return nil, tracking, state.StepTimeoutError{Timeout: timeout}
+	}
+
 	// Handle no response errors.
 	if errors.Is(err, ErrUnableToReach) {
 		l.Warn("EOF writing request to SDK",
@@ -16,6 +16,7 @@ import (
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/inngest"
 	"github.com/inngest/inngest/pkg/syscode"
 	"github.com/stretchr/testify/require"
 )
@@ -239,3 +240,54 @@ func TestTiming(t *testing.T) {
 	require.True(t, result.ServerProcessing > time.Second)
 	require.True(t, result.Total(time.Time{}) > time.Second)
 }
+
+func TestStepTimeout(t *testing.T) {
+	input := []byte(`{"event":{"name":"hi","data":{}}}`)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	timeout := "100ms"
+	client := Client(SecureDialerOpts{AllowPrivate: true})
+
+	t.Run("planned steps fail with a step error", func(t *testing.T) {
+		dr, _, err := DoRequest(context.Background(), client, Request{
+			URL:   parseURL(ts.URL),
+			Input: input,
+			Step:  inngest.Step{ID: "step", Timeout: &timeout},
+			Edge: inngest.Edge{
+				Incoming:                  "step",
+				IncomingGeneratorStep:     "hashed",
+				IncomingGeneratorStepName: "slow",
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, dr.Generator, 1)
+
+		op := dr.Generator[0]
+		require.Equal(t, enums.OpcodeStepError, op.Op)
+		require.Equal(t, "hashed", op.ID)
+		require.Equal(t, "slow", op.UserDefinedName())
+		require.Equal(t, state.StepTimeoutErrorName, op.Error.Name)
+		require.Equal(t, state.StepTimeoutErrorName, dr.UserError.Name)
+	})
+
+	t.Run("unplanned requests fail the attempt", func(t *testing.T) {
+		dr, _, err := DoRequest(context.Background(), client, Request{
+			URL:   parseURL(ts.URL),
+			Input: input,
+			Step:  inngest.Step{ID: "step", Timeout: &timeout},
+			Edge:  inngest.Edge{Incoming: "step"},
+		})
+		var timeoutErr state.StepTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		require.Equal(t, 100*time.Millisecond, timeoutErr.Timeout)
+		require.NotNil(t, dr.Err)
+		require.Equal(t, state.StepTimeoutErrorName, dr.UserError.Name)
+		require.Nil(t, dr.Generator)
+	})
+}
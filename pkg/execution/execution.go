@@ -124,7 +124,11 @@ type Executor interface {
 
 type ResumeSignalResult struct {
 	MatchedSignal bool
-	RunID         *ulid.ULID
+	// RunID is the first run resumed by the signal.
+	RunID *ulid.ULID
+	// RunIDs are all runs resumed by the signal.  A signal resumes every
+	// waiting run whose expression matches.
+	RunIDs []ulid.ULID
 }
 
 // PublishFinishedEventOpts represents the options for publishing a finished event.
@@ -209,6 +213,10 @@ type CancelRequest struct {
 	Expression     *string
 	UserID         *uuid.UUID
 	CancellationID *ulid.ULID
+	// ReplacedByRunID is the ID of the run that replaced the cancelled run.  This
+	// is set when a singleton function using SingletonModeCancel cancels its
+	// in-progress run in favour of a newly scheduled one.
+	ReplacedByRunID *ulid.ULID
 
 	// ForceLifecycleHook is used to force the OnFunctionCancelled lifecycle
 	// hook to run even if the function is already finalized. This is useful
@@ -214,6 +214,27 @@ func WithStateSizeLimits(limit func(id sv2.ID) int) ExecutorOpt {
 	}
 }
 
+// WithPriorityFactorRange limits the run priority factors of functions within
+// each environment.  The returned range is in seconds and is always bounded by
+// consts.PriorityFactorMin and consts.PriorityFactorMax.
+func WithPriorityFactorRange(f func(ctx context.Context, accountID, envID uuid.UUID) (min, max int64)) ExecutorOpt {
+	return func(e execution.Executor) error {
+		e.(*executor).priorityRange = f
+		return nil
+	}
+}
+
+// StaticPriorityFactorRange returns a priority factor range used by every
+// environment, or nil if both min and max are zero.
+func StaticPriorityFactorRange(lo, hi int64) func(ctx context.Context, accountID, envID uuid.UUID) (min, max int64) {
+	if lo == 0 && hi == 0 {
+		return nil
+	}
+	return func(ctx context.Context, accountID, envID uuid.UUID) (int64, int64) {
+		return lo, hi
+	}
+}
+
 func WithDebouncer(d debounce.Debouncer) ExecutorOpt {
 	return func(e execution.Executor) error {
 		e.(*executor).debouncer = d
@@ -333,6 +354,9 @@ type executor struct {
 	// stateSizeLimit finds state size limits for a given run
 	stateSizeLimit func(sv2.ID) int
 
+	// priorityRange finds the range of run priority factors for an environment.
+	priorityRange func(ctx context.Context, accountID, envID uuid.UUID) (min, max int64)
+
 	preDeleteStateSizeReporter execution.PreDeleteStateSizeReporter
 
 	assignedQueueShard redis_state.QueueShard
@@ -341,6 +365,27 @@ type executor struct {
 	traceReader cqrs.TraceReader
 }
 
+// runPriorityFactor evaluates the function's run priority factor for the given
+// event, limited to the environment's priority factor range.
+func (e *executor) runPriorityFactor(ctx context.Context, fn inngest.Function, evt map[string]any, accountID, envID uuid.UUID) int64 {
+	factor, err := fn.RunPriorityFactor(ctx, evt)
+	if err != nil {
+		e.log.Warn("error evaluating run priority factor",
+			"error", err,
+			"function_id", fn.ID,
+		)
+	}
+
+	if e.priorityRange == nil {
+		return factor
+	}
+
+	lo, hi := e.priorityRange(ctx, accountID, envID)
+	lo = min(max(lo, consts.PriorityFactorMin), 0)
+	hi = max(min(hi, consts.PriorityFactorMax), 0)
+	return min(max(factor, lo), hi)
+}
+
 func (e *executor) SetFinalizer(f execution.FinalizePublisher) {
 	e.finishHandler = f
 }
@@ -413,8 +458,10 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 		return nil, fmt.Errorf("app ID is required to schedule a run")
 	}
 
+	isPaused := req.FunctionPausedAt != nil && req.FunctionPausedAt.Before(time.Now())
+
 	if req.Function.Debounce != nil && !req.PreventDebounce {
-		err := e.debouncer.Debounce(ctx, debounce.DebounceItem{
+		di := debounce.DebounceItem{
 			AccountID:        req.AccountID,
 			WorkspaceID:      req.WorkspaceID,
 			AppID:            req.AppID,
@@ -423,11 +470,27 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 			EventID:          req.Events[0].GetInternalID(),
 			Event:            req.Events[0].GetEvent(),
 			FunctionPausedAt: req.FunctionPausedAt,
-		}, req.Function)
-		if err != nil {
-			return nil, err
 		}
-		return nil, ErrFunctionDebounced
+
+		if req.Function.Debounce.Mode != enums.DebounceModeLeading {
+			if err := e.debouncer.Debounce(ctx, di, req.Function); err != nil {
+				return nil, err
+			}
+			return nil, ErrFunctionDebounced
+		}
+
+		// Leading-edge debounces run immediately with the first event in
+		// each window, skipping the rest.  Paused functions skip every event
+		// without claiming a window, such that each skipped run is backfilled.
+		if !isPaused {
+			start, err := e.debouncer.Leading(ctx, di, req.Function)
+			if err != nil {
+				return nil, err
+			}
+			if !start {
+				return nil, ErrFunctionDebounced
+			}
+		}
 	}
 
 	// Run IDs are created embedding the timestamp now, when the function is being scheduled.
@@ -460,7 +523,7 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 	}
 	// Evaluate the run priority based off of the input event data.
 	evtMap := req.Events[0].GetEvent().Map()
-	factor, _ := req.Function.RunPriorityFactor(ctx, evtMap)
+	factor := e.runPriorityFactor(ctx, req.Function, evtMap, req.AccountID, req.WorkspaceID)
 	// function run spanID
 	spanID := run.NewSpanID(ctx)
 
@@ -507,7 +570,6 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 	}
 
 	// If this is paused, immediately end just before creating state.
-	isPaused := req.FunctionPausedAt != nil && req.FunctionPausedAt.Before(time.Now())
 	if isPaused {
 		return e.handleFunctionSkipped(ctx, req, metadata, evts, enums.SkipReasonFunctionPaused)
 	}
@@ -531,28 +593,36 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 	// Create singleton information and try to handle it prior to creating state.
 	//
 	var singletonConfig *queue.Singleton
+	// replacedRunID is the in-progress singleton run to cancel once this run is
+	// enqueued, when using SingletonModeCancel.
+	var replacedRunID *ulid.ULID
 	data := req.Events[0].GetEvent().Map()
 
-	// Ignores Cancel mode for now
-	if req.Function.Singleton != nil && req.Function.Singleton.Mode == enums.SingletonModeSkip {
+	if req.Function.Singleton != nil {
 		singletonKey, err := singleton.SingletonKey(ctx, req.Function.ID, *req.Function.Singleton, data)
 		switch {
 		case err == nil:
 			// Attempt to early handle function singletons, function runs could still fail
 			// to enqueue later on when it atomically tries to acquire a function mutex.
-			alreadyRunning, err := e.singletonMgr.Singleton(ctx, singletonKey, *req.Function.Singleton)
+			currentRunID, err := e.singletonMgr.Singleton(ctx, singletonKey, *req.Function.Singleton)
 			if err != nil {
 				return nil, err
 			}
 
-			if alreadyRunning {
-				// TODO: Handle cancellation mode
-
-				// Immediately end before creating state
-				return nil, ErrFunctionSkipped
+			if currentRunID != nil {
+				switch req.Function.Singleton.Mode {
+				case enums.SingletonModeCancel:
+					// The new run takes over the singleton lock when enqueued, and the
+					// in-progress run is only cancelled afterwards.  This ensures that a
+					// failed enqueue never leaves nothing running.
+					replacedRunID = currentRunID
+				default:
+					// Immediately end before creating state
+					return nil, ErrFunctionSkipped
+				}
 			}
 
-			singletonConfig = &queue.Singleton{Key: singletonKey}
+			singletonConfig = &queue.Singleton{Key: singletonKey, Mode: req.Function.Singleton.Mode}
 		case errors.Is(err, singleton.ErrNotASingleton):
 			// We no-op, and we run the function normally not as a singleton
 		default:
@@ -720,6 +790,17 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 		return nil, fmt.Errorf("error enqueueing source edge '%v': %w", queueKey, err)
 	}
 
+	if replacedRunID != nil && *replacedRunID != metadata.ID.RunID {
+		if err := e.cancelSingletonRun(ctx, req, metadata.ID.RunID, *replacedRunID); err != nil {
+			// The new run is already enqueued, so don't fail scheduling.
+			logger.StdlibLogger(ctx).Error(
+				"error cancelling replaced singleton run",
+				"error", err,
+				"run_id", replacedRunID.String(),
+			)
+		}
+	}
+
 	for _, e := range e.lifecycles {
 		go e.OnFunctionScheduled(context.WithoutCancel(ctx), metadata, item, req.Events)
 	}
@@ -727,6 +808,36 @@ func (e *executor) Schedule(ctx context.Context, req execution.ScheduleRequest)
 	return &metadata, nil
 }
 
+// cancelSingletonRun cancels the in-progress run replaced by a new singleton run.
+// The new run must already be enqueued, having taken over the singleton lock.
+func (e *executor) cancelSingletonRun(ctx context.Context, req execution.ScheduleRequest, runID, currentRunID ulid.ULID) error {
+	id := sv2.ID{
+		RunID:      currentRunID,
+		FunctionID: req.Function.ID,
+		Tenant: sv2.Tenant{
+			AppID:     req.AppID,
+			EnvID:     req.WorkspaceID,
+			AccountID: req.AccountID,
+		},
+	}
+
+	evtID := req.Events[0].GetInternalID()
+	err := e.Cancel(ctx, id, execution.CancelRequest{
+		EventID:         &evtID,
+		ReplacedByRunID: &runID,
+	})
+	if err != nil {
+		return fmt.Errorf("error cancelling singleton run: %w", err)
+	}
+
+	e.log.Debug("cancelled singleton run",
+		"run_id", currentRunID.String(),
+		"replaced_by_run_id", runID.String(),
+		"workflow_id", req.Function.ID.String(),
+	)
+	return nil
+}
+
 func (e *executor) handleFunctionSkipped(ctx context.Context, req execution.ScheduleRequest, metadata sv2.Metadata, evts []json.RawMessage, reason enums.SkipReason) (*sv2.Metadata, error) {
 	for _, e := range e.lifecycles {
 		go e.OnFunctionSkipped(context.WithoutCancel(ctx), metadata, execution.SkipState{
@@ -2701,6 +2812,16 @@ func (e *executor) handleGeneratorWaitForSignal(ctx context.Context, i *runInsta
 	if err != nil {
 		return fmt.Errorf("unable to parse signal expires: %w", err)
 	}
+	if opts.If != nil {
+		if _, err := e.newExpressionEvaluator(ctx, *opts.If); err != nil {
+			return state.WrapInStandardError(
+				err,
+				"InvalidExpression",
+				"Wait for signal If expression failed to compile",
+				err.Error(),
+			)
+		}
+	}
 
 	pauseID := inngest.DeterministicSha1UUID(i.md.ID.RunID.String() + gen.ID)
 	opcode := gen.Op.String()
@@ -2731,6 +2852,7 @@ func (e *executor) handleGeneratorWaitForSignal(ctx context.Context, i *runInsta
 		Expires:                 state.Time(expires),
 		DataKey:                 gen.ID,
 		SignalID:                &opts.Signal,
+		Expression:              opts.If,
 		ReplaceSignalOnConflict: shouldReplaceSignalOnConflict,
 		MaxAttempts:             i.item.MaxAttempts,
 		Metadata: map[string]any{
@@ -3257,34 +3379,76 @@ func (e *executor) ResumeSignal(ctx context.Context, workspaceID uuid.UUID, sign
 		}
 	}()
 
-	pause, err := e.pm.PauseBySignalID(ctx, workspaceID, signalID)
+	pauses, err := e.pm.PausesBySignalID(ctx, workspaceID, signalID)
 	if err != nil {
-		err = fmt.Errorf("error getting pause by signal ID: %w", err)
+		err = fmt.Errorf("error getting pauses by signal ID: %w", err)
 		return
 	}
 
 	res = &execution.ResumeSignalResult{}
 
-	if pause == nil {
+	if len(pauses) == 0 {
 		l.Debug("no pause found for signal")
 		return
 	}
 
+	// Resume every waiting run whose expression matches the signal.  A failure
+	// to resume one run doesn't prevent the remaining runs from resuming.
+	for _, pause := range pauses {
+		resumed, perr := e.resumeSignalPause(ctx, l, *pause, signalID, data)
+		if perr != nil {
+			l.Error("error resuming pause from signal", "error", perr, "pause_id", pause.ID.String())
+			err = errors.Join(err, fmt.Errorf("error resuming pause %s: %w", pause.ID, perr))
+			continue
+		}
+		if !resumed {
+			continue
+		}
+
+		runID := pause.Identifier.RunID
+		res.RunIDs = append(res.RunIDs, runID)
+		if res.RunID == nil {
+			res.RunID = &runID
+		}
+		res.MatchedSignal = true
+	}
+
+	return
+}
+
+// resumeSignalPause resumes a single pause waiting for a signal, returning
+// whether the pause was resumed.
+func (e *executor) resumeSignalPause(ctx context.Context, l logger.Logger, pause state.Pause, signalID string, data json.RawMessage) (bool, error) {
+	l = l.With("pause_id", pause.ID.String())
+
 	if pause.Expires.Time().Before(time.Now()) {
 		l.Debug("encountered expired signal")
 
 		shouldDelete := pause.Expires.Time().Add(consts.PauseExpiredDeletionGracePeriod).Before(time.Now())
 		if shouldDelete {
 			l.Debug("deleting expired pause")
-			_ = e.pm.DeletePause(ctx, *pause)
+			_ = e.pm.DeletePause(ctx, pause)
 		}
 
-		return
+		return false, nil
+	}
+
+	// Only resume waits whose expression matches the signal payload.
+	if pause.Expression != nil {
+		ok, err := signalMatches(ctx, *pause.Expression, signalID, data)
+		if err != nil {
+			l.Warn("error evaluating signal expression", "error", err)
+			return false, nil
+		}
+		if !ok {
+			l.Debug("signal did not match pause expression")
+			return false, nil
+		}
 	}
 
 	l.Debug("resuming pause from signal", "pause.DataKey", pause.DataKey)
 
-	err = e.Resume(ctx, *pause, execution.ResumeRequest{
+	err := e.Resume(ctx, pause, execution.ResumeRequest{
 		RunID:          &pause.Identifier.RunID,
 		StepName:       pause.StepName,
 		IdempotencyKey: signalID,
@@ -3300,16 +3464,40 @@ func (e *executor) ResumeSignal(ctx context.Context, workspaceID uuid.UUID, sign
 			errors.Is(err, state.ErrPauseNotFound) ||
 			errors.Is(err, state.ErrRunNotFound) {
 			// Just return that we found nothing
-			err = nil
+			return false, nil
 		}
 
-		return
+		return false, err
 	}
 
-	res.MatchedSignal = true
-	res.RunID = &pause.Identifier.RunID
+	return true, nil
+}
 
-	return
+// signalMatches evaluates a signal wait's expression against the incoming
+// signal.  The payload is available as `async.data`, mirroring waitForEvent.
+func signalMatches(ctx context.Context, expr string, signalID string, data json.RawMessage) (bool, error) {
+	var payload any
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return false, fmt.Errorf("error unmarshalling signal data: %w", err)
+		}
+	}
+
+	eval, err := expressions.NewExpressionEvaluator(ctx, expr)
+	if err != nil {
+		return false, err
+	}
+	val, _, err := eval.Evaluate(ctx, expressions.NewData(map[string]any{
+		"async": map[string]any{
+			"signal": signalID,
+			"data":   payload,
+		},
+	}))
+	if err != nil {
+		return false, err
+	}
+	result, _ := val.(bool)
+	return result, nil
 }
 
 type execError struct {
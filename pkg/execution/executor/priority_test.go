@@ -0,0 +1,63 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPriorityFactorRange(t *testing.T) {
+	ctx := context.Background()
+	expr := "event.data.priority"
+	fn := inngest.Function{Priority: &inngest.Priority{Run: &expr}}
+	evt := func(p int) map[string]any {
+		return map[string]any{"data": map[string]any{"priority": p}}
+	}
+
+	e := &executor{log: logger.StdlibLogger(ctx)}
+	accountID, envID := uuid.New(), uuid.New()
+
+	t.Run("uses the default range", func(t *testing.T) {
+		require.EqualValues(t, 600, e.runPriorityFactor(ctx, fn, evt(600), accountID, envID))
+		require.EqualValues(t, consts.PriorityFactorMax, e.runPriorityFactor(ctx, fn, evt(1<<30), accountID, envID))
+	})
+
+	t.Run("uses the environment's range", func(t *testing.T) {
+		e.priorityRange = func(ctx context.Context, a, env uuid.UUID) (int64, int64) {
+			if env == envID {
+				return -60, 300
+			}
+			return consts.PriorityFactorMin, consts.PriorityFactorMax
+		}
+
+		require.EqualValues(t, 300, e.runPriorityFactor(ctx, fn, evt(600), accountID, envID))
+		require.EqualValues(t, -60, e.runPriorityFactor(ctx, fn, evt(-600), accountID, envID))
+		require.EqualValues(t, 600, e.runPriorityFactor(ctx, fn, evt(600), accountID, uuid.New()))
+	})
+
+	t.Run("bounds the environment's range", func(t *testing.T) {
+		e.priorityRange = func(ctx context.Context, a, env uuid.UUID) (int64, int64) {
+			return -1 << 40, 1 << 40
+		}
+		require.EqualValues(t, consts.PriorityFactorMax, e.runPriorityFactor(ctx, fn, evt(1<<35), accountID, envID))
+	})
+
+	t.Run("uses a static range", func(t *testing.T) {
+		e.priorityRange = StaticPriorityFactorRange(-30, 120)
+		require.EqualValues(t, 120, e.runPriorityFactor(ctx, fn, evt(600), accountID, envID))
+		require.EqualValues(t, -30, e.runPriorityFactor(ctx, fn, evt(-600), accountID, uuid.New()))
+
+		require.Nil(t, StaticPriorityFactorRange(0, 0))
+	})
+
+	t.Run("ignores invalid expressions", func(t *testing.T) {
+		invalid := "event.data.priority +"
+		fn := inngest.Function{Priority: &inngest.Priority{Run: &invalid}}
+		require.EqualValues(t, 0, e.runPriorityFactor(ctx, fn, evt(600), accountID, envID))
+	})
+}
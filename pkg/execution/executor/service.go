@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/inngest/inngest/pkg/config"
@@ -15,8 +16,10 @@ import (
 	"github.com/inngest/inngest/pkg/event"
 	"github.com/inngest/inngest/pkg/execution"
 	"github.com/inngest/inngest/pkg/execution/batch"
+	"github.com/inngest/inngest/pkg/execution/cron"
 	"github.com/inngest/inngest/pkg/execution/debounce"
 	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
 	"github.com/inngest/inngest/pkg/execution/state"
 	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
 	"github.com/inngest/inngest/pkg/inngest"
@@ -74,6 +77,12 @@ func WithServiceBatcher(b batch.BatchManager) func(s *svc) {
 	}
 }
 
+func WithServiceCronManager(c cron.CronManager) func(s *svc) {
+	return func(s *svc) {
+		s.crons = c
+	}
+}
+
 func WithServiceLogger(l logger.Logger) func(s *svc) {
 	return func(s *svc) {
 		s.log = l
@@ -100,7 +109,10 @@ type svc struct {
 	exec      execution.Executor
 	debouncer debounce.Debouncer
 	batcher   batch.BatchManager
+	crons     cron.CronManager
 	log       logger.Logger
+	// publisher publishes events emitted by function runs and crons.
+	publisher pubsub.Publisher
 
 	wg sync.WaitGroup
 
@@ -122,6 +134,11 @@ func (s *svc) Pre(ctx context.Context) error {
 		return fmt.Errorf("no queue provided")
 	}
 
+	s.publisher, err = pubsub.NewPublisher(ctx, s.config.EventStream.Service)
+	if err != nil {
+		return fmt.Errorf("failed to create publisher: %w", err)
+	}
+
 	finishHandler, err := s.getFinishHandler(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create finish handler: %w", err)
@@ -136,11 +153,6 @@ func (s *svc) Executor() execution.Executor {
 }
 
 func (s *svc) getFinishHandler(ctx context.Context) (func(context.Context, sv2.ID, []event.Event) error, error) {
-	pb, err := pubsub.NewPublisher(ctx, s.config.EventStream.Service)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create publisher: %w", err)
-	}
-
 	topicName := s.config.EventStream.Service.Concrete.TopicName()
 
 	return func(ctx context.Context, id sv2.ID, events []event.Event) error {
@@ -158,7 +170,7 @@ func (s *svc) getFinishHandler(ctx context.Context) (func(context.Context, sv2.I
 				carrier := itrace.NewTraceCarrier()
 				itrace.UserTracer().Propagator().Inject(ctx, propagation.MapCarrier(carrier.Context))
 
-				err = pb.Publish(
+				err = s.publisher.Publish(
 					ctx,
 					topicName,
 					pubsub.Message{
@@ -204,6 +216,10 @@ func (s *svc) Run(ctx context.Context) error {
 			err = s.handleDebounce(ctx, item)
 		case queue.KindScheduleBatch:
 			err = s.handleScheduledBatch(ctx, item)
+		case queue.KindCron:
+			err = s.handleCron(ctx, item)
+		case queue.KindScheduledEvent:
+			err = s.handleScheduledEvent(ctx, item)
 		case queue.KindQueueMigrate:
 			// NOOP:
 			// this kind don't work in the Dev server
@@ -336,7 +352,9 @@ func (s *svc) handleScheduledBatch(ctx context.Context, item queue.Item) error {
 		AppID:           item.Identifier.AppID,
 		FunctionID:      item.Identifier.WorkflowID,
 		FunctionVersion: fn.FunctionVersion,
-	}, nil); err != nil {
+	}, &execution.BatchExecOpts{
+		FunctionPausedAt: s.functionPausedAt(ctx, item.Identifier.WorkspaceID, fn.ID),
+	}); err != nil {
 		return fmt.Errorf("could not retrieve and schedule batch items: %w", err)
 	}
 
@@ -422,6 +440,184 @@ func (s *svc) handleDebounce(ctx context.Context, item queue.Item) error {
 	return nil
 }
 
+// handleCron processes a single cron tick, enqueueing the following tick and
+// scheduling the function if the tick should fire.
+func (s *svc) handleCron(ctx context.Context, item queue.Item) error {
+	if s.crons == nil {
+		return fmt.Errorf("no cron manager provided")
+	}
+
+	p := cron.CronPayload{}
+	if err := json.Unmarshal(item.Payload.(json.RawMessage), &p); err != nil {
+		return fmt.Errorf("error unmarshalling cron payload: %w", err)
+	}
+
+	l := s.log.With(
+		"function_id", p.FunctionID.String(),
+		"function_version", p.FunctionVersion,
+		"cron", p.Expression,
+	)
+
+	all, err := s.data.Functions(ctx)
+	if err != nil {
+		return err
+	}
+
+	var fn *inngest.Function
+	for _, f := range all {
+		if f.ID != p.FunctionID || f.FunctionVersion != p.FunctionVersion {
+			continue
+		}
+		for _, t := range f.Triggers {
+			if t.CronTrigger != nil && t.CronTrigger.Cron == p.Expression {
+				fn = &f
+				break
+			}
+		}
+	}
+
+	if fn == nil {
+		// The function was deleted or updated since this tick was enqueued, so
+		// end the chain.  Syncing the new version creates its own record.
+		l.Debug("cron no longer exists, removing")
+		return s.crons.Remove(ctx, p.CronItem)
+	}
+
+	fire, err := s.crons.Next(ctx, p)
+	if err != nil {
+		return err
+	}
+	if !fire {
+		return nil
+	}
+
+	ctx, span := run.NewSpan(ctx,
+		run.WithScope(consts.OtelScopeCron),
+		run.WithName(consts.OtelSpanCron),
+		run.WithSpanAttributes(
+			attribute.String(consts.OtelSysAccountID, p.AccountID.String()),
+			attribute.String(consts.OtelSysWorkspaceID, p.WorkspaceID.String()),
+			attribute.String(consts.OtelSysAppID, p.AppID.String()),
+			attribute.String(consts.OtelSysFunctionID, p.FunctionID.String()),
+			attribute.Int(consts.OtelSysFunctionVersion, p.FunctionVersion),
+		),
+	)
+	defer span.End()
+
+	evt := p.Event(time.Now())
+	trackedEvent := event.NewOSSTrackedEvent(evt, nil)
+	pausedAt := s.functionPausedAt(ctx, p.WorkspaceID, fn.ID)
+
+	if fn.IsBatchEnabled() {
+		bi := batch.BatchItem{
+			AccountID:       p.AccountID,
+			WorkspaceID:     p.WorkspaceID,
+			AppID:           p.AppID,
+			FunctionID:      fn.ID,
+			FunctionVersion: fn.FunctionVersion,
+			EventID:         trackedEvent.GetInternalID(),
+			Event:           evt,
+		}
+		if err := s.exec.AppendAndScheduleBatch(ctx, *fn, bi, &execution.BatchExecOpts{
+			FunctionPausedAt: pausedAt,
+		}); err != nil {
+			return fmt.Errorf("could not append and schedule batch item: %w", err)
+		}
+	} else {
+		_, err := s.exec.Schedule(ctx, execution.ScheduleRequest{
+			Function:         *fn,
+			AccountID:        p.AccountID,
+			WorkspaceID:      p.WorkspaceID,
+			AppID:            p.AppID,
+			Events:           []event.TrackedEvent{trackedEvent},
+			IdempotencyKey:   &evt.ID,
+			FunctionPausedAt: pausedAt,
+		})
+		switch {
+		case err == nil,
+			errors.Is(err, ErrFunctionDebounced),
+			errors.Is(err, ErrFunctionSkipped),
+			errors.Is(err, ErrFunctionSkippedIdempotency),
+			errors.Is(err, state.ErrIdentifierExists):
+		default:
+			return fmt.Errorf("error scheduling cron: %w", err)
+		}
+	}
+
+	// Publish the cron event so that it's visible alongside other events.
+	byt, err := json.Marshal(trackedEvent)
+	if err != nil {
+		l.Error("error marshaling cron event", "error", err)
+		return nil
+	}
+	err = s.publisher.Publish(
+		ctx,
+		s.config.EventStream.Service.Concrete.TopicName(),
+		pubsub.Message{
+			Name:      event.EventReceivedName,
+			Data:      string(byt),
+			Timestamp: trackedEvent.GetEvent().Time(),
+		},
+	)
+	if err != nil {
+		l.Error("error publishing cron event", "error", err)
+	}
+
+	return nil
+}
+
+// functionPausedAt returns the time the given function was paused, or nil if
+// the function isn't paused.
+func (s *svc) functionPausedAt(ctx context.Context, wsID, fnID uuid.UUID) *time.Time {
+	fn, err := s.data.GetFunctionByInternalUUID(ctx, wsID, fnID)
+	if err != nil {
+		s.log.Warn("error loading function pause state", "error", err, "function_id", fnID)
+		return nil
+	}
+	return fn.PausedAt
+}
+
+// handleScheduledEvent publishes an event which was held until its timestamp,
+// triggering functions and resuming pauses as if the event was just received.
+func (s *svc) handleScheduledEvent(ctx context.Context, item queue.Item) error {
+	p := scheduledevent.Payload{}
+	if err := json.Unmarshal(item.Payload.(json.RawMessage), &p); err != nil {
+		return fmt.Errorf("error unmarshalling scheduled event payload: %w", err)
+	}
+
+	trackedEvent := p.TrackedEvent()
+	byt, err := json.Marshal(trackedEvent)
+	if err != nil {
+		return fmt.Errorf("error marshalling scheduled event: %w", err)
+	}
+
+	s.log.Debug("publishing scheduled event",
+		"event_name", p.Event.Name,
+		"internal_id", p.InternalID.String(),
+		"external_id", p.Event.ID,
+	)
+
+	carrier := itrace.NewTraceCarrier()
+	itrace.UserTracer().Propagator().Inject(ctx, propagation.MapCarrier(carrier.Context))
+
+	err = s.publisher.Publish(
+		ctx,
+		s.config.EventStream.Service.Concrete.TopicName(),
+		pubsub.Message{
+			Name:      event.EventReceivedName,
+			Data:      string(byt),
+			Timestamp: time.Now(),
+			Metadata: map[string]any{
+				consts.OtelPropagationKey: carrier,
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("error publishing scheduled event: %w", err)
+	}
+	return nil
+}
+
 func (s *svc) findFunctionByID(ctx context.Context, fnID uuid.UUID) (*inngest.Function, error) {
 	fns, err := s.data.Functions(ctx)
 	if err != nil {
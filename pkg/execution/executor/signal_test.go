@@ -0,0 +1,158 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalMatches(t *testing.T) {
+	ctx := context.Background()
+	data := json.RawMessage(`{"user_id":"u_1","amount":10}`)
+
+	ok, err := signalMatches(ctx, `async.data.user_id == "u_1"`, "sig", data)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = signalMatches(ctx, `async.data.amount > 20`, "sig", data)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = signalMatches(ctx, `async.signal == "sig"`, "sig", nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = signalMatches(ctx, `async.data.user_id == "u_1"`, "sig", json.RawMessage(`{`))
+	require.Error(t, err)
+}
+
+type signalPauseManager struct {
+	state.PauseManager
+
+	pauses   []*state.Pause
+	consumed []uuid.UUID
+	// fail contains pauses which fail to be consumed.
+	fail map[uuid.UUID]error
+}
+
+func (m *signalPauseManager) PausesBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) ([]*state.Pause, error) {
+	return m.pauses, nil
+}
+
+func (m *signalPauseManager) LeasePause(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (m *signalPauseManager) ConsumePause(ctx context.Context, p state.Pause, opts state.ConsumePauseOpts) (state.ConsumePauseResult, func() error, error) {
+	if err, ok := m.fail[p.ID]; ok {
+		return state.ConsumePauseResult{}, func() error { return nil }, err
+	}
+	m.consumed = append(m.consumed, p.ID)
+	return state.ConsumePauseResult{}, func() error { return nil }, nil
+}
+
+type signalRunService struct {
+	sv2.RunService
+}
+
+func (signalRunService) LoadMetadata(ctx context.Context, id sv2.ID) (sv2.Metadata, error) {
+	return sv2.Metadata{ID: id}, nil
+}
+
+type signalQueue struct {
+	queue.Queue
+}
+
+func signalPause(wsID uuid.UUID, signal string, expr *string) *state.Pause {
+	return &state.Pause{
+		ID:          uuid.New(),
+		WorkspaceID: wsID,
+		Identifier: state.PauseIdentifier{
+			RunID:      ulid.Make(),
+			FunctionID: uuid.New(),
+			AccountID:  uuid.New(),
+		},
+		Expires:    state.Time(time.Now().Add(time.Hour)),
+		SignalID:   &signal,
+		Expression: expr,
+	}
+}
+
+func TestResumeSignalFansOut(t *testing.T) {
+	ctx := context.Background()
+	wsID := uuid.New()
+	signal := "order.approved"
+
+	pause := func(expr *string) *state.Pause {
+		return signalPause(wsID, signal, expr)
+	}
+
+	matching := `async.data.order_id == "o_1"`
+	other := `async.data.order_id == "o_2"`
+	pauses := []*state.Pause{pause(nil), pause(&matching), pause(&matching), pause(&other)}
+
+	pm := &signalPauseManager{pauses: pauses}
+	e := &executor{
+		log:   logger.StdlibLogger(ctx),
+		pm:    pm,
+		smv2:  signalRunService{},
+		queue: signalQueue{},
+	}
+
+	res, err := e.ResumeSignal(ctx, wsID, signal, json.RawMessage(`{"order_id":"o_1"}`))
+	require.NoError(t, err)
+	require.True(t, res.MatchedSignal)
+	require.Equal(t, pauses[0].Identifier.RunID, *res.RunID)
+	require.Equal(t, []ulid.ULID{
+		pauses[0].Identifier.RunID,
+		pauses[1].Identifier.RunID,
+		pauses[2].Identifier.RunID,
+	}, res.RunIDs)
+	require.Equal(t, []uuid.UUID{pauses[0].ID, pauses[1].ID, pauses[2].ID}, pm.consumed)
+}
+
+func TestResumeSignalContinuesAfterErrors(t *testing.T) {
+	ctx := context.Background()
+	wsID := uuid.New()
+	signal := "order.approved"
+
+	pauses := []*state.Pause{
+		signalPause(wsID, signal, nil),
+		signalPause(wsID, signal, nil),
+		signalPause(wsID, signal, nil),
+	}
+
+	consumeErr := errors.New("consume failed")
+	pm := &signalPauseManager{
+		pauses: pauses,
+		fail:   map[uuid.UUID]error{pauses[1].ID: consumeErr},
+	}
+	e := &executor{
+		log:   logger.StdlibLogger(ctx),
+		pm:    pm,
+		smv2:  signalRunService{},
+		queue: signalQueue{},
+	}
+
+	res, err := e.ResumeSignal(ctx, wsID, signal, nil)
+	require.ErrorIs(t, err, consumeErr)
+	require.ErrorContains(t, err, pauses[1].ID.String())
+
+	// The pauses either side of the failure are still resumed.
+	require.True(t, res.MatchedSignal)
+	require.Equal(t, []ulid.ULID{
+		pauses[0].Identifier.RunID,
+		pauses[2].Identifier.RunID,
+	}, res.RunIDs)
+	require.Equal(t, []uuid.UUID{pauses[0].ID, pauses[2].ID}, pm.consumed)
+}
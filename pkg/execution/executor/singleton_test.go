@@ -0,0 +1,208 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// singletonLog records the order in which runs are enqueued and deleted.
+type singletonLog struct {
+	l       sync.Mutex
+	entries []string
+}
+
+func (s *singletonLog) add(entry string) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.entries = append(s.entries, entry)
+}
+
+func (s *singletonLog) get() []string {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return append([]string{}, s.entries...)
+}
+
+type singletonLock struct {
+	current *ulid.ULID
+}
+
+func (s singletonLock) Singleton(ctx context.Context, key string, c inngest.Singleton) (*ulid.ULID, error) {
+	return s.current, nil
+}
+
+type singletonState struct {
+	state.State
+
+	id state.Identifier
+}
+
+func (s singletonState) Identifier() state.Identifier {
+	return s.id
+}
+
+type singletonRunService struct {
+	sv2.RunService
+
+	log *singletonLog
+}
+
+func (r singletonRunService) Create(ctx context.Context, s sv2.CreateState) (state.State, error) {
+	return singletonState{id: sv2.V1FromMetadata(s.Metadata)}, nil
+}
+
+func (r singletonRunService) LoadMetadata(ctx context.Context, id sv2.ID) (sv2.Metadata, error) {
+	return sv2.Metadata{ID: id}, nil
+}
+
+func (r singletonRunService) LoadEvents(ctx context.Context, id sv2.ID) ([]json.RawMessage, error) {
+	return []json.RawMessage{json.RawMessage(`{"name":"test/event","data":{}}`)}, nil
+}
+
+func (r singletonRunService) Delete(ctx context.Context, id sv2.ID) (bool, error) {
+	r.log.add("delete " + id.RunID.String())
+	return true, nil
+}
+
+type singletonQueue struct {
+	queue.Queue
+
+	log *singletonLog
+	err error
+}
+
+func (q singletonQueue) Enqueue(ctx context.Context, item queue.Item, at time.Time, opts queue.EnqueueOpts) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.log.add("enqueue " + item.Identifier.RunID.String())
+	return nil
+}
+
+type singletonFunctions struct {
+	fn inngest.Function
+}
+
+func (f singletonFunctions) LoadFunction(ctx context.Context, envID, fnID uuid.UUID) (*state.ExecutorFunction, error) {
+	return &state.ExecutorFunction{Function: &f.fn}, nil
+}
+
+// singletonLifecycle records cancelled runs.
+type singletonLifecycle struct {
+	execution.NoopLifecyceListener
+
+	cancelled chan execution.CancelRequest
+}
+
+func (l singletonLifecycle) OnFunctionCancelled(ctx context.Context, md sv2.Metadata, cr execution.CancelRequest, evts []json.RawMessage) {
+	l.cancelled <- cr
+}
+
+func newSingletonExecutor(ctx context.Context, fn inngest.Function, current *ulid.ULID, log *singletonLog, enqueueErr error) (*executor, singletonLifecycle) {
+	lifecycle := singletonLifecycle{cancelled: make(chan execution.CancelRequest, 1)}
+	return &executor{
+		log:          logger.StdlibLogger(ctx),
+		smv2:         singletonRunService{log: log},
+		queue:        singletonQueue{log: log, err: enqueueErr},
+		singletonMgr: singletonLock{current: current},
+		fl:           singletonFunctions{fn: fn},
+		lifecycles:   []execution.LifecycleListener{lifecycle},
+		shardFinder: func(ctx context.Context, accountId uuid.UUID, queueName *string) (redis_state.QueueShard, error) {
+			return redis_state.QueueShard{}, nil
+		},
+	}, lifecycle
+}
+
+func TestScheduleSingletonCancelMode(t *testing.T) {
+	ctx := context.Background()
+
+	fn := inngest.Function{
+		ID:        uuid.New(),
+		Name:      "singleton",
+		Singleton: &inngest.Singleton{Mode: enums.SingletonModeCancel},
+	}
+	req := execution.ScheduleRequest{
+		Function:    fn,
+		AccountID:   uuid.New(),
+		WorkspaceID: uuid.New(),
+		AppID:       uuid.New(),
+		Events: []event.TrackedEvent{
+			event.NewOSSTrackedEvent(event.Event{Name: "test/event", Data: map[string]any{}}, nil),
+		},
+	}
+
+	t.Run("the in-progress run is cancelled after the new run is enqueued", func(t *testing.T) {
+		current := ulid.Make()
+		log := &singletonLog{}
+		e, lifecycle := newSingletonExecutor(ctx, fn, &current, log, nil)
+
+		md, err := e.Schedule(ctx, req)
+		require.NoError(t, err)
+
+		select {
+		case cr := <-lifecycle.cancelled:
+			require.Equal(t, md.ID.RunID, *cr.ReplacedByRunID)
+			require.Equal(t, req.Events[0].GetInternalID(), *cr.EventID)
+		case <-time.After(time.Second):
+			require.Fail(t, "the in-progress run wasn't cancelled")
+		}
+
+		require.Equal(t, []string{
+			"enqueue " + md.ID.RunID.String(),
+			"delete " + current.String(),
+		}, log.get())
+	})
+
+	t.Run("the in-progress run isn't cancelled if enqueueing fails", func(t *testing.T) {
+		current := ulid.Make()
+		log := &singletonLog{}
+		e, lifecycle := newSingletonExecutor(ctx, fn, &current, log, errors.New("enqueue failed"))
+
+		_, err := e.Schedule(ctx, req)
+		require.ErrorContains(t, err, "enqueue failed")
+		require.Empty(t, log.get())
+		require.Empty(t, lifecycle.cancelled)
+	})
+
+	t.Run("runs are scheduled without an in-progress run", func(t *testing.T) {
+		log := &singletonLog{}
+		e, lifecycle := newSingletonExecutor(ctx, fn, nil, log, nil)
+
+		md, err := e.Schedule(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"enqueue " + md.ID.RunID.String()}, log.get())
+		require.Empty(t, lifecycle.cancelled)
+	})
+
+	t.Run("skip mode skips the new run", func(t *testing.T) {
+		skip := fn
+		skip.Singleton = &inngest.Singleton{Mode: enums.SingletonModeSkip}
+		skipReq := req
+		skipReq.Function = skip
+
+		current := ulid.Make()
+		log := &singletonLog{}
+		e, _ := newSingletonExecutor(ctx, skip, &current, log, nil)
+
+		_, err := e.Schedule(ctx, skipReq)
+		require.ErrorIs(t, err, ErrFunctionSkipped)
+		require.Empty(t, log.get())
+	})
+}
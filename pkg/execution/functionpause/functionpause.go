@@ -0,0 +1,87 @@
+package functionpause
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution/replay"
+)
+
+var (
+	// ErrBackfillUnavailable is returned when unpausing a function with a
+	// backfill and no replay manager is configured.
+	ErrBackfillUnavailable = fmt.Errorf("backfilling skipped runs is not available")
+)
+
+// Pauser pauses and unpauses functions.  While a function is paused, new runs
+// are recorded as skipped instead of being scheduled.
+type Pauser struct {
+	Functions cqrs.FunctionReader
+	Writer    cqrs.FunctionPauseWriter
+	// Replays backfills runs skipped while the function was paused.  This is
+	// optional;  unpausing with a backfill fails if this is nil.
+	Replays *replay.Manager
+}
+
+// Pause pauses the given function.  Pausing a function which is already paused
+// keeps the original pause time.
+func (p Pauser) Pause(ctx context.Context, wsID, fnID uuid.UUID) (*cqrs.Function, error) {
+	fn, err := p.Functions.GetFunctionByInternalUUID(ctx, wsID, fnID)
+	if err != nil {
+		return nil, err
+	}
+	if fn.IsPaused() {
+		return fn, nil
+	}
+
+	now := time.Now()
+	return p.Writer.UpdateFunctionPausedAt(ctx, cqrs.UpdateFunctionPausedAtParams{
+		ID:       fnID,
+		PausedAt: &now,
+	})
+}
+
+// Unpause unpauses the given function.  If backfill is true, every run skipped
+// since the function was paused is replayed, and the backfill's replay is
+// returned.
+func (p Pauser) Unpause(ctx context.Context, accountID, wsID, fnID uuid.UUID, backfill bool) (*cqrs.Function, *cqrs.Replay, error) {
+	if backfill && p.Replays == nil {
+		return nil, nil, ErrBackfillUnavailable
+	}
+
+	fn, err := p.Functions.GetFunctionByInternalUUID(ctx, wsID, fnID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !fn.IsPaused() {
+		return fn, nil, nil
+	}
+	pausedAt := *fn.PausedAt
+
+	// Unpause before backfilling, else the replayed runs would be skipped too.
+	fn, err = p.Writer.UpdateFunctionPausedAt(ctx, cqrs.UpdateFunctionPausedAtParams{ID: fnID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if !backfill {
+		return fn, nil, nil
+	}
+
+	r, err := p.Replays.Create(ctx, cqrs.Replay{
+		AccountID:   accountID,
+		WorkspaceID: wsID,
+		FunctionID:  fnID,
+		Name:        fmt.Sprintf("Backfill runs skipped since %s", pausedAt.UTC().Format(time.RFC3339)),
+		FromTime:    pausedAt,
+		ToTime:      time.Now(),
+		Statuses:    []enums.ReplayRunStatus{enums.ReplayRunStatusSkippedPaused},
+	})
+	if err != nil {
+		return fn, nil, fmt.Errorf("error backfilling skipped runs: %w", err)
+	}
+	return fn, r, nil
+}
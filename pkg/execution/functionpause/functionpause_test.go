@@ -0,0 +1,64 @@
+package functionpause
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauser(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+	require.NoError(t, err)
+	mgr := base_cqrs.NewCQRS(db, "sqlite")
+
+	accountID, wsID := uuid.New(), uuid.New()
+	fn, err := mgr.InsertFunction(ctx, cqrs.InsertFunctionParams{
+		ID:        uuid.New(),
+		AppID:     uuid.New(),
+		Name:      "test",
+		Slug:      "test",
+		Config:    "{}",
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	p := Pauser{Functions: mgr, Writer: mgr}
+
+	t.Run("pausing keeps the original pause time", func(t *testing.T) {
+		paused, err := p.Pause(ctx, wsID, fn.ID)
+		require.NoError(t, err)
+		require.True(t, paused.IsPaused())
+
+		again, err := p.Pause(ctx, wsID, fn.ID)
+		require.NoError(t, err)
+		require.Equal(t, paused.PausedAt.UnixMilli(), again.PausedAt.UnixMilli())
+	})
+
+	t.Run("backfilling requires replays", func(t *testing.T) {
+		_, _, err := p.Unpause(ctx, accountID, wsID, fn.ID, true)
+		require.ErrorIs(t, err, ErrBackfillUnavailable)
+
+		found, err := mgr.GetFunctionByInternalUUID(ctx, wsID, fn.ID)
+		require.NoError(t, err)
+		require.True(t, found.IsPaused())
+	})
+
+	t.Run("unpauses", func(t *testing.T) {
+		unpaused, backfill, err := p.Unpause(ctx, accountID, wsID, fn.ID, false)
+		require.NoError(t, err)
+		require.False(t, unpaused.IsPaused())
+		require.Nil(t, backfill)
+
+		// Unpausing an unpaused function is a no-op.
+		unpaused, _, err = p.Unpause(ctx, accountID, wsID, fn.ID, false)
+		require.NoError(t, err)
+		require.False(t, unpaused.IsPaused())
+	})
+}
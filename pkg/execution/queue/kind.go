@@ -11,5 +11,7 @@ const (
 	KindScheduleBatch   = "schedule-batch"
 	KindEdgeError       = "edge-error" // KindEdgeError is used to indicate a final step error attempting a graceful save.
 	KindQueueMigrate    = "queue-migrate"
-	KindPauseBlockFlush = "pbf" // Flushes pauses from the buffer to blocks.
+	KindPauseBlockFlush = "pbf"             // Flushes pauses from the buffer to blocks.
+	KindCron            = "cron"            // Fires a single cron tick for a function version.
+	KindScheduledEvent  = "scheduled-event" // Publishes an event sent with a future timestamp.
 )
@@ -203,6 +203,9 @@ type JobResponse struct {
 	Kind string `json:"kind"`
 	// Attempt
 	Attempt int `json:"attempt"`
+	// Score is the time at which the job is processed after applying its
+	// priority factor, in milliseconds.  This is only set by FunctionJobs.
+	Score int64 `json:"score,omitempty"`
 
 	Raw any
 }
@@ -239,6 +242,18 @@ type JobQueueReader interface {
 		limit,
 		offset int64,
 	) ([]JobResponse, error)
+
+	// FunctionJobs reads pending items in a function's queue, in the order in
+	// which they're processed.  Each item's Score is its effective time after
+	// applying the run's priority factor.
+	FunctionJobs(
+		ctx context.Context,
+		queueShardName string,
+		workspaceID uuid.UUID,
+		workflowID uuid.UUID,
+		limit,
+		offset int64,
+	) ([]JobResponse, error)
 }
 
 // MigratePayload stores the information to be used when migrating a queue shard to another one
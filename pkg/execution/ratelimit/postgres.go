@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/inngest/inngest/pkg/inngest"
+)
+
+// pgNowMillis is the database's current time in milliseconds.  The database's
+// clock is used so that every server sees the same time, as with Redis.
+const pgNowMillis = `(EXTRACT(EPOCH FROM clock_timestamp()) * 1000)::BIGINT`
+
+// NewPostgres returns a RateLimiter which stores GCRA state in Postgres.
+func NewPostgres(db *sql.DB) RateLimiter {
+	return &postgresStore{db: db}
+}
+
+type postgresStore struct {
+	db *sql.DB
+}
+
+func (p *postgresStore) RateLimit(ctx context.Context, key string, c inngest.RateLimit) (bool, time.Duration, error) {
+	return rateLimit(ctx, p, key, c)
+}
+
+// GetWithTime returns the value of the key if it is in the store
+// or -1 if it does not exist. It also returns the current time at
+// the database to microsecond precision.
+func (p *postgresStore) GetWithTime(ctx context.Context, key string) (int64, time.Time, error) {
+	var (
+		now int64
+		v   sql.NullInt64
+	)
+	err := p.db.QueryRowContext(ctx, `
+		WITH now AS (SELECT (EXTRACT(EPOCH FROM clock_timestamp()) * 1000000)::BIGINT AS us)
+		SELECT now.us, rate_limits.value FROM now
+		LEFT JOIN rate_limits ON rate_limits.key = $1 AND rate_limits.expires_at > now.us / 1000`,
+		key,
+	).Scan(&now, &v)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if !v.Valid {
+		return -1, time.UnixMicro(now), nil
+	}
+	return v.Int64, time.UnixMicro(now), nil
+}
+
+// SetIfNotExistsWithTTL sets the value of key only if it is not
+// already set in the store it returns whether a new value was set.
+func (p *postgresStore) SetIfNotExistsWithTTL(ctx context.Context, key string, value int64, ttl time.Duration) (bool, error) {
+	res, err := p.db.ExecContext(ctx, `
+		INSERT INTO rate_limits (key, value, expires_at) VALUES ($1, $2, `+pgNowMillis+` + $3)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, expires_at = EXCLUDED.expires_at
+		WHERE rate_limits.expires_at <= `+pgNowMillis,
+		key, value, minTTL(ttl).Milliseconds(),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// CompareAndSwapWithTTL atomically compares the value at key to the
+// old value. If it matches, it sets it to the new value and returns
+// true. Otherwise, it returns false. If the key does not exist in the
+// store, it returns false with no error. If the swap succeeds, the
+// ttl for the key is updated atomically.
+func (p *postgresStore) CompareAndSwapWithTTL(ctx context.Context, key string, old, new int64, ttl time.Duration) (bool, error) {
+	res, err := p.db.ExecContext(ctx, `
+		UPDATE rate_limits SET value = $3, expires_at = `+pgNowMillis+` + $4
+		WHERE key = $1 AND value = $2 AND expires_at > `+pgNowMillis,
+		key, old, new, minTTL(ttl).Milliseconds(),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// minTTL ensures that keys are kept for a minimum of one second, matching the
+// Redis store.
+func minTTL(ttl time.Duration) time.Duration {
+	if ttl < time.Second {
+		return time.Second
+	}
+	return ttl
+}
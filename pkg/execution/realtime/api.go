@@ -11,6 +11,7 @@ import (
 	"github.com/coder/websocket"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/inngest/inngest/pkg/api/apiv1/apiv1auth"
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
@@ -62,6 +63,8 @@ func (a *api) setup() {
 		r.Use(realtimeAuthMW(a.opts.JWTSecret, a.opts.AuthMiddleware))
 
 		r.Get("/realtime/connect", a.GetWebsocketUpgrade)
+		r.Get("/realtime/sse", a.GetSSE)
+		r.Get("/realtime/topics/{channel}/presence", a.GetPresence)
 		r.Post("/realtime/token", a.PostCreateJWT)
 	})
 
@@ -131,6 +134,24 @@ func (a *api) GetWebsocketUpgrade(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Clients reconnecting can pass the ID of the last message received as a
+	// cursor, receiving any messages retained in history since.
+	var cursor ulid.ULID
+	if c := r.URL.Query().Get("cursor"); c != "" {
+		if cursor, err = ulid.Parse(c); err != nil {
+			w.Header().Add("content-type", "application/json")
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid cursor"))
+			return
+		}
+	}
+
+	metadata, err := presenceParam(r)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
 	ws, err := websocket.Accept(w, r, &websocket.AcceptOptions{
 		InsecureSkipVerify: true, // We don't care about verifying the origin.
 	})
@@ -155,6 +176,8 @@ func (a *api) GetWebsocketUpgrade(w http.ResponseWriter, r *http.Request) {
 		auth.WorkspaceID(),
 		a.opts.JWTSecret,
 		ws,
+		cursor,
+		metadata,
 		auth.Topics,
 	)
 	if err != nil {
@@ -172,9 +195,154 @@ func (a *api) GetWebsocketUpgrade(w http.ResponseWriter, r *http.Request) {
 		)
 	}
 
+	// Remove the subscription immediately, ensuring that others in the
+	// subscription's channels are notified that it left.
+	if err := a.opts.Broadcaster.CloseSubscription(context.WithoutCancel(ctx), sub.ID()); err != nil {
+		logger.StdlibLogger(ctx).Warn("error closing ws subscription", "error", err)
+	}
 	_ = ws.CloseNow()
 }
 
+// GetSSE subscribes to the JWT's topics via server-sent-events, for clients that
+// cannot use websockets.  Clients resuming a connection can pass the last seen
+// message ID via the Last-Event-ID header or the ?last_event_id query param,
+// receiving any messages retained in history since.
+func (a *api) GetSSE(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	auth, err := realtimeAuth(ctx)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 401, "Not authenticated"))
+		return
+	}
+
+	var lastID ulid.ULID
+	last := r.Header.Get("Last-Event-ID")
+	if last == "" {
+		last = r.URL.Query().Get("last_event_id")
+	}
+	if last != "" {
+		if lastID, err = ulid.Parse(last); err != nil {
+			w.Header().Add("content-type", "application/json")
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 400, "Invalid Last-Event-ID"))
+			return
+		}
+	}
+
+	metadata, err := presenceParam(r)
+	if err != nil {
+		w.Header().Add("content-type", "application/json")
+		_ = publicerr.WriteHTTP(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "text/event-stream")
+	w.Header().Set("cache-control", "no-cache")
+	w.Header().Set("connection", "keep-alive")
+	// Disable response buffering in proxies such as nginx.
+	w.Header().Set("x-accel-buffering", "no")
+
+	logger.StdlibLogger(ctx).Info(
+		"new realtime sse connection",
+		"acct_id", auth.AccountID(),
+		"env_id", auth.Env,
+		"topics", auth.Topics,
+	)
+
+	// Flush the headers before subscribing so that the client knows the stream
+	// has opened, and so that published messages are written after the headers.
+	w.WriteHeader(http.StatusOK)
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	sub, err := NewSSESubscription(
+		ctx,
+		a.opts.Broadcaster,
+		auth.AccountID(),
+		auth.WorkspaceID(),
+		w,
+		lastID,
+		metadata,
+		auth.Topics,
+	)
+	if err != nil {
+		// NOTE: The stream has already opened, so the client sees the
+		// connection close and reconnects.
+		logger.StdlibLogger(ctx).Error("error creating sse subscription", "error", err)
+		return
+	}
+
+	// Block until the client disconnects or the broadcaster closes the
+	// subscription, then ensure that nothing else is written to the response.
+	_ = sub.Poll(ctx)
+	if err := a.opts.Broadcaster.CloseSubscription(context.WithoutCancel(ctx), sub.ID()); err != nil {
+		logger.StdlibLogger(ctx).Warn("error closing sse subscription", "error", err)
+	}
+	_ = sub.Close()
+}
+
+// GetPresence lists the subscribers within a channel.  This can be called with a
+// realtime JWT for any of the channel's topics, or via the standard auth middleware.
+func (a *api) GetPresence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Add("content-type", "application/json")
+
+	channel := chi.URLParam(r, "channel")
+
+	var envID uuid.UUID
+	if claims, err := realtimeAuth(ctx); err == nil {
+		allowed := false
+		for _, t := range claims.Topics {
+			if t.Channel == channel {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			_ = publicerr.WriteHTTP(w, publicerr.Errorf(403, "Token does not allow access to this channel"))
+			return
+		}
+		envID = claims.WorkspaceID()
+	} else {
+		auth, err := a.opts.AuthFinder(ctx)
+		if err != nil {
+			_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 401, "Not authenticated"))
+			return
+		}
+		envID = auth.WorkspaceID()
+	}
+
+	members, err := a.opts.Broadcaster.Presence(ctx, envID, channel)
+	if err != nil {
+		_ = publicerr.WriteHTTP(w, publicerr.Wrapf(err, 500, "Error reading presence"))
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"channel": channel,
+		"count":   len(members),
+		"members": members,
+	})
+}
+
+// presenceParam returns the presence metadata from the ?presence query param, which
+// must be valid JSON.
+func presenceParam(r *http.Request) (json.RawMessage, error) {
+	p := r.URL.Query().Get("presence")
+	if p == "" {
+		return nil, nil
+	}
+	if len(p) > MaxPresenceMetadataSize {
+		return nil, publicerr.Errorf(400, "Presence metadata must be less than %d bytes", MaxPresenceMetadataSize)
+	}
+	if !json.Valid([]byte(p)) {
+		return nil, publicerr.Errorf(400, "Presence metadata must be valid JSON")
+	}
+	return json.RawMessage(p), nil
+}
+
 func (a *api) PostPublish(w http.ResponseWriter, r *http.Request) {
 	// Allow publishing of arbitrary data using the environment signing
 	// key as the auth token.
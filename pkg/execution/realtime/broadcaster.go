@@ -12,6 +12,7 @@ import (
 	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
 	"github.com/inngest/inngest/pkg/logger"
 	"github.com/inngest/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
 )
 
 var (
@@ -28,18 +29,30 @@ var (
 
 // NewInProcessBroadcaster is a single broadcaster which manages active subscriptions
 // in-memory and broadcasts to connected subscribers.
-func NewInProcessBroadcaster() Broadcaster {
-	return newBroadcaster()
+func NewInProcessBroadcaster(opts ...BroadcasterOpt) Broadcaster {
+	o := broadcasterOpts{}
+	for _, apply := range opts {
+		apply(&o)
+	}
+
+	b := newBroadcaster()
+	if o.historyEnabled() {
+		b.history = newMemoryHistory(o.historySize, o.historyTTL)
+	}
+	return b
 }
 
 func newBroadcaster() *broadcaster {
-	return &broadcaster{
-		closing: 0,
-		subs:    map[uuid.UUID]*activesub{},
-		topics:  map[string]topicsub{},
-		l:       &sync.RWMutex{},
-		conds:   map[string]*sync.Cond{},
+	b := &broadcaster{
+		closing:  0,
+		subs:     map[uuid.UUID]*activesub{},
+		topics:   map[string]topicsub{},
+		l:        &sync.RWMutex{},
+		conds:    map[string]*sync.Cond{},
+		presence: newMemoryPresence(),
 	}
+	b.publisher = b
+	return b
 }
 
 // broadcaster represents a set of subscriptions for one or more topics.
@@ -68,6 +81,16 @@ type broadcaster struct {
 	// conds is a map of subscriptionID-topic hashes to a sync.Cond, allowing
 	// us to
 	conds map[string]*sync.Cond
+
+	// history retains recently published messages for SubscribeFrom calls.  This
+	// is nil if history is disabled.
+	history history
+
+	// presence stores the subscribers within each channel.
+	presence presence
+	// publisher publishes presence messages.  This is the broadcaster itself, or the
+	// wrapping broadcaster for implementations which embed a broadcaster.
+	publisher Publisher
 }
 
 func (b *broadcaster) Subscribe(ctx context.Context, s Subscription, topics []streamingtypes.Topic) error {
@@ -77,6 +100,10 @@ func (b *broadcaster) Subscribe(ctx context.Context, s Subscription, topics []st
 	return b.subscribe(ctx, s, topics, nil, nil)
 }
 
+func (b *broadcaster) SubscribeFrom(ctx context.Context, s Subscription, topics []Topic, cursor ulid.ULID) error {
+	return b.subscribeFrom(ctx, s, topics, cursor, b.Subscribe)
+}
+
 // subscribe ensures that a given Subscription is subscribed to the provided topics.
 // The onSubscribe callback is called when the subscription starts for eahc topic, and the
 // onUnsubscribe callback is called when the subscription ends, eg. when Close or Unsubscribe
@@ -95,6 +122,11 @@ func (b *broadcaster) subscribe(
 		return ErrBroadcasterClosed
 	}
 
+	// Notify channels that the subscription joined after releasing the lock,
+	// as presence messages are published via the broadcaster.
+	var joined []presenceChannel
+	defer func() { b.join(ctx, s, joined) }()
+
 	b.l.Lock()
 	defer b.l.Unlock()
 
@@ -136,9 +168,11 @@ func (b *broadcaster) subscribe(
 
 	if as, ok := b.subs[s.ID()]; ok {
 		as.AddTopics(topics...)
+		joined = as.JoinChannels()
 	} else {
 		as = &activesub{Subscription: s}
 		as.AddTopics(topics...)
+		joined = as.JoinChannels()
 		b.subs[s.ID()] = as
 		// This is the first time we've seen a subscription.  Send
 		// keepalives after an interval to ensure that the connection
@@ -194,6 +228,16 @@ func (b *broadcaster) Unsubscribe(ctx context.Context, subID uuid.UUID, topics [
 		return ErrBroadcasterClosed
 	}
 
+	var (
+		as   *activesub
+		left []presenceChannel
+	)
+	defer func() {
+		if len(left) > 0 {
+			b.leave(ctx, as.Subscription, left)
+		}
+	}()
+
 	b.l.Lock()
 	defer b.l.Unlock()
 
@@ -227,12 +271,23 @@ func (b *broadcaster) Unsubscribe(ctx context.Context, subID uuid.UUID, topics [
 		}
 	}
 
+	left = as.LeaveChannels()
 	return nil
 }
 
 // CloseSubscription shuts down a subscription, removing it from all topics and removing the subscription
 // from the subscription map.
 func (b *broadcaster) CloseSubscription(ctx context.Context, subscriptionID uuid.UUID) error {
+	var (
+		as   *activesub
+		left []presenceChannel
+	)
+	defer func() {
+		if len(left) > 0 {
+			b.leave(ctx, as.Subscription, left)
+		}
+	}()
+
 	b.l.Lock()
 	defer b.l.Unlock()
 
@@ -257,6 +312,9 @@ func (b *broadcaster) CloseSubscription(ctx context.Context, subscriptionID uuid
 
 	// Then remove the subscription from our subscription map
 	delete(b.subs, subscriptionID)
+
+	as.Topics = nil
+	left = as.LeaveChannels()
 	return nil
 }
 
@@ -296,6 +354,20 @@ func (b *broadcaster) Close(ctx context.Context) error {
 }
 
 func (b *broadcaster) Publish(ctx context.Context, m Message) {
+	if m.ID == (ulid.ULID{}) {
+		m.ID = ulid.Make()
+	}
+
+	// Retain the message before publishing, so that any subscriptions replaying
+	// history receive the message once.
+	if b.history != nil && retained(m) {
+		for _, t := range m.Topics() {
+			if err := b.history.Append(ctx, t, m); err != nil {
+				logger.StdlibLogger(ctx).Warn("error appending realtime history", "error", err)
+			}
+		}
+	}
+
 	b.l.RLock()
 	defer b.l.RUnlock()
 
@@ -403,18 +475,20 @@ func (b *broadcaster) keepalive(ctx context.Context, subID uuid.UUID) {
 		// ensure the subscription ID exists, else it has been closed.
 		b.l.RLock()
 		sub, ok := b.subs[subID]
+		b.l.RUnlock()
 		if !ok {
 			return
 		}
-		b.l.RUnlock()
 
 		err := sub.SendKeepalive(Message{
 			Kind:      streamingtypes.MessageKindPing,
 			CreatedAt: time.Now(),
 		})
 		if err == nil {
-			// reset the error count on success.
+			// reset the error count on success, and ensure that the
+			// subscription isn't removed from presence as stale.
 			errCount = 0
+			b.refreshPresence(ctx, subID)
 		}
 		if err != nil {
 			errCount += 1
@@ -442,6 +516,10 @@ type activesub struct {
 
 	// Topics lists all topics that the subscription is interested in
 	Topics map[string]Topic
+
+	// Channels lists all channels that the subscription has joined, by
+	// presence key.
+	Channels map[string]presenceChannel
 }
 
 func (a *activesub) AddTopics(t ...Topic) {
@@ -454,6 +532,48 @@ func (a *activesub) AddTopics(t ...Topic) {
 	}
 }
 
+// JoinChannels records the channels of the subscription's topics, returning any
+// channels which were newly joined.
+func (a *activesub) JoinChannels() []presenceChannel {
+	if a.Channels == nil {
+		a.Channels = map[string]presenceChannel{}
+	}
+
+	joined := []presenceChannel{}
+	for _, t := range a.Topics {
+		if t.Channel == "" {
+			continue
+		}
+		key := presenceKey(t.EnvID, t.Channel)
+		if _, ok := a.Channels[key]; ok {
+			continue
+		}
+		c := presenceChannel{EnvID: t.EnvID, Channel: t.Channel, JoinedAt: time.Now()}
+		a.Channels[key] = c
+		joined = append(joined, c)
+	}
+	return joined
+}
+
+// LeaveChannels removes any channels which the subscription no longer has topics
+// for, returning the channels which were left.
+func (a *activesub) LeaveChannels() []presenceChannel {
+	remaining := map[string]struct{}{}
+	for _, t := range a.Topics {
+		remaining[presenceKey(t.EnvID, t.Channel)] = struct{}{}
+	}
+
+	left := []presenceChannel{}
+	for key, c := range a.Channels {
+		if _, ok := remaining[key]; ok {
+			continue
+		}
+		delete(a.Channels, key)
+		left = append(left, c)
+	}
+	return left
+}
+
 // topicsub represents subscriptions to a particular topic, for lookup
 // by topic -> subscribers.
 type topicsub struct {
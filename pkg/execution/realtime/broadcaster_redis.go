@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
 	"github.com/redis/rueidis"
 )
 
@@ -23,12 +24,31 @@ const (
 //
 // The messages pass from executors (calling .Publish) to gateways (susbcribed to redis pub/sub via
 // .Subscribe calls), being sent to all interested subscribers.
-func NewRedisBroadcaster(pubc, subc rueidis.Client) Broadcaster {
-	return &redisBroadcaster{
-		broadcaster: newBroadcaster(),
+//
+// If history is enabled via WithHistory, messages are retained in a Redis stream per topic
+// so that subscribers connected to any gateway can replay them.  Channel presence is also
+// stored in Redis, allowing any gateway to list the subscribers within a channel.
+func NewRedisBroadcaster(pubc, subc rueidis.Client, opts ...BroadcasterOpt) Broadcaster {
+	o := broadcasterOpts{}
+	for _, apply := range opts {
+		apply(&o)
+	}
+
+	b := newBroadcaster()
+	if o.historyEnabled() {
+		b.history = newRedisHistory(pubc, o.historySize, o.historyTTL)
+	}
+	b.presence = newRedisPresence(pubc)
+
+	rb := &redisBroadcaster{
+		broadcaster: b,
 		pubc:        pubc,
 		subc:        subc,
 	}
+	// Publish presence messages via Redis, so that subscribers on every
+	// broadcaster are notified.
+	b.publisher = rb
+	return rb
 }
 
 type redisBroadcaster struct {
@@ -45,6 +65,12 @@ type redisBroadcaster struct {
 // Publish publishes a message to Redis' pub-sub.  This is then caught by any subscribers
 // to the same Redis pub-sub channels, which push the message to any connected Subscriptions.
 func (b *redisBroadcaster) Publish(ctx context.Context, m Message) {
+	// Set the ID before publishing, so that every replica sends the same ID
+	// to its subscribers.
+	if m.ID == (ulid.ULID{}) {
+		m.ID = ulid.Make()
+	}
+
 	// Push the message to Redis' pub/sub so that all other replicas of the
 	// broadcaster receive the same content.  This ensures that every subscription
 	// publishes message data.
@@ -61,6 +87,17 @@ func (b *redisBroadcaster) Publish(ctx context.Context, m Message) {
 
 	for _, t := range m.Topics() {
 		go func(t Topic) {
+			// Retain the message before publishing, so that any subscriptions
+			// replaying history receive the message once.
+			if b.history != nil && retained(m) {
+				if err := b.history.Append(pubCtx, t, m); err != nil {
+					logger.StdlibLogger(ctx).Warn(
+						"error appending realtime history",
+						"error", err,
+						"topic", t,
+					)
+				}
+			}
 			b.publish(pubCtx, t.String(), string(content))
 		}(t)
 	}
@@ -147,6 +184,12 @@ func (b *redisBroadcaster) Subscribe(ctx context.Context, s Subscription, topics
 	return err
 }
 
+// SubscribeFrom subscribes to the given topics via Redis' pub/sub, first writing any
+// messages in the topics' history after the cursor.
+func (b *redisBroadcaster) SubscribeFrom(ctx context.Context, s Subscription, topics []Topic, cursor ulid.ULID) error {
+	return b.subscribeFrom(ctx, s, topics, cursor, b.Subscribe)
+}
+
 func (b *redisBroadcaster) redisPubsub(ctx context.Context, s Subscription, t Topic) error {
 	cmd := b.subc.B().Subscribe().Channel(t.String()).Build()
 	err := b.subc.Receive(ctx, cmd, func(msg rueidis.PubSubMessage) {
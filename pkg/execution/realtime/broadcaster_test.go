@@ -36,6 +36,7 @@ func TestBroadcaster(t *testing.T) {
 
 	t.Run("broadcasting", func(t *testing.T) {
 		msg := Message{
+			ID:      ulid.Make(),
 			Kind:    streamingtypes.MessageKindData,
 			Data:    json.RawMessage(`"output"`),
 			Channel: ulid.MustNew(ulid.Now(), rand.Reader).String(),
@@ -0,0 +1,370 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+)
+
+var (
+	// DefaultHistorySize is the default number of messages retained per topic
+	// when history is enabled.
+	DefaultHistorySize = 100
+	// DefaultHistoryTTL is the default duration that messages are retained for
+	// when history is enabled.
+	DefaultHistoryTTL = 5 * time.Minute
+)
+
+// BroadcasterOpt configures a broadcaster.
+type BroadcasterOpt func(o *broadcasterOpts)
+
+type broadcasterOpts struct {
+	historySize int
+	historyTTL  time.Duration
+}
+
+// WithHistory retains the last size messages published to each topic, for up
+// to ttl.  Subscribers calling SubscribeFrom receive any retained messages
+// published after their cursor before live messages, eg. when reconnecting after
+// a network blip.
+//
+// A zero size or ttl leaves that bound unset.  History is disabled if both are
+// zero.
+func WithHistory(size int, ttl time.Duration) BroadcasterOpt {
+	return func(o *broadcasterOpts) {
+		o.historySize = size
+		o.historyTTL = ttl
+	}
+}
+
+func (o broadcasterOpts) historyEnabled() bool {
+	return o.historySize > 0 || o.historyTTL > 0
+}
+
+// history stores recently published messages for each topic.
+type history interface {
+	// Append adds a published message to the topic's history, trimming the
+	// history to its bounds.
+	Append(ctx context.Context, t Topic, m Message) error
+	// Since returns the retained messages for the topic published after the
+	// given cursor, oldest first.
+	Since(ctx context.Context, t Topic, cursor ulid.ULID) ([]Message, error)
+}
+
+// retained returns whether the message should be retained in history.  Presence
+// messages aren't retained, as the current members of a channel can be read via
+// Presence.
+func retained(m Message) bool {
+	return m.Kind != streamingtypes.MessageKindPresence
+}
+
+// expired returns whether the message is older than the history's ttl.
+func expired(m Message, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+	return time.Since(ulid.Time(m.ID.Time())) > ttl
+}
+
+// newMemoryHistory creates an in-memory history, used by the in-process
+// broadcaster.
+func newMemoryHistory(size int, ttl time.Duration) *memoryHistory {
+	return &memoryHistory{
+		size:   size,
+		ttl:    ttl,
+		topics: map[string][]Message{},
+	}
+}
+
+type memoryHistory struct {
+	size int
+	ttl  time.Duration
+
+	l      sync.Mutex
+	topics map[string][]Message
+	// sweptAt records when expired topics were last evicted.
+	sweptAt time.Time
+}
+
+func (h *memoryHistory) Append(ctx context.Context, t Topic, m Message) error {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	key := t.String()
+	h.topics[key] = h.trim(append(h.topics[key], m))
+	h.sweep()
+	return nil
+}
+
+// sweep evicts topics whose messages have all expired, such that topics which
+// are no longer published to or read don't stay in memory.  This runs at most
+// once per ttl, and must be called with the lock held.
+func (h *memoryHistory) sweep() {
+	if h.ttl <= 0 || time.Since(h.sweptAt) < h.ttl {
+		return
+	}
+	h.sweptAt = time.Now()
+
+	for key, msgs := range h.topics {
+		if msgs = h.trim(msgs); len(msgs) == 0 {
+			delete(h.topics, key)
+			continue
+		}
+		h.topics[key] = msgs
+	}
+}
+
+func (h *memoryHistory) Since(ctx context.Context, t Topic, cursor ulid.ULID) ([]Message, error) {
+	h.l.Lock()
+	defer h.l.Unlock()
+
+	key := t.String()
+	msgs := h.trim(h.topics[key])
+	if len(msgs) == 0 {
+		delete(h.topics, key)
+		return nil, nil
+	}
+	h.topics[key] = msgs
+
+	result := []Message{}
+	for _, m := range msgs {
+		if m.ID.Compare(cursor) > 0 {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// trim removes expired messages and messages over the history's size.  This
+// must be called with the lock held.
+func (h *memoryHistory) trim(msgs []Message) []Message {
+	if h.size > 0 && len(msgs) > h.size {
+		msgs = msgs[len(msgs)-h.size:]
+	}
+	for len(msgs) > 0 && expired(msgs[0], h.ttl) {
+		msgs = msgs[1:]
+	}
+	return msgs
+}
+
+// newRedisHistory creates a history backed by a Redis stream per topic, shared
+// by all redis broadcasters.
+func newRedisHistory(c rueidis.Client, size int, ttl time.Duration) *redisHistory {
+	return &redisHistory{c: c, size: size, ttl: ttl}
+}
+
+type redisHistory struct {
+	c    rueidis.Client
+	size int
+	ttl  time.Duration
+}
+
+func (h *redisHistory) key(t Topic) string {
+	return "realtime:history:" + t.String()
+}
+
+func (h *redisHistory) Append(ctx context.Context, t Topic, m Message) error {
+	content, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshalling realtime history: %w", err)
+	}
+
+	key := h.key(t)
+	cmds := make(rueidis.Commands, 0, 3)
+	if h.size > 0 {
+		cmds = append(cmds, h.c.B().Xadd().Key(key).Maxlen().Threshold(strconv.Itoa(h.size)).Id("*").FieldValue().FieldValue("msg", string(content)).Build())
+	} else {
+		cmds = append(cmds, h.c.B().Xadd().Key(key).Id("*").FieldValue().FieldValue("msg", string(content)).Build())
+	}
+	if h.ttl > 0 {
+		minID := strconv.FormatInt(time.Now().Add(-h.ttl).UnixMilli(), 10)
+		cmds = append(
+			cmds,
+			h.c.B().Xtrim().Key(key).Minid().Threshold(minID).Build(),
+			h.c.B().Pexpire().Key(key).Milliseconds(h.ttl.Milliseconds()).Build(),
+		)
+	}
+
+	for _, res := range h.c.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			return fmt.Errorf("error appending realtime history: %w", err)
+		}
+	}
+	return nil
+}
+
+func (h *redisHistory) Since(ctx context.Context, t Topic, cursor ulid.ULID) ([]Message, error) {
+	entries, err := h.c.Do(ctx, h.c.B().Xrange().Key(h.key(t)).Start("-").End("+").Build()).AsXRange()
+	if rueidis.IsRedisNil(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading realtime history: %w", err)
+	}
+
+	result := []Message{}
+	for _, e := range entries {
+		m := Message{}
+		if err := json.Unmarshal([]byte(e.FieldValues["msg"]), &m); err != nil {
+			logger.StdlibLogger(ctx).Warn(
+				"error unmarshalling realtime history",
+				"error", err,
+				"topic", t,
+			)
+			continue
+		}
+		if m.ID.Compare(cursor) <= 0 || expired(m, h.ttl) {
+			continue
+		}
+		result = append(result, m)
+	}
+	// Messages are appended by many publishers, so sort by ID to ensure that
+	// they're written in order.
+	sort.Slice(result, func(i, j int) bool { return result[i].ID.Compare(result[j].ID) < 0 })
+	return result, nil
+}
+
+// subscribeFrom subscribes using the given subscribe function, writing any messages
+// in the topics' history after the cursor before any live messages.
+func (b *broadcaster) subscribeFrom(
+	ctx context.Context,
+	s Subscription,
+	topics []Topic,
+	cursor ulid.ULID,
+	subscribe func(ctx context.Context, s Subscription, topics []Topic) error,
+) error {
+	if b.history == nil || len(topics) == 0 {
+		return subscribe(ctx, s, topics)
+	}
+
+	// Subscribe before reading the history so that no messages are lost
+	// between the two.  Live messages are held until the backlog is written,
+	// and any message in both is only written once.
+	rs := &replaySub{
+		Subscription: s,
+		replaying:    true,
+		seen:         map[ulid.ULID]struct{}{},
+	}
+	if err := subscribe(ctx, rs, topics); err != nil {
+		return err
+	}
+
+	backlog := map[ulid.ULID]Message{}
+	for _, t := range topics {
+		msgs, err := b.history.Since(ctx, t, cursor)
+		if err != nil {
+			logger.StdlibLogger(ctx).Warn(
+				"error reading realtime history",
+				"error", err,
+				"topic", t,
+				"subscription_id", s.ID(),
+			)
+			continue
+		}
+		for _, m := range msgs {
+			backlog[m.ID] = m
+		}
+	}
+
+	msgs := make([]Message, 0, len(backlog))
+	for _, m := range backlog {
+		msgs = append(msgs, m)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].ID.Compare(msgs[j].ID) < 0 })
+
+	rs.replay(ctx, msgs)
+	return nil
+}
+
+// replaySub wraps a Subscription, holding live messages while the subscription's
+// backlog is written.
+type replaySub struct {
+	Subscription
+
+	l         sync.Mutex
+	replaying bool
+	pending   []pendingWrite
+	// seen records the IDs of the backlog, ensuring that messages which were
+	// retained and published live are only written once.
+	seen map[ulid.ULID]struct{}
+}
+
+type pendingWrite struct {
+	id ulid.ULID
+	f  func() error
+}
+
+func (r *replaySub) WriteMessage(m Message) error {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if _, ok := r.seen[m.ID]; ok {
+		return nil
+	}
+	if r.replaying {
+		r.pending = append(r.pending, pendingWrite{
+			id: m.ID,
+			f:  func() error { return r.Subscription.WriteMessage(m) },
+		})
+		return nil
+	}
+	return r.Subscription.WriteMessage(m)
+}
+
+func (r *replaySub) WriteChunk(c Chunk) error {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if r.replaying {
+		r.pending = append(r.pending, pendingWrite{
+			f: func() error { return r.Subscription.WriteChunk(c) },
+		})
+		return nil
+	}
+	return r.Subscription.WriteChunk(c)
+}
+
+// replay writes the backlog followed by any live messages received while
+// replaying, then passes all further writes through to the subscription.
+func (r *replaySub) replay(ctx context.Context, backlog []Message) {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	for _, m := range backlog {
+		r.seen[m.ID] = struct{}{}
+		if err := r.Subscription.WriteMessage(m); err != nil {
+			logger.StdlibLogger(ctx).Warn(
+				"error replaying realtime history",
+				"error", err,
+				"subscription_id", r.ID(),
+				"protocol", r.Protocol(),
+			)
+		}
+	}
+
+	for _, p := range r.pending {
+		if _, ok := r.seen[p.id]; ok {
+			continue
+		}
+		if err := p.f(); err != nil {
+			logger.StdlibLogger(ctx).Warn(
+				"error publishing to subscription",
+				"error", err,
+				"subscription_id", r.ID(),
+				"protocol", r.Protocol(),
+			)
+		}
+	}
+
+	r.pending = nil
+	r.replaying = false
+}
@@ -0,0 +1,178 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryHistory(t *testing.T) {
+	ctx := context.Background()
+	topic := Topic{Kind: streamingtypes.TopicKindRun, Channel: "user:123", Name: "ai"}
+
+	t.Run("retains the last n messages", func(t *testing.T) {
+		h := newMemoryHistory(2, 0)
+		msgs := historyMessages(3)
+		for _, m := range msgs {
+			require.NoError(t, h.Append(ctx, topic, m))
+		}
+
+		found, err := h.Since(ctx, topic, ulid.ULID{})
+		require.NoError(t, err)
+		require.Equal(t, msgs[1:], found)
+
+		found, err = h.Since(ctx, topic, msgs[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, msgs[2:], found)
+	})
+
+	t.Run("expires old messages", func(t *testing.T) {
+		h := newMemoryHistory(0, time.Minute)
+		old := streamingtypes.NewMessage(streamingtypes.MessageKindData, "old")
+		old.ID = ulid.MustNew(ulid.Timestamp(time.Now().Add(-2*time.Minute)), nil)
+		current := streamingtypes.NewMessage(streamingtypes.MessageKindData, "current")
+
+		require.NoError(t, h.Append(ctx, topic, old))
+		require.NoError(t, h.Append(ctx, topic, current))
+
+		found, err := h.Since(ctx, topic, ulid.ULID{})
+		require.NoError(t, err)
+		require.Equal(t, []Message{current}, found)
+	})
+
+	t.Run("evicts idle topics", func(t *testing.T) {
+		h := newMemoryHistory(0, time.Minute)
+		idle := Topic{Kind: streamingtypes.TopicKindRun, Channel: "user:456", Name: "ai"}
+		old := streamingtypes.NewMessage(streamingtypes.MessageKindData, "old")
+		old.ID = ulid.MustNew(ulid.Timestamp(time.Now().Add(-2*time.Minute)), nil)
+
+		// Messages in the idle topic expired after they were appended.
+		h.topics[idle.String()] = []Message{old}
+
+		// Appending to another topic sweeps the idle topic.
+		current := streamingtypes.NewMessage(streamingtypes.MessageKindData, "current")
+		require.NoError(t, h.Append(ctx, topic, current))
+		require.NotContains(t, h.topics, idle.String())
+		require.Contains(t, h.topics, topic.String())
+	})
+}
+
+func TestRedisHistory(t *testing.T) {
+	ctx := context.Background()
+	topic := Topic{Kind: streamingtypes.TopicKindRun, Channel: "user:123", Name: "ai"}
+
+	r := miniredis.RunT(t)
+	c, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	h := newRedisHistory(c, 2, time.Minute)
+
+	found, err := h.Since(ctx, topic, ulid.ULID{})
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	msgs := historyMessages(3)
+	for _, m := range msgs {
+		require.NoError(t, h.Append(ctx, topic, m))
+	}
+
+	found, err = h.Since(ctx, topic, ulid.ULID{})
+	require.NoError(t, err)
+	require.Equal(t, msgs[1:], found)
+
+	found, err = h.Since(ctx, topic, msgs[2].ID)
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	require.True(t, r.TTL(h.key(topic)) > 0)
+}
+
+func TestSubscribeFrom(t *testing.T) {
+	ctx := context.Background()
+
+	r := miniredis.RunT(t)
+	newClient := func() rueidis.Client {
+		c, err := rueidis.NewClient(rueidis.ClientOption{
+			InitAddress:  []string{r.Addr()},
+			DisableCache: true,
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	broadcasters := map[string]func() Broadcaster{
+		"in process": func() Broadcaster {
+			return NewInProcessBroadcaster(WithHistory(10, time.Minute))
+		},
+		"redis": func() Broadcaster {
+			return NewRedisBroadcaster(newClient(), newClient(), WithHistory(10, time.Minute))
+		},
+	}
+
+	for name, f := range broadcasters {
+		t.Run(name, func(t *testing.T) {
+			b := f()
+			channel := ulid.Make().String()
+
+			msgs := historyMessages(3)
+			for n := range msgs {
+				msgs[n].Channel = channel
+				b.Publish(ctx, msgs[n])
+			}
+			// Redis publishes asynchronously.
+			<-time.After(100 * time.Millisecond)
+
+			var l sync.Mutex
+			received := []Message{}
+			sub := NewInmemorySubscription(uuid.New(), func(m Message) error {
+				l.Lock()
+				defer l.Unlock()
+				received = append(received, m)
+				return nil
+			})
+
+			err := b.SubscribeFrom(ctx, sub, msgs[0].Topics(), msgs[0].ID)
+			require.NoError(t, err)
+			<-time.After(100 * time.Millisecond)
+
+			live := streamingtypes.NewMessage(streamingtypes.MessageKindData, "live")
+			live.Channel = channel
+			live.Topic = "ai"
+			b.Publish(ctx, live)
+
+			require.Eventually(t, func() bool {
+				l.Lock()
+				defer l.Unlock()
+				return len(received) == 3
+			}, 2*time.Second, 10*time.Millisecond)
+
+			l.Lock()
+			defer l.Unlock()
+			require.Equal(t, msgs[1].ID, received[0].ID)
+			require.Equal(t, msgs[2].ID, received[1].ID)
+			require.Equal(t, live.ID, received[2].ID)
+		})
+	}
+}
+
+func historyMessages(n int) []Message {
+	msgs := make([]Message, n)
+	for i := range msgs {
+		msgs[i] = streamingtypes.NewMessage(streamingtypes.MessageKindData, json.RawMessage(`"output"`))
+		msgs[i].Channel = "user:123"
+		msgs[i].Topic = "ai"
+	}
+	return msgs
+}
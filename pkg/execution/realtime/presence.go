@@ -0,0 +1,293 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/inngest/inngest/pkg/util"
+	"github.com/redis/rueidis"
+)
+
+type PresenceMember = streamingtypes.PresenceMember
+
+var (
+	// PresenceTTL is the duration after which a member is removed from a channel
+	// if its broadcaster stops refreshing it, eg. if the broadcaster crashes.  Members
+	// are refreshed with every keepalive.
+	PresenceTTL = 3 * KeepaliveInterval
+	// MaxPresenceMetadataSize is the maximum size of a subscriber's presence metadata.
+	MaxPresenceMetadataSize = 1024
+)
+
+// PresenceSubscription is a Subscription which attaches metadata to its presence
+// in each subscribed channel, eg. a user's name.
+type PresenceSubscription interface {
+	Subscription
+
+	// Metadata returns the subscription's presence metadata, if any.
+	Metadata() json.RawMessage
+}
+
+// presenceMetadata returns the metadata for the given subscription, if any.
+func presenceMetadata(s Subscription) json.RawMessage {
+	if r, ok := s.(*replaySub); ok {
+		s = r.Subscription
+	}
+	if ps, ok := s.(PresenceSubscription); ok {
+		return ps.Metadata()
+	}
+	return nil
+}
+
+// presence stores the members of each channel.
+type presence interface {
+	// Join adds a member to a channel, or refreshes the member if it already
+	// exists.
+	Join(ctx context.Context, envID uuid.UUID, channel string, m PresenceMember) error
+	// Leave removes a member from a channel.
+	Leave(ctx context.Context, envID uuid.UUID, channel string, id uuid.UUID) error
+	// Members returns all members of a channel, ordered by the time they joined.
+	Members(ctx context.Context, envID uuid.UUID, channel string) ([]PresenceMember, error)
+}
+
+func presenceKey(envID uuid.UUID, channel string) string {
+	// Hash the channel such that user-generated channels aren't too long.
+	return fmt.Sprintf("%s:%s", envID, util.XXHash(channel))
+}
+
+func sortMembers(members []PresenceMember) {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].JoinedAt.Before(members[j].JoinedAt)
+	})
+}
+
+// presenceChannel is a channel that a subscription has joined.
+type presenceChannel struct {
+	EnvID    uuid.UUID
+	Channel  string
+	JoinedAt time.Time
+}
+
+func newMemoryPresence() *memoryPresence {
+	return &memoryPresence{channels: map[string]map[uuid.UUID]PresenceMember{}}
+}
+
+// memoryPresence stores members in-memory, used by the in-process broadcaster.
+type memoryPresence struct {
+	l        sync.Mutex
+	channels map[string]map[uuid.UUID]PresenceMember
+}
+
+func (p *memoryPresence) Join(ctx context.Context, envID uuid.UUID, channel string, m PresenceMember) error {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	key := presenceKey(envID, channel)
+	if _, ok := p.channels[key]; !ok {
+		p.channels[key] = map[uuid.UUID]PresenceMember{}
+	}
+	p.channels[key][m.ID] = m
+	return nil
+}
+
+func (p *memoryPresence) Leave(ctx context.Context, envID uuid.UUID, channel string, id uuid.UUID) error {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	key := presenceKey(envID, channel)
+	delete(p.channels[key], id)
+	if len(p.channels[key]) == 0 {
+		delete(p.channels, key)
+	}
+	return nil
+}
+
+func (p *memoryPresence) Members(ctx context.Context, envID uuid.UUID, channel string) ([]PresenceMember, error) {
+	p.l.Lock()
+	defer p.l.Unlock()
+
+	members := []PresenceMember{}
+	for _, m := range p.channels[presenceKey(envID, channel)] {
+		members = append(members, m)
+	}
+	sortMembers(members)
+	return members, nil
+}
+
+func newRedisPresence(c rueidis.Client) *redisPresence {
+	return &redisPresence{c: c}
+}
+
+// redisPresence stores members in a Redis hash per channel, shared by all redis
+// broadcasters.
+type redisPresence struct {
+	c rueidis.Client
+}
+
+// redisPresenceMember is a member stored in Redis, alongside the time it was
+// last refreshed.
+type redisPresenceMember struct {
+	PresenceMember
+	SeenAt int64 `json:"seen_at"`
+}
+
+func (p *redisPresence) key(envID uuid.UUID, channel string) string {
+	return "realtime:presence:" + presenceKey(envID, channel)
+}
+
+func (p *redisPresence) Join(ctx context.Context, envID uuid.UUID, channel string, m PresenceMember) error {
+	byt, err := json.Marshal(redisPresenceMember{
+		PresenceMember: m,
+		SeenAt:         time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling presence member: %w", err)
+	}
+
+	key := p.key(envID, channel)
+	for _, res := range p.c.DoMulti(
+		ctx,
+		p.c.B().Hset().Key(key).FieldValue().FieldValue(m.ID.String(), string(byt)).Build(),
+		p.c.B().Pexpire().Key(key).Milliseconds(PresenceTTL.Milliseconds()).Build(),
+	) {
+		if err := res.Error(); err != nil {
+			return fmt.Errorf("error joining presence: %w", err)
+		}
+	}
+	return nil
+}
+
+func (p *redisPresence) Leave(ctx context.Context, envID uuid.UUID, channel string, id uuid.UUID) error {
+	cmd := p.c.B().Hdel().Key(p.key(envID, channel)).Field(id.String()).Build()
+	if err := p.c.Do(ctx, cmd).Error(); err != nil {
+		return fmt.Errorf("error leaving presence: %w", err)
+	}
+	return nil
+}
+
+func (p *redisPresence) Members(ctx context.Context, envID uuid.UUID, channel string) ([]PresenceMember, error) {
+	key := p.key(envID, channel)
+	all, err := p.c.Do(ctx, p.c.B().Hgetall().Key(key).Build()).AsStrMap()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("error reading presence: %w", err)
+	}
+
+	members := []PresenceMember{}
+	stale := []string{}
+	for field, val := range all {
+		m := redisPresenceMember{}
+		if err := json.Unmarshal([]byte(val), &m); err != nil {
+			stale = append(stale, field)
+			continue
+		}
+		// Members which haven't been refreshed belong to broadcasters which
+		// have shut down without removing their subscriptions.
+		if time.Since(time.UnixMilli(m.SeenAt)) > PresenceTTL {
+			stale = append(stale, field)
+			continue
+		}
+		members = append(members, m.PresenceMember)
+	}
+
+	if len(stale) > 0 {
+		if err := p.c.Do(ctx, p.c.B().Hdel().Key(key).Field(stale...).Build()).Error(); err != nil {
+			logger.StdlibLogger(ctx).Warn("error removing stale presence members", "error", err)
+		}
+	}
+
+	sortMembers(members)
+	return members, nil
+}
+
+func (b *broadcaster) Presence(ctx context.Context, envID uuid.UUID, channel string) ([]PresenceMember, error) {
+	return b.presence.Members(ctx, envID, channel)
+}
+
+// join adds the subscription to each channel, notifying the channel's "$presence"
+// topic.
+func (b *broadcaster) join(ctx context.Context, s Subscription, channels []presenceChannel) {
+	for _, c := range channels {
+		m := PresenceMember{
+			ID:       s.ID(),
+			Metadata: presenceMetadata(s),
+			JoinedAt: c.JoinedAt,
+		}
+		if err := b.presence.Join(ctx, c.EnvID, c.Channel, m); err != nil {
+			logger.StdlibLogger(ctx).Warn(
+				"error joining realtime presence",
+				"error", err,
+				"subscription_id", s.ID(),
+			)
+		}
+		b.publishPresence(ctx, streamingtypes.PresenceActionJoin, c, m)
+	}
+}
+
+// leave removes the subscription from each channel, notifying the channel's
+// "$presence" topic.
+func (b *broadcaster) leave(ctx context.Context, s Subscription, channels []presenceChannel) {
+	for _, c := range channels {
+		if err := b.presence.Leave(ctx, c.EnvID, c.Channel, s.ID()); err != nil {
+			logger.StdlibLogger(ctx).Warn(
+				"error leaving realtime presence",
+				"error", err,
+				"subscription_id", s.ID(),
+			)
+		}
+		b.publishPresence(ctx, streamingtypes.PresenceActionLeave, c, PresenceMember{
+			ID:       s.ID(),
+			Metadata: presenceMetadata(s),
+			JoinedAt: c.JoinedAt,
+		})
+	}
+}
+
+// refreshPresence refreshes the subscription within each of its channels,
+// ensuring that the subscription isn't removed as stale.
+func (b *broadcaster) refreshPresence(ctx context.Context, subID uuid.UUID) {
+	b.l.RLock()
+	as, ok := b.subs[subID]
+	if !ok {
+		b.l.RUnlock()
+		return
+	}
+	channels := make([]presenceChannel, 0, len(as.Channels))
+	for _, c := range as.Channels {
+		channels = append(channels, c)
+	}
+	metadata := presenceMetadata(as.Subscription)
+	b.l.RUnlock()
+
+	for _, c := range channels {
+		err := b.presence.Join(ctx, c.EnvID, c.Channel, PresenceMember{
+			ID:       subID,
+			Metadata: metadata,
+			JoinedAt: c.JoinedAt,
+		})
+		if err != nil {
+			logger.StdlibLogger(ctx).Warn(
+				"error refreshing realtime presence",
+				"error", err,
+				"subscription_id", subID,
+			)
+		}
+	}
+}
+
+func (b *broadcaster) publishPresence(ctx context.Context, action streamingtypes.PresenceAction, c presenceChannel, m PresenceMember) {
+	msg := streamingtypes.NewMessage(streamingtypes.MessageKindPresence, streamingtypes.PresenceData{
+		Action:         action,
+		PresenceMember: m,
+	})
+	msg.EnvID = c.EnvID
+	msg.Channel = c.Channel
+	msg.Topic = streamingtypes.TopicNamePresence
+	b.publisher.Publish(ctx, msg)
+}
@@ -0,0 +1,257 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresence(t *testing.T) {
+	ctx := context.Background()
+
+	r := miniredis.RunT(t)
+	newClient := func() rueidis.Client {
+		c, err := rueidis.NewClient(rueidis.ClientOption{
+			InitAddress:  []string{r.Addr()},
+			DisableCache: true,
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	broadcasters := map[string]func() (Broadcaster, Broadcaster){
+		"in process": func() (Broadcaster, Broadcaster) {
+			b := NewInProcessBroadcaster()
+			return b, b
+		},
+		"redis": func() (Broadcaster, Broadcaster) {
+			pubc := newClient()
+			return NewRedisBroadcaster(pubc, newClient()), NewRedisBroadcaster(pubc, newClient())
+		},
+	}
+
+	for name, f := range broadcasters {
+		t.Run(name, func(t *testing.T) {
+			b1, b2 := f()
+			envID := uuid.New()
+			channel := ulid.Make().String()
+
+			// Watch the channel's presence topic on the second broadcaster.
+			var l sync.Mutex
+			notifications := []streamingtypes.PresenceData{}
+			watcher := NewInmemorySubscription(uuid.New(), func(m Message) error {
+				if m.Kind != streamingtypes.MessageKindPresence {
+					return nil
+				}
+				data := streamingtypes.PresenceData{}
+				if err := json.Unmarshal(m.Data, &data); err != nil {
+					return err
+				}
+				l.Lock()
+				defer l.Unlock()
+				notifications = append(notifications, data)
+				return nil
+			})
+			err := b2.Subscribe(ctx, watcher, []Topic{{
+				Kind:    streamingtypes.TopicKindRun,
+				EnvID:   envID,
+				Channel: channel,
+				Name:    streamingtypes.TopicNamePresence,
+			}})
+			require.NoError(t, err)
+
+			// The watcher is notified that it joined the channel itself.
+			require.Eventually(t, func() bool {
+				l.Lock()
+				defer l.Unlock()
+				return len(notifications) == 1
+			}, 2*time.Second, 10*time.Millisecond)
+			l.Lock()
+			require.Equal(t, watcher.ID(), notifications[0].ID)
+			notifications = notifications[:0]
+			l.Unlock()
+
+			// Join via the first broadcaster, subscribing to multiple topics
+			// within the channel.
+			sub := presenceSub{
+				Subscription: NewInmemorySubscription(uuid.New(), nil),
+				metadata:     json.RawMessage(`{"name":"ada"}`),
+			}
+			err = b1.Subscribe(ctx, sub, []Topic{
+				{Kind: streamingtypes.TopicKindRun, EnvID: envID, Channel: channel, Name: "a"},
+				{Kind: streamingtypes.TopicKindRun, EnvID: envID, Channel: channel, Name: "b"},
+			})
+			require.NoError(t, err)
+
+			require.Eventually(t, func() bool {
+				l.Lock()
+				defer l.Unlock()
+				return len(notifications) == 1
+			}, 2*time.Second, 10*time.Millisecond)
+			l.Lock()
+			require.Equal(t, streamingtypes.PresenceActionJoin, notifications[0].Action)
+			require.Equal(t, sub.ID(), notifications[0].ID)
+			require.JSONEq(t, `{"name":"ada"}`, string(notifications[0].Metadata))
+			l.Unlock()
+
+			// Both broadcasters list the watcher and the new subscription.
+			for _, b := range []Broadcaster{b1, b2} {
+				members, err := b.Presence(ctx, envID, channel)
+				require.NoError(t, err)
+				require.Len(t, members, 2)
+				require.Equal(t, watcher.ID(), members[0].ID)
+				require.Equal(t, sub.ID(), members[1].ID)
+				require.JSONEq(t, `{"name":"ada"}`, string(members[1].Metadata))
+			}
+
+			// Unsubscribing from one topic remains within the channel.
+			err = b1.Unsubscribe(ctx, sub.ID(), []Topic{
+				{Kind: streamingtypes.TopicKindRun, EnvID: envID, Channel: channel, Name: "a"},
+			})
+			require.NoError(t, err)
+			members, err := b2.Presence(ctx, envID, channel)
+			require.NoError(t, err)
+			require.Len(t, members, 2)
+
+			// Closing the subscription leaves the channel.
+			require.NoError(t, b1.CloseSubscription(ctx, sub.ID()))
+			require.Eventually(t, func() bool {
+				l.Lock()
+				defer l.Unlock()
+				return len(notifications) == 2
+			}, 2*time.Second, 10*time.Millisecond)
+			l.Lock()
+			require.Equal(t, streamingtypes.PresenceActionLeave, notifications[1].Action)
+			require.Equal(t, sub.ID(), notifications[1].ID)
+			l.Unlock()
+
+			members, err = b2.Presence(ctx, envID, channel)
+			require.NoError(t, err)
+			require.Len(t, members, 1)
+			require.Equal(t, watcher.ID(), members[0].ID)
+		})
+	}
+}
+
+func TestRedisPresenceStale(t *testing.T) {
+	ctx := context.Background()
+
+	r := miniredis.RunT(t)
+	c, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	p := newRedisPresence(c)
+	envID := uuid.New()
+
+	// Store a member which was last refreshed before the presence TTL, eg. as
+	// its broadcaster crashed.
+	stale := redisPresenceMember{
+		PresenceMember: PresenceMember{ID: uuid.New(), JoinedAt: time.Now()},
+		SeenAt:         time.Now().Add(-2 * PresenceTTL).UnixMilli(),
+	}
+	byt, err := json.Marshal(stale)
+	require.NoError(t, err)
+	r.HSet(p.key(envID, "chan"), stale.ID.String(), string(byt))
+
+	current := PresenceMember{ID: uuid.New(), JoinedAt: time.Now()}
+	require.NoError(t, p.Join(ctx, envID, "chan", current))
+
+	members, err := p.Members(ctx, envID, "chan")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	require.Equal(t, current.ID, members[0].ID)
+	// Stale members are removed when read.
+	require.Empty(t, r.HGet(p.key(envID, "chan"), stale.ID.String()))
+}
+
+func TestPresenceAPI(t *testing.T) {
+	b := NewInProcessBroadcaster()
+	s := httptest.NewServer(NewAPI(APIOpts{
+		JWTSecret:   []byte("foo"),
+		Broadcaster: b,
+	}))
+	defer s.Close()
+
+	topic := Topic{
+		Kind:    streamingtypes.TopicKindRun,
+		Channel: "doc:123",
+		Name:    "edits",
+	}
+	jwt, err := newToken(t, s.URL, topic)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(
+		http.MethodGet,
+		s.URL+"/realtime/sse?token="+jwt+"&presence="+url.QueryEscape(`{"name":"ada"}`),
+		nil,
+	)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	defer resp.Body.Close()
+
+	t.Run("lists members", func(t *testing.T) {
+		var result struct {
+			Channel string           `json:"channel"`
+			Count   int              `json:"count"`
+			Members []PresenceMember `json:"members"`
+		}
+		require.Eventually(t, func() bool {
+			resp, err := http.Get(s.URL + "/realtime/topics/doc:123/presence?token=" + jwt)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, 200, resp.StatusCode)
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+			return result.Count == 1
+		}, 2*time.Second, 10*time.Millisecond)
+
+		require.Equal(t, "doc:123", result.Channel)
+		require.JSONEq(t, `{"name":"ada"}`, string(result.Members[0].Metadata))
+	})
+
+	t.Run("tokens are scoped to their channels", func(t *testing.T) {
+		resp, err := http.Get(s.URL + "/realtime/topics/doc:456/presence?token=" + jwt)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 403, resp.StatusCode)
+	})
+
+	t.Run("metadata must be valid json", func(t *testing.T) {
+		resp, err := http.Get(s.URL + "/realtime/sse?token=" + jwt + "&presence=nope")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, 400, resp.StatusCode)
+	})
+
+	// The in-process broadcaster uses the dev server's environment.
+	members, err := b.Presence(context.Background(), consts.DevServerEnvID, "doc:123")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+}
+
+// presenceSub is an in-memory subscription with presence metadata.
+type presenceSub struct {
+	Subscription
+	metadata json.RawMessage
+}
+
+func (p presenceSub) Metadata() json.RawMessage {
+	return p.metadata
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/oklog/ulid/v2"
 )
 
 type Message = streamingtypes.Message
@@ -52,9 +53,23 @@ type Broadcaster interface {
 	// cancelled or Unsubscribe is called on the subscription ID and topic pair.
 	Subscribe(ctx context.Context, s Subscription, topics []Topic) error
 
+	// SubscribeFrom subscribes as with Subscribe, first writing any messages retained
+	// in the topics' history which were published after the given cursor - the ID of
+	// the last message the subscriber received.  This allows subscribers to receive
+	// messages published while reconnecting.
+	//
+	// If the broadcaster doesn't retain history, this is equivalent to Subscribe.
+	SubscribeFrom(ctx context.Context, s Subscription, topics []Topic, cursor ulid.ULID) error
+
 	// Unsubscribe a subscription from a set of specific topics.
 	Unsubscribe(ctx context.Context, subID uuid.UUID, topics []Topic) error
 
+	// Presence returns the subscribers within the given channel, ordered by the
+	// time they joined.  Subscribers join a channel when subscribing to any of the
+	// channel's topics, and are notified of others joining or leaving via the
+	// channel's "$presence" topic.
+	Presence(ctx context.Context, envID uuid.UUID, channel string) ([]PresenceMember, error)
+
 	// CloseSubscription closes a subscription, removing it from the broadcaster
 	// and stopping any messages from being published.  This terminates the subscription,
 	// unsubscribing it from all topics.
@@ -47,6 +47,9 @@ const (
 	// MessageKindUnsubscribe is a message kind that indicates the subscription should
 	// stop listening to the given topics
 	MessageKindUnsubscribe = MessageKind("unsub")
+	// MessageKindPresence is a message kind sent to a channel's "$presence" topic
+	// when a subscriber joins or leaves the channel.
+	MessageKindPresence = MessageKind("presence")
 	// MessageKindClosing is a message kind sent when the server is closing the
 	// realtime connection.  The subscriber should attempt to reconnect immediately,
 	// as the broadcaster will stop broadcasting on the current connection within 5
@@ -68,15 +71,26 @@ const (
 	TopicNameStep = "$step"
 	// TopicNameRun represents a topic for the run's result.
 	TopicNameRun = "$run"
+	// TopicNamePresence represents a topic for a channel's presence messages,
+	// sent when subscribers join or leave the channel.
+	TopicNamePresence = "$presence"
+
+	// PresenceActionJoin indicates that a subscriber joined a channel.
+	PresenceActionJoin = PresenceAction("join")
+	// PresenceActionLeave indicates that a subscriber left a channel.
+	PresenceActionLeave = PresenceAction("leave")
 )
 
+// PresenceAction represents whether a subscriber joined or left a channel.
+type PresenceAction string
+
 // NewMessage creates a new message with the given kind and data.  If the data is
 // not of type byte or json.RawMessage, the data will be marshalled to JSON before
 // being set.
 //
-// Note that other fields in the message are not set.
+// Note that other fields in the message, apart from the ID, are not set.
 func NewMessage(kind MessageKind, data any) Message {
-	msg := Message{Kind: kind, CreatedAt: time.Now().Truncate(time.Millisecond).UTC()}
+	msg := Message{ID: ulid.Make(), Kind: kind, CreatedAt: time.Now().Truncate(time.Millisecond).UTC()}
 	switch v := data.(type) {
 	case json.RawMessage:
 		msg.Data = v
@@ -140,6 +154,10 @@ func (t Topic) String() string {
 
 // Message represents a single message sent on realtime topics.
 type Message struct {
+	// ID is a unique, time-ordered ID for the message.  This is set by the
+	// broadcaster when the message is published, and allows subscribers to
+	// resume from a given message.
+	ID ulid.ULID `json:"id,omitempty,omitzero"`
 	// Kind represents the message kind.
 	Kind MessageKind `json:"kind"`
 	// Data represents the data in the message.
@@ -278,3 +296,20 @@ type Chunk struct {
 	// RunID is used for debugging purposes only, and does not constrain topics.
 	RunID ulid.ULID `json:"run_id,omitempty,omitzero"`
 }
+
+// PresenceMember represents a subscriber within a channel.
+type PresenceMember struct {
+	// ID is the subscription ID.
+	ID uuid.UUID `json:"id"`
+	// Metadata is the metadata attached by the subscriber when subscribing,
+	// eg. a user's name.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	// JoinedAt is the time that the subscriber joined the channel.
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// PresenceData is the data for presence messages.
+type PresenceData struct {
+	Action PresenceAction `json:"action"`
+	PresenceMember
+}
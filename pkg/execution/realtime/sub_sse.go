@@ -0,0 +1,187 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// NewSSESubscription creates a new server-sent-events subscription which writes
+// messages to the given http response.
+//
+// Unlike websockets, SSE connections are read-only:  the topics are fixed when the
+// subscription is created from the JWT, and clients reconnect with a new token to
+// change topics.
+//
+// Each message is written with its ID as the event ID.  Browsers send the last
+// seen ID in the Last-Event-ID header when reconnecting;  this should be passed
+// as lastID, ensuring that messages already seen by the client are not written
+// again and that any messages retained by the broadcaster's history are replayed.
+//
+// The given metadata is attached to the subscription's presence within each subscribed
+// channel.
+func NewSSESubscription(
+	ctx context.Context,
+	b Broadcaster,
+	acctID, envID uuid.UUID,
+	w http.ResponseWriter,
+	lastID ulid.ULID,
+	metadata json.RawMessage,
+	topics []Topic,
+) (ReadWriteSubscription, error) {
+	if b == nil {
+		return nil, fmt.Errorf("Cannot make sse connection without broadcaster")
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, fmt.Errorf("sse connections require a flushable response writer")
+	}
+
+	sub := &SubscriptionSSE{
+		id:       uuid.New(),
+		acctID:   acctID,
+		envID:    envID,
+		w:        w,
+		flusher:  flusher,
+		lastID:   lastID,
+		metadata: metadata,
+		l:        &sync.Mutex{},
+		done:     make(chan struct{}),
+	}
+
+	if lastID == (ulid.ULID{}) {
+		return sub, b.Subscribe(ctx, sub, topics)
+	}
+	return sub, b.SubscribeFrom(ctx, sub, topics, lastID)
+}
+
+// SubscriptionSSE represents a server-sent-events subscription.
+type SubscriptionSSE struct {
+	id uuid.UUID
+
+	// acctID represents the authenticated account ID when initializing
+	// the sse connection
+	acctID uuid.UUID
+	// envID represents the authenticated environment ID when initializing
+	// the sse connection
+	envID uuid.UUID
+
+	w       http.ResponseWriter
+	flusher http.Flusher
+
+	// lastID is the ID of the last message seen by the client before
+	// reconnecting.  Messages with an ID at or before this are skipped.
+	lastID ulid.ULID
+
+	// metadata is the subscription's presence metadata.
+	metadata json.RawMessage
+
+	// l locks writes to the response, as the broadcaster publishes to each topic
+	// concurrently.
+	l *sync.Mutex
+	// closed is set when the subscription is closed, after which the response
+	// must no longer be written to.
+	closed bool
+	done   chan struct{}
+}
+
+func (s *SubscriptionSSE) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *SubscriptionSSE) Protocol() string {
+	return "sse"
+}
+
+func (s *SubscriptionSSE) Metadata() json.RawMessage {
+	return s.metadata
+}
+
+func (s *SubscriptionSSE) WriteMessage(m Message) error {
+	// Ensure that the data is valid JSON.  Note that sometimes
+	// m.Data is set as a raw string - eg. the channel ID.
+	if !json.Valid(m.Data) {
+		enc, err := json.Marshal(string(m.Data))
+		if err != nil {
+			return err
+		}
+		m.Data = enc
+	}
+
+	byt, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if m.ID != (ulid.ULID{}) {
+		if m.ID.Compare(s.lastID) <= 0 {
+			// The client has already seen this message.
+			return nil
+		}
+		return s.write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", m.ID, m.Kind, byt))
+	}
+	return s.write(fmt.Sprintf("event: %s\ndata: %s\n\n", m.Kind, byt))
+}
+
+func (s *SubscriptionSSE) WriteChunk(c Chunk) error {
+	byt, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	// Chunks have no ID, so that clients resume from the stream's
+	// start or end message.
+	return s.write(fmt.Sprintf("event: %s\ndata: %s\n\n", c.Kind, byt))
+}
+
+func (s *SubscriptionSSE) SendKeepalive(m Message) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	// Send a comment, which is ignored by clients.
+	return s.write(": ping\n\n")
+}
+
+func (s *SubscriptionSSE) Close() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+	return nil
+}
+
+// Poll blocks until the subscription is closed or the context is cancelled,
+// eg. when the client disconnects.
+func (s *SubscriptionSSE) Poll(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return nil
+	}
+}
+
+// write writes the given event and flushes the response.  This must be called
+// with the lock held.
+func (s *SubscriptionSSE) write(event string) error {
+	if s.closed {
+		return fmt.Errorf("sse subscription is closed")
+	}
+	if _, err := s.w.Write([]byte(event)); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
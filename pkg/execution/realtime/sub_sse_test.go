@@ -0,0 +1,172 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEMessage(t *testing.T) {
+	ctx := context.Background()
+
+	b := NewInProcessBroadcaster()
+	s := httptest.NewServer(NewAPI(APIOpts{
+		JWTSecret:   []byte("foo"),
+		Broadcaster: b,
+	}))
+	defer s.Close()
+
+	topic := Topic{
+		Kind:    streamingtypes.TopicKindRun,
+		Channel: "user:123",
+		Name:    "ai",
+	}
+
+	t.Run("broadcasting publishes to sse", func(t *testing.T) {
+		events := sseConnect(t, s, "", topic)
+		<-time.After(time.Second)
+
+		send := Message{
+			ID:      ulid.Make(),
+			Kind:    streamingtypes.MessageKindRun,
+			Data:    json.RawMessage(`"foo"`),
+			Channel: "user:123",
+			Topic:   "ai",
+			EnvID:   consts.DevServerEnvID,
+		}
+		b.Publish(ctx, send)
+
+		evt := readEventWithin(t, events, time.Second)
+		require.Equal(t, send.ID.String(), evt.id)
+		require.Equal(t, string(streamingtypes.MessageKindRun), evt.event)
+
+		msg := Message{}
+		require.NoError(t, json.Unmarshal([]byte(evt.data), &msg))
+		require.Equal(t, send.ID, msg.ID)
+		require.EqualValues(t, `"foo"`, string(msg.Data))
+	})
+
+	t.Run("last event ID skips seen messages", func(t *testing.T) {
+		seen := Message{
+			ID:      ulid.Make(),
+			Kind:    streamingtypes.MessageKindData,
+			Data:    json.RawMessage(`"seen"`),
+			Channel: "user:123",
+			Topic:   "ai",
+			EnvID:   consts.DevServerEnvID,
+		}
+		unseen := seen
+		unseen.ID = ulid.Make()
+		unseen.Data = json.RawMessage(`"unseen"`)
+
+		events := sseConnect(t, s, seen.ID.String(), topic)
+		<-time.After(time.Second)
+
+		b.Publish(ctx, seen)
+		b.Publish(ctx, unseen)
+
+		evt := readEventWithin(t, events, time.Second)
+		require.Equal(t, unseen.ID.String(), evt.id)
+	})
+
+	t.Run("streams publish chunks", func(t *testing.T) {
+		events := sseConnect(t, s, "", topic)
+		<-time.After(time.Second)
+
+		resp, err := http.Post(
+			s.URL+"/realtime/publish?channel=user:123&topic=ai",
+			"text/stream",
+			strings.NewReader("test please"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, resp.StatusCode, 200)
+
+		start := readEventWithin(t, events, time.Second)
+		chunk := readEventWithin(t, events, time.Second)
+		end := readEventWithin(t, events, time.Second)
+
+		require.Equal(t, string(streamingtypes.MessageKindDataStreamStart), start.event)
+		require.NotEmpty(t, start.id)
+		require.Equal(t, string(streamingtypes.MessageKindDataStreamEnd), end.event)
+
+		require.Equal(t, string(streamingtypes.MessageKindDataStreamChunk), chunk.event)
+		require.Empty(t, chunk.id)
+		c := Chunk{}
+		require.NoError(t, json.Unmarshal([]byte(chunk.data), &c))
+		require.Equal(t, "test please", c.Data)
+	})
+
+	t.Run("unauthenticated requests fail", func(t *testing.T) {
+		resp, err := http.Get(s.URL + "/realtime/sse")
+		require.NoError(t, err)
+		require.Equal(t, 401, resp.StatusCode)
+	})
+}
+
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+// sseConnect opens an SSE connection, returning a channel of parsed events.
+// Comments such as keepalives are ignored.
+func sseConnect(t *testing.T, s *httptest.Server, lastID string, topic Topic) chan sseEvent {
+	jwt, err := newToken(t, s.URL, topic)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, s.URL+"/realtime/sse?token="+jwt, nil)
+	require.NoError(t, err)
+	if lastID != "" {
+		req.Header.Set("Last-Event-ID", lastID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("content-type"))
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	events := make(chan sseEvent, 10)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		evt := sseEvent{}
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if evt.event != "" {
+					events <- evt
+				}
+				evt = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				evt.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				evt.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				evt.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return events
+}
+
+func readEventWithin(t *testing.T, events chan sseEvent, dur time.Duration) sseEvent {
+	select {
+	case <-time.After(dur):
+		t.Fatalf("didnt receive event within timeout")
+	case evt := <-events:
+		return evt
+	}
+	return sseEvent{}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
 	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
 )
 
 // NewWebsocketSubscription handles creating a new websocket subscription for a given
@@ -25,12 +26,18 @@ import (
 //     topics.
 //   - The websocket subscriber listens for incoming messages which can subscribe and unsubscribe from
 //     new topics at will (given a valid JWT in the websocket message, for subscription requests)
+//
+// If a cursor is given, any messages in the broadcaster's history published after the cursor are
+// written before live messages.  The given metadata is attached to the subscription's presence
+// within each subscribed channel.
 func NewWebsocketSubscription(
 	ctx context.Context,
 	b Broadcaster,
 	acctID, envID uuid.UUID,
 	jwtSigningKey []byte,
 	conn *websocket.Conn,
+	cursor ulid.ULID,
+	metadata json.RawMessage,
 	topics []Topic,
 ) (ReadWriteSubscription, error) {
 	sub := &SubscriptionWS{
@@ -40,13 +47,16 @@ func NewWebsocketSubscription(
 		id:            uuid.New(),
 		ws:            conn,
 		jwtSigningKey: jwtSigningKey,
+		metadata:      metadata,
 	}
 
 	if b == nil {
 		return nil, fmt.Errorf("Cannot make websocket connection without broadcaster")
 	}
-	err := b.Subscribe(ctx, sub, topics)
-	return sub, err
+	if cursor == (ulid.ULID{}) {
+		return sub, b.Subscribe(ctx, sub, topics)
+	}
+	return sub, b.SubscribeFrom(ctx, sub, topics, cursor)
 }
 
 // SubscriptionWS represents a websocket subscription
@@ -65,6 +75,9 @@ type SubscriptionWS struct {
 	jwtSigningKey []byte
 
 	ws *websocket.Conn
+
+	// metadata is the subscription's presence metadata.
+	metadata json.RawMessage
 }
 
 func (s SubscriptionWS) ID() uuid.UUID {
@@ -75,6 +88,10 @@ func (s SubscriptionWS) Protocol() string {
 	return "ws"
 }
 
+func (s SubscriptionWS) Metadata() json.RawMessage {
+	return s.metadata
+}
+
 func (s SubscriptionWS) WriteMessage(m Message) error {
 	// Ensure that the data is valid JSON.  NOte that sometimes
 	// m.Data is set as a raw string - eg. the channel ID.
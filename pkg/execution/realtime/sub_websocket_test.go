@@ -15,6 +15,7 @@ import (
 	"github.com/coder/websocket"
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/execution/realtime/streamingtypes"
+	"github.com/oklog/ulid/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,6 +39,7 @@ func TestWebsocketMessage(t *testing.T) {
 	// Broadcasting should publish.
 	t.Run("broadcasting publishes to websocket", func(t *testing.T) {
 		send := Message{
+			ID:        ulid.Make(),
 			Kind:      streamingtypes.MessageKindRun,
 			Data:      json.RawMessage(`"foo"`),
 			CreatedAt: time.Now().Truncate(time.Millisecond).UTC(),
@@ -0,0 +1,371 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution"
+	"github.com/inngest/inngest/pkg/execution/executor"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/history_reader"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/jonboulle/clockwork"
+	"github.com/oklog/ulid/v2"
+)
+
+// Interval is the interval at which running replays are processed.  Each
+// replay schedules at most its rate of runs per second within each interval.
+var Interval = time.Second
+
+// MaxAttempts is the number of consecutive ticks a replay may fail to process,
+// eg. due to transient database errors, before the replay is marked as failed.
+var MaxAttempts = 5
+
+var (
+	ErrReplayEnded = fmt.Errorf("replay has already ended")
+)
+
+// RunReader loads the historical runs to replay.
+type RunReader interface {
+	GetReplayRuns(ctx context.Context, opts history_reader.GetReplayRunsOpts) ([]history_reader.ReplayRun, error)
+	CountReplayRuns(ctx context.Context, opts history_reader.CountReplayRunsOpts) (history_reader.ReplayRunCounts, error)
+}
+
+type Opt func(m *Manager)
+
+func WithLogger(l logger.Logger) Opt {
+	return func(m *Manager) {
+		m.log = l
+	}
+}
+
+func WithClock(c clockwork.Clock) Opt {
+	return func(m *Manager) {
+		m.c = c
+	}
+}
+
+// NewManager returns a manager which creates replays and schedules their runs.
+func NewManager(
+	replays cqrs.ReplayReadWriter,
+	runs RunReader,
+	events cqrs.EventReader,
+	fns cqrs.FunctionReader,
+	exec execution.Executor,
+	opts ...Opt,
+) *Manager {
+	m := &Manager{
+		replays: replays,
+		runs:    runs,
+		events:  events,
+		fns:     fns,
+		exec:    exec,
+		c:       clockwork.NewRealClock(),
+		log:     logger.StdlibLogger(context.Background()),
+
+		failures: map[uuid.UUID]int{},
+	}
+	for _, apply := range opts {
+		apply(m)
+	}
+	return m
+}
+
+// Manager creates, pauses and resumes replays, and implements service.Service
+// to schedule the runs of every running replay.
+type Manager struct {
+	replays cqrs.ReplayReadWriter
+	runs    RunReader
+	events  cqrs.EventReader
+	fns     cqrs.FunctionReader
+	exec    execution.Executor
+
+	c   clockwork.Clock
+	log logger.Logger
+
+	// l serializes ticks, guarding failures.
+	l sync.Mutex
+	// failures counts the consecutive failed attempts to process each replay.
+	failures map[uuid.UUID]int
+}
+
+// Create validates and stores a new replay, which starts running immediately.
+func (m *Manager) Create(ctx context.Context, r cqrs.Replay) (*cqrs.Replay, error) {
+	if r.Rate == 0 {
+		r.Rate = cqrs.DefaultReplayRate
+	}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	fn, err := m.fns.GetFunctionByInternalUUID(ctx, r.WorkspaceID, r.FunctionID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading function: %w", err)
+	}
+
+	counts, err := m.runs.CountReplayRuns(ctx, history_reader.CountReplayRunsOpts{
+		AccountID:   r.AccountID,
+		WorkspaceID: r.WorkspaceID,
+		WorkflowID:  &r.FunctionID,
+		LowerTime:   r.FromTime,
+		UpperTime:   r.ToTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error counting runs: %w", err)
+	}
+
+	r.ID = uuid.New()
+	r.AppID = fn.AppID
+	r.Status = enums.ReplayStatusRunning
+	r.TotalRuns = total(r, counts)
+	if r.Name == "" {
+		r.Name = fmt.Sprintf("Replay %s", m.c.Now().UTC().Format(time.RFC3339))
+	}
+
+	if err := m.replays.InsertReplay(ctx, r); err != nil {
+		return nil, fmt.Errorf("error creating replay: %w", err)
+	}
+	return m.replays.Replay(ctx, r.WorkspaceID, r.ID)
+}
+
+// Pause stops a running replay from scheduling runs.
+func (m *Manager) Pause(ctx context.Context, wsID, id uuid.UUID) (*cqrs.Replay, error) {
+	return m.setStatus(ctx, wsID, id, enums.ReplayStatusPaused)
+}
+
+// Resume continues scheduling runs for a paused replay.
+func (m *Manager) Resume(ctx context.Context, wsID, id uuid.UUID) (*cqrs.Replay, error) {
+	return m.setStatus(ctx, wsID, id, enums.ReplayStatusRunning)
+}
+
+func (m *Manager) setStatus(ctx context.Context, wsID, id uuid.UUID, status enums.ReplayStatus) (*cqrs.Replay, error) {
+	r, err := m.replays.Replay(ctx, wsID, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.EndedAt != nil {
+		return nil, ErrReplayEnded
+	}
+	if r.Status == status {
+		return r, nil
+	}
+	if err := m.replays.UpdateReplayStatus(ctx, wsID, id, status, nil); err != nil {
+		return nil, err
+	}
+	return m.replays.Replay(ctx, wsID, id)
+}
+
+func (m *Manager) Name() string {
+	return "replay"
+}
+
+func (m *Manager) Pre(ctx context.Context) error {
+	return nil
+}
+
+func (m *Manager) Run(ctx context.Context) error {
+	t := m.c.NewTicker(Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.Chan():
+			if err := m.Tick(ctx); err != nil {
+				m.log.Error("error processing replays", "error", err)
+			}
+		}
+	}
+}
+
+func (m *Manager) Stop(ctx context.Context) error {
+	return nil
+}
+
+// Tick schedules the next batch of runs for every running replay.  Concurrent
+// calls are serialized.
+func (m *Manager) Tick(ctx context.Context) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	running, err := m.replays.ReplaysByStatus(ctx, enums.ReplayStatusRunning)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range running {
+		err := m.process(ctx, r)
+		if err == nil {
+			delete(m.failures, r.ID)
+			continue
+		}
+
+		m.log.Error("error processing replay", "error", err, "replay_id", r.ID)
+
+		// Retry on the next tick, unless the function no longer exists or the
+		// replay keeps failing.
+		m.failures[r.ID]++
+		if m.failures[r.ID] < MaxAttempts && !errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		delete(m.failures, r.ID)
+
+		msg := err.Error()
+		if err := m.replays.UpdateReplayStatus(ctx, r.WorkspaceID, r.ID, enums.ReplayStatusFailed, &msg); err != nil {
+			m.log.Error("error failing replay", "error", err, "replay_id", r.ID)
+		}
+	}
+	return nil
+}
+
+// process schedules up to the replay's rate of runs, storing the replay's
+// progress and completing the replay once every matching run is processed.
+func (m *Manager) process(ctx context.Context, r cqrs.Replay) error {
+	fnCQRS, err := m.fns.GetFunctionByInternalUUID(ctx, r.WorkspaceID, r.FunctionID)
+	if err != nil {
+		return fmt.Errorf("error loading function: %w", err)
+	}
+	fn, err := fnCQRS.InngestFunction()
+	if err != nil {
+		return fmt.Errorf("error loading function: %w", err)
+	}
+
+	limit := int(float64(r.Rate) * Interval.Seconds())
+	if limit < 1 {
+		limit = 1
+	}
+
+	statuses, reasons := r.RunStatuses()
+	runs, err := m.runs.GetReplayRuns(ctx, history_reader.GetReplayRunsOpts{
+		AccountID:   r.AccountID,
+		WorkspaceID: r.WorkspaceID,
+		WorkflowID:  &r.FunctionID,
+		LowerTime:   r.FromTime,
+		UpperTime:   r.ToTime,
+		Statuses:    statuses,
+		SkipReasons: reasons,
+		Limit:       limit,
+		Cursor:      r.Cursor,
+	})
+	if err != nil {
+		return fmt.Errorf("error loading runs: %w", err)
+	}
+
+	for _, run := range runs {
+		if err := m.schedule(ctx, r, *fn, run); err != nil {
+			m.log.Warn("error scheduling replayed run",
+				"error", err,
+				"replay_id", r.ID,
+				"run_id", run.ID,
+			)
+			r.FailedRuns++
+		} else {
+			r.ScheduledRuns++
+		}
+		r.ProcessedRuns++
+		r.Cursor = &run.ID
+	}
+
+	if len(runs) > 0 {
+		if err := m.replays.UpdateReplayProgress(ctx, r); err != nil {
+			return fmt.Errorf("error updating replay progress: %w", err)
+		}
+	}
+	if len(runs) < limit {
+		return m.replays.UpdateReplayStatus(ctx, r.WorkspaceID, r.ID, enums.ReplayStatusCompleted, nil)
+	}
+	return nil
+}
+
+func (m *Manager) schedule(ctx context.Context, r cqrs.Replay, fn inngest.Function, run history_reader.ReplayRun) error {
+	evts, err := m.loadEvents(ctx, run)
+	if err != nil {
+		return err
+	}
+
+	// Each run is only replayed once per replay, even if progress fails to
+	// save and the run is processed again.
+	key := fmt.Sprintf("%s:%s", r.ID, run.ID)
+	_, err = m.exec.Schedule(ctx, execution.ScheduleRequest{
+		Function:       fn,
+		AccountID:      r.AccountID,
+		WorkspaceID:    r.WorkspaceID,
+		AppID:          r.AppID,
+		Events:         evts,
+		OriginalRunID:  &run.ID,
+		ReplayID:       &r.ID,
+		IdempotencyKey: &key,
+	})
+	if errors.Is(err, state.ErrIdentifierExists) || errors.Is(err, executor.ErrFunctionSkippedIdempotency) {
+		return nil
+	}
+	return err
+}
+
+// loadEvents loads the events which triggered the given run.
+func (m *Manager) loadEvents(ctx context.Context, run history_reader.ReplayRun) ([]event.TrackedEvent, error) {
+	ids := []ulid.ULID{run.EventID}
+	if run.BatchID != nil {
+		batch, err := m.events.GetEventBatchByRunID(ctx, run.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("error loading event batch: %w", err)
+		}
+		// Batches with a single event aren't stored.
+		if batch != nil {
+			ids = batch.EventIDs()
+		}
+	}
+
+	found, err := m.events.GetEventsByInternalIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error loading events: %w", err)
+	}
+
+	byID := make(map[ulid.ULID]*cqrs.Event, len(found))
+	for _, evt := range found {
+		byID[evt.InternalID()] = evt
+	}
+
+	evts := make([]event.TrackedEvent, 0, len(ids))
+	for _, id := range ids {
+		evt, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("event %s not found", id)
+		}
+		evts = append(evts, event.NewOSSTrackedEventWithID(evt.Event(), id))
+	}
+	return evts, nil
+}
+
+// total returns the number of runs matching the replay's statuses.
+func total(r cqrs.Replay, counts history_reader.ReplayRunCounts) int {
+	statuses, reasons := r.RunStatuses()
+
+	n := 0
+	for _, s := range statuses {
+		switch s {
+		case enums.RunStatusCompleted:
+			n += counts.CompletedCount
+		case enums.RunStatusFailed:
+			n += counts.FailedCount
+		case enums.RunStatusCancelled:
+			n += counts.CancelledCount
+		}
+	}
+	for _, s := range reasons {
+		if s == enums.SkipReasonFunctionPaused {
+			n += counts.SkippedPausedCount
+		}
+	}
+	return n
+}
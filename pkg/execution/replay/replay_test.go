@@ -0,0 +1,257 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution"
+	"github.com/inngest/inngest/pkg/execution/state"
+	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
+	"github.com/inngest/inngest/pkg/history_reader"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type replayStore struct {
+	cqrs.ReplayReadWriter
+
+	replays map[uuid.UUID]*cqrs.Replay
+}
+
+func (s *replayStore) ReplaysByStatus(ctx context.Context, status enums.ReplayStatus) ([]cqrs.Replay, error) {
+	res := []cqrs.Replay{}
+	for _, r := range s.replays {
+		if r.Status == status {
+			res = append(res, *r)
+		}
+	}
+	return res, nil
+}
+
+func (s *replayStore) UpdateReplayProgress(ctx context.Context, r cqrs.Replay) error {
+	// Progress never changes the status.
+	r.Status = s.replays[r.ID].Status
+	s.replays[r.ID] = &r
+	return nil
+}
+
+func (s *replayStore) UpdateReplayStatus(ctx context.Context, wsID uuid.UUID, id uuid.UUID, status enums.ReplayStatus, err *string) error {
+	s.replays[id].Status = status
+	s.replays[id].Error = err
+	return nil
+}
+
+// replayRuns returns runs in ID order after the given cursor, recording every
+// query.
+type replayRuns struct {
+	runs []history_reader.ReplayRun
+	// err is returned by the next errCount queries.
+	err      error
+	errCount int
+	queries  []history_reader.GetReplayRunsOpts
+}
+
+func (r *replayRuns) GetReplayRuns(ctx context.Context, opts history_reader.GetReplayRunsOpts) ([]history_reader.ReplayRun, error) {
+	r.queries = append(r.queries, opts)
+	if r.errCount > 0 {
+		r.errCount--
+		return nil, r.err
+	}
+
+	res := []history_reader.ReplayRun{}
+	for _, run := range r.runs {
+		if opts.Cursor != nil && run.ID.Compare(*opts.Cursor) <= 0 {
+			continue
+		}
+		if len(res) == opts.Limit {
+			break
+		}
+		res = append(res, run)
+	}
+	return res, nil
+}
+
+func (r *replayRuns) CountReplayRuns(ctx context.Context, opts history_reader.CountReplayRunsOpts) (history_reader.ReplayRunCounts, error) {
+	return history_reader.ReplayRunCounts{CompletedCount: len(r.runs)}, nil
+}
+
+type replayEvents struct {
+	cqrs.EventReader
+
+	events map[ulid.ULID]*cqrs.Event
+}
+
+func (e replayEvents) GetEventsByInternalIDs(ctx context.Context, ids []ulid.ULID) ([]*cqrs.Event, error) {
+	res := []*cqrs.Event{}
+	for _, id := range ids {
+		if evt, ok := e.events[id]; ok {
+			res = append(res, evt)
+		}
+	}
+	return res, nil
+}
+
+type replayFunctions struct {
+	cqrs.FunctionReader
+
+	// deleted returns sql.ErrNoRows for every function.
+	deleted bool
+}
+
+func (f replayFunctions) GetFunctionByInternalUUID(ctx context.Context, wsID uuid.UUID, fnID uuid.UUID) (*cqrs.Function, error) {
+	if f.deleted {
+		return nil, sql.ErrNoRows
+	}
+	config := fmt.Sprintf(`{"id":%q,"name":"fn"}`, fnID)
+	return &cqrs.Function{ID: fnID, Config: json.RawMessage(config)}, nil
+}
+
+// replayExecutor schedules runs idempotently, recording every scheduled run.
+type replayExecutor struct {
+	execution.Executor
+
+	keys map[string]bool
+	reqs []execution.ScheduleRequest
+}
+
+func (e *replayExecutor) Schedule(ctx context.Context, req execution.ScheduleRequest) (*sv2.Metadata, error) {
+	if e.keys[*req.IdempotencyKey] {
+		return nil, state.ErrIdentifierExists
+	}
+	e.keys[*req.IdempotencyKey] = true
+	e.reqs = append(e.reqs, req)
+	return &sv2.Metadata{ID: sv2.ID{RunID: ulid.Make()}}, nil
+}
+
+// newTestManager returns a manager with a single running replay at the given
+// rate over n historical runs.
+func newTestManager(t *testing.T, rate, n int) (*Manager, *cqrs.Replay, *replayStore, *replayRuns, *replayExecutor) {
+	t.Helper()
+
+	r := &cqrs.Replay{
+		ID:          uuid.New(),
+		AccountID:   uuid.New(),
+		WorkspaceID: uuid.New(),
+		FunctionID:  uuid.New(),
+		FromTime:    time.Now().Add(-time.Hour),
+		ToTime:      time.Now(),
+		Statuses:    []enums.ReplayRunStatus{enums.ReplayRunStatusAll},
+		Rate:        rate,
+		Status:      enums.ReplayStatusRunning,
+		TotalRuns:   n,
+	}
+
+	store := &replayStore{replays: map[uuid.UUID]*cqrs.Replay{r.ID: r}}
+	runs := &replayRuns{}
+	events := replayEvents{events: map[ulid.ULID]*cqrs.Event{}}
+	for i := 0; i < n; i++ {
+		evt := &cqrs.Event{ID: ulid.Make(), EventName: "test/event", EventData: map[string]any{"i": i}}
+		events.events[evt.ID] = evt
+		runs.runs = append(runs.runs, history_reader.ReplayRun{
+			ID:         ulid.Make(),
+			EventID:    evt.ID,
+			WorkflowID: r.FunctionID,
+		})
+	}
+	exec := &replayExecutor{keys: map[string]bool{}}
+
+	m := NewManager(store, runs, events, replayFunctions{}, exec)
+	return m, r, store, runs, exec
+}
+
+func TestTickSchedulesRunsAtRate(t *testing.T) {
+	ctx := context.Background()
+	m, r, store, runs, exec := newTestManager(t, 2, 5)
+
+	// Each tick schedules at most the replay's rate of runs, continuing from
+	// the last processed run.
+	require.NoError(t, m.Tick(ctx))
+	require.Len(t, exec.reqs, 2)
+	require.Nil(t, runs.queries[0].Cursor)
+	require.Equal(t, 2, runs.queries[0].Limit)
+
+	saved := store.replays[r.ID]
+	require.Equal(t, enums.ReplayStatusRunning, saved.Status)
+	require.Equal(t, runs.runs[1].ID, *saved.Cursor)
+	require.Equal(t, 2, saved.ProcessedRuns)
+	require.Equal(t, 2, saved.ScheduledRuns)
+
+	require.NoError(t, m.Tick(ctx))
+	require.Len(t, exec.reqs, 4)
+	require.Equal(t, runs.runs[1].ID, *runs.queries[1].Cursor)
+	require.Equal(t, runs.runs[3].ID, *store.replays[r.ID].Cursor)
+
+	for i, req := range exec.reqs {
+		require.Equal(t, runs.runs[i].ID, *req.OriginalRunID)
+		require.Equal(t, r.ID, *req.ReplayID)
+		require.Len(t, req.Events, 1)
+		require.Equal(t, runs.runs[i].EventID, req.Events[0].GetInternalID())
+	}
+}
+
+func TestTickCompletesReplay(t *testing.T) {
+	ctx := context.Background()
+	m, r, store, _, exec := newTestManager(t, 2, 3)
+
+	require.NoError(t, m.Tick(ctx))
+	require.Equal(t, enums.ReplayStatusRunning, store.replays[r.ID].Status)
+
+	// The last page has fewer runs than the limit, completing the replay.
+	require.NoError(t, m.Tick(ctx))
+	require.Equal(t, enums.ReplayStatusCompleted, store.replays[r.ID].Status)
+	require.Equal(t, 3, store.replays[r.ID].ProcessedRuns)
+	require.Len(t, exec.reqs, 3)
+
+	// Completed replays are no longer processed.
+	require.NoError(t, m.Tick(ctx))
+	require.Len(t, exec.reqs, 3)
+}
+
+func TestTickRetriesTransientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries on the next tick", func(t *testing.T) {
+		m, r, store, runs, exec := newTestManager(t, 10, 3)
+		runs.err = errors.New("connection reset")
+		runs.errCount = MaxAttempts - 1
+
+		for i := 0; i < MaxAttempts-1; i++ {
+			require.NoError(t, m.Tick(ctx))
+			require.Equal(t, enums.ReplayStatusRunning, store.replays[r.ID].Status)
+		}
+		require.Empty(t, exec.reqs)
+
+		require.NoError(t, m.Tick(ctx))
+		require.Equal(t, enums.ReplayStatusCompleted, store.replays[r.ID].Status)
+		require.Len(t, exec.reqs, 3)
+	})
+
+	t.Run("fails after max attempts", func(t *testing.T) {
+		m, r, store, runs, _ := newTestManager(t, 10, 3)
+		runs.err = errors.New("connection reset")
+		runs.errCount = MaxAttempts
+
+		for i := 0; i < MaxAttempts; i++ {
+			require.NoError(t, m.Tick(ctx))
+		}
+		require.Equal(t, enums.ReplayStatusFailed, store.replays[r.ID].Status)
+		require.NotNil(t, store.replays[r.ID].Error)
+		require.Contains(t, *store.replays[r.ID].Error, "connection reset")
+	})
+
+	t.Run("fails immediately if the function is deleted", func(t *testing.T) {
+		m, r, store, _, _ := newTestManager(t, 10, 3)
+		m.fns = replayFunctions{deleted: true}
+
+		require.NoError(t, m.Tick(ctx))
+		require.Equal(t, enums.ReplayStatusFailed, store.replays[r.ID].Status)
+	})
+}
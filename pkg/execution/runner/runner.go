@@ -3,7 +3,6 @@ package runner
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"runtime/debug"
 	"sync"
@@ -15,11 +14,14 @@ import (
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventroute"
 	"github.com/inngest/inngest/pkg/execution"
 	"github.com/inngest/inngest/pkg/execution/batch"
+	"github.com/inngest/inngest/pkg/execution/cron"
 	"github.com/inngest/inngest/pkg/execution/executor"
 	"github.com/inngest/inngest/pkg/execution/queue"
 	"github.com/inngest/inngest/pkg/execution/ratelimit"
+	"github.com/inngest/inngest/pkg/execution/realtime"
 	"github.com/inngest/inngest/pkg/execution/state"
 	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
 	"github.com/inngest/inngest/pkg/expressions"
@@ -29,10 +31,7 @@ import (
 	"github.com/inngest/inngest/pkg/service"
 	itrace "github.com/inngest/inngest/pkg/telemetry/trace"
 	"github.com/oklog/ulid/v2"
-	"github.com/robfig/cron/v3"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -94,6 +93,12 @@ func WithBatchManager(b batch.BatchManager) func(s *svc) {
 	}
 }
 
+func WithCronManager(c cron.CronManager) func(s *svc) {
+	return func(s *svc) {
+		s.crons = c
+	}
+}
+
 func WithRateLimiter(rl ratelimit.RateLimiter) func(s *svc) {
 	return func(s *svc) {
 		s.rl = rl
@@ -114,6 +119,15 @@ func WithPublisher(p pubsub.Publisher) func(s *svc) {
 	}
 }
 
+// WithRealtimeRoutes publishes events matching the given routes to realtime
+// subscribers as the events are delivered.
+func WithRealtimeRoutes(r *eventroute.Router, p realtime.Publisher) func(s *svc) {
+	return func(s *svc) {
+		s.routes = r
+		s.realtime = p
+	}
+}
+
 func WithLogger(l logger.Logger) func(s *svc) {
 	return func(s *svc) {
 		s.log = l
@@ -148,12 +162,16 @@ type svc struct {
 	batcher batch.BatchManager
 	// rl rate-limits functions.
 	rl ratelimit.RateLimiter
-	// cronmanager allows the creation of new scheduled functions.
-	cronmanager *cron.Cron
-	em          *event.Manager
+	// crons schedules cron triggers as durable queue items.
+	crons cron.CronManager
+	em    *event.Manager
 
 	tracker *Tracker
 
+	// routes publishes matching events to realtime subscribers via realtime.
+	routes   *eventroute.Router
+	realtime realtime.Publisher
+
 	log logger.Logger
 }
 
@@ -188,17 +206,14 @@ func (s *svc) Pre(ctx context.Context) error {
 }
 
 func (s *svc) Run(ctx context.Context) error {
-	// Each runner service is responsible for initializing cron-based executions.
-	// As the runners are shared-nothing, there is contention when running multiple
-	// services;  each individual service will attempt to create a new cron execution
-	// simultaneously.  We currently rely on idempotency within the state store to
-	// ensure that only one run can execute.
+	// Each runner service is responsible for syncing cron-based executions.  Every
+	// tick is a queue item with a deterministic job ID, and each tick enqueues the
+	// next, so only one run is scheduled per tick regardless of how many runners
+	// sync.  Ticks missed while all runners were down are handled by the cron
+	// manager's catch-up policy.
 	//
-	// In the future, we may want to add distributed locking and/or a limit on the
-	// number of concurrent services that can schedule crons.  We don't really want
-	// to rely on a single executor to 'claim' ownership:  we'd have to implement
-	// more complex logic to check for the last heartbeat and valid cron scheduled,
-	// then backtrack to re-execute in the case of node downtime.  This is simple.
+	// Functions which fail to sync are logged, so this only errors without a cron
+	// manager.
 	if err := s.InitializeCrons(ctx); err != nil {
 		return err
 	}
@@ -212,99 +227,46 @@ func (s *svc) Run(ctx context.Context) error {
 }
 
 func (s *svc) Stop(ctx context.Context) error {
-	if s.cronmanager != nil {
-		cronCtx := s.cronmanager.Stop()
-		select {
-		case <-cronCtx.Done():
-		case <-ctx.Done():
-			return fmt.Errorf("error waiting for scheduled executions to finish")
-		}
-	}
 	return nil
 }
 
 func (s *svc) InitializeCrons(ctx context.Context) error {
-	// If a previous cron manager exists, cancel it.
-	if s.cronmanager != nil {
-		s.cronmanager.Stop()
+	if s.crons == nil {
+		return fmt.Errorf("no cron manager provided")
 	}
 
-	s.cronmanager = cron.New(
-		cron.WithParser(
-			cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow),
-		),
-	)
-
-	// Set the functions within the engine, then iterate through each function's
-	// triggers so that we can easily invoke them.  We also need to immediately
-	// set up cron timers to invoke functions on a schedule.
+	// Sync the cron triggers of every scheduled function.  Each trigger keeps
+	// its own next-fire record, so this only creates records and queue items
+	// for new function versions.
+	//
+	// Errors are logged rather than returned, such that one function failing to
+	// sync doesn't prevent the crons of every other function from running.
 	fns, err := s.data.FunctionsScheduled(ctx)
 	if err != nil {
-		return err
+		s.log.Error("error loading scheduled functions", "error", err)
+		return nil
 	}
 
-	for _, f := range fns {
-		fn := f
-		// Set up a cron schedule for the current function.
-		for _, t := range f.Triggers {
+	for _, fn := range fns {
+		l := s.log.With("function_id", fn.ID.String())
+
+		f, err := s.cqrs.GetFunctionByInternalUUID(ctx, consts.DevServerEnvID, fn.ID)
+		if err != nil {
+			l.Error("error loading scheduled function", "error", err)
+			continue
+		}
+
+		for _, t := range fn.Triggers {
 			if t.CronTrigger == nil {
 				continue
 			}
-			cron := t.CronTrigger.Cron
-			_, err := s.cronmanager.AddFunc(cron, func() {
-				// Create a new context to avoid "context canceled" errors. This
-				// callback is run as a non-blocking goroutine in Cron.Start, so
-				// contexts from outside its scope will likely be cancelled
-				// before the function is run
-				ctx := context.Background()
-
-				ctx, span := itrace.UserTracer().Provider().
-					Tracer(consts.OtelScopeCron).
-					Start(ctx, "cron", trace.WithAttributes(
-						attribute.String(consts.OtelSysFunctionID, fn.ID.String()),
-						attribute.Int(consts.OtelSysFunctionVersion, fn.FunctionVersion),
-					))
-				defer span.End()
-
-				trackedEvent := event.NewOSSTrackedEvent(event.Event{
-					Data: map[string]any{
-						"cron": cron,
-					},
-					ID:        time.Now().UTC().Format(time.RFC3339),
-					Name:      event.FnCronName,
-					Timestamp: time.Now().UnixMilli(),
-				}, nil)
-
-				byt, err := json.Marshal(trackedEvent)
-				if err == nil {
-					err := s.publisher.Publish(
-						ctx,
-						s.config.EventStream.Service.TopicName(),
-						pubsub.Message{
-							Name:      event.EventReceivedName,
-							Data:      string(byt),
-							Timestamp: time.Now(),
-						},
-					)
-					if err != nil {
-						s.log.Error("error publishing cron event", "error", err)
-					}
-				} else {
-					s.log.Error("error marshaling cron event", "error", err)
-				}
-
-				err = s.initialize(ctx, fn, trackedEvent)
-				if err != nil {
-					s.log.Error("error initializing scheduled function", "error", err)
-				}
-			})
-			if err != nil {
-				return err
+			ci := cron.NewCronItem(consts.DevServerAccountID, consts.DevServerEnvID, f.AppID, fn, *t.CronTrigger)
+			if err := s.crons.Sync(ctx, ci); err != nil {
+				l.Error("error syncing cron", "error", err, "cron", t.CronTrigger.Cron)
 			}
 		}
 	}
 
-	s.cronmanager.Start()
 	return nil
 }
 
@@ -389,6 +351,10 @@ func (s *svc) handleMessage(ctx context.Context, m pubsub.Message) error {
 
 	l.Info("received event")
 
+	// Publish events to realtime routes once they're delivered, such that events
+	// scheduled for the future are only published when they're due.
+	s.routes.Publish(ctx, s.realtime, tracked.GetWorkspaceID(), tracked.GetEvent())
+
 	var errs error
 	wg := &sync.WaitGroup{}
 
@@ -421,6 +387,19 @@ func (s *svc) handleMessage(ctx context.Context, m pubsub.Message) error {
 		}()
 	}
 
+	// Signals may be delivered via "inngest/signal.send" events for producers
+	// which only use the event API.
+	if tracked.GetEvent().IsSignalEvent() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.signals(ctx, tracked); err != nil {
+				l.Error("error resuming signal from event", "error", err)
+				errs = multierror.Append(errs, err)
+			}
+		}()
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -605,6 +584,20 @@ func (s *svc) invokes(ctx context.Context, evt event.TrackedEvent) error {
 	return s.executor.HandleInvokeFinish(ctx, evt)
 }
 
+// signals resumes the signal wait addressed by a signal event.
+func (s *svc) signals(ctx context.Context, evt event.TrackedEvent) error {
+	data, err := evt.GetEvent().SignalData()
+	if err != nil {
+		return fmt.Errorf("invalid signal event: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+
+	_, err = s.executor.ResumeSignal(ctx, evt.GetWorkspaceID(), data.Signal, data.Data)
+	return err
+}
+
 // pauses searches for and triggers all pauses from this event.
 func (s *svc) pauses(ctx context.Context, evt event.TrackedEvent) error {
 	l := logger.StdlibLogger(ctx).With(
@@ -641,7 +634,10 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 		"function_id", fn.ID.String(),
 	)
 
-	var appID uuid.UUID
+	var (
+		appID    uuid.UUID
+		pausedAt *time.Time
+	)
 	wsID := evt.GetWorkspaceID()
 	{
 		fn, err := s.cqrs.GetFunctionByInternalUUID(ctx, wsID, fn.ID)
@@ -649,6 +645,7 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 			return err
 		}
 		appID = fn.AppID
+		pausedAt = fn.PausedAt
 	}
 
 	if fn.IsBatchEnabled() {
@@ -662,7 +659,9 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 			AccountID:       consts.DevServerAccountID,
 		}
 
-		if err := s.executor.AppendAndScheduleBatch(ctx, fn, bi, nil); err != nil {
+		if err := s.executor.AppendAndScheduleBatch(ctx, fn, bi, &execution.BatchExecOpts{
+			FunctionPausedAt: pausedAt,
+		}); err != nil {
 			return fmt.Errorf("could not append and schedule batch item: %w", err)
 		}
 
@@ -705,10 +704,11 @@ func (s *svc) initialize(ctx context.Context, fn inngest.Function, evt event.Tra
 
 	l.Info("initializing fn")
 	_, err := Initialize(ctx, InitOpts{
-		appID: appID,
-		fn:    fn,
-		evt:   evt,
-		exec:  s.executor,
+		appID:    appID,
+		fn:       fn,
+		evt:      evt,
+		exec:     s.executor,
+		pausedAt: pausedAt,
 	})
 	if err == state.ErrIdentifierExists {
 		// This run exists;  do not attempt to recreate it.
@@ -725,6 +725,8 @@ type InitOpts struct {
 	fn    inngest.Function
 	evt   event.TrackedEvent
 	exec  execution.Executor
+	// pausedAt is set if the function is paused, skipping the run.
+	pausedAt *time.Time
 }
 
 // Initialize creates a new funciton run identifier for the given workflow and
@@ -753,12 +755,13 @@ func Initialize(ctx context.Context, opts InitOpts) (*sv2.Metadata, error) {
 
 	// If this is a debounced function, run this through a debouncer.
 	md, err := opts.exec.Schedule(ctx, execution.ScheduleRequest{
-		WorkspaceID:    wsID,
-		AppID:          opts.appID,
-		Function:       fn,
-		Events:         []event.TrackedEvent{tracked},
-		IdempotencyKey: &idempotencyKey,
-		AccountID:      consts.DevServerAccountID,
+		WorkspaceID:      wsID,
+		AppID:            opts.appID,
+		Function:         fn,
+		Events:           []event.TrackedEvent{tracked},
+		IdempotencyKey:   &idempotencyKey,
+		AccountID:        consts.DevServerAccountID,
+		FunctionPausedAt: opts.pausedAt,
 	})
 
 	switch err {
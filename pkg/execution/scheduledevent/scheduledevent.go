@@ -0,0 +1,186 @@
+// Package scheduledevent holds events sent with a future timestamp in the
+// queue, publishing them once their timestamp is reached.
+package scheduledevent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/jonboulle/clockwork"
+	"github.com/oklog/ulid/v2"
+)
+
+// DefaultMinDelay is the default minimum delay between receiving an event and
+// its timestamp for the event to be scheduled.  Events with timestamps within
+// this delay are published immediately, such that senders whose clocks run
+// slightly ahead of the server don't have their events delayed.
+const DefaultMinDelay = time.Minute
+
+var ErrNotFound = fmt.Errorf("scheduled event not found")
+
+// Scheduler holds events until their timestamp is reached.
+type Scheduler interface {
+	// ShouldSchedule returns whether the given event's timestamp is far
+	// enough in the future for the event to be scheduled.
+	ShouldSchedule(evt event.Event) bool
+	// Schedule enqueues the given event to be published at its timestamp.
+	// Scheduling an event with the same ID as a pending scheduled event is a
+	// no-op.
+	Schedule(ctx context.Context, p Payload) error
+	// Cancel removes a scheduled event by its ID, returning ErrNotFound if
+	// the event isn't scheduled, eg. because it was already published.
+	// Events sent without an ID are cancelled using their internal ID.  A
+	// cancelled event may be scheduled again.
+	Cancel(ctx context.Context, accountID, workspaceID uuid.UUID, eventID string) error
+}
+
+// Payload represents the data stored within the queue's payload for a
+// scheduled event.
+type Payload struct {
+	AccountID   uuid.UUID `json:"aID"`
+	WorkspaceID uuid.UUID `json:"wsID"`
+	// InternalID is the internal ID of the event, returned when the event was
+	// received and used when the event is published.
+	InternalID ulid.ULID `json:"id"`
+	// Event is the event to publish.
+	Event event.Event `json:"event"`
+}
+
+// TrackedEvent returns the tracked event to publish.
+func (p Payload) TrackedEvent() event.TrackedEvent {
+	return event.NewOSSTrackedEventWithID(p.Event, p.InternalID)
+}
+
+// EventID returns the ID used to cancel the scheduled event: the event's ID,
+// or its internal ID if the event was sent without an ID.
+func (p Payload) EventID() string {
+	if p.Event.ID != "" {
+		return p.Event.ID
+	}
+	return p.InternalID.String()
+}
+
+// JobID returns the deterministic queue job ID for the scheduled event.
+func JobID(workspaceID uuid.UUID, eventID string) string {
+	return fmt.Sprintf("scheduled-event:%s:%s", workspaceID, eventID)
+}
+
+type RedisSchedulerOpt func(s *redisScheduler)
+
+func WithLogger(l logger.Logger) RedisSchedulerOpt {
+	return func(s *redisScheduler) {
+		s.log = l
+	}
+}
+
+func WithClock(c clockwork.Clock) RedisSchedulerOpt {
+	return func(s *redisScheduler) {
+		s.c = c
+	}
+}
+
+// WithMinDelay sets the minimum delay between receiving an event and its
+// timestamp for the event to be scheduled.  Delays of zero or less use
+// DefaultMinDelay.
+func WithMinDelay(d time.Duration) RedisSchedulerOpt {
+	return func(s *redisScheduler) {
+		if d > 0 {
+			s.minDelay = d
+		}
+	}
+}
+
+// NewRedisScheduler returns a Scheduler which stores scheduled events as
+// queue items in the given shard.
+func NewRedisScheduler(shard redis_state.QueueShard, q redis_state.QueueManager, opts ...RedisSchedulerOpt) Scheduler {
+	s := &redisScheduler{
+		c:        clockwork.NewRealClock(),
+		shard:    shard,
+		q:        q,
+		log:      logger.StdlibLogger(context.Background()),
+		minDelay: DefaultMinDelay,
+	}
+
+	for _, apply := range opts {
+		apply(s)
+	}
+
+	return s
+}
+
+type redisScheduler struct {
+	c        clockwork.Clock
+	shard    redis_state.QueueShard
+	q        redis_state.QueueManager
+	log      logger.Logger
+	minDelay time.Duration
+}
+
+func (s *redisScheduler) ShouldSchedule(evt event.Event) bool {
+	return evt.Time().After(s.c.Now().Add(s.minDelay))
+}
+
+func (s *redisScheduler) Schedule(ctx context.Context, p Payload) error {
+	jobID := JobID(p.WorkspaceID, p.EventID())
+	maxAttempts := consts.MaxRetries + 1
+
+	queueName := queue.KindScheduledEvent
+	err := s.q.Enqueue(ctx, queue.Item{
+		JobID:       &jobID,
+		GroupID:     uuid.New().String(),
+		WorkspaceID: p.WorkspaceID,
+		Kind:        queue.KindScheduledEvent,
+		Identifier:  identifier(p.AccountID, p.WorkspaceID),
+		Attempt:     0,
+		MaxAttempts: &maxAttempts,
+		Payload:     p,
+		QueueName:   &queueName,
+	}, p.Event.Time(), queue.EnqueueOpts{})
+	if err == redis_state.ErrQueueItemExists {
+		s.log.Debug("queue item already exists for scheduled event", "job_id", jobID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error enqueueing scheduled event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisScheduler) Cancel(ctx context.Context, accountID, workspaceID uuid.UUID, eventID string) error {
+	// Don't retain the job ID: the same event may be scheduled again once
+	// cancelled.
+	queueName := queue.KindScheduledEvent
+	err := s.q.Dequeue(ctx, s.shard, queue.QueueItem{
+		ID:          queue.HashID(ctx, JobID(workspaceID, eventID)),
+		WorkspaceID: workspaceID,
+		QueueName:   &queueName,
+		Data: queue.Item{
+			Kind:       queue.KindScheduledEvent,
+			Identifier: identifier(accountID, workspaceID),
+			QueueName:  &queueName,
+		},
+	}, redis_state.DequeueOptionDisableIdempotency())
+	if err == redis_state.ErrQueueItemNotFound {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("error cancelling scheduled event: %w", err)
+	}
+	return nil
+}
+
+func identifier(accountID, workspaceID uuid.UUID) state.Identifier {
+	return state.Identifier{
+		AccountID:   accountID,
+		WorkspaceID: workspaceID,
+	}
+}
@@ -0,0 +1,125 @@
+package scheduledevent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/jonboulle/clockwork"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisScheduler(t *testing.T) {
+	r := miniredis.RunT(t)
+
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+
+	unshardedClient := redis_state.NewUnshardedClient(rc, redis_state.StateDefaultKey, redis_state.QueueDefaultKey)
+	shard := redis_state.QueueShard{Name: consts.DefaultQueueShardName, RedisClient: unshardedClient.Queue(), Kind: string(enums.QueueShardKindRedis)}
+
+	q := redis_state.NewQueue(
+		shard,
+		redis_state.WithQueueShardClients(
+			map[string]redis_state.QueueShard{
+				shard.Name: shard,
+			},
+		),
+		redis_state.WithShardSelector(func(ctx context.Context, accountId uuid.UUID, queueName *string) (redis_state.QueueShard, error) {
+			return shard, nil
+		}),
+	)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := clockwork.NewFakeClockAt(now)
+	s := NewRedisScheduler(shard, q, WithClock(clock))
+	short := NewRedisScheduler(shard, q, WithClock(clock), WithMinDelay(time.Second))
+
+	ctx := context.Background()
+	kg := shard.RedisClient.KeyGenerator()
+	accountID, wsID := uuid.New(), uuid.New()
+
+	t.Run("only future events are scheduled", func(t *testing.T) {
+		require.False(t, s.ShouldSchedule(event.Event{Timestamp: now.UnixMilli()}))
+		require.False(t, s.ShouldSchedule(event.Event{Timestamp: now.Add(DefaultMinDelay).UnixMilli()}))
+		require.True(t, s.ShouldSchedule(event.Event{Timestamp: now.Add(time.Hour).UnixMilli()}))
+
+		// Clock skew within the min delay doesn't delay events.
+		require.False(t, s.ShouldSchedule(event.Event{Timestamp: now.Add(5 * time.Second).UnixMilli()}))
+		require.True(t, short.ShouldSchedule(event.Event{Timestamp: now.Add(5 * time.Second).UnixMilli()}))
+	})
+
+	p := Payload{
+		AccountID:   accountID,
+		WorkspaceID: wsID,
+		InternalID:  ulid.Make(),
+		Event: event.Event{
+			ID:        "reminder-1",
+			Name:      "app/reminder.due",
+			Data:      map[string]any{"email": "a@b.com"},
+			Timestamp: now.Add(time.Hour).UnixMilli(),
+		},
+	}
+	itemID := queue.HashID(ctx, JobID(wsID, "reminder-1"))
+
+	t.Run("schedule enqueues an item at the event's timestamp", func(t *testing.T) {
+		require.NoError(t, s.Schedule(ctx, p))
+		// Scheduling the same event again is a no-op.
+		require.NoError(t, s.Schedule(ctx, p))
+
+		keys, err := r.HKeys(kg.QueueItem())
+		require.NoError(t, err)
+		require.Equal(t, []string{itemID}, keys)
+
+		qi := queue.QueueItem{}
+		require.NoError(t, json.Unmarshal([]byte(r.HGet(kg.QueueItem(), itemID)), &qi))
+		require.Equal(t, queue.KindScheduledEvent, qi.Data.Kind)
+		require.Equal(t, p.Event.Timestamp, qi.AtMS)
+
+		loaded := Payload{}
+		require.NoError(t, json.Unmarshal(qi.Data.Payload.(json.RawMessage), &loaded))
+		require.Equal(t, p.InternalID, loaded.InternalID)
+		require.Equal(t, p.InternalID, loaded.TrackedEvent().GetInternalID())
+		require.Equal(t, "reminder-1", loaded.TrackedEvent().GetEvent().ID)
+	})
+
+	t.Run("cancel removes the item", func(t *testing.T) {
+		require.NoError(t, s.Cancel(ctx, accountID, wsID, "reminder-1"))
+		require.False(t, r.Exists(kg.QueueItem()) && r.HGet(kg.QueueItem(), itemID) != "")
+
+		require.ErrorIs(t, s.Cancel(ctx, accountID, wsID, "reminder-1"), ErrNotFound)
+		require.ErrorIs(t, s.Cancel(ctx, accountID, wsID, "unknown"), ErrNotFound)
+	})
+
+	t.Run("cancelled events can be scheduled again", func(t *testing.T) {
+		require.False(t, r.Exists(kg.Idempotency(itemID)))
+
+		require.NoError(t, s.Schedule(ctx, p))
+		require.NotEmpty(t, r.HGet(kg.QueueItem(), itemID))
+
+		require.NoError(t, s.Cancel(ctx, accountID, wsID, "reminder-1"))
+		require.Empty(t, r.HGet(kg.QueueItem(), itemID))
+	})
+
+	t.Run("events without an ID are cancelled by internal ID", func(t *testing.T) {
+		anon := p
+		anon.InternalID = ulid.Make()
+		anon.Event.ID = ""
+
+		require.NoError(t, s.Schedule(ctx, anon))
+		require.NoError(t, s.Cancel(ctx, accountID, wsID, anon.InternalID.String()))
+	})
+}
@@ -0,0 +1,36 @@
+package singleton
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+)
+
+// NewPostgres returns a Singleton which reads the singleton locks held by the
+// postgres queue.
+func NewPostgres(db *sql.DB) Singleton {
+	return &postgresStore{db: db}
+}
+
+type postgresStore struct {
+	db *sql.DB
+}
+
+func (p *postgresStore) Singleton(ctx context.Context, key string, s inngest.Singleton) (*ulid.ULID, error) {
+	return singleton(ctx, p, key, s)
+}
+
+func (p *postgresStore) GetCurrentRunID(ctx context.Context, key string) (*ulid.ULID, error) {
+	var val string
+	err := p.db.QueryRowContext(ctx, `SELECT run_id FROM queue_singletons WHERE key = $1`, key).Scan(&val)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseRunID(val)
+}
@@ -2,9 +2,12 @@ package singleton
 
 import (
 	"context"
+
 	"github.com/inngest/inngest/pkg/execution/queue"
 	"github.com/inngest/inngest/pkg/execution/state/redis_state"
 	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
 )
 
 func New(ctx context.Context, r *redis_state.QueueClient) Singleton {
@@ -15,18 +18,29 @@ type redisStore struct {
 	r *redis_state.QueueClient
 }
 
-func (r *redisStore) Singleton(ctx context.Context, key string, s inngest.Singleton) (bool, error) {
+func (r *redisStore) Singleton(ctx context.Context, key string, s inngest.Singleton) (*ulid.ULID, error) {
 	return singleton(ctx, r, key, s)
 }
 
-func (r *redisStore) Exists(ctx context.Context, key string) (bool, error) {
+func (r *redisStore) GetCurrentRunID(ctx context.Context, key string) (*ulid.ULID, error) {
 	key = r.r.KeyGenerator().SingletonKey(&queue.Singleton{Key: key})
 
 	client := r.r.Client()
-	// result will be either 0 or 1
-	result, err := r.r.Client().Do(ctx, client.B().Exists().Key(key).Build()).AsInt64()
+	// The singleton key stores the run ID of the run currently holding the lock.
+	val, err := client.Do(ctx, client.B().Get().Key(key).Build()).ToString()
+	if rueidis.IsRedisNil(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseRunID(val)
+}
+
+func parseRunID(val string) (*ulid.ULID, error) {
+	runID, err := ulid.Parse(val)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	return result > 0, nil
+	return &runID, nil
 }
@@ -3,12 +3,12 @@ package singleton
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/google/uuid"
 	"github.com/inngest/inngest/pkg/expressions"
 	"github.com/inngest/inngest/pkg/inngest"
 	"github.com/inngest/inngest/pkg/util"
+	"github.com/oklog/ulid/v2"
 )
 
 var (
@@ -17,7 +17,9 @@ var (
 )
 
 type Singleton interface {
-	Singleton(ctx context.Context, key string, c inngest.Singleton) (bool, error)
+	// Singleton returns the ID of the run currently holding the singleton lock for
+	// the given key, or nil if no other singleton instance is in progress.
+	Singleton(ctx context.Context, key string, c inngest.Singleton) (*ulid.ULID, error)
 }
 
 // SingletonKey returns the singleton key given a function ID, singleton config,
@@ -46,10 +48,10 @@ func hash(res any, id uuid.UUID) string {
 	return fmt.Sprintf("%s-%s", id, sum)
 }
 
-func singleton(ctx context.Context, store SingletonStore, key string, s inngest.Singleton) (bool, error) {
-	result, err := store.Exists(ctx, key)
+func singleton(ctx context.Context, store SingletonStore, key string, s inngest.Singleton) (*ulid.ULID, error) {
+	runID, err := store.GetCurrentRunID(ctx, key)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("error loading singleton run: %w", err)
 	}
-	return result, err
+	return runID, nil
 }
@@ -2,8 +2,12 @@ package singleton
 
 import (
 	"context"
+
+	"github.com/oklog/ulid/v2"
 )
 
 type SingletonStore interface {
-	Exists(ctx context.Context, key string) (bool, error)
+	// GetCurrentRunID returns the ID of the run currently holding the singleton
+	// lock for the given key, or nil if the lock is free.
+	GetCurrentRunID(ctx context.Context, key string) (*ulid.ULID, error)
 }
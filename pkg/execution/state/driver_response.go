@@ -36,6 +36,27 @@ type UserError struct {
 	Cause json.RawMessage `json:"cause,omitempty"`
 }
 
+// StepTimeoutErrorName is the error name used when a step exceeds its timeout.
+const StepTimeoutErrorName = "StepTimeoutError"
+
+// StepTimeoutError is returned by drivers when a request exceeds the step's
+// configured timeout.
+type StepTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e StepTimeoutError) Error() string {
+	return fmt.Sprintf("step timed out after %s", e.Timeout)
+}
+
+// UserError returns the timeout as a user-facing error for OpcodeStepError.
+func (e StepTimeoutError) UserError() *UserError {
+	return &UserError{
+		Name:    StepTimeoutErrorName,
+		Message: e.Error(),
+	}
+}
+
 // DriverResponse is returned after a driver executes an action.  This represents any
 // output from running the step, including the output (as a JSON map), the error, and
 // whether the driver's response is "scheduled", eg. the driver is running the job
@@ -211,6 +211,9 @@ type SignalOpts struct {
 	Signal     string `json:"signal"`
 	Timeout    string `json:"timeout"`
 	OnConflict string `json:"conflict"`
+	// If is an optional expression evaluated against the signal payload.  The
+	// wait is only resumed when the expression matches.
+	If *string `json:"if,omitempty"`
 }
 
 func (s *SignalOpts) UnmarshalAny(a any) error {
@@ -86,6 +86,10 @@ type PauseGetter interface {
 	// PauseBySignalCorrelationID returns a given pause by the correlation ID.
 	PauseBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) (*Pause, error)
 
+	// PausesBySignalID returns all pauses waiting for a signal:  the pause returned
+	// by PauseBySignalID, if any, plus every signal pause with an expression.
+	PausesBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) ([]*Pause, error)
+
 	// PauseCreatedAt returns the timestamp a pause was created, using the given
 	// workspace <> event Index.
 	PauseCreatedAt(ctx context.Context, workspaceID uuid.UUID, event string, pauseID uuid.UUID) (time.Time, error)
@@ -260,6 +264,13 @@ func (p Pause) GetID() uuid.UUID {
 	return p.ID
 }
 
+// IsSignalWaiter returns whether this is a signal pause with an expression.  Many
+// of these may wait for the same signal, so they're stored separately from the
+// signal ID -> pause ID mapping.
+func (p Pause) IsSignalWaiter() bool {
+	return p.SignalID != nil && *p.SignalID != "" && p.Expression != nil && *p.Expression != ""
+}
+
 func (p Pause) GetExpression() string {
 	if p.Expression == nil {
 		return ""
@@ -0,0 +1,710 @@
+package postgres_state
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/expr"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// pauseExpiryGrace is the additional time a pause remains loadable past its
+	// expiry, allowing us to process timeouts by pause ID.
+	pauseExpiryGrace = 10 * time.Minute
+
+	// pauseIterChunk is the number of pauses loaded per query when iterating.
+	pauseIterChunk = 1000
+)
+
+// PauseCreatedAt returns the timestamp a pause was created, using the given
+// workspace <> event Index.
+func (m mgr) PauseCreatedAt(ctx context.Context, workspaceID uuid.UUID, event string, pauseID uuid.UUID) (time.Time, error) {
+	var ts int64
+	err := m.db.QueryRowContext(ctx,
+		`SELECT created_at FROM pauses WHERE id = $1 AND workspace_id = $2 AND event = $3`,
+		pauseID, workspaceID, event,
+	).Scan(&ts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, fmt.Errorf("pause timestamp not found")
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ts), nil
+}
+
+func (m mgr) SavePause(ctx context.Context, p state.Pause) (int64, error) {
+	packed, err := json.Marshal(p)
+	if err != nil {
+		return 0, err
+	}
+
+	// `evt` is used to search for pauses based on event names. We only want to
+	// do this if this pause is not part of an invoke. If it is, we don't want
+	// to index it by event name as the pause will be processed by correlation
+	// ID.
+	evt := ""
+	if p.Event != nil && (p.InvokeCorrelationID == nil || *p.InvokeCorrelationID == "") {
+		evt = *p.Event
+	}
+
+	var count int64
+	err = WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO pauses (id, workspace_id, run_id, event, pause, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (id) DO NOTHING`,
+			p.ID,
+			p.WorkspaceID,
+			p.Identifier.RunID.String(),
+			evt,
+			string(packed),
+			time.Now().UnixMilli(),
+			// Add at least 10 minutes to this pause, allowing us to process the
+			// pause by ID for 10 minutes past expiry.
+			p.Expires.Time().Add(pauseExpiryGrace).UnixMilli(),
+		)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return state.ErrPauseAlreadyExists
+		}
+
+		if p.InvokeCorrelationID != nil && *p.InvokeCorrelationID != "" {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO pause_invokes (workspace_id, correlation_id, pause_id) VALUES ($1, $2, $3)
+				ON CONFLICT (workspace_id, correlation_id) DO NOTHING`,
+				p.WorkspaceID, *p.InvokeCorrelationID, p.ID,
+			); err != nil {
+				return err
+			}
+		}
+
+		if p.SignalID != nil && *p.SignalID != "" {
+			if err := saveSignal(ctx, tx, p); err != nil {
+				return err
+			}
+		}
+
+		return tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM pauses WHERE workspace_id = $1 AND event = $2`,
+			p.WorkspaceID, evt,
+		).Scan(&count)
+	})
+
+	switch {
+	case err == nil:
+		return count, nil
+	case errors.Is(err, state.ErrPauseAlreadyExists):
+		return -1, state.ErrPauseAlreadyExists
+	case errors.Is(err, state.ErrSignalConflict):
+		return 0, state.ErrSignalConflict
+	default:
+		return 0, fmt.Errorf("error finalizing: %w", err)
+	}
+}
+
+// saveSignal stores the signal ID -> pause ID mapping for a pause.
+func saveSignal(ctx context.Context, tx *sql.Tx, p state.Pause) error {
+	if p.IsSignalWaiter() {
+		// Signal waits with an expression may share a signal ID, and each wait
+		// whose expression matches is resumed.
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO pause_signal_waiters (workspace_id, signal_id, pause_id) VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING`,
+			p.WorkspaceID, *p.SignalID, p.ID,
+		)
+		return err
+	}
+
+	if p.ReplaceSignalOnConflict {
+		// Note that this may be overwriting an existing signal wait, which is
+		// intentional.  The previous signal wait will be left to reach its
+		// timeout.
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO pause_signals (workspace_id, signal_id, pause_id) VALUES ($1, $2, $3)
+			ON CONFLICT (workspace_id, signal_id) DO UPDATE SET pause_id = EXCLUDED.pause_id`,
+			p.WorkspaceID, *p.SignalID, p.ID,
+		)
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO pause_signals (workspace_id, signal_id, pause_id) VALUES ($1, $2, $3)
+		ON CONFLICT (workspace_id, signal_id) DO NOTHING`,
+		p.WorkspaceID, *p.SignalID, p.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	// The signal already exists.  This may be an idempotent retry for saving
+	// the same pause;  if not, we're trying to duplicate a signal.
+	var existing uuid.UUID
+	err = tx.QueryRowContext(ctx,
+		`SELECT pause_id FROM pause_signals WHERE workspace_id = $1 AND signal_id = $2`,
+		p.WorkspaceID, *p.SignalID,
+	).Scan(&existing)
+	if err != nil {
+		return err
+	}
+	if existing != p.ID {
+		return state.ErrSignalConflict
+	}
+	return nil
+}
+
+func (m mgr) LeasePause(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+
+	// Lease the pause if it's not leased or the lease has expired.  Note that
+	// pauses may exist in a different datastore, so this doesn't require the
+	// pause to be stored here.
+	res, err := m.db.ExecContext(ctx, `
+		INSERT INTO pause_leases (pause_id, leased_until) VALUES ($1, $2)
+		ON CONFLICT (pause_id) DO UPDATE SET leased_until = EXCLUDED.leased_until
+		WHERE pause_leases.leased_until <= $3`,
+		id, now.Add(state.PauseLeaseDuration).UnixMilli(), now.UnixMilli(),
+	)
+	if err != nil {
+		return fmt.Errorf("error leasing pause: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return state.ErrPauseLeased
+	}
+	return nil
+}
+
+func (m mgr) deletePausesForRun(ctx context.Context, runID ulid.ULID) error {
+	rows, err := m.db.QueryContext(ctx, `SELECT id FROM pauses WHERE run_id = $1`, runID.String())
+	if err != nil {
+		return err
+	}
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		_ = m.DeletePauseByID(ctx, id)
+	}
+	return nil
+}
+
+func (m mgr) DeletePauseByID(ctx context.Context, pauseID uuid.UUID) error {
+	// Attempt to fetch this pause so that invoke and signal mappings are
+	// also removed.
+	pause, err := m.PauseByID(ctx, pauseID)
+	if err == nil && pause != nil {
+		return m.DeletePause(ctx, *pause)
+	}
+	return m.DeletePause(ctx, state.Pause{
+		ID: pauseID,
+	})
+}
+
+func (m mgr) DeletePause(ctx context.Context, p state.Pause) error {
+	err := WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pauses WHERE id = $1`, p.ID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pause_leases WHERE pause_id = $1`, p.ID); err != nil {
+			return err
+		}
+		if p.InvokeCorrelationID != nil && *p.InvokeCorrelationID != "" {
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM pause_invokes WHERE workspace_id = $1 AND correlation_id = $2 AND pause_id = $3`,
+				p.WorkspaceID, *p.InvokeCorrelationID, p.ID,
+			); err != nil {
+				return err
+			}
+		}
+		if p.SignalID != nil && *p.SignalID != "" {
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM pause_signals WHERE workspace_id = $1 AND signal_id = $2 AND pause_id = $3`,
+				p.WorkspaceID, *p.SignalID, p.ID,
+			); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM pause_signal_waiters WHERE workspace_id = $1 AND signal_id = $2 AND pause_id = $3`,
+				p.WorkspaceID, *p.SignalID, p.ID,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting pause: %w", err)
+	}
+	return nil
+}
+
+func (m mgr) ConsumePause(ctx context.Context, pause state.Pause, opts state.ConsumePauseOpts) (state.ConsumePauseResult, func() error, error) {
+	if opts.IdempotencyKey == "" {
+		return state.ConsumePauseResult{},
+			func() error { return nil },
+			state.ErrConsumePauseKeyMissing
+	}
+
+	res, err := m.consumePause(ctx, pause, opts)
+	cleanup := func() error {
+		err := m.DeletePause(ctx, pause)
+		if err != nil {
+			logger.StdlibLogger(ctx).Error("error deleting pause after consumption", "error", err, "pause", pause)
+		}
+		return err
+	}
+
+	return res, cleanup, err
+}
+
+func (m mgr) consumePause(ctx context.Context, p state.Pause, opts state.ConsumePauseOpts) (state.ConsumePauseResult, error) {
+	marshalledData, err := json.Marshal(opts.Data)
+	if err != nil {
+		return state.ConsumePauseResult{}, fmt.Errorf("cannot marshal data to store in state: %w", err)
+	}
+
+	result := state.ConsumePauseResult{DidConsume: true}
+	err = WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		// Lock the run so that consuming is serialized with saving responses.
+		var pendingByt string
+		err := tx.QueryRowContext(ctx,
+			`SELECT pending FROM run_state WHERE run_id = $1 FOR UPDATE`,
+			p.Identifier.RunID.String(),
+		).Scan(&pendingByt)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The run may no longer exist;  there's no state to update.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		pending := []string{}
+		if err := json.Unmarshal([]byte(pendingByt), &pending); err != nil {
+			return fmt.Errorf("error unmarshalling pending steps: %w", err)
+		}
+
+		if p.DataKey != "" {
+			res, err := tx.ExecContext(ctx, `
+				INSERT INTO pause_consumes (run_id, pause_id, idempotency_key, expires_at) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (run_id, pause_id) DO NOTHING`,
+				p.Identifier.RunID.String(), p.ID, opts.IdempotencyKey,
+				time.Now().Add(consts.FunctionIdempotencyPeriod).UnixMilli(),
+			)
+			if err != nil {
+				return err
+			}
+
+			if n, _ := res.RowsAffected(); n == 0 {
+				// This pause has been consumed before.  Only succeed if it was
+				// consumed with the same idempotency key.
+				var key string
+				err := tx.QueryRowContext(ctx,
+					`SELECT idempotency_key FROM pause_consumes WHERE run_id = $1 AND pause_id = $2`,
+					p.Identifier.RunID.String(), p.ID,
+				).Scan(&key)
+				if err != nil {
+					return err
+				}
+				if key != opts.IdempotencyKey {
+					result = state.ConsumePauseResult{}
+					return nil
+				}
+			} else {
+				// idempotency check: only ever consume a pause once
+				res, err := tx.ExecContext(ctx, `
+					INSERT INTO run_steps (run_id, step_id, output, stack_index)
+					VALUES ($1, $2, $3, (SELECT COALESCE(MAX(stack_index), 0) + 1 FROM run_steps WHERE run_id = $1))
+					ON CONFLICT (run_id, step_id) DO UPDATE SET output = EXCLUDED.output, stack_index = EXCLUDED.stack_index
+					WHERE run_steps.output IS NULL`,
+					p.Identifier.RunID.String(), p.DataKey, string(marshalledData),
+				)
+				if err != nil {
+					return err
+				}
+				if n, _ := res.RowsAffected(); n == 0 {
+					result = state.ConsumePauseResult{}
+					return nil
+				}
+
+				pending = slices.DeleteFunc(pending, func(s string) bool { return s == p.DataKey })
+				pendingUpdate, err := json.Marshal(pending)
+				if err != nil {
+					return err
+				}
+				if _, err := tx.ExecContext(ctx, `
+					UPDATE run_state SET state_size = state_size + $2, step_count = step_count + 1, pending = $3
+					WHERE run_id = $1`,
+					p.Identifier.RunID.String(), len(marshalledData), string(pendingUpdate),
+				); err != nil {
+					return err
+				}
+			}
+		}
+
+		result.HasPendingSteps = len(pending) > 0
+		return nil
+	})
+	if err != nil {
+		return state.ConsumePauseResult{}, fmt.Errorf("error consuming pause: %w", err)
+	}
+	return result, nil
+}
+
+func (m mgr) EventHasPauses(ctx context.Context, workspaceID uuid.UUID, event string) (bool, error) {
+	var exists bool
+	err := m.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pauses WHERE workspace_id = $1 AND event = $2 AND expires_at > $3)`,
+		workspaceID, event, time.Now().UnixMilli(),
+	).Scan(&exists)
+	return exists, err
+}
+
+func (m mgr) PauseExists(ctx context.Context, pauseID uuid.UUID) error {
+	var exists bool
+	err := m.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pauses WHERE id = $1 AND expires_at > $2)`,
+		pauseID, time.Now().UnixMilli(),
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return state.ErrPauseNotFound
+	}
+	return nil
+}
+
+func (m mgr) PauseByID(ctx context.Context, pauseID uuid.UUID) (*state.Pause, error) {
+	var str string
+	err := m.db.QueryRowContext(ctx,
+		`SELECT pause FROM pauses WHERE id = $1 AND expires_at > $2`,
+		pauseID, time.Now().UnixMilli(),
+	).Scan(&str)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, state.ErrPauseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	pause := &state.Pause{}
+	err = json.Unmarshal([]byte(str), pause)
+	return pause, err
+}
+
+func (m mgr) PauseByInvokeCorrelationID(ctx context.Context, wsID uuid.UUID, correlationID string) (*state.Pause, error) {
+	var pauseID uuid.UUID
+	err := m.db.QueryRowContext(ctx,
+		`SELECT pause_id FROM pause_invokes WHERE workspace_id = $1 AND correlation_id = $2`,
+		wsID, correlationID,
+	).Scan(&pauseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, state.ErrInvokePauseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.PauseByID(ctx, pauseID)
+}
+
+func (m mgr) PauseBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) (*state.Pause, error) {
+	var pauseID uuid.UUID
+	err := m.db.QueryRowContext(ctx,
+		`SELECT pause_id FROM pause_signals WHERE workspace_id = $1 AND signal_id = $2`,
+		wsID, signalID,
+	).Scan(&pauseID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signalID: %w", err)
+	}
+
+	p, err := m.PauseByID(ctx, pauseID)
+	if err != nil {
+		if err == state.ErrPauseNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pause by ID: %w", err)
+	}
+	return p, nil
+}
+
+func (m mgr) PausesBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) ([]*state.Pause, error) {
+	pauses := []*state.Pause{}
+	p, err := m.PauseBySignalID(ctx, wsID, signalID)
+	if err != nil {
+		return nil, err
+	}
+	if p != nil {
+		pauses = append(pauses, p)
+	}
+
+	rows, err := m.db.QueryContext(ctx,
+		`SELECT pause_id FROM pause_signal_waiters WHERE workspace_id = $1 AND signal_id = $2`,
+		wsID, signalID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signal waiters: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	waiters, err := m.PausesByID(ctx, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pauses by ID: %w", err)
+	}
+	return append(pauses, waiters...), nil
+}
+
+func (m mgr) PausesByID(ctx context.Context, ids ...uuid.UUID) ([]*state.Pause, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	strIDs := make([]string, len(ids))
+	for n, id := range ids {
+		strIDs[n] = id.String()
+	}
+
+	rows, err := m.db.QueryContext(ctx,
+		`SELECT pause FROM pauses WHERE id = ANY($1::uuid[]) AND expires_at > $2`,
+		strIDs, time.Now().UnixMilli(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var merr error
+	pauses := []*state.Pause{}
+	for rows.Next() {
+		var item string
+		if err := rows.Scan(&item); err != nil {
+			return nil, err
+		}
+		pause := &state.Pause{}
+		if err := json.Unmarshal([]byte(item), pause); err != nil {
+			merr = errors.Join(merr, err)
+			continue
+		}
+		pauses = append(pauses, pause)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return pauses, merr
+}
+
+// PausesByEvent returns all pauses for a given event within a workspace.
+func (m mgr) PausesByEvent(ctx context.Context, workspaceID uuid.UUID, event string) (state.PauseIterator, error) {
+	return m.PausesByEventSince(ctx, workspaceID, event, time.Time{})
+}
+
+func (m mgr) PausesByEventSince(ctx context.Context, workspaceID uuid.UUID, event string, since time.Time) (state.PauseIterator, error) {
+	var sinceMS int64
+	if !since.IsZero() {
+		sinceMS = since.UnixMilli()
+	}
+
+	iter := &pauseIter{
+		db:          m.db,
+		workspaceID: workspaceID,
+		event:       event,
+		since:       sinceMS,
+		now:         time.Now().UnixMilli(),
+	}
+	err := iter.init(ctx)
+	return iter, err
+}
+
+func (m mgr) EvaluablesByID(ctx context.Context, ids ...uuid.UUID) ([]expr.Evaluable, error) {
+	items, err := m.PausesByID(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	evaluables := make([]expr.Evaluable, len(items))
+	for n, i := range items {
+		evaluables[n] = i
+	}
+	return evaluables, nil
+}
+
+func (m mgr) LoadEvaluablesSince(ctx context.Context, workspaceID uuid.UUID, eventName string, since time.Time, do func(context.Context, expr.Evaluable) error) error {
+	// Keep a list of pauses that should be deleted because they've expired.
+	expired := []*state.Pause{}
+
+	it, err := m.PausesByEventSince(ctx, workspaceID, eventName, since)
+	if err != nil {
+		return err
+	}
+	for it.Next(ctx) {
+		pause := it.Val(ctx)
+		if pause == nil {
+			continue
+		}
+
+		if pause.Expires.Time().Before(time.Now()) {
+			shouldDelete := pause.Expires.Time().Add(consts.PauseExpiredDeletionGracePeriod).Before(time.Now())
+			if shouldDelete {
+				expired = append(expired, pause)
+			}
+			continue
+		}
+
+		if err := do(ctx, pause); err != nil {
+			return err
+		}
+	}
+
+	// GC pauses on fetch.
+	for _, pause := range expired {
+		_ = m.DeletePause(ctx, *pause)
+	}
+
+	if it.Error() != context.Canceled {
+		return it.Error()
+	}
+	return nil
+}
+
+// pauseIter iterates over pauses for an event in creation order, loading
+// pauses in chunks via keyset pagination.
+type pauseIter struct {
+	db          *sql.DB
+	workspaceID uuid.UUID
+	event       string
+	since       int64
+	now         int64
+
+	count int
+	idx   int64
+
+	// buf stores the loaded, unread pauses.
+	buf []*state.Pause
+	// cursor stores the created_at and ID of the last loaded pause.
+	cursorAt int64
+	cursorID uuid.UUID
+	done     bool
+
+	val *state.Pause
+	err error
+}
+
+func (i *pauseIter) init(ctx context.Context) error {
+	return i.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM pauses
+		WHERE workspace_id = $1 AND event = $2 AND created_at >= $3 AND expires_at > $4`,
+		i.workspaceID, i.event, i.since, i.now,
+	).Scan(&i.count)
+}
+
+func (i *pauseIter) fetch(ctx context.Context) error {
+	rows, err := i.db.QueryContext(ctx, `
+		SELECT id, pause, created_at FROM pauses
+		WHERE workspace_id = $1 AND event = $2 AND created_at >= $3 AND expires_at > $4
+		AND (created_at, id) > ($5, $6)
+		ORDER BY created_at, id
+		LIMIT $7`,
+		i.workspaceID, i.event, i.since, i.now, i.cursorAt, i.cursorID, pauseIterChunk,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var (
+			id  uuid.UUID
+			str string
+			at  int64
+		)
+		if err := rows.Scan(&id, &str, &at); err != nil {
+			return err
+		}
+		n++
+		i.cursorAt, i.cursorID = at, id
+
+		pause := &state.Pause{}
+		if err := json.Unmarshal([]byte(str), pause); err != nil {
+			continue
+		}
+		i.buf = append(i.buf, pause)
+	}
+	if n < pauseIterChunk {
+		i.done = true
+	}
+	return rows.Err()
+}
+
+func (i *pauseIter) Count() int {
+	return i.count
+}
+
+func (i *pauseIter) Index() int64 {
+	return i.idx
+}
+
+func (i *pauseIter) Next(ctx context.Context) bool {
+	if len(i.buf) == 0 && !i.done {
+		if i.err = i.fetch(ctx); i.err != nil {
+			return false
+		}
+	}
+
+	if len(i.buf) == 0 {
+		i.val = nil
+		i.err = context.Canceled
+		return false
+	}
+
+	i.val = i.buf[0]
+	i.buf = i.buf[1:]
+	i.idx++
+	return true
+}
+
+func (i *pauseIter) Val(ctx context.Context) *state.Pause {
+	return i.val
+}
+
+func (i *pauseIter) Error() error {
+	return i.err
+}
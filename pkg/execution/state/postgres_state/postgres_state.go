@@ -0,0 +1,757 @@
+// Package postgres_state implements function run state, pauses, and the
+// execution queue on top of Postgres.  This allows self-hosted deployments
+// to run without Redis by sharing the database used for CQRS.
+//
+// Tables are created via the postgres migrations in pkg/cqrs/base_cqrs, so
+// the *sql.DB passed in should be opened via base_cqrs.New.
+package postgres_state
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/enums"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/oklog/ulid/v2"
+)
+
+// A number to version backend logic in order to prevent non-backward compatible
+// changes to break
+const currentVersion = 1
+
+// Opt represents an option to use when creating a postgres-backed state store.
+type Opt func(m *mgr)
+
+// New returns a state manager which uses Postgres as the backing state store.
+func New(ctx context.Context, db *sql.DB, opts ...Opt) (state.Manager, error) {
+	if db == nil {
+		return nil, fmt.Errorf("a postgres database is required")
+	}
+
+	m := &mgr{db: db}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
+}
+
+type mgr struct {
+	db *sql.DB
+}
+
+// WithTx runs f within a transaction, committing if f returns nil.
+func WithTx(ctx context.Context, db *sql.DB, f func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := f(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m mgr) New(ctx context.Context, input state.Input) (state.State, error) {
+	// Firstly, check idempotency.  This happens outside of the transaction which
+	// creates state so that concurrent creates for the same key resolve to the
+	// same run ID, mirroring the redis implementation.
+	runID, err := m.idempotencyCheck(ctx, input.Identifier)
+	switch err {
+	case nil: // no-op
+	case state.ErrInvalidIdentifier: // no-op
+	default:
+		return nil, err
+	}
+
+	// If a state already exists with the idempotency key, override the input's runID and continue
+	if runID != nil && input.Identifier.RunID != *runID {
+		input.Identifier.RunID = *runID
+	}
+
+	events, err := json.Marshal(input.EventBatchData)
+	if err != nil {
+		return nil, err
+	}
+
+	var stepsByt []byte
+	if len(input.Steps) > 0 {
+		stepsByt, err = json.Marshal(input.Steps)
+		if err != nil {
+			return nil, fmt.Errorf("error storing run state in postgres when marshalling steps: %w", err)
+		}
+	}
+
+	var stepInputsByt []byte
+	if len(input.StepInputs) > 0 {
+		stepInputsByt, err = json.Marshal(input.StepInputs)
+		if err != nil {
+			return nil, fmt.Errorf("error storing run state in postgres when marshalling step inputs: %w", err)
+		}
+	}
+
+	metadata := runMetadata{
+		Identifier:     input.Identifier,
+		Debugger:       input.Debugger,
+		Version:        currentVersion,
+		RequestVersion: consts.RequestVersionUnknown, // Always use -1 to indicate unset hash version until first request.
+		Context:        input.Context,
+		Status:         enums.RunStatusScheduled,
+		SpanID:         input.SpanID,
+		EventSize:      len(events),
+		StateSize:      len(events) + len(stepsByt) + len(stepInputsByt),
+		StepCount:      len(input.Steps),
+	}
+	if input.RunType != nil {
+		metadata.RunType = *input.RunType
+	}
+
+	metadataByt, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error storing run state in postgres: %w", err)
+	}
+
+	created := false
+	err = WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO run_state (run_id, account_id, function_id, status, metadata, events, state_size, event_size, step_count, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (run_id) DO NOTHING`,
+			input.Identifier.RunID.String(),
+			input.Identifier.AccountID,
+			input.Identifier.WorkflowID,
+			int(metadata.Status),
+			string(metadataByt),
+			string(events),
+			metadata.StateSize,
+			metadata.EventSize,
+			metadata.StepCount,
+			time.Now().UnixMilli(),
+		)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			// State is already created.
+			return nil
+		}
+		created = true
+
+		// Save pre-memoized steps, pushing each onto the stack.
+		for n, step := range input.Steps {
+			byt, err := json.Marshal(step.Data)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO run_steps (run_id, step_id, output, stack_index) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (run_id, step_id) DO UPDATE SET output = EXCLUDED.output, stack_index = EXCLUDED.stack_index`,
+				input.Identifier.RunID.String(), step.ID, string(byt), n+1,
+			); err != nil {
+				return err
+			}
+		}
+
+		// Save pre-memoized step inputs.
+		for _, step := range input.StepInputs {
+			byt, err := json.Marshal(step.Data)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO run_steps (run_id, step_id, input) VALUES ($1, $2, $3)
+				ON CONFLICT (run_id, step_id) DO UPDATE SET input = EXCLUDED.input`,
+				input.Identifier.RunID.String(), step.ID, string(byt),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error storing run state in postgres: %w", err)
+	}
+
+	if !created {
+		st, err := m.Load(ctx, input.Identifier.AccountID, input.Identifier.RunID)
+		if err != nil {
+			return nil, err
+		}
+		return st, state.ErrIdentifierExists
+	}
+
+	return state.NewStateInstance(
+		input.Identifier,
+		metadata.Metadata(),
+		input.EventBatchData,
+		input.Steps,
+		make([]string, 0),
+	), nil
+}
+
+// idempotencyCheck checks if the function state already exists, and return the runID of the existing state
+// if it does
+func (m mgr) idempotencyCheck(ctx context.Context, id state.Identifier) (*ulid.ULID, error) {
+	now := time.Now()
+
+	// Claim the key if it's unset or has expired.  Concurrent inserts block on the
+	// primary key until the first transaction commits, so only one claim wins.
+	res, err := m.db.ExecContext(ctx, `
+		INSERT INTO run_idempotency (function_id, key, run_id, expires_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (function_id, key) DO UPDATE SET run_id = EXCLUDED.run_id, expires_at = EXCLUDED.expires_at
+		WHERE run_idempotency.expires_at < $5`,
+		id.WorkflowID,
+		id.IdempotencyKey(),
+		id.RunID.String(),
+		now.Add(consts.FunctionIdempotencyPeriod).UnixMilli(),
+		now.UnixMilli(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil, nil // no previous state exists, entirely new
+	}
+
+	var prev string
+	err = m.db.QueryRowContext(ctx,
+		`SELECT run_id FROM run_idempotency WHERE function_id = $1 AND key = $2`,
+		id.WorkflowID, id.IdempotencyKey(),
+	).Scan(&prev)
+	if err != nil {
+		return nil, err
+	}
+
+	if prev == consts.FunctionIdempotencyTombstone {
+		return nil, state.ErrIdentifierTomestone
+	}
+
+	// if there are existing values, the state might have already been created
+	runID, err := ulid.Parse(prev)
+	if err != nil {
+		// there already is a value but is not a valid ULID
+		return nil, state.ErrInvalidIdentifier
+	}
+
+	return &runID, nil
+}
+
+func (m mgr) UpdateMetadata(ctx context.Context, accountID uuid.UUID, runID ulid.ULID, md state.MetadataUpdate) error {
+	return WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		var byt string
+		err := tx.QueryRowContext(ctx,
+			`SELECT metadata FROM run_state WHERE run_id = $1 FOR UPDATE`,
+			runID.String(),
+		).Scan(&byt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return state.ErrRunNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		existing := runMetadata{}
+		if err := json.Unmarshal([]byte(byt), &existing); err != nil {
+			return fmt.Errorf("unable to unmarshal run metadata: %w", err)
+		}
+
+		existing.DisableImmediateExecution = md.DisableImmediateExecution
+		existing.RequestVersion = md.RequestVersion
+		if existing.StartedAt == 0 && !md.StartedAt.IsZero() {
+			existing.StartedAt = md.StartedAt.UnixMilli()
+		}
+		if md.HasAI {
+			existing.HasAI = true
+		}
+
+		updated, err := json.Marshal(existing)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE run_state SET metadata = $2 WHERE run_id = $1`,
+			runID.String(), string(updated),
+		)
+		return err
+	})
+}
+
+func (m mgr) IsComplete(ctx context.Context, accountId uuid.UUID, runID ulid.ULID) (bool, error) {
+	var status int
+	err := m.db.QueryRowContext(ctx,
+		`SELECT status FROM run_state WHERE run_id = $1`,
+		runID.String(),
+	).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, state.ErrRunNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+	return status != int(enums.RunStatusRunning), nil
+}
+
+func (m mgr) Exists(ctx context.Context, accountId uuid.UUID, runID ulid.ULID) (bool, error) {
+	var exists bool
+	err := m.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM run_state WHERE run_id = $1)`,
+		runID.String(),
+	).Scan(&exists)
+	return exists, err
+}
+
+func (m mgr) metadata(ctx context.Context, runID ulid.ULID) (*runMetadata, error) {
+	var (
+		status int
+		byt    string
+		md     = &runMetadata{}
+	)
+	err := m.db.QueryRowContext(ctx,
+		`SELECT status, metadata, state_size, event_size, step_count FROM run_state WHERE run_id = $1`,
+		runID.String(),
+	).Scan(&status, &byt, &md.StateSize, &md.EventSize, &md.StepCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, state.ErrRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(byt), md); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal run metadata: %w", err)
+	}
+	md.Status = enums.RunStatus(status)
+	return md, nil
+}
+
+func (m mgr) Cancel(ctx context.Context, id state.Identifier) error {
+	var result error
+	err := WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		var status int
+		err := tx.QueryRowContext(ctx,
+			`SELECT status FROM run_state WHERE run_id = $1 FOR UPDATE`,
+			id.RunID.String(),
+		).Scan(&status)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// If run has ended (cancelled, completed, failed). We only allow cancellation
+		// of scheduled or running functions
+		switch enums.RunStatus(status) {
+		case enums.RunStatusRunning, enums.RunStatusScheduled:
+		case enums.RunStatusCompleted:
+			result = state.ErrFunctionComplete
+			return nil
+		case enums.RunStatusFailed:
+			result = state.ErrFunctionFailed
+			return nil
+		case enums.RunStatusCancelled:
+			result = state.ErrFunctionCancelled
+			return nil
+		default:
+			result = fmt.Errorf("unknown return value cancelling function: %d", status)
+			return nil
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE run_state SET status = $2 WHERE run_id = $1`,
+			id.RunID.String(), int(enums.RunStatusCancelled),
+		)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error cancelling: %w", err)
+	}
+	return result
+}
+
+func (m mgr) SetStatus(ctx context.Context, id state.Identifier, status enums.RunStatus) error {
+	_, err := m.db.ExecContext(ctx,
+		`UPDATE run_state SET status = $2 WHERE run_id = $1`,
+		id.RunID.String(), int(status),
+	)
+	if err != nil {
+		return fmt.Errorf("error setting status: %w", err)
+	}
+	return nil
+}
+
+func (m mgr) Metadata(ctx context.Context, accountId uuid.UUID, runID ulid.ULID) (*state.Metadata, error) {
+	metadata, err := m.metadata(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+	meta := metadata.Metadata()
+	return &meta, nil
+}
+
+func (m mgr) LoadEvents(ctx context.Context, accountId uuid.UUID, fnID uuid.UUID, runID ulid.ULID) ([]json.RawMessage, error) {
+	var byt string
+	err := m.db.QueryRowContext(ctx,
+		`SELECT events FROM run_state WHERE run_id = $1`,
+		runID.String(),
+	).Scan(&byt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, state.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch; %w", err)
+	}
+
+	var events []json.RawMessage
+	if err := json.Unmarshal([]byte(byt), &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch; %w", err)
+	}
+	return events, nil
+}
+
+// stepRow is a single row from run_steps.
+type stepRow struct {
+	ID     string
+	Output sql.NullString
+	Input  sql.NullString
+	Index  sql.NullInt64
+}
+
+func (m mgr) steps(ctx context.Context, runID ulid.ULID) ([]stepRow, error) {
+	rows, err := m.db.QueryContext(ctx,
+		`SELECT step_id, output, input, stack_index FROM run_steps WHERE run_id = $1`,
+		runID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading actions; %w", err)
+	}
+	defer rows.Close()
+
+	steps := []stepRow{}
+	for rows.Next() {
+		s := stepRow{}
+		if err := rows.Scan(&s.ID, &s.Output, &s.Input, &s.Index); err != nil {
+			return nil, err
+		}
+		steps = append(steps, s)
+	}
+	return steps, rows.Err()
+}
+
+func (m mgr) LoadSteps(ctx context.Context, accountId uuid.UUID, fnID uuid.UUID, runID ulid.ULID) (map[string]json.RawMessage, error) {
+	rows, err := m.steps(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := map[string]json.RawMessage{}
+	for _, row := range rows {
+		if row.Output.Valid {
+			steps[row.ID] = json.RawMessage(row.Output.String)
+			continue
+		}
+		if row.Input.Valid {
+			wrappedData, err := json.Marshal(map[string]json.RawMessage{
+				"input": json.RawMessage(row.Input.String),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to wrap action input for \"%s\"; %w", row.ID, err)
+			}
+			steps[row.ID] = wrappedData
+		}
+	}
+	return steps, nil
+}
+
+func (m mgr) Load(ctx context.Context, accountId uuid.UUID, runID ulid.ULID) (state.State, error) {
+	metadata, err := m.metadata(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata; %w", err)
+	}
+
+	var byt string
+	err = m.db.QueryRowContext(ctx,
+		`SELECT events FROM run_state WHERE run_id = $1`,
+		runID.String(),
+	).Scan(&byt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch; %w", err)
+	}
+	events := []map[string]any{}
+	if err := json.Unmarshal([]byte(byt), &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal batch; %w", err)
+	}
+
+	rows, err := m.steps(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []state.MemoizedStep{}
+	for _, row := range rows {
+		if !row.Input.Valid {
+			continue
+		}
+		wrappedData, err := json.Marshal(map[string]json.RawMessage{
+			"input": json.RawMessage(row.Input.String),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap action input for \"%s\"; %w", row.ID, err)
+		}
+		actions = append(actions, state.MemoizedStep{
+			ID:   row.ID,
+			Data: wrappedData,
+		})
+	}
+	for _, row := range rows {
+		if !row.Output.Valid {
+			continue
+		}
+		var data any
+		if err := json.Unmarshal([]byte(row.Output.String), &data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal step \"%s\" with data \"%s\"; %w", row.ID, row.Output.String, err)
+		}
+		actions = append(actions, state.MemoizedStep{
+			ID:   row.ID,
+			Data: data,
+		})
+	}
+
+	return state.NewStateInstance(metadata.Identifier, metadata.Metadata(), events, actions, stack(rows)), nil
+}
+
+// stack returns the step IDs in rows ordered by their position in the stack.
+func stack(rows []stepRow) []string {
+	stacked := []stepRow{}
+	for _, row := range rows {
+		if row.Index.Valid {
+			stacked = append(stacked, row)
+		}
+	}
+	slices.SortFunc(stacked, func(a, b stepRow) int {
+		return int(a.Index.Int64 - b.Index.Int64)
+	})
+
+	ids := make([]string, len(stacked))
+	for n, row := range stacked {
+		ids[n] = row.ID
+	}
+	return ids
+}
+
+func (m mgr) stack(ctx context.Context, runID ulid.ULID) ([]string, error) {
+	rows, err := m.steps(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching stack: %w", err)
+	}
+	return stack(rows), nil
+}
+
+func (m mgr) StackIndex(ctx context.Context, accountId uuid.UUID, runID ulid.ULID, stepID string) (int, error) {
+	stack, err := m.stack(ctx, runID)
+	if err != nil {
+		return 0, err
+	}
+	if len(stack) == 0 {
+		return 0, nil
+	}
+	for n, i := range stack {
+		if i == stepID {
+			return n + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("step not found in stack: %s", stepID)
+}
+
+func (m mgr) SaveResponse(ctx context.Context, i state.Identifier, stepID, marshalledOuptut string) (bool, error) {
+	var (
+		hasPending bool
+		result     error
+	)
+
+	err := WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		// Lock the run so that concurrent saves are serialized.
+		var pendingByt string
+		err := tx.QueryRowContext(ctx,
+			`SELECT pending FROM run_state WHERE run_id = $1 FOR UPDATE`,
+			i.RunID.String(),
+		).Scan(&pendingByt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return state.ErrRunNotFound
+		}
+		if err != nil {
+			return err
+		}
+		pending := []string{}
+		if err := json.Unmarshal([]byte(pendingByt), &pending); err != nil {
+			return fmt.Errorf("error unmarshalling pending steps: %w", err)
+		}
+
+		var output, input sql.NullString
+		err = tx.QueryRowContext(ctx,
+			`SELECT output, input FROM run_steps WHERE run_id = $1 AND step_id = $2`,
+			i.RunID.String(), stepID,
+		).Scan(&output, &input)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if output.Valid {
+			// If the data is exactly the same, this is an idempotent save.
+			if output.String == marshalledOuptut {
+				hasPending = len(pending) > 0
+				result = state.ErrIdempotentResponse
+				return nil
+			}
+			result = state.ErrDuplicateResponse
+			return nil
+		}
+
+		// If we're saving a response for a step that previously had input, remove the
+		// input from the state size in order to keep it as accurate as possible.
+		stateSizeDelta := len(marshalledOuptut)
+		if input.Valid {
+			stateSizeDelta -= len(input.String)
+		}
+
+		pending = slices.DeleteFunc(pending, func(s string) bool { return s == stepID })
+		hasPending = len(pending) > 0
+		pendingUpdate, err := json.Marshal(pending)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO run_steps (run_id, step_id, output, stack_index)
+			VALUES ($1, $2, $3, (SELECT COALESCE(MAX(stack_index), 0) + 1 FROM run_steps WHERE run_id = $1))
+			ON CONFLICT (run_id, step_id) DO UPDATE SET output = EXCLUDED.output, stack_index = EXCLUDED.stack_index`,
+			i.RunID.String(), stepID, marshalledOuptut,
+		); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE run_state SET state_size = state_size + $2, step_count = step_count + 1, pending = $3
+			WHERE run_id = $1`,
+			i.RunID.String(), stateSizeDelta, string(pendingUpdate),
+		)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("error saving response: %w", err)
+	}
+	return hasPending, result
+}
+
+func (m mgr) SavePending(ctx context.Context, i state.Identifier, pending []string) error {
+	byt, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("error marshalling pending steps: %w", err)
+	}
+	_, err = m.db.ExecContext(ctx,
+		`UPDATE run_state SET pending = $2 WHERE run_id = $1`,
+		i.RunID.String(), string(byt),
+	)
+	if err != nil {
+		return fmt.Errorf("error saving pending: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes state and pauses for the run from the state store.
+//
+// Returns a boolean indicating whether it performed deletion. If the run had
+// parallel steps then it may be false, since parallel steps cause the function
+// end to be reached multiple times in a single run
+func (m mgr) Delete(ctx context.Context, i state.Identifier) (bool, error) {
+	id := i
+	if i.Key == "" {
+		if md, err := m.metadata(ctx, i.RunID); err == nil {
+			id = md.Identifier
+		}
+	}
+
+	// update the idempotency key to the tombstone value to indicate this run is done
+	// do scheduling knows to not need to continue attempting to do so
+	_, _ = m.db.ExecContext(ctx,
+		`UPDATE run_idempotency SET run_id = $3 WHERE function_id = $1 AND key = $2`,
+		id.WorkflowID, id.IdempotencyKey(), consts.FunctionIdempotencyTombstone,
+	)
+
+	performedDeletion := false
+	err := WithTx(ctx, m.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM run_steps WHERE run_id = $1`, i.RunID.String()); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM pause_consumes WHERE run_id = $1`, i.RunID.String()); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM run_state WHERE run_id = $1`, i.RunID.String())
+		if err != nil {
+			return err
+		}
+		n, _ := res.RowsAffected()
+		performedDeletion = n > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err := m.deletePausesForRun(ctx, i.RunID); err != nil {
+		return false, err
+	}
+
+	return performedDeletion, nil
+}
+
+// runMetadata is stored for each invocation of a function.  Status and sizes are
+// stored as columns so that they can be updated atomically;  everything else is
+// stored as JSON.
+type runMetadata struct {
+	Identifier state.Identifier `json:"id"`
+	Status     enums.RunStatus  `json:"-"`
+	// These are the fields for standard state metadata.
+	StateSize                 int            `json:"-"`
+	EventSize                 int            `json:"-"`
+	StepCount                 int            `json:"-"`
+	Debugger                  bool           `json:"debugger"`
+	RunType                   string         `json:"runType,omitempty"`
+	Version                   int            `json:"version"`
+	RequestVersion            int            `json:"rv"`
+	Context                   map[string]any `json:"ctx,omitempty"`
+	DisableImmediateExecution bool           `json:"die,omitempty"`
+	SpanID                    string         `json:"sid"`
+	StartedAt                 int64          `json:"sat,omitempty"`
+	HasAI                     bool           `json:"hasAI,omitempty"`
+}
+
+func (r runMetadata) Metadata() state.Metadata {
+	m := state.Metadata{
+		Identifier:                r.Identifier,
+		Debugger:                  r.Debugger,
+		Status:                    r.Status,
+		Version:                   r.Version,
+		RequestVersion:            r.RequestVersion,
+		Context:                   r.Context,
+		DisableImmediateExecution: r.DisableImmediateExecution,
+		SpanID:                    r.SpanID,
+		HasAI:                     r.HasAI,
+	}
+	// only convert to time if runMetadata's StartedAt is > 0
+	if r.StartedAt > 0 {
+		m.StartedAt = time.UnixMilli(r.StartedAt)
+	}
+
+	if r.RunType != "" {
+		m.RunType = &r.RunType
+	}
+	return m
+}
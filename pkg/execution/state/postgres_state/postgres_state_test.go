@@ -0,0 +1,256 @@
+package postgres_state_test
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
+	"github.com/inngest/inngest/pkg/enums"
+	osqueue "github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/postgres_state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/execution/state/testharness"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/require"
+)
+
+var tables = []string{
+	"run_state",
+	"run_steps",
+	"run_idempotency",
+	"pauses",
+	"pause_invokes",
+	"pause_signals",
+	"pause_signal_waiters",
+	"pause_leases",
+	"pause_consumes",
+	"queue_items",
+	"queue_idempotency",
+	"queue_functions",
+	"queue_singletons",
+}
+
+// initPostgres connects to the database in POSTGRES_URI, running migrations.  Tests
+// are skipped if POSTGRES_URI is unset.
+func initPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+
+	uri := os.Getenv("POSTGRES_URI")
+	if uri == "" {
+		t.Skip("POSTGRES_URI not set")
+	}
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{PostgresURI: uri})
+	require.NoError(t, err)
+	truncate(t, db)
+	return db
+}
+
+func truncate(t *testing.T, db *sql.DB) {
+	for _, table := range tables {
+		_, err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s", table))
+		require.NoError(t, err)
+	}
+}
+
+func TestStateHarness(t *testing.T) {
+	db := initPostgres(t)
+
+	sm, err := postgres_state.New(context.Background(), db)
+	require.NoError(t, err)
+
+	create := func() (state.Manager, func()) {
+		return sm, func() {
+			truncate(t, db)
+		}
+	}
+
+	testharness.CheckState(t, create)
+}
+
+func TestQueueEnqueueRun(t *testing.T) {
+	db := initPostgres(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := postgres_state.NewQueue(db, postgres_state.WithPollTick(10*time.Millisecond))
+
+	jobID := "test-job"
+	item := osqueue.Item{
+		JobID:       &jobID,
+		WorkspaceID: uuid.New(),
+		Kind:        osqueue.KindEdge,
+		Identifier: state.Identifier{
+			AccountID:  uuid.New(),
+			WorkflowID: uuid.New(),
+			RunID:      ulid.MustNew(ulid.Now(), nil),
+		},
+	}
+
+	require.NoError(t, q.Enqueue(ctx, item, time.Now(), osqueue.EnqueueOpts{}))
+	err := q.Enqueue(ctx, item, time.Now(), osqueue.EnqueueOpts{})
+	require.ErrorIs(t, err, redis_state.ErrQueueItemExists)
+
+	count, err := q.OutstandingJobCount(ctx, item.WorkspaceID, item.Identifier.WorkflowID, item.Identifier.RunID)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	var calls int32
+	go func() {
+		_ = q.Run(ctx, func(ctx context.Context, info osqueue.RunInfo, i osqueue.Item) (osqueue.RunResult, error) {
+			// Fail the first attempt to ensure the item is retried.
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return osqueue.RunResult{}, osqueue.RetryAtError(fmt.Errorf("nope"), nil)
+			}
+			return osqueue.RunResult{}, nil
+		})
+	}()
+
+	require.Eventually(t, func() bool {
+		count, err := q.OutstandingJobCount(ctx, item.WorkspaceID, item.Identifier.WorkflowID, item.Identifier.RunID)
+		return err == nil && count == 0
+	}, 30*time.Second, 50*time.Millisecond)
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+	// Dequeued items remain idempotent.
+	err = q.Enqueue(ctx, item, time.Now(), osqueue.EnqueueOpts{})
+	require.ErrorIs(t, err, redis_state.ErrQueueItemExists)
+}
+
+func TestQueuePausedFunction(t *testing.T) {
+	db := initPostgres(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := postgres_state.NewQueue(db, postgres_state.WithPollTick(10*time.Millisecond))
+
+	item := osqueue.Item{
+		WorkspaceID: uuid.New(),
+		Kind:        osqueue.KindEdge,
+		Identifier: state.Identifier{
+			AccountID:  uuid.New(),
+			WorkflowID: uuid.New(),
+			RunID:      ulid.MustNew(ulid.Now(), nil),
+		},
+	}
+	require.NoError(t, q.SetFunctionPaused(ctx, item.Identifier.AccountID, item.Identifier.WorkflowID, true))
+	require.NoError(t, q.Enqueue(ctx, item, time.Now(), osqueue.EnqueueOpts{}))
+
+	var calls int32
+	go func() {
+		_ = q.Run(ctx, func(ctx context.Context, info osqueue.RunInfo, i osqueue.Item) (osqueue.RunResult, error) {
+			atomic.AddInt32(&calls, 1)
+			return osqueue.RunResult{}, nil
+		})
+	}()
+
+	<-time.After(250 * time.Millisecond)
+	require.EqualValues(t, 0, atomic.LoadInt32(&calls))
+
+	require.NoError(t, q.SetFunctionPaused(ctx, item.Identifier.AccountID, item.Identifier.WorkflowID, false))
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestQueueSingleton(t *testing.T) {
+	db := initPostgres(t)
+	ctx := context.Background()
+
+	q := postgres_state.NewQueue(db)
+
+	fnID := uuid.New()
+	item := func(jobID string) osqueue.Item {
+		return osqueue.Item{
+			JobID:       &jobID,
+			WorkspaceID: uuid.New(),
+			Kind:        osqueue.KindStart,
+			Identifier: state.Identifier{
+				AccountID:  uuid.New(),
+				WorkflowID: fnID,
+				RunID:      ulid.MustNew(ulid.Now(), rand.Reader),
+			},
+			Singleton: &osqueue.Singleton{Key: "singleton-key"},
+		}
+	}
+
+	first := item("first")
+	require.NoError(t, q.Enqueue(ctx, first, time.Now(), osqueue.EnqueueOpts{}))
+
+	// The lock is held by the first run.
+	err := q.Enqueue(ctx, item("second"), time.Now(), osqueue.EnqueueOpts{})
+	require.ErrorIs(t, err, redis_state.ErrQueueItemSingletonExists)
+
+	// Dequeueing the first run's only item releases the lock.
+	err = q.Dequeue(ctx, postgres_state.QueueShard, osqueue.QueueItem{ID: osqueue.HashID(ctx, "first")})
+	require.NoError(t, err)
+	require.NoError(t, q.Enqueue(ctx, item("second"), time.Now(), osqueue.EnqueueOpts{}))
+
+	// Cancel mode takes over the lock.
+	third := item("third")
+	third.Singleton.Mode = enums.SingletonModeCancel
+	require.NoError(t, q.Enqueue(ctx, third, time.Now(), osqueue.EnqueueOpts{}))
+
+	var runID string
+	require.NoError(t, db.QueryRow(`SELECT run_id FROM queue_singletons WHERE key = $1`, "singleton-key").Scan(&runID))
+	require.Equal(t, third.Identifier.RunID.String(), runID)
+}
+
+func TestQueueStatusCount(t *testing.T) {
+	db := initPostgres(t)
+	ctx := context.Background()
+
+	q := postgres_state.NewQueue(db)
+
+	fnID := uuid.New()
+	for _, kind := range []string{osqueue.KindStart, osqueue.KindEdge, osqueue.KindEdgeError, osqueue.KindSleep} {
+		item := osqueue.Item{
+			WorkspaceID: uuid.New(),
+			Kind:        kind,
+			Identifier: state.Identifier{
+				AccountID:  uuid.New(),
+				WorkflowID: fnID,
+				RunID:      ulid.MustNew(ulid.Now(), rand.Reader),
+			},
+		}
+		require.NoError(t, q.Enqueue(ctx, item, time.Now(), osqueue.EnqueueOpts{}))
+	}
+
+	for status, expected := range map[string]int64{"start": 1, "in-progress": 2, "sleep": 1, "unknown": 0} {
+		count, err := q.StatusCount(ctx, fnID, status)
+		require.NoError(t, err)
+		require.Equal(t, expected, count, status)
+	}
+}
+
+func TestValidateFunction(t *testing.T) {
+	key := "event.data.user_id"
+
+	require.NoError(t, postgres_state.ValidateFunction(inngest.Function{
+		Concurrency: &inngest.ConcurrencyLimits{Limits: []inngest.Concurrency{{Limit: 5}}},
+	}))
+
+	err := postgres_state.ValidateFunction(inngest.Function{
+		Throttle: &inngest.Throttle{Limit: 1, Period: time.Minute},
+	})
+	require.ErrorIs(t, err, postgres_state.ErrFunctionUnsupported)
+
+	err = postgres_state.ValidateFunction(inngest.Function{
+		Concurrency: &inngest.ConcurrencyLimits{Limits: []inngest.Concurrency{{Limit: 5, Key: &key}}},
+	})
+	require.ErrorIs(t, err, postgres_state.ErrFunctionUnsupported)
+
+	err = postgres_state.ValidateFunction(inngest.Function{
+		Concurrency: &inngest.ConcurrencyLimits{Limits: []inngest.Concurrency{{Limit: 5, Scope: enums.ConcurrencyScopeAccount}}},
+	})
+	require.ErrorIs(t, err, postgres_state.ErrFunctionUnsupported)
+}
@@ -0,0 +1,945 @@
+package postgres_state
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mrand "math/rand"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/backoff"
+	"github.com/inngest/inngest/pkg/enums"
+	osqueue "github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/inngest"
+	"github.com/inngest/inngest/pkg/logger"
+	"github.com/jonboulle/clockwork"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// QueueShardName is the queue shard name reported to run functions.
+	QueueShardName = "postgres"
+
+	defaultNumWorkers     = 100
+	defaultPollTick       = 50 * time.Millisecond
+	defaultIdempotencyTTL = 12 * time.Hour
+	// partitionPeekMax is the maximum number of partitions peeked per poll.
+	partitionPeekMax = 100
+)
+
+// QueueShard is the single shard of the postgres queue.  It has no redis client;
+// shard arguments passed to the queue are ignored.
+var QueueShard = redis_state.QueueShard{
+	Name: QueueShardName,
+	Kind: string(enums.QueueShardKindPostgres),
+}
+
+// QueueManager is a Postgres-backed queue.  Leasing uses SELECT ... FOR UPDATE
+// SKIP LOCKED, and each partition is guarded by a transaction-level advisory
+// lock so that concurrency limits are checked consistently across nodes.
+//
+// Queue items are stored as JSON in the queue_items table.  The queue fulfils
+// the redis queue's interface and returns the same errors, so that callers can
+// use the two interchangeably.  Function concurrency limits and singletons are
+// enforced;  throttling and custom concurrency keys are not, so functions must
+// be checked with ValidateFunction before they're synced.
+type QueueManager interface {
+	redis_state.QueueManager
+}
+
+// ErrFunctionUnsupported is returned when a function uses flow control which the
+// postgres queue doesn't enforce.
+var ErrFunctionUnsupported = fmt.Errorf("function is not supported by the postgres queue")
+
+// ValidateFunction returns ErrFunctionUnsupported if the function uses throttling
+// or concurrency limits other than the function's own limit.
+func ValidateFunction(fn inngest.Function) error {
+	if fn.Throttle != nil {
+		return fmt.Errorf("%w: %s uses throttling", ErrFunctionUnsupported, fn.GetSlug())
+	}
+	if fn.Concurrency != nil {
+		for _, c := range fn.Concurrency.Limits {
+			if c.IsCustomLimit() {
+				return fmt.Errorf("%w: %s uses concurrency keys or scopes", ErrFunctionUnsupported, fn.GetSlug())
+			}
+		}
+	}
+	return nil
+}
+
+type QueueOpt func(q *queue)
+
+func WithNumWorkers(n int32) QueueOpt {
+	return func(q *queue) {
+		q.numWorkers = n
+	}
+}
+
+func WithPollTick(t time.Duration) QueueOpt {
+	return func(q *queue) {
+		q.pollTick = t
+	}
+}
+
+func WithIdempotencyTTL(t time.Duration) QueueOpt {
+	return func(q *queue) {
+		q.idempotencyTTL = t
+	}
+}
+
+func WithBackoffFunc(f backoff.BackoffFunc) QueueOpt {
+	return func(q *queue) {
+		q.backoffFunc = f
+	}
+}
+
+// WithConcurrencyLimit sets the function-level concurrency limit getter.  A limit
+// of zero or less means the function's partition is unlimited.
+func WithConcurrencyLimit(f func(ctx context.Context, fnID uuid.UUID) int) QueueOpt {
+	return func(q *queue) {
+		q.concurrencyLimit = f
+	}
+}
+
+// WithQueueKindMapping maps queue item kinds to a dedicated queue name, as per
+// the redis queue.
+func WithQueueKindMapping(mapping map[string]string) QueueOpt {
+	return func(q *queue) {
+		q.queueKindMapping = mapping
+	}
+}
+
+// WithClock allows replacing the queue's default (real) clock by a mock, for testing.
+func WithClock(c clockwork.Clock) QueueOpt {
+	return func(q *queue) {
+		q.clock = c
+	}
+}
+
+// NewQueue returns a queue backed by Postgres.
+func NewQueue(db *sql.DB, opts ...QueueOpt) QueueManager {
+	q := &queue{
+		db:               db,
+		numWorkers:       defaultNumWorkers,
+		pollTick:         defaultPollTick,
+		idempotencyTTL:   defaultIdempotencyTTL,
+		backoffFunc:      backoff.DefaultBackoff,
+		queueKindMapping: map[string]string{},
+		clock:            clockwork.NewRealClock(),
+		quit:             make(chan error, 1),
+		wg:               &sync.WaitGroup{},
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+type queue struct {
+	db *sql.DB
+
+	numWorkers       int32
+	pollTick         time.Duration
+	idempotencyTTL   time.Duration
+	backoffFunc      backoff.BackoffFunc
+	concurrencyLimit func(ctx context.Context, fnID uuid.UUID) int
+	queueKindMapping map[string]string
+	clock            clockwork.Clock
+
+	// quit is sent to when a QuitError is returned from a run func.
+	quit chan error
+	// wg stores a waitgroup for all in-progress jobs
+	wg *sync.WaitGroup
+}
+
+// partitionID returns the partition for a queue item:  its queue name, if set,
+// or its function ID.
+func partitionID(qi osqueue.QueueItem) string {
+	if qi.QueueName != nil {
+		return *qi.QueueName
+	}
+	if qi.Data.QueueName != nil {
+		return *qi.Data.QueueName
+	}
+	return qi.FunctionID.String()
+}
+
+func (q *queue) Enqueue(ctx context.Context, item osqueue.Item, at time.Time, opts osqueue.EnqueueOpts) error {
+	if item.Metadata == nil {
+		item.Metadata = map[string]string{}
+	}
+
+	id := ""
+	if item.JobID != nil {
+		id = *item.JobID
+	}
+
+	if item.QueueName == nil {
+		// Check if we have a kind mapping.
+		if name, ok := q.queueKindMapping[item.Kind]; ok {
+			item.QueueName = &name
+		}
+	}
+
+	qi := osqueue.QueueItem{
+		ID:          id,
+		AtMS:        at.UnixMilli(),
+		WorkspaceID: item.WorkspaceID,
+		FunctionID:  item.Identifier.WorkflowID,
+		Data:        item,
+		QueueName:   item.QueueName,
+		WallTimeMS:  at.UnixMilli(),
+	}
+
+	if item.QueueName == nil && qi.FunctionID == uuid.Nil {
+		logger.StdlibLogger(ctx).Error("attempted to enqueue QueueItem without function ID or queueName override", "item", qi)
+		return fmt.Errorf("queue name or function ID must be set")
+	}
+
+	// Use the queue item's score, ensuring we process older function runs first
+	// (eg. before at)
+	now := q.clock.Now()
+	score := qi.Score(now)
+
+	if factor := qi.Data.GetPriorityFactor(); factor != 0 {
+		// Ensure we mutate the AtMS time by the given priority factor.
+		qi.AtMS -= factor
+	}
+
+	if len(qi.ID) == 0 {
+		qi.SetID(ctx, ulid.MustNew(ulid.Now(), rand.Reader).String())
+	} else if !opts.PassthroughJobId {
+		qi.SetID(ctx, qi.ID)
+	}
+	if qi.Data.JobID == nil {
+		qi.Data.JobID = &qi.ID
+	}
+	if at.Before(now) {
+		// Normalize to now to minimize latency.
+		qi.WallTimeMS = now.UnixMilli()
+	}
+	qi.EnqueuedAt = now.UnixMilli()
+
+	byt, err := json.Marshal(qi)
+	if err != nil {
+		return fmt.Errorf("error marshalling queue item: %w", err)
+	}
+
+	return WithTx(ctx, q.db, func(tx *sql.Tx) error {
+		// Items are idempotent for the idempotency TTL after being dequeued.
+		var exists bool
+		err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM queue_idempotency WHERE id = $1 AND expires_at > $2)`,
+			qi.ID, now.UnixMilli(),
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return redis_state.ErrQueueItemExists
+		}
+
+		if qi.Data.Singleton != nil && qi.Data.Singleton.Key != "" {
+			if err := lockSingleton(ctx, tx, *qi.Data.Singleton, qi.Data.Identifier.RunID); err != nil {
+				return err
+			}
+		}
+
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO queue_items (id, partition_id, account_id, workspace_id, function_id, run_id, kind, item, at, enqueued_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (id) DO NOTHING`,
+			qi.ID,
+			partitionID(qi),
+			qi.Data.Identifier.AccountID,
+			qi.WorkspaceID,
+			qi.FunctionID,
+			qi.Data.Identifier.RunID.String(),
+			qi.Data.Kind,
+			string(byt),
+			score,
+			qi.EnqueuedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("error enqueueing item: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return redis_state.ErrQueueItemExists
+		}
+		return nil
+	})
+}
+
+// lockSingleton acquires the singleton lock for the given run, returning
+// ErrQueueItemSingletonExists if another run holds the lock.  Singletons in
+// cancel mode take over the lock from the run they replace.
+func lockSingleton(ctx context.Context, tx *sql.Tx, s osqueue.Singleton, runID ulid.ULID) error {
+	if s.Mode == enums.SingletonModeCancel {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO queue_singletons (key, run_id) VALUES ($1, $2)
+			ON CONFLICT (key) DO UPDATE SET run_id = EXCLUDED.run_id`,
+			s.Key, runID.String(),
+		)
+		if err != nil {
+			return fmt.Errorf("error taking over singleton lock: %w", err)
+		}
+		return nil
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO queue_singletons (key, run_id) VALUES ($1, $2)
+		ON CONFLICT (key) DO NOTHING`,
+		s.Key, runID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("error acquiring singleton lock: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return redis_state.ErrQueueItemSingletonExists
+	}
+	return nil
+}
+
+func (q *queue) Run(ctx context.Context, f osqueue.RunFunc) error {
+	sem := make(chan struct{}, q.numWorkers)
+	tick := q.clock.NewTicker(q.pollTick)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.wg.Wait()
+			return nil
+		case err := <-q.quit:
+			q.wg.Wait()
+			return err
+		case <-tick.Chan():
+		}
+
+		capacity := cap(sem) - len(sem)
+		if capacity <= 0 {
+			continue
+		}
+
+		items, err := q.lease(ctx, capacity)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.StdlibLogger(ctx).Error("error leasing queue items", "error", err)
+			}
+			continue
+		}
+
+		for _, qi := range items {
+			sem <- struct{}{}
+			q.wg.Add(1)
+			go func(qi osqueue.QueueItem) {
+				defer func() {
+					<-sem
+					q.wg.Done()
+				}()
+				if err := q.process(ctx, qi, f); err != nil {
+					logger.StdlibLogger(ctx).Error("error processing queue item", "error", err, "item", qi)
+				}
+			}(qi)
+		}
+	}
+}
+
+// lease leases up to limit available items across all ready partitions.
+func (q *queue) lease(ctx context.Context, limit int) ([]osqueue.QueueItem, error) {
+	now := q.clock.Now().UnixMilli()
+
+	// Peek the partitions with the oldest available items, so that partitions
+	// outside of the peek limit aren't starved.
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT partition_id FROM queue_items qi
+		WHERE at <= $1 AND (leased_until IS NULL OR leased_until < $1)
+		AND NOT EXISTS (SELECT 1 FROM queue_functions f WHERE f.function_id = qi.function_id AND f.paused)
+		GROUP BY partition_id
+		ORDER BY MIN(at)
+		LIMIT $2`,
+		now, partitionPeekMax,
+	)
+	if err != nil {
+		return nil, err
+	}
+	partitions := []string{}
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		partitions = append(partitions, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Shuffle peeked partitions so that no single function starves the others.
+	mrand.Shuffle(len(partitions), func(i, j int) {
+		partitions[i], partitions[j] = partitions[j], partitions[i]
+	})
+
+	items := []osqueue.QueueItem{}
+	for _, p := range partitions {
+		if len(items) >= limit {
+			break
+		}
+		leased, err := q.leasePartition(ctx, p, limit-len(items))
+		if err != nil {
+			return items, err
+		}
+		items = append(items, leased...)
+	}
+	return items, nil
+}
+
+// leasePartition leases up to limit items from a single partition.  This holds a
+// transaction-level advisory lock on the partition, so if another worker is
+// leasing from the partition it's skipped.
+func (q *queue) leasePartition(ctx context.Context, partition string, limit int) ([]osqueue.QueueItem, error) {
+	items := []osqueue.QueueItem{}
+	err := WithTx(ctx, q.db, func(tx *sql.Tx) error {
+		var locked bool
+		err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock(hashtext($1))`, partition).Scan(&locked)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		now := q.clock.Now()
+
+		// Respect function concurrency limits by counting in-progress items.
+		if fnID, err := uuid.Parse(partition); err == nil && q.concurrencyLimit != nil {
+			if max := q.concurrencyLimit(ctx, fnID); max > 0 {
+				var inProgress int
+				err := tx.QueryRowContext(ctx,
+					`SELECT COUNT(*) FROM queue_items WHERE partition_id = $1 AND leased_until >= $2`,
+					partition, now.UnixMilli(),
+				).Scan(&inProgress)
+				if err != nil {
+					return err
+				}
+				limit = min(limit, max-inProgress)
+				if limit <= 0 {
+					return nil
+				}
+			}
+		}
+
+		rows, err := tx.QueryContext(ctx, `
+			SELECT item FROM queue_items
+			WHERE partition_id = $1 AND at <= $2 AND (leased_until IS NULL OR leased_until < $2)
+			ORDER BY at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED`,
+			partition, now.UnixMilli(), limit,
+		)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var byt string
+			if err := rows.Scan(&byt); err != nil {
+				rows.Close()
+				return err
+			}
+			qi := osqueue.QueueItem{}
+			if err := json.Unmarshal([]byte(byt), &qi); err != nil {
+				rows.Close()
+				return fmt.Errorf("error unmarshalling queue item: %w", err)
+			}
+			items = append(items, qi)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		until := now.Add(redis_state.QueueLeaseDuration)
+		for n := range items {
+			leaseID := ulid.MustNew(ulid.Timestamp(until), rand.Reader)
+			items[n].LeaseID = &leaseID
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE queue_items SET lease_id = $2, leased_until = $3 WHERE id = $1`,
+				items[n].ID, leaseID.String(), until.UnixMilli(),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error leasing partition %s: %w", partition, err)
+	}
+	return items, nil
+}
+
+// ExtendLease extends the lease for a given queue item, returning the new lease ID.
+func (q *queue) ExtendLease(ctx context.Context, qi osqueue.QueueItem, leaseID ulid.ULID, duration time.Duration) (*ulid.ULID, error) {
+	until := q.clock.Now().Add(duration)
+	newLeaseID := ulid.MustNew(ulid.Timestamp(until), rand.Reader)
+
+	res, err := q.db.ExecContext(ctx,
+		`UPDATE queue_items SET lease_id = $3, leased_until = $4 WHERE id = $1 AND lease_id = $2`,
+		qi.ID, leaseID.String(), newLeaseID.String(), until.UnixMilli(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error extending lease: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return &newLeaseID, nil
+	}
+
+	var exists bool
+	err = q.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM queue_items WHERE id = $1)`, qi.ID,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, redis_state.ErrQueueItemNotFound
+	}
+	return nil, redis_state.ErrQueueItemLeaseMismatch
+}
+
+func (q *queue) process(ctx context.Context, qi osqueue.QueueItem, f osqueue.RunFunc) error {
+	var err error
+	leaseID := qi.LeaseID
+
+	// Continually the lease while this job is being processed.
+	extendLeaseTick := q.clock.NewTicker(redis_state.QueueLeaseDuration / 2)
+	defer extendLeaseTick.Stop()
+
+	errCh := make(chan error, 2)
+	doneCh := make(chan struct{})
+
+	// Continually extend lease in the background while we're working on this job
+	go func() {
+		for {
+			select {
+			case <-doneCh:
+				return
+			case <-extendLeaseTick.Chan():
+				if ctx.Err() != nil {
+					// Don't extend lease when the ctx is done.
+					return
+				}
+				if leaseID == nil {
+					errCh <- fmt.Errorf("cannot extend lease since lease ID is nil")
+					return
+				}
+				// Once a job has started, use a BG context to always renew.
+				leaseID, err = q.ExtendLease(context.Background(), qi, *leaseID, redis_state.QueueLeaseDuration)
+				if err != nil && err != redis_state.ErrQueueItemNotFound {
+					errCh <- fmt.Errorf("error extending lease while processing: %w", err)
+					return
+				}
+			}
+		}
+	}()
+
+	jobCtx, jobCancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer jobCancel()
+
+	jobCtx = osqueue.WithJobID(jobCtx, qi.ID)
+	if qi.Data.GroupID != "" {
+		jobCtx = state.WithGroupID(jobCtx, qi.Data.GroupID)
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				// Always retry this job.
+				logger.StdlibLogger(ctx).Error("job panicked", "error", fmt.Errorf("%v", r), "stack", string(debug.Stack()))
+				errCh <- osqueue.AlwaysRetryError(fmt.Errorf("job panicked: %v", r))
+			}
+		}()
+
+		n := q.clock.Now()
+		if qi.WallTimeMS == 0 {
+			qi.WallTimeMS = qi.AtMS // backcompat while WallTimeMS isn't valid.
+		}
+
+		runInfo := osqueue.RunInfo{
+			Latency:        n.Sub(time.UnixMilli(qi.WallTimeMS)),
+			QueueShardName: QueueShardName,
+		}
+
+		// Call the run func.
+		if _, err := f(jobCtx, runInfo, qi.Data); err != nil {
+			errCh <- err
+			return
+		}
+
+		// Closing this channel prevents the goroutine which extends lease from leaking,
+		// and dequeues the job
+		close(doneCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		// Job errored or extending lease errored.  Cancel the job ASAP.
+		jobCancel()
+
+		if osqueue.ShouldRetry(err, qi.Data.Attempt, qi.Data.GetMaxAttempts()) {
+			at := q.backoffFunc(qi.Data.Attempt)
+
+			// Attempt to find any RetryAtSpecifier in the error tree.
+			if specifier := osqueue.AsRetryAtError(err); specifier != nil {
+				if next := specifier.NextRetryAt(); next != nil {
+					at = *next
+				}
+			}
+
+			if !osqueue.IsAlwaysRetryable(err) {
+				qi.Data.Attempt += 1
+			}
+
+			if err := q.Requeue(context.WithoutCancel(ctx), QueueShard, qi, at); err != nil {
+				if err == redis_state.ErrQueueItemNotFound {
+					// Safe. The executor may have dequeued.
+					return nil
+				}
+				return fmt.Errorf("error requeueing job: %w", err)
+			}
+			if _, ok := err.(osqueue.QuitError); ok {
+				q.sendQuit(err)
+				return err
+			}
+			return nil
+		}
+
+		// Dequeue this entirely, as this permanently failed.
+		if err := q.Dequeue(context.WithoutCancel(ctx), QueueShard, qi); err != nil {
+			if err == redis_state.ErrQueueItemNotFound {
+				// Safe. The executor may have dequeued.
+				return nil
+			}
+			return err
+		}
+
+		if _, ok := err.(osqueue.QuitError); ok {
+			logger.StdlibLogger(ctx).Warn("received queue quit error", "error", err)
+			q.sendQuit(err)
+			return err
+		}
+
+	case <-doneCh:
+		if err := q.Dequeue(context.WithoutCancel(ctx), QueueShard, qi); err != nil {
+			if err == redis_state.ErrQueueItemNotFound {
+				// Safe. The executor may have dequeued.
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (q *queue) sendQuit(err error) {
+	select {
+	case q.quit <- err:
+	default:
+	}
+}
+
+// Dequeue removes an item from the queue entirely, retaining its ID for the
+// idempotency TTL so that the item can't be re-enqueued unless idempotency is
+// disabled.  Dequeueing a run's last item releases any singleton lock held by
+// the run.
+func (q *queue) Dequeue(ctx context.Context, _ redis_state.QueueShard, i osqueue.QueueItem, opts ...redis_state.DequeueOpt) error {
+	o := redis_state.DequeueOpts{}
+	for _, apply := range opts {
+		apply(&o)
+	}
+
+	return WithTx(ctx, q.db, func(tx *sql.Tx) error {
+		var runID string
+		err := tx.QueryRowContext(ctx, `DELETE FROM queue_items WHERE id = $1 RETURNING run_id`, i.ID).Scan(&runID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return redis_state.ErrQueueItemNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("error dequeueing item: %w", err)
+		}
+
+		if !o.DisableIdempotency {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO queue_idempotency (id, expires_at) VALUES ($1, $2)
+				ON CONFLICT (id) DO UPDATE SET expires_at = EXCLUDED.expires_at`,
+				i.ID, q.clock.Now().Add(q.idempotencyTTL).UnixMilli(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			DELETE FROM queue_singletons WHERE run_id = $1
+			AND NOT EXISTS (SELECT 1 FROM queue_items WHERE run_id = $1)`,
+			runID,
+		)
+		if err != nil {
+			return fmt.Errorf("error releasing singleton lock: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveQueueItem removes an item from the queue without retaining its ID.
+func (q *queue) RemoveQueueItem(ctx context.Context, shard string, partitionKey string, itemID string) error {
+	if _, err := q.db.ExecContext(ctx, `DELETE FROM queue_items WHERE id = $1`, itemID); err != nil {
+		return fmt.Errorf("error removing queue item: %w", err)
+	}
+	return nil
+}
+
+// Requeue requeues an item in the future, releasing its lease.
+func (q *queue) Requeue(ctx context.Context, _ redis_state.QueueShard, i osqueue.QueueItem, at time.Time) error {
+	i.AtMS = at.UnixMilli()
+	i.WallTimeMS = at.UnixMilli()
+	i.LeaseID = nil
+
+	byt, err := json.Marshal(i)
+	if err != nil {
+		return fmt.Errorf("error marshalling queue item: %w", err)
+	}
+
+	res, err := q.db.ExecContext(ctx, `
+		UPDATE queue_items SET item = $2, at = $3, lease_id = NULL, leased_until = NULL
+		WHERE id = $1`,
+		i.ID, string(byt), at.UnixMilli(),
+	)
+	if err != nil {
+		return fmt.Errorf("error requeueing item: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return redis_state.ErrQueueItemNotFound
+	}
+	return nil
+}
+
+// RequeueByJobID requeues a job for a specific time given a job ID.  This fails
+// with ErrQueueItemAlreadyLeased if the job is in progress.
+func (q *queue) RequeueByJobID(ctx context.Context, _ redis_state.QueueShard, jobID string, at time.Time) error {
+	id := osqueue.HashID(ctx, jobID)
+
+	return WithTx(ctx, q.db, func(tx *sql.Tx) error {
+		var (
+			byt         string
+			leasedUntil sql.NullInt64
+		)
+		err := tx.QueryRowContext(ctx,
+			`SELECT item, leased_until FROM queue_items WHERE id = $1 FOR UPDATE`, id,
+		).Scan(&byt, &leasedUntil)
+		if errors.Is(err, sql.ErrNoRows) {
+			return redis_state.ErrQueueItemNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if leasedUntil.Valid && leasedUntil.Int64 > q.clock.Now().UnixMilli() {
+			return redis_state.ErrQueueItemAlreadyLeased
+		}
+
+		qi := osqueue.QueueItem{}
+		if err := json.Unmarshal([]byte(byt), &qi); err != nil {
+			return fmt.Errorf("error unmarshalling queue item: %w", err)
+		}
+		qi.AtMS = at.UnixMilli()
+		qi.WallTimeMS = at.UnixMilli()
+
+		updated, err := json.Marshal(qi)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE queue_items SET item = $2, at = $3 WHERE id = $1`,
+			id, string(updated), at.UnixMilli(),
+		)
+		return err
+	})
+}
+
+func (q *queue) SetFunctionPaused(ctx context.Context, accountId uuid.UUID, fnID uuid.UUID, paused bool) error {
+	_, err := q.db.ExecContext(ctx, `
+		INSERT INTO queue_functions (function_id, paused) VALUES ($1, $2)
+		ON CONFLICT (function_id) DO UPDATE SET paused = EXCLUDED.paused`,
+		fnID, paused,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating function paused state: %w", err)
+	}
+	return nil
+}
+
+// SetFunctionMigrate is unsupported, as the postgres queue has a single shard.
+func (q *queue) SetFunctionMigrate(ctx context.Context, sourceShard string, fnID uuid.UUID, migrate bool) error {
+	return fmt.Errorf("queue migrations are not supported by the postgres queue")
+}
+
+// Migrate is unsupported, as the postgres queue has a single shard.
+func (q *queue) Migrate(ctx context.Context, shard string, fnID uuid.UUID, limit int64, concurrency int, handler osqueue.QueueMigrationHandler) (int64, error) {
+	return 0, fmt.Errorf("queue migrations are not supported by the postgres queue")
+}
+
+func (q *queue) OutstandingJobCount(ctx context.Context, workspaceID, workflowID uuid.UUID, runID ulid.ULID) (int, error) {
+	var count int
+	err := q.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM queue_items WHERE run_id = $1`, runID.String(),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting outstanding jobs: %w", err)
+	}
+	return count, nil
+}
+
+func (q *queue) RunningCount(ctx context.Context, workflowID uuid.UUID) (int64, error) {
+	var count int64
+	err := q.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM queue_items WHERE function_id = $1 AND leased_until >= $2`,
+		workflowID, q.clock.Now().UnixMilli(),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error inspecting running job count: %w", err)
+	}
+	return count, nil
+}
+
+// statusKinds maps the redis queue's status indexes to the item kinds they
+// contain.
+var statusKinds = map[string][]string{
+	"start":       {osqueue.KindStart},
+	"in-progress": {osqueue.KindEdge, osqueue.KindEdgeError},
+	"sleep":       {osqueue.KindSleep},
+}
+
+func (q *queue) StatusCount(ctx context.Context, workflowID uuid.UUID, status string) (int64, error) {
+	kinds, ok := statusKinds[status]
+	if !ok {
+		return 0, nil
+	}
+
+	var count int64
+	err := q.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM queue_items WHERE function_id = $1 AND kind IN ($2, $3)`,
+		workflowID, kinds[0], kinds[len(kinds)-1],
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error inspecting function queue status: %w", err)
+	}
+	return count, nil
+}
+
+func (q *queue) RunJobs(ctx context.Context, queueShardName string, workspaceID, workflowID uuid.UUID, runID ulid.ULID, limit, offset int64) ([]osqueue.JobResponse, error) {
+	if limit > 1000 || limit <= 0 {
+		limit = 1000
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT item,
+			(SELECT COUNT(*) FROM queue_items p WHERE p.partition_id = qi.partition_id AND (p.at, p.id) < (qi.at, qi.id))
+		FROM queue_items qi
+		WHERE run_id = $1 AND workspace_id = $2
+		ORDER BY at, id
+		LIMIT $3 OFFSET $4`,
+		runID.String(), workspaceID, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error reading jobs: %w", err)
+	}
+	defer rows.Close()
+
+	resp := []osqueue.JobResponse{}
+	for rows.Next() {
+		var (
+			byt string
+			pos int64
+		)
+		if err := rows.Scan(&byt, &pos); err != nil {
+			return nil, err
+		}
+		qi := &osqueue.QueueItem{}
+		if err := json.Unmarshal([]byte(byt), qi); err != nil {
+			return nil, fmt.Errorf("error unmarshalling queue item: %w", err)
+		}
+		resp = append(resp, osqueue.JobResponse{
+			At:       time.UnixMilli(qi.AtMS),
+			Position: pos,
+			Kind:     qi.Data.Kind,
+			Attempt:  qi.Data.Attempt,
+			Raw:      qi,
+		})
+	}
+	return resp, rows.Err()
+}
+
+func (q *queue) FunctionJobs(ctx context.Context, queueShardName string, workspaceID, workflowID uuid.UUID, limit, offset int64) ([]osqueue.JobResponse, error) {
+	if limit > 1000 || limit <= 0 {
+		limit = 1000
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := q.db.QueryContext(ctx, `
+		SELECT item, at FROM queue_items
+		WHERE partition_id = $1
+		ORDER BY at, id
+		LIMIT $2 OFFSET $3`,
+		workflowID.String(), limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error reading function queue: %w", err)
+	}
+	defer rows.Close()
+
+	resp := []osqueue.JobResponse{}
+	n := int64(0)
+	for rows.Next() {
+		var (
+			byt   string
+			score int64
+		)
+		if err := rows.Scan(&byt, &score); err != nil {
+			return nil, err
+		}
+		pos := offset + n
+		n++
+
+		qi := &osqueue.QueueItem{}
+		if err := json.Unmarshal([]byte(byt), qi); err != nil {
+			return nil, fmt.Errorf("error unmarshalling queue item: %w", err)
+		}
+		if qi.Data.Identifier.WorkspaceID != workspaceID {
+			continue
+		}
+		resp = append(resp, osqueue.JobResponse{
+			At:       time.UnixMilli(qi.AtMS),
+			Position: pos,
+			Kind:     qi.Data.Kind,
+			Attempt:  qi.Data.Attempt,
+			Score:    score,
+			Raw:      qi,
+		})
+	}
+	return resp, rows.Err()
+}
@@ -121,6 +121,9 @@ type GlobalKeyGenerator interface {
 	// Signal returns the key used to store the correlation key associated with
 	// signal functions
 	Signal(ctx context.Context, wsID uuid.UUID) string
+	// SignalWaiters returns the key used to store the IDs of signal pauses with
+	// an expression.  Many of these pauses may wait for the same signal.
+	SignalWaiters(ctx context.Context, wsID uuid.UUID, signalID string) string
 }
 
 type globalKeyGenerator struct {
@@ -135,6 +138,10 @@ func (u globalKeyGenerator) Signal(ctx context.Context, wsID uuid.UUID) string {
 	return fmt.Sprintf("{%s}:signal:%s", u.stateDefaultKey, wsID)
 }
 
+func (u globalKeyGenerator) SignalWaiters(ctx context.Context, wsID uuid.UUID, signalID string) string {
+	return fmt.Sprintf("{%s}:signal-waiters:%s:%s", u.stateDefaultKey, wsID, signalID)
+}
+
 type QueueKeyGenerator interface {
 	// QueueItem returns the key for the hash containing all items within a
 	// queue for a function.
@@ -244,6 +251,9 @@ type QueueKeyGenerator interface {
 	SingletonKey(s *osqueue.Singleton) string
 	// SingletonRunKey returns the singleton run id key that stores the singleton key for a given run.
 	SingletonRunKey(r string) string
+	// CronNextFire returns the key for the hash storing the next-fire record of each
+	// cron trigger for the given function.
+	CronNextFire(fnID uuid.UUID) string
 
 	// FnMetadata returns the key for a function's metadata.
 	// This is a JSON object; see queue.FnMetadata.
@@ -312,6 +322,10 @@ func (u queueKeyGenerator) SingletonRunKey(runID string) string {
 	return fmt.Sprintf("{%s}:singleton-run:%s", u.queueDefaultKey, runID)
 }
 
+func (u queueKeyGenerator) CronNextFire(fnID uuid.UUID) string {
+	return fmt.Sprintf("{%s}:cron:%s", u.queueDefaultKey, fnID)
+}
+
 func (u queueKeyGenerator) PartitionMeta(id string) string {
 	return fmt.Sprintf("{%s}:partition:meta:%s", u.queueDefaultKey, id)
 }
@@ -515,6 +529,9 @@ type BatchKeyGenerator interface {
 	// BatchMetadata returns the key used to store the metadata related
 	// to a batch
 	BatchMetadata(ctx context.Context, functionId uuid.UUID, batchId ulid.ULID) string
+	// BatchIndex returns the key for the hash of a function's open batches,
+	// mapping batch IDs to batch details.
+	BatchIndex(ctx context.Context, functionId uuid.UUID) string
 }
 
 type batchKeyGenerator struct {
@@ -549,6 +566,10 @@ func (u batchKeyGenerator) BatchMetadata(ctx context.Context, functionId uuid.UU
 	return fmt.Sprintf("%s:metadata", u.Batch(ctx, functionId, batchID))
 }
 
+func (u batchKeyGenerator) BatchIndex(ctx context.Context, functionId uuid.UUID) string {
+	return fmt.Sprintf("{%s}:workflows:%s:batches", u.PrefixByFunctionId(ctx, u.queueDefaultKey, true, functionId), functionId)
+}
+
 type DebounceKeyGenerator interface {
 	// QueueItem returns the key for the hash containing all items within a
 	// queue for a function.  This is used to check leases on debounce jobs.
@@ -556,6 +577,9 @@ type DebounceKeyGenerator interface {
 	// DebouncePointer returns the key which stores the pointer to the current debounce
 	// for a given function.
 	DebouncePointer(ctx context.Context, fnID uuid.UUID, key string) string
+	// DebounceLeading returns the key which stores the current leading-edge debounce
+	// window for a given function.
+	DebounceLeading(ctx context.Context, fnID uuid.UUID, key string) string
 	// Debounce returns the key for storing debounce-related data given a debounce ID.
 	Debounce(ctx context.Context) string
 	// DebounceMigrating returns the key for storing the in-progress debounce migration flag to prevent
@@ -574,6 +598,12 @@ func (u debounceKeyGenerator) DebouncePointer(ctx context.Context, fnID uuid.UUI
 	return fmt.Sprintf("{%s}:debounce-ptrs:%s:%s", u.queueDefaultKey, fnID, key)
 }
 
+// DebounceLeading returns the key which stores the current leading-edge debounce
+// window for a given function.
+func (u debounceKeyGenerator) DebounceLeading(ctx context.Context, fnID uuid.UUID, key string) string {
+	return fmt.Sprintf("{%s}:debounce-leading:%s:%s", u.queueDefaultKey, fnID, key)
+}
+
 // Debounce returns the key for storing debounce-related data given a debounce ID.
 // This is a hash of debounce IDs -> debounces.
 func (u debounceKeyGenerator) Debounce(ctx context.Context) string {
@@ -15,6 +15,7 @@ local keyPauseAddIdx = KEYS[5]
 local keyPauseExpIdx = KEYS[6]
 local keyRunPauses   = KEYS[7]
 local keyPausesIdx   = KEYS[8]
+local keySignalWaiters = KEYS[9]
 
 local pauseID       = ARGV[1]
 local invokeCorrelationId = ARGV[2]
@@ -37,6 +38,7 @@ if signalCorrelationId ~= false and signalCorrelationId ~= "" and signalCorrelat
   if redis.call("HGET", pauseSignalKey, signalCorrelationId) == pauseID then
     redis.call("HDEL", pauseSignalKey, signalCorrelationId)
   end
+  redis.call("SREM", keySignalWaiters, pauseID)
 end
 
 -- Add an index of when the pause was added.
@@ -51,6 +51,7 @@ local shadowPartitionItem     = ARGV[12]
 local backlogItem             = ARGV[13]
 local backlogID               = ARGV[14]
 local normalizeFromBacklogID  = ARGV[15]
+local replaceSingleton        = tonumber(ARGV[16])
 
 -- $include(update_pointer_score.lua)
 -- $include(ends_with.lua)
@@ -75,8 +76,16 @@ end
 
 -- Check if the item is a singleton and if an existing item already exists
 if exists_without_ending(singletonKey, ":singleton:-") then 
-  if redis.call("EXISTS", singletonKey) ~= 0 then
-    return 2
+  local singletonRunID = redis.call("GET", singletonKey)
+  if singletonRunID ~= nil and singletonRunID ~= false then
+    if replaceSingleton ~= 1 then
+      return 2
+    end
+
+    -- Take over the lock from the run being replaced, removing its pointer to the
+    -- singleton key so that dequeueing its remaining items leaves the lock intact.
+    local prevSingletonRunKey = string.sub(singletonRunKey, 1, #singletonRunKey - #runID) .. singletonRunID
+    redis.call("DEL", prevSingletonRunKey)
   end
 
   -- Set the singleton key to the item ID
@@ -13,6 +13,7 @@ local keyPauseAddIdx = KEYS[5]
 local keyPauseExpIdx = KEYS[6]
 local keyRunPauses   = KEYS[7]
 local keyPausesIdx   = KEYS[8]
+local keySignalWaiters = KEYS[9]
 
 local pause          = ARGV[1]
 local pauseID        = ARGV[2]
@@ -22,6 +23,7 @@ local signalCorrelationID = ARGV[5]
 local extendedExpiry = tonumber(ARGV[6])
 local nowUnixSeconds = tonumber(ARGV[7])
 local canReplaceSignal = tonumber(ARGV[8])
+local isSignalWaiter = tonumber(ARGV[9])
 
 
 if redis.call("SETNX", pauseKey, pause) == 0 then
@@ -51,7 +53,14 @@ if invokeCorrelationID ~= false and invokeCorrelationID ~= "" and invokeCorrelat
 end
 
 if signalCorrelationID ~= false and signalCorrelationID ~= "" and signalCorrelationID ~= nil then
-	if canReplaceSignal == 1 then
+	if isSignalWaiter == 1 then
+		-- Signal waits with an expression may share a signal ID, and each wait
+		-- whose expression matches is resumed.
+		redis.call("SADD", keySignalWaiters, pauseID)
+		if redis.call("TTL", keySignalWaiters) < extendedExpiry then
+			redis.call("EXPIRE", keySignalWaiters, extendedExpiry)
+		end
+	elseif canReplaceSignal == 1 then
 		-- Note that this may be overwriting an existing signal wait, which is
 		-- intentional. When running this transaction, we could be part of an
 		-- idempotent retry or be overwriting an existing signal wait with
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	mrand "math/rand"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -156,11 +157,28 @@ type QueueManager interface {
 	osqueue.Queue
 	osqueue.QueueDirectAccess
 
-	Dequeue(ctx context.Context, queueShard QueueShard, i osqueue.QueueItem) error
+	Dequeue(ctx context.Context, queueShard QueueShard, i osqueue.QueueItem, opts ...DequeueOpt) error
 	Requeue(ctx context.Context, queueShard QueueShard, i osqueue.QueueItem, at time.Time) error
 	RequeueByJobID(ctx context.Context, queueShard QueueShard, jobID string, at time.Time) error
 }
 
+type DequeueOpt func(o *DequeueOpts)
+
+// DequeueOpts configures a single Dequeue call.
+type DequeueOpts struct {
+	// DisableIdempotency removes the item without retaining its ID, such that
+	// an item with the same job ID can be enqueued again immediately.
+	DisableIdempotency bool
+}
+
+// DequeueOptionDisableIdempotency removes the item without retaining its ID
+// for the idempotency TTL.
+func DequeueOptionDisableIdempotency() DequeueOpt {
+	return func(o *DequeueOpts) {
+		o.DisableIdempotency = true
+	}
+}
+
 // PartitionPriorityFinder returns the priority for a given queue partition.
 type PartitionPriorityFinder func(ctx context.Context, part QueuePartition) uint
 
@@ -1329,6 +1347,13 @@ func (q *queue) EnqueueItem(ctx context.Context, shard QueueShard, i osqueue.Que
 		enqueueToBacklogsVal = "1"
 	}
 
+	// Singletons in cancel mode take over the lock from the run they replace, which
+	// is cancelled once the new run is enqueued.
+	replaceSingletonVal := "0"
+	if i.Data.Singleton != nil && i.Data.Singleton.Mode == enums.SingletonModeCancel {
+		replaceSingletonVal = "1"
+	}
+
 	args, err := StrSlice([]any{
 		i,
 		i.ID,
@@ -1352,6 +1377,7 @@ func (q *queue) EnqueueItem(ctx context.Context, shard QueueShard, i osqueue.Que
 		backlog.BacklogID,
 
 		opts.NormalizeFromBacklogID,
+		replaceSingletonVal,
 	})
 	if err != nil {
 		return i, err
@@ -1451,6 +1477,109 @@ func (q *queue) RunJobs(ctx context.Context, queueShardName string, workspaceID,
 	return resp, nil
 }
 
+func (q *queue) FunctionJobs(ctx context.Context, queueShardName string, workspaceID, workflowID uuid.UUID, limit, offset int64) ([]osqueue.JobResponse, error) {
+	if limit > 1000 || limit <= 0 {
+		limit = 1000
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	shard, ok := q.queueShardClients[queueShardName]
+	if !ok {
+		return nil, fmt.Errorf("queue shard %s not found", queueShardName)
+	}
+
+	if shard.Kind != string(enums.QueueShardKindRedis) {
+		return nil, fmt.Errorf("unsupported queue shard kind for FunctionJobs: %s", shard.Kind)
+	}
+
+	ctx = redis_telemetry.WithScope(redis_telemetry.WithOpName(ctx, "FunctionJobs"), redis_telemetry.ScopeQueue)
+
+	rc := shard.RedisClient.unshardedRc
+	kg := shard.RedisClient.kg
+
+	// Read the first offset+limit items of the function's ready queue and of each
+	// of its backlogs, as items are enqueued to backlogs when key queues are
+	// enabled.  Both use the same score, so merging them gives the order in which
+	// items are processed.
+	readSet := func(key string) ([]rueidis.ZScore, error) {
+		cmd := rc.B().Zrange().
+			Key(key).
+			Min("0").
+			Max(strconv.FormatInt(offset+limit-1, 10)).
+			Withscores().
+			Build()
+		return rc.Do(ctx, cmd).AsZScores()
+	}
+
+	scores, err := readSet(kg.FnQueueSet(workflowID.String()))
+	if err != nil {
+		return nil, fmt.Errorf("error reading function queue: %w", err)
+	}
+
+	backlogIDs, err := rc.Do(ctx, rc.B().Zrange().Key(kg.ShadowPartitionSet(workflowID.String())).Min("0").Max("-1").Build()).AsStrSlice()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("error reading function backlogs: %w", err)
+	}
+	for _, backlogID := range backlogIDs {
+		backlog, err := readSet(kg.BacklogSet(backlogID))
+		if err != nil {
+			return nil, fmt.Errorf("error reading function backlog: %w", err)
+		}
+		scores = append(scores, backlog...)
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score == scores[j].Score {
+			return scores[i].Member < scores[j].Member
+		}
+		return scores[i].Score < scores[j].Score
+	})
+	if int64(len(scores)) <= offset {
+		return []osqueue.JobResponse{}, nil
+	}
+	scores = scores[offset:]
+	if int64(len(scores)) > limit {
+		scores = scores[:limit]
+	}
+
+	ids := make([]string, len(scores))
+	for n, s := range scores {
+		ids[n] = s.Member
+	}
+
+	jsonItems, err := rc.Do(ctx, rc.B().Hmget().Key(kg.QueueItem()).Field(ids...).Build()).AsStrSlice()
+	if err != nil {
+		return nil, fmt.Errorf("error reading jobs: %w", err)
+	}
+
+	resp := []osqueue.JobResponse{}
+	for n, str := range jsonItems {
+		if len(str) == 0 {
+			continue
+		}
+		qi := &osqueue.QueueItem{}
+		if err := json.Unmarshal([]byte(str), qi); err != nil {
+			return nil, fmt.Errorf("error unmarshalling queue item: %w", err)
+		}
+		// Positions include other workspaces' items, as the queue is shared.
+		if qi.Data.Identifier.WorkspaceID != workspaceID {
+			continue
+		}
+		resp = append(resp, osqueue.JobResponse{
+			At:       time.UnixMilli(qi.AtMS),
+			Position: offset + int64(n),
+			Kind:     qi.Data.Kind,
+			Attempt:  qi.Data.Attempt,
+			Score:    int64(scores[n].Score),
+			Raw:      qi,
+		})
+	}
+
+	return resp, nil
+}
+
 func (q *queue) OutstandingJobCount(ctx context.Context, workspaceID, workflowID uuid.UUID, runID ulid.ULID) (int, error) {
 	ctx = redis_telemetry.WithScope(redis_telemetry.WithOpName(ctx, "OutstandingJobCount"), redis_telemetry.ScopeQueue)
 
@@ -2493,7 +2622,7 @@ func (q *queue) activeKeysToGarbageCollect(i osqueue.QueueItem, partition QueueS
 }
 
 // Dequeue removes an item from the queue entirely.
-func (q *queue) Dequeue(ctx context.Context, queueShard QueueShard, i osqueue.QueueItem) error {
+func (q *queue) Dequeue(ctx context.Context, queueShard QueueShard, i osqueue.QueueItem, opts ...DequeueOpt) error {
 	ctx = redis_telemetry.WithScope(redis_telemetry.WithOpName(ctx, "Dequeue"), redis_telemetry.ScopeQueue)
 
 	if queueShard.Kind != string(enums.QueueShardKindRedis) {
@@ -2558,10 +2687,18 @@ func (q *queue) Dequeue(ctx context.Context, queueShard QueueShard, i osqueue.Qu
 		}
 	}
 
+	o := DequeueOpts{}
+	for _, apply := range opts {
+		apply(&o)
+	}
+
 	idempotency := q.idempotencyTTL
 	if q.idempotencyTTLFunc != nil {
 		idempotency = q.idempotencyTTLFunc(ctx, i)
 	}
+	if o.DisableIdempotency {
+		idempotency = 0
+	}
 
 	args, err := StrSlice([]any{
 		i.ID,
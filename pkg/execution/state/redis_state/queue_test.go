@@ -7134,6 +7134,45 @@ func TestQueueEnqueueItemSingleton(t *testing.T) {
 		newQueueItem := getQueueItem(t, r, item1.ID)
 		require.NotEqual(t, found.ID, newQueueItem.ID)
 	})
+
+	t.Run("It takes over the singleton lock in cancel mode", func(t *testing.T) {
+		key := "cancel-example"
+		kg := q.primaryQueueShard.RedisClient.kg
+		singleton := &osqueue.Singleton{Key: key, Mode: enums.SingletonModeCancel}
+
+		prevRunID := ulid.MustNew(ulid.Now(), rand.Reader)
+		prev, err := q.EnqueueItem(ctx, q.primaryQueueShard, osqueue.QueueItem{
+			Data: osqueue.Item{
+				Kind:       osqueue.KindStart,
+				Identifier: state.Identifier{RunID: prevRunID},
+				Singleton:  singleton,
+			},
+		}, start, osqueue.EnqueueOpts{})
+		require.NoError(t, err)
+
+		runID := ulid.MustNew(ulid.Now(), rand.Reader)
+		_, err = q.EnqueueItem(ctx, q.primaryQueueShard, osqueue.QueueItem{
+			Data: osqueue.Item{
+				Kind:       osqueue.KindStart,
+				Identifier: state.Identifier{RunID: runID},
+				Singleton:  singleton,
+			},
+		}, start, osqueue.EnqueueOpts{})
+		require.NoError(t, err)
+
+		val, err := r.Get(kg.SingletonKey(singleton))
+		require.NoError(t, err)
+		require.Equal(t, runID.String(), val)
+		require.False(t, r.Exists(kg.SingletonRunKey(prevRunID.String())))
+
+		// Dequeueing the replaced run's items leaves the new lock intact.
+		err = q.Dequeue(ctx, q.primaryQueueShard, prev)
+		require.NoError(t, err)
+
+		val, err = r.Get(kg.SingletonKey(singleton))
+		require.NoError(t, err)
+		require.Equal(t, runID.String(), val)
+	})
 }
 
 func TestQueueActiveCounters(t *testing.T) {
@@ -7880,3 +7919,96 @@ func zcard(t *testing.T, rc rueidis.Client, key string) int {
 
 	return int(num)
 }
+
+func TestQueueFunctionJobs(t *testing.T) {
+	r := miniredis.RunT(t)
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	shard := QueueShard{Kind: string(enums.QueueShardKindRedis), RedisClient: NewQueueClient(rc, QueueDefaultKey), Name: consts.DefaultQueueShardName}
+	keyQueues := false
+	q := NewQueue(
+		shard,
+		WithQueueShardClients(map[string]QueueShard{shard.Name: shard}),
+		WithAllowKeyQueues(func(ctx context.Context, acctID uuid.UUID) bool {
+			return keyQueues
+		}),
+	)
+	ctx := context.Background()
+
+	now := time.Now()
+	accountID, wsID, fnID := uuid.New(), uuid.New(), uuid.New()
+
+	enqueueTo := func(wsID uuid.UUID, factor *int64) ulid.ULID {
+		runID := ulid.MustNew(ulid.Timestamp(now), rand.Reader)
+		err := q.Enqueue(ctx, osqueue.Item{
+			WorkspaceID:    wsID,
+			Kind:           osqueue.KindStart,
+			PriorityFactor: factor,
+			Identifier: state.Identifier{
+				AccountID:   accountID,
+				WorkspaceID: wsID,
+				WorkflowID:  fnID,
+				RunID:       runID,
+			},
+		}, now, osqueue.EnqueueOpts{})
+		require.NoError(t, err)
+		return runID
+	}
+	enqueue := func(factor *int64) ulid.ULID {
+		return enqueueTo(wsID, factor)
+	}
+
+	factor := int64(60)
+	normal := enqueue(nil)
+	prioritized := enqueue(&factor)
+
+	jobs, err := q.FunctionJobs(ctx, shard.Name, wsID, fnID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	require.Equal(t, prioritized, jobs[0].Raw.(*osqueue.QueueItem).Data.Identifier.RunID)
+	require.EqualValues(t, 0, jobs[0].Position)
+	require.Equal(t, now.UnixMilli()-60_000, jobs[0].Score)
+	require.Equal(t, normal, jobs[1].Raw.(*osqueue.QueueItem).Data.Identifier.RunID)
+	require.EqualValues(t, 1, jobs[1].Position)
+	require.Equal(t, now.UnixMilli(), jobs[1].Score)
+
+	t.Run("paginates", func(t *testing.T) {
+		jobs, err := q.FunctionJobs(ctx, shard.Name, wsID, fnID, 1, 1)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, normal, jobs[0].Raw.(*osqueue.QueueItem).Data.Identifier.RunID)
+		require.EqualValues(t, 1, jobs[0].Position)
+	})
+
+	t.Run("filters by workspace", func(t *testing.T) {
+		jobs, err := q.FunctionJobs(ctx, shard.Name, uuid.New(), fnID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, jobs, 0)
+	})
+
+	t.Run("reads backlogs and positions items across workspaces", func(t *testing.T) {
+		otherFactor, backlogFactor := int64(90), int64(30)
+		_ = enqueueTo(uuid.New(), &otherFactor)
+
+		keyQueues = true
+		backlogged := enqueue(&backlogFactor)
+		require.Equal(t, 1, zcard(t, rc, shard.RedisClient.kg.ShadowPartitionSet(fnID.String())))
+
+		jobs, err := q.FunctionJobs(ctx, shard.Name, wsID, fnID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, jobs, 3)
+
+		require.Equal(t, prioritized, jobs[0].Raw.(*osqueue.QueueItem).Data.Identifier.RunID)
+		require.EqualValues(t, 1, jobs[0].Position)
+		require.Equal(t, backlogged, jobs[1].Raw.(*osqueue.QueueItem).Data.Identifier.RunID)
+		require.EqualValues(t, 2, jobs[1].Position)
+		require.Equal(t, normal, jobs[2].Raw.(*osqueue.QueueItem).Data.Identifier.RunID)
+		require.EqualValues(t, 3, jobs[2].Position)
+	})
+}
@@ -882,6 +882,7 @@ func (m unshardedMgr) SavePause(ctx context.Context, p state.Pause) (int64, erro
 		pause.kg.PauseIndex(ctx, "exp", p.WorkspaceID, evt),
 		pause.kg.RunPauses(ctx, p.Identifier.RunID),
 		pause.kg.GlobalPauseIndex(ctx),
+		global.kg.SignalWaiters(ctx, p.WorkspaceID, signalCorrId),
 	}
 
 	replaceSignalOnConflict := "0"
@@ -889,6 +890,11 @@ func (m unshardedMgr) SavePause(ctx context.Context, p state.Pause) (int64, erro
 		replaceSignalOnConflict = "1"
 	}
 
+	isSignalWaiter := "0"
+	if p.IsSignalWaiter() {
+		isSignalWaiter = "1"
+	}
+
 	args, err := StrSlice([]any{
 		string(packed),
 		p.ID.String(),
@@ -900,6 +906,7 @@ func (m unshardedMgr) SavePause(ctx context.Context, p state.Pause) (int64, erro
 		int(extendedExpiry),
 		nowUnixSeconds,
 		replaceSignalOnConflict,
+		isSignalWaiter,
 	})
 	if err != nil {
 		return 0, err
@@ -1115,6 +1122,7 @@ func (m unshardedMgr) DeletePause(ctx context.Context, p state.Pause) error {
 		pause.kg.PauseIndex(ctx, "exp", p.WorkspaceID, evt),
 		runPausesKey,
 		pause.kg.GlobalPauseIndex(ctx),
+		global.kg.SignalWaiters(ctx, p.WorkspaceID, signalCorrId),
 	}
 
 	status, err := scripts["deletePause"].Exec(
@@ -1301,6 +1309,41 @@ func (m unshardedMgr) PauseBySignalID(ctx context.Context, wsID uuid.UUID, signa
 	return p, nil
 }
 
+func (m unshardedMgr) PausesBySignalID(ctx context.Context, wsID uuid.UUID, signalID string) ([]*state.Pause, error) {
+	ctx = redis_telemetry.WithScope(redis_telemetry.WithOpName(ctx, "PausesBySignalID"), redis_telemetry.ScopePauses)
+
+	pauses := []*state.Pause{}
+	p, err := m.PauseBySignalID(ctx, wsID, signalID)
+	if err != nil {
+		return nil, err
+	}
+	if p != nil {
+		pauses = append(pauses, p)
+	}
+
+	global := m.u.Global()
+	cmd := global.Client().B().Smembers().Key(global.kg.SignalWaiters(ctx, wsID, signalID)).Build()
+	members, err := global.Client().Do(ctx, cmd).AsStrSlice()
+	if err != nil && !rueidis.IsRedisNil(err) {
+		return nil, fmt.Errorf("failed to get signal waiters: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pauseID UUID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	waiters, err := m.PausesByID(ctx, ids...)
+	if err != nil && err != state.ErrPauseNotFound {
+		return nil, fmt.Errorf("failed to get pauses by ID: %w", err)
+	}
+	return append(pauses, waiters...), nil
+}
+
 func (m unshardedMgr) PausesByID(ctx context.Context, ids ...uuid.UUID) ([]*state.Pause, error) {
 	ctx = redis_telemetry.WithScope(redis_telemetry.WithOpName(ctx, "PausesByID"), redis_telemetry.ScopePauses)
 
@@ -94,6 +94,7 @@ func CheckState(t *testing.T, gen Generator) {
 		"ConsumePause/WithEmptyDataKey":    checkConsumePauseWithEmptyDataKey,
 		"ConsumePauseIdempotency":          checkConsumePauseIdempotency,
 		"DeletePause":                      checkDeletePause,
+		"PausesBySignalID":                 checkPausesBySignalID,
 		"PausesByEvent/Empty":              checkPausesByEvent_empty,
 		"PausesByEvent/Single":             checkPausesByEvent_single,
 		"PausesByEvent/Multiple":           checkPausesByEvent_multi,
@@ -612,6 +613,59 @@ func checkDeletePause(t *testing.T, m state.Manager) {
 	})
 }
 
+func checkPausesBySignalID(t *testing.T, m state.Manager) {
+	ctx := context.Background()
+	s := setup(t, m)
+
+	signal := "order.approved"
+	pause := func(expr *string) state.Pause {
+		return state.Pause{
+			ID:          uuid.New(),
+			WorkspaceID: s.Identifier().WorkspaceID,
+			Identifier: state.PauseIdentifier{
+				RunID:      s.Identifier().RunID,
+				FunctionID: s.Identifier().WorkflowID,
+				AccountID:  s.Identifier().AccountID,
+			},
+			Outgoing:   inngest.TriggerName,
+			Incoming:   w.Steps[0].ID,
+			Expires:    state.Time(time.Now().Add(time.Minute)),
+			SignalID:   &signal,
+			Expression: expr,
+		}
+	}
+
+	a, b := `async.data.id == "a"`, `async.data.id == "b"`
+	exclusive, waiterA, waiterB := pause(nil), pause(&a), pause(&b)
+	for _, p := range []state.Pause{exclusive, waiterA, waiterB} {
+		_, err := m.SavePause(ctx, p)
+		require.NoError(t, err)
+	}
+
+	// Only signal waits without an expression conflict.
+	_, err := m.SavePause(ctx, pause(nil))
+	require.ErrorIs(t, err, state.ErrSignalConflict)
+
+	ids := func() []uuid.UUID {
+		pauses, err := m.PausesBySignalID(ctx, s.Identifier().WorkspaceID, signal)
+		require.NoError(t, err)
+		ids := []uuid.UUID{}
+		for _, p := range pauses {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	require.ElementsMatch(t, []uuid.UUID{exclusive.ID, waiterA.ID, waiterB.ID}, ids())
+
+	require.NoError(t, m.DeletePause(ctx, waiterA))
+	require.ElementsMatch(t, []uuid.UUID{exclusive.ID, waiterB.ID}, ids())
+
+	pauses, err := m.PausesBySignalID(ctx, s.Identifier().WorkspaceID, "other")
+	require.NoError(t, err)
+	require.Empty(t, pauses)
+}
+
 func checkConsumePause(t *testing.T, m state.Manager) {
 	ctx := context.Background()
 	s := setup(t, m)
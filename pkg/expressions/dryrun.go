@@ -0,0 +1,129 @@
+package expressions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/inngest/inngest/pkg/expressions/exprenv"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DryRunResult is the detailed result of evaluating an expression, used to debug
+// expressions such as triggers, keys and cancellations.
+type DryRunResult struct {
+	// Result is the value the expression evaluated to.  Attributes which aren't
+	// present in the data are treated as null, as when matching events.
+	Result any
+	// Type is the CEL type name of the result, eg. "bool" or "string".
+	Type string
+	// Residual is the expression remaining after substituting the given data,
+	// if any attributes are unknown.  For example, this is the expression stored
+	// to match `async` events with the triggering event's data interpolated.
+	Residual *string
+	// Unknown lists the attributes referenced by the expression which aren't
+	// present in the data, eg. "async.data.id".
+	Unknown []string
+	// Error is set if the expression failed to compile or evaluate.
+	Error *DryRunError
+}
+
+// DryRunError is a compilation or evaluation error.  Line and Column are
+// 1-indexed, and are zero if the error doesn't have a position.
+type DryRunError struct {
+	Message string
+	Line    int
+	Column  int
+}
+
+// DryRun evaluates the given expression against the given data, reporting the
+// result, unknown attributes and any errors.  The expression is validated using
+// the given policy, which should be the policy applied when the expression is
+// registered, eg. DefaultRestrictiveValidationPolicy for cancellation and
+// waitForEvent expressions, or nil for triggers and keys.
+//
+// Compile, validation and evaluation errors are reported within the result;
+// the returned error is only set if the expression can't be handled at all.
+func DryRun(ctx context.Context, policy *ValidationPolicy, expression string, data map[string]any) (*DryRunResult, error) {
+	env, err := exprenv.Env()
+	if err != nil {
+		return nil, err
+	}
+
+	// Compile the expression to report type check errors with their position.
+	// This mirrors Validate, which is called when functions are registered.
+	// Expressions are otherwise only parsed when evaluated.
+	if _, issues := env.Compile(expression); issues != nil && issues.Err() != nil {
+		res := &DryRunResult{Error: &DryRunError{Message: issues.Err().Error()}}
+		if errs := issues.Errors(); len(errs) > 0 {
+			loc := errs[0].Location
+			res.Error = &DryRunError{
+				Message: errs[0].Message,
+				Line:    loc.Line(),
+				// CEL columns are 0-indexed.
+				Column: loc.Column() + 1,
+			}
+		}
+		return res, nil
+	}
+	if err := Validate(ctx, policy, expression); err != nil {
+		return &DryRunResult{Error: &DryRunError{Message: err.Error()}}, nil
+	}
+
+	// Use the same evaluator as when matching, which lifts literals from the
+	// expression into `vars`.
+	evaluator, err := NewExpressionEvaluator(ctx, expression)
+	if err != nil {
+		return &DryRunResult{Error: &DryRunError{Message: err.Error()}}, nil
+	}
+	eval, ok := evaluator.(*expressionEvaluator)
+	if !ok {
+		return nil, fmt.Errorf("unexpected evaluator type: %T", evaluator)
+	}
+	if len(eval.liftedVars) > 0 {
+		data = maps.Clone(data)
+		data["vars"] = eval.liftedVars
+	}
+
+	d := NewData(data)
+	res := &DryRunResult{Unknown: []string{}}
+	for _, path := range eval.UsedAttributes(ctx).FullPaths() {
+		if _, ok := d.Get(ctx, path); !ok {
+			res.Unknown = append(res.Unknown, strings.Join(path, "."))
+		}
+	}
+	sort.Strings(res.Unknown)
+
+	if len(res.Unknown) > 0 {
+		if ast, err := residual(ctx, eval.ast, eval.env, data); err == nil {
+			residual := ast.Source().Content()
+			res.Residual = &residual
+		}
+	}
+
+	prog, act, err := program(ctx, eval.ast, eval.env, d, true, eval.attrs)
+	if err != nil {
+		res.Error = &DryRunError{Message: err.Error()}
+		return res, nil
+	}
+	val, _, err := prog.Eval(act)
+	switch {
+	case err != nil:
+		res.Error = &DryRunError{Message: err.Error()}
+	case val == nil || types.IsUnknown(val) || types.IsError(val):
+		res.Error = &DryRunError{Message: ErrNoResult.Error()}
+	default:
+		res.Type = val.Type().TypeName()
+		res.Result = val.Value()
+		// Convert maps and lists to plain Go values so that they can be
+		// marshalled.
+		if native, err := val.ConvertToNative(reflect.TypeOf(&structpb.Value{})); err == nil {
+			res.Result = native.(*structpb.Value).AsInterface()
+		}
+	}
+	return res, nil
+}
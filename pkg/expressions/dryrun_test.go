@@ -0,0 +1,124 @@
+package expressions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	event := map[string]any{
+		"name": "order/created",
+		"data": map[string]any{
+			"id":    "ord_1",
+			"total": 120,
+			"items": []any{"a", "b"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		policy   *ValidationPolicy
+		data     map[string]any
+		result   any
+		typ      string
+		residual string
+		unknown  []string
+		err      *DryRunError
+	}{
+		{
+			name:    "bool",
+			expr:    `event.data.total > 100`,
+			data:    map[string]any{"event": event},
+			result:  true,
+			typ:     "bool",
+			unknown: []string{},
+		},
+		{
+			name:    "string",
+			expr:    `event.data.id + "-" + event.name`,
+			data:    map[string]any{"event": event},
+			result:  "ord_1-order/created",
+			typ:     "string",
+			unknown: []string{},
+		},
+		{
+			name:    "list",
+			expr:    `event.data.items`,
+			data:    map[string]any{"event": event},
+			result:  []any{"a", "b"},
+			typ:     "list",
+			unknown: []string{},
+		},
+		{
+			name:     "async is unknown",
+			expr:     `event.data.id == async.data.id`,
+			data:     map[string]any{"event": event},
+			result:   false,
+			typ:      "bool",
+			residual: `"ord_1" == async.data.id`,
+			unknown:  []string{"async.data.id"},
+		},
+		{
+			name: "async is known",
+			expr: `event.data.id == async.data.id`,
+			data: map[string]any{
+				"event": event,
+				"async": map[string]any{"data": map[string]any{"id": "ord_1"}},
+			},
+			result:  true,
+			typ:     "bool",
+			unknown: []string{},
+		},
+		{
+			name:    "literals",
+			expr:    `event.data.total > 100 && event.data.items[0] == "a"`,
+			policy:  DefaultRestrictiveValidationPolicy(),
+			data:    map[string]any{"event": event},
+			result:  true,
+			typ:     "bool",
+			unknown: []string{},
+		},
+		{
+			name:   "policy violation",
+			expr:   `size(event.data.items) > 1`,
+			policy: DefaultRestrictiveValidationPolicy(),
+			data:   map[string]any{"event": event},
+			err:    &DryRunError{},
+		},
+		{
+			name: "compile error",
+			expr: "event.data.id ==\n  'a' &&",
+			err:  &DryRunError{Line: 2, Column: 9},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := DryRun(ctx, test.policy, test.expr, test.data)
+			require.NoError(t, err)
+
+			if test.err != nil {
+				require.NotNil(t, res.Error)
+				require.NotEmpty(t, res.Error.Message)
+				require.Equal(t, test.err.Line, res.Error.Line)
+				require.Equal(t, test.err.Column, res.Error.Column)
+				return
+			}
+
+			require.Nil(t, res.Error)
+			require.EqualValues(t, test.result, res.Result)
+			require.Equal(t, test.typ, res.Type)
+			require.Equal(t, test.unknown, res.Unknown)
+			if test.residual == "" {
+				require.Nil(t, res.Residual)
+			} else {
+				require.NotNil(t, res.Residual)
+				require.Equal(t, test.residual, *res.Residual)
+			}
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package exprenv
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/inngest/inngest/pkg/dateutil"
+	"github.com/karlseguin/ccache/v2"
 	"github.com/xhit/go-str2duration/v2"
 
 	// "github.com/google/cel-go/checker/decls"
@@ -226,6 +233,57 @@ func celDeclarations() []cel.EnvOption {
 			),
 		),
 
+		newFunctionEnvOption(
+			"trim",
+			decls.Overload(
+				"trim",
+				[]*types.Type{types.StringType},
+				types.StringType,
+			),
+		),
+		newFunctionEnvOption(
+			"split",
+			decls.Overload(
+				"split",
+				[]*types.Type{types.StringType, types.StringType},
+				types.NewListType(types.StringType),
+			),
+		),
+		newFunctionEnvOption(
+			"join",
+			decls.Overload(
+				"join",
+				[]*types.Type{types.NewListType(types.DynType), types.StringType},
+				types.StringType,
+			),
+		),
+		newFunctionEnvOption(
+			"regex_extract",
+			decls.Overload(
+				"regex_extract",
+				[]*types.Type{types.StringType, types.StringType},
+				types.StringType,
+			),
+		),
+
+		// Hashing functions, eg. for bucketing keys.
+		newFunctionEnvOption(
+			"sha256",
+			decls.Overload(
+				"sha256",
+				[]*types.Type{types.StringType},
+				types.StringType,
+			),
+		),
+		newFunctionEnvOption(
+			"hash",
+			decls.Overload(
+				"hash",
+				[]*types.Type{types.StringType},
+				types.IntType,
+			),
+		),
+
 		// Time functions
 		newFunctionEnvOption(
 			"date",
@@ -316,6 +374,78 @@ func CELOverloads() []*functions.Overload {
 				return types.NewStringInterfaceMap(types.DefaultTypeAdapter, mapped)
 			},
 		},
+		{
+			Operator: "trim",
+			Unary: func(i ref.Val) ref.Val {
+				str, _ := i.Value().(string)
+				return types.String(strings.TrimSpace(str))
+			},
+		},
+		{
+			Operator: "split",
+			Binary: func(lhs, rhs ref.Val) ref.Val {
+				str, _ := lhs.Value().(string)
+				sep, _ := rhs.Value().(string)
+				return types.NewStringList(types.DefaultTypeAdapter, strings.Split(str, sep))
+			},
+		},
+		{
+			Operator: "join",
+			Binary: func(lhs, rhs ref.Val) ref.Val {
+				list, ok := lhs.(traits.Lister)
+				if !ok {
+					return types.String("")
+				}
+				sep, _ := rhs.Value().(string)
+				parts := []string{}
+				for it := list.Iterator(); it.HasNext() == types.True; {
+					parts = append(parts, fmt.Sprintf("%v", it.Next().Value()))
+				}
+				return types.String(strings.Join(parts, sep))
+			},
+		},
+		{
+			Operator: "regex_extract",
+			Binary: func(lhs, rhs ref.Val) ref.Val {
+				// Returns the first capture group of the first match, or the
+				// entire match if the pattern has no capture groups.  An empty
+				// string is returned if nothing matches.
+				str, _ := lhs.Value().(string)
+				pattern, _ := rhs.Value().(string)
+				re, err := compileRegex(pattern)
+				if err != nil {
+					return types.NewErr("invalid regex: %s", err)
+				}
+				match := re.FindStringSubmatch(str)
+				switch len(match) {
+				case 0:
+					return types.String("")
+				case 1:
+					return types.String(match[0])
+				default:
+					return types.String(match[1])
+				}
+			},
+		},
+		{
+			Operator: "sha256",
+			Unary: func(i ref.Val) ref.Val {
+				str, _ := i.Value().(string)
+				sum := sha256.Sum256([]byte(str))
+				return types.String(hex.EncodeToString(sum[:]))
+			},
+		},
+		{
+			Operator: "hash",
+			Unary: func(i ref.Val) ref.Val {
+				// Returns a stable, non-negative int for bucketing, eg.
+				// `hash(event.data.user_id) % 10`.
+				str, _ := i.Value().(string)
+				h := fnv.New64a()
+				_, _ = h.Write([]byte(str))
+				return types.Int(int64(h.Sum64() & math.MaxInt64))
+			},
+		},
 		{
 			Operator: "now",
 			Function: func(args ...ref.Val) ref.Val {
@@ -358,3 +488,19 @@ func CELOverloads() []*functions.Overload {
 		},
 	}
 }
+
+// regexCache stores compiled patterns used within regex_extract, as the same
+// pattern is typically evaluated against many events.
+var regexCache = ccache.New(ccache.Configure().MaxSize(1_000))
+
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if item := regexCache.Get(pattern); item != nil {
+		return item.Value().(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Set(pattern, re, CacheTTL)
+	return re, nil
+}
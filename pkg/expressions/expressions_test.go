@@ -983,6 +983,79 @@ func TestEvaluateExpression(t *testing.T) {
 			// no error, but it doesn't match.
 			"",
 		},
+		// String, regex, hashing and list helpers
+		{
+			`lowercase(trim(event.data.email)) + "|" + split(event.data.id, "_")[0] + "|" + join(event.data.tags, ",")`,
+			map[string]interface{}{
+				"event": map[string]any{
+					"data": map[string]any{
+						"email": " Test@Example.com ",
+						"id":    "usr_123",
+						"tags":  []any{"a", "b"},
+					},
+				},
+			},
+			"test@example.com|usr|a,b",
+			nil,
+			false,
+			"",
+		},
+		{
+			`regex_extract(event.data.email, "@(.+)$") + regex_extract(event.data.email, "^nope")`,
+			map[string]interface{}{
+				"event": map[string]any{
+					"data": map[string]any{"email": "test@example.com"},
+				},
+			},
+			"example.com",
+			nil,
+			false,
+			"",
+		},
+		{
+			`sha256(event.data.id)`,
+			map[string]interface{}{
+				"event": map[string]any{
+					"data": map[string]any{"id": "ab_1"},
+				},
+			},
+			"87b164fa079a8d05ef206f23c2d176fc5630af7a50424333d14bcedc228c0f65",
+			nil,
+			false,
+			"",
+		},
+		{
+			`hash(event.data.id) == hash("ab_1") && hash(event.data.id) >= 0 && hash(event.data.id) % 4 < 4`,
+			map[string]interface{}{
+				"event": map[string]any{
+					"data": map[string]any{"id": "ab_1"},
+				},
+			},
+			true,
+			nil,
+			false,
+			"",
+		},
+		{
+			`event.data.tags.exists(t, t == "b") && event.data.tags.map(t, uppercase(t)) == ["A", "B"]`,
+			map[string]interface{}{
+				"event": map[string]any{
+					"data": map[string]any{"tags": []any{"a", "b"}},
+				},
+			},
+			true,
+			nil,
+			false,
+			"",
+		},
+		{
+			`duration("1h") + duration("30m") > duration("80m")`,
+			map[string]interface{}{},
+			true,
+			nil,
+			false,
+			"",
+		},
 		// Event data time manipulation
 		{
 			"date(event.from) + duration('6h35m10s') == date('2020-01-01T18:35:10')",
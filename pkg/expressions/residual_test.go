@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/inngest/expr"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,6 +92,41 @@ func TestInterpolate(t *testing.T) {
 				},
 			},
 		},
+		// helpers applied to event data are evaluated into literals
+		{
+			exprInput:    `sha256(event.data.id) == async.data.hash`,
+			exprExpected: `"87b164fa079a8d05ef206f23c2d176fc5630af7a50424333d14bcedc228c0f65" == async.data.hash`,
+			vars: map[string]any{
+				"event": map[string]any{
+					"data": map[string]any{
+						"id": "ab_1",
+					},
+				},
+			},
+		},
+		{
+			exprInput:    `lowercase(trim(event.data.email)) == async.data.email`,
+			exprExpected: `"test@example.com" == async.data.email`,
+			vars: map[string]any{
+				"event": map[string]any{
+					"data": map[string]any{
+						"email": " Test@Example.com ",
+					},
+				},
+			},
+		},
+		{
+			exprInput:    `event.data.tags.exists(t, t == "vip") && regex_extract(event.data.email, "@(.+)$") == async.data.domain`,
+			exprExpected: `"example.com" == async.data.domain`,
+			vars: map[string]any{
+				"event": map[string]any{
+					"data": map[string]any{
+						"tags":  []any{"new", "vip"},
+						"email": "test@example.com",
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -100,3 +136,26 @@ func TestInterpolate(t *testing.T) {
 		require.EqualValues(t, test.exprExpected, actual)
 	}
 }
+
+func TestInterpolateHelpersAreAggregatable(t *testing.T) {
+	ctx := context.Background()
+	vars := map[string]any{
+		"event": map[string]any{
+			"data": map[string]any{
+				"id":   "ab_1",
+				"tags": []any{"vip"},
+			},
+		},
+	}
+
+	interpolated, err := Interpolate(ctx, `event.data.tags.exists(t, t == "vip") && sha256(event.data.id) == async.data.hash`, vars)
+	require.NoError(t, err)
+
+	// Helpers and macros applied to event data are interpolated away, leaving
+	// a single predicate which can be matched by aggregate trees.
+	parsed, err := ParserSingleton().Parse(ctx, expr.StringExpression(interpolated))
+	require.NoError(t, err)
+	require.False(t, parsed.HasMacros)
+	require.NotNil(t, parsed.Root.Predicate)
+	require.Equal(t, "async.data.hash", parsed.Root.Predicate.Ident)
+}
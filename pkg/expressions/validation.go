@@ -3,6 +3,7 @@ package expressions
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"slices"
 
 	"github.com/google/cel-go/cel"
@@ -17,6 +18,12 @@ type ValidationPolicy struct {
 	DisallowNonLogicFns bool
 	AllowDateTimeFns    bool
 	AllowMathFns        bool
+	AllowStringFns      bool
+	AllowHashFns        bool
+	AllowListMacros     bool
+
+	// comprehension is set when validating the expanded steps of a list macro.
+	comprehension bool
 }
 
 func DefaultRestrictiveValidationPolicy() *ValidationPolicy {
@@ -44,6 +51,26 @@ func DefaultRestrictiveValidationPolicy() *ValidationPolicy {
 		// See the mathFnNames function in validation.go for a detailed list.
 		// If DisallowNonLogicFns is false, this option has no effect (i.e. all functions are allowed).
 		AllowMathFns: false,
+
+		// AllowStringFns determines whether string helpers are allowed.
+		// This option is only used if DisallowNonLogicFns is true; it adds some functions (e.g.
+		// lowercase, trim, split, regex_extract) to the set of allowed functions.
+		// See the stringFnNames function in validation.go for a detailed list.
+		// The helpers may only be applied to `event` data and literals, which are interpolated
+		// into literals before aggregate matching, keeping expressions on the fast path.
+		AllowStringFns: true,
+
+		// AllowHashFns determines whether hashing functions (sha256, hash) are allowed.
+		// This option is only used if DisallowNonLogicFns is true, and has the same restrictions
+		// as AllowStringFns.  The modulo of a hash is also allowed for bucketing, eg.
+		// `hash(event.data.id) % 10 == 3`, even if AllowMathFns is false.
+		AllowHashFns: true,
+
+		// AllowListMacros determines whether the list macros (all, exists, exists_one, map,
+		// filter) are allowed even if DisallowMacros is true.  Function calls within the macros
+		// are still validated, and macros may only be used with `event` data as macros over
+		// `async` data can't be aggregate matched.
+		AllowListMacros: false,
 	}
 }
 
@@ -66,6 +93,10 @@ func Validate(_ context.Context, policy *ValidationPolicy, expression string) er
 		return NewCompileError(err)
 	}
 
+	if err := validateRegexes(expr.GetExpr()); err != nil {
+		return newValidationErr(err)
+	}
+
 	if policy != nil {
 		if err = validateAST(policy, expr.GetExpr()); err != nil {
 			return newValidationErr(err)
@@ -75,6 +106,70 @@ func Validate(_ context.Context, policy *ValidationPolicy, expression string) er
 	return nil
 }
 
+// validateRegexes ensures that every literal pattern passed to a regex function
+// compiles, so that invalid patterns are caught when validating instead of erroring
+// each time the expression is evaluated.
+func validateRegexes(expr *celexprpb.Expr) (err error) {
+	walkExpr(expr, func(e *celexprpb.Expr) {
+		call := e.GetCallExpr()
+		if call == nil || !slices.Contains(regexFnNames(), call.GetFunction()) {
+			return
+		}
+		args := call.GetArgs()
+		pattern := args[len(args)-1].GetConstExpr()
+		if pattern == nil {
+			return
+		}
+		if _, rerr := regexp.Compile(pattern.GetStringValue()); rerr != nil {
+			err = multierror.Append(err, fmt.Errorf("invalid regex in '%s': %w", call.GetFunction(), rerr))
+		}
+	})
+	return err
+}
+
+// referencesAsync returns whether the given expression references `async` data,
+// which is only known when matching and can't be interpolated.
+func referencesAsync(expr *celexprpb.Expr) (found bool) {
+	walkExpr(expr, func(e *celexprpb.Expr) {
+		if e.GetIdentExpr().GetName() == "async" {
+			found = true
+		}
+	})
+	return found
+}
+
+// walkExpr calls f for every expression within the given AST.
+func walkExpr(expr *celexprpb.Expr, f func(e *celexprpb.Expr)) {
+	if expr == nil {
+		return
+	}
+	f(expr)
+	switch kind := expr.GetExprKind().(type) {
+	case *celexprpb.Expr_SelectExpr:
+		walkExpr(kind.SelectExpr.GetOperand(), f)
+	case *celexprpb.Expr_CallExpr:
+		walkExpr(kind.CallExpr.GetTarget(), f)
+		for _, arg := range kind.CallExpr.GetArgs() {
+			walkExpr(arg, f)
+		}
+	case *celexprpb.Expr_ListExpr:
+		for _, el := range kind.ListExpr.GetElements() {
+			walkExpr(el, f)
+		}
+	case *celexprpb.Expr_StructExpr:
+		for _, entry := range kind.StructExpr.GetEntries() {
+			walkExpr(entry.GetMapKey(), f)
+			walkExpr(entry.GetValue(), f)
+		}
+	case *celexprpb.Expr_ComprehensionExpr:
+		walkExpr(kind.ComprehensionExpr.GetIterRange(), f)
+		walkExpr(kind.ComprehensionExpr.GetAccuInit(), f)
+		walkExpr(kind.ComprehensionExpr.GetLoopCondition(), f)
+		walkExpr(kind.ComprehensionExpr.GetLoopStep(), f)
+		walkExpr(kind.ComprehensionExpr.GetResult(), f)
+	}
+}
+
 // validateAST iterates through an expression AST and checks for policy violations.
 //
 // It does not short-circuit after a violation is found; the returned error is a
@@ -88,10 +183,28 @@ func validateAST(policy *ValidationPolicy, expr *celexprpb.Expr) (violations err
 	case *celexprpb.Expr_ComprehensionExpr:
 		// ComprehensionKind represents a comprehension expression generated by a macro.
 		thisExp := expr.GetComprehensionExpr()
-		if policy.DisallowMacros {
+		if policy.DisallowMacros && !policy.AllowListMacros {
 			violations = multierror.Append(violations,
 				fmt.Errorf("macros are disallowed (contains '%s')",
 					thisExp.String()))
+			break
+		}
+		if policy.AllowListMacros {
+			if referencesAsync(expr) {
+				violations = multierror.Append(violations,
+					fmt.Errorf("macros may only be used with event data"))
+				break
+			}
+			// Validate the list and the macro's steps, which contain the
+			// user's predicate or transform.  Macros are expanded using
+			// internal functions which are always allowed.
+			inner := *policy
+			inner.comprehension = true
+			for _, e := range []*celexprpb.Expr{thisExp.GetIterRange(), thisExp.GetLoopStep()} {
+				if innerErrs := validateAST(&inner, e); innerErrs != nil {
+					violations = multierror.Append(violations, innerErrs)
+				}
+			}
 		}
 	case *celexprpb.Expr_SelectExpr:
 		thisExp := expr.GetSelectExpr()
@@ -119,6 +232,16 @@ func validateAST(policy *ValidationPolicy, expr *celexprpb.Expr) (violations err
 				// intentional no-op: this is allowed
 			} else if policy.AllowMathFns && slices.Contains(mathFnNames(), fnName) {
 				// intentional no-op: this is allowed
+			} else if policy.AllowHashFns && fnName == celops.Modulo && isHashCall(thisExp.GetArgs()[0]) {
+				// intentional no-op: bucketing hashes is allowed
+			} else if (policy.AllowStringFns && slices.Contains(stringFnNames(), fnName)) ||
+				(policy.AllowHashFns && slices.Contains(hashFnNames(), fnName)) {
+				if referencesAsync(expr) {
+					violations = multierror.Append(violations,
+						fmt.Errorf("'%s' may only be used with event data", fnName))
+				}
+			} else if policy.comprehension && slices.Contains(comprehensionFnNames(), fnName) {
+				// intentional no-op: this is allowed
 			} else {
 				violations = multierror.Append(violations,
 					fmt.Errorf("function calls are disallowed (uses '%s')",
@@ -137,6 +260,11 @@ func validateAST(policy *ValidationPolicy, expr *celexprpb.Expr) (violations err
 	return
 }
 
+// isHashCall returns whether the given expression calls a hashing function.
+func isHashCall(expr *celexprpb.Expr) bool {
+	return slices.Contains(hashFnNames(), expr.GetCallExpr().GetFunction())
+}
+
 func macroFnNames() []string {
 	return []string{
 		celops.Has,
@@ -186,6 +314,39 @@ func typeConvFnNames() []string {
 	}
 }
 
+func stringFnNames() []string {
+	return []string{
+		"lowercase",
+		"uppercase",
+		"trim",
+		"split",
+		"join",
+		"regex_extract",
+	}
+}
+
+func hashFnNames() []string {
+	return []string{
+		"sha256",
+		"hash",
+	}
+}
+
+func regexFnNames() []string {
+	return []string{
+		"matches",
+		"regex_extract",
+	}
+}
+
+// comprehensionFnNames are internal functions used when expanding list macros.
+func comprehensionFnNames() []string {
+	return []string{
+		"@not_strictly_false",
+		celops.Add,
+	}
+}
+
 func dateTimeFnNames() []string {
 	return []string{
 		"date",
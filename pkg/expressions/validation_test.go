@@ -10,6 +10,9 @@ import (
 func Test_Validation(t *testing.T) {
 	t.Parallel()
 
+	listMacroPolicy := DefaultRestrictiveValidationPolicy()
+	listMacroPolicy.AllowListMacros = true
+
 	tests := []struct {
 		expr        string
 		policy      *ValidationPolicy
@@ -230,6 +233,111 @@ func Test_Validation(t *testing.T) {
 			policy:      DefaultRestrictiveValidationPolicy(),
 			expectValid: false,
 		},
+		{
+			expr:        `event.data.tags.all(x, x != '') && event.data.tags.map(x, lowercase(x)) == async.data.tags`,
+			policy:      &ValidationPolicy{DisallowMacros: true, DisallowNonLogicFns: true, AllowStringFns: true, AllowListMacros: true},
+			expectValid: true,
+		},
+		{
+			expr:        `event.data.tags.exists(x, x.endsWith('a'))`,
+			policy:      &ValidationPolicy{DisallowMacros: true, DisallowNonLogicFns: true, AllowListMacros: true},
+			expectValid: false,
+		},
+		{
+			expr:        `lowercase(async.data.email) == event.data.email`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: false,
+		},
+		{
+			expr:        `event.data.title.matches('[a-z')`,
+			policy:      nil,
+			expectValid: false,
+		},
+		{
+			expr:        `regex_extract(event.data.email, '@(.+)$') == async.data.domain`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: true,
+		},
+		{
+			expr:        `regex_extract(event.data.email, '(') == async.data.domain`,
+			policy:      nil,
+			expectValid: false,
+		},
+		{
+			expr:        `lowercase(trim(event.data.email)) == async.data.email && split(event.data.id, '_')[0] == 'usr'`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: true,
+		},
+		{
+			expr:        `join(event.data.tags, ',') == async.data.tags`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: true,
+		},
+		{
+			expr:        `sha256(event.data.id) == async.data.hash`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: true,
+		},
+		{
+			expr:        `hash(event.data.id) % 10 == 3`,
+			policy:      nil,
+			expectValid: true,
+		},
+		{
+			expr:        `hash(event.data.id) % 10 == 3`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: true,
+		},
+		{
+			expr:        `event.data.count % 10 == 3`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: false,
+		},
+		{
+			expr:        `hash(event.data.id) + 10 == 3`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: false,
+		},
+		{
+			expr:        `hash(async.data.id) % 10 == 3`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: false,
+		},
+		{
+			expr:        `event.data.tags.all(x, x != '') && event.data.tags.map(x, lowercase(x)) == async.data.tags`,
+			policy:      DefaultRestrictiveValidationPolicy(),
+			expectValid: false,
+		},
+		{
+			expr:        `event.data.tags.all(x, x != '') && event.data.tags.map(x, lowercase(x)) == async.data.tags`,
+			policy:      listMacroPolicy,
+			expectValid: true,
+		},
+		{
+			expr:        "event.data.tags.exists(x, x == 'd') == false",
+			policy:      listMacroPolicy,
+			expectValid: true,
+		},
+		{
+			expr:        `async.data.items.exists(x, x == event.data.id)`,
+			policy:      listMacroPolicy,
+			expectValid: false,
+		},
+		{
+			expr:        `event.data.items.exists(x, x == async.data.id)`,
+			policy:      listMacroPolicy,
+			expectValid: false,
+		},
+		{
+			expr:        "[1, 2, 3].all(x, x > 0) && event.status == '200'",
+			policy:      listMacroPolicy,
+			expectValid: true,
+		},
+		{
+			expr:        `date(event.data.ts) + duration('1h') > now()`,
+			policy:      nil,
+			expectValid: true,
+		},
 		{
 			expr:        "event.data.some.unknown.contains(event.data.title)",
 			policy:      nil,
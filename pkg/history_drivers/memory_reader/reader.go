@@ -231,9 +231,10 @@ func toRunHistory(item history.History) (*history_reader.RunHistory, error) {
 	var cancel *history_reader.RunHistoryCancel
 	if item.Cancel != nil {
 		cancel = &history_reader.RunHistoryCancel{
-			EventID:    item.Cancel.EventID,
-			Expression: item.Cancel.Expression,
-			UserID:     item.Cancel.UserID,
+			EventID:         item.Cancel.EventID,
+			Expression:      item.Cancel.Expression,
+			UserID:          item.Cancel.UserID,
+			ReplacedByRunID: item.Cancel.ReplacedByRunID,
 		}
 	}
 
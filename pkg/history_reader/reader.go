@@ -305,9 +305,10 @@ type RunHistory struct {
 }
 
 type RunHistoryCancel struct {
-	EventID    *ulid.ULID `json:"eventID"`
-	Expression *string    `json:"expression"`
-	UserID     *uuid.UUID `json:"userID"`
+	EventID         *ulid.ULID `json:"eventID"`
+	Expression      *string    `json:"expression"`
+	UserID          *uuid.UUID `json:"userID"`
+	ReplacedByRunID *ulid.ULID `json:"replacedByRunID"`
 }
 
 type RunHistoryResult struct {
@@ -39,9 +39,15 @@ func NewEventBatchConfig(conf map[string]any) (*EventBatchConfig, error) {
 // is fulfilled
 // - The batch is full
 // - The time to wait is up
+// - An appended event matches the FlushIf expression
 type EventBatchConfig struct {
 	Key *string `json:"key,omitempty"`
 
+	// FlushIf is an optional expression evaluated against each appended event.
+	// If the expression is true the batch, including the event, is started
+	// immediately, eg. `event.data.final == true`.
+	FlushIf *string `json:"flushIf,omitempty"`
+
 	// MaxSize is the maximum number of events that can be
 	// included in a batch
 	MaxSize int `json:"maxSize"`
@@ -89,5 +95,14 @@ func (c EventBatchConfig) IsValid(ctx context.Context) error {
 		}
 	}
 
+	if c.FlushIf != nil {
+		if exprErr := expressions.Validate(ctx, nil, *c.FlushIf); exprErr != nil {
+			return syscode.Error{
+				Code:    syscode.CodeBatchFlushIfInvalid,
+				Message: fmt.Sprintf("batch flushIf expression is invalid: %s", exprErr),
+			}
+		}
+	}
+
 	return nil
 }
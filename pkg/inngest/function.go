@@ -244,16 +244,32 @@ type Priority struct {
 }
 
 type Debounce struct {
-	Key     *string `json:"key,omitempty"`
-	Period  string  `json:"period"`
+	Key    *string `json:"key,omitempty"`
+	Period string  `json:"period"`
+	// Timeout is the maximum time a debounce can be extended by new events.  Once
+	// the timeout passes, the debounced function runs regardless of new events.
 	Timeout *string `json:"timeout,omitempty"`
+	// MaxWait is an alias for Timeout.  Timeout takes precedence if both are set.
+	MaxWait *string `json:"maxWait,omitempty"`
+	// Mode determines whether the function runs with the last event once the period
+	// passes (`trailing`, the default) or immediately with the first event (`leading`).
+	Mode enums.DebounceMode `json:"mode,omitempty"`
+}
+
+// timeout returns the configured timeout string, preferring Timeout over MaxWait.
+func (d Debounce) timeout() *string {
+	if d.Timeout != nil && *d.Timeout != "" {
+		return d.Timeout
+	}
+	return d.MaxWait
 }
 
 func (d Debounce) TimeoutDuration() *time.Duration {
-	if d.Timeout == nil || *d.Timeout == "" {
+	timeout := d.timeout()
+	if timeout == nil || *timeout == "" {
 		return nil
 	}
-	if dur, err := str2duration.ParseDuration(*d.Timeout); err == nil {
+	if dur, err := str2duration.ParseDuration(*timeout); err == nil {
 		return &dur
 	}
 	return nil
@@ -354,6 +370,10 @@ func (f Function) Validate(ctx context.Context) error {
 			err = multierror.Append(err, fmt.Errorf("All steps must have a name"))
 		}
 
+		if step.Timeout != nil && *step.Timeout != "" && step.TimeoutDuration() == nil {
+			err = multierror.Append(err, fmt.Errorf("Step timeout must be a valid, positive duration: %s", *step.Timeout))
+		}
+
 		uri, serr := url.Parse(step.URI)
 		if serr != nil {
 			err = multierror.Append(err, fmt.Errorf("Steps must have a valid URI"))
@@ -416,6 +436,15 @@ func (f Function) Validate(ctx context.Context) error {
 		if period > consts.MaxDebouncePeriod {
 			err = multierror.Append(err, fmt.Errorf("The debounce period of '%s' is greater than the max of: %s", f.Debounce.Period, consts.MaxDebouncePeriod))
 		}
+		if !f.Debounce.Mode.IsADebounceMode() {
+			err = multierror.Append(err, fmt.Errorf("Debounce mode '%d' is invalid", f.Debounce.Mode))
+		}
+	}
+
+	if f.Singleton != nil {
+		if serr := f.Singleton.Validate(ctx); serr != nil {
+			err = multierror.Append(err, serr)
+		}
 	}
 
 	// Validate rate limit expression
@@ -516,3 +545,17 @@ type Singleton struct {
 	// Use `skip` to skip the new run, or `cancel` to stop the current instance and run the new one.
 	Mode enums.SingletonMode `json:"mode"`
 }
+
+// Validate returns an error if the singleton mode or key expression is invalid.
+func (s Singleton) Validate(ctx context.Context) error {
+	var err error
+	if !s.Mode.IsASingletonMode() {
+		err = multierror.Append(err, fmt.Errorf("Singleton mode '%d' is invalid", s.Mode))
+	}
+	if s.Key != nil && *s.Key != "" {
+		if exprErr := expressions.Validate(ctx, nil, *s.Key); exprErr != nil {
+			err = multierror.Append(err, fmt.Errorf("Singleton expression is invalid: %s", exprErr))
+		}
+	}
+	return err
+}
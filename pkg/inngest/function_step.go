@@ -2,8 +2,10 @@ package inngest
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/inngest/inngest/pkg/consts"
+	"github.com/xhit/go-str2duration/v2"
 )
 
 // Step represents a single unit of code (action) which runs as part of a step function, in a DAG.
@@ -22,6 +24,25 @@ type Step struct {
 	// ConcurrencyKey allows steps to share concurrency slots across multiple functions, eg. for
 	// rate limiting across multiple functions.
 	ConcurrencyKey *string `json:"concurrencyKey,omitempty"`
+
+	// Timeout optionally limits how long a single request to this step may run
+	// before the attempt is failed with a StepTimeoutError.  This is capped by
+	// consts.MaxFunctionTimeout.
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// TimeoutDuration returns the parsed step timeout, or nil if no valid timeout
+// is set.
+func (s Step) TimeoutDuration() *time.Duration {
+	if s.Timeout == nil || *s.Timeout == "" {
+		return nil
+	}
+	dur, err := str2duration.ParseDuration(*s.Timeout)
+	if err != nil || dur <= 0 {
+		return nil
+	}
+	dur = min(dur, consts.MaxFunctionTimeout)
+	return &dur
 }
 
 // RetryCount returns the number of retries for this step.
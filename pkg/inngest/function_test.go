@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/inngest/inngest/pkg/consts"
+	"github.com/inngest/inngest/pkg/enums"
 	"github.com/stretchr/testify/require"
 )
 
@@ -71,6 +73,31 @@ func TestValidate(t *testing.T) {
 			require.Contains(t, err.Error(), "Non-supported step schema: htt")
 		})
 
+		t.Run("With an invalid step timeout", func(t *testing.T) {
+			f := Function{
+				Name: "hi",
+				Triggers: []Trigger{
+					{
+						EventTrigger: &EventTrigger{
+							Event: "fail",
+						},
+					},
+				},
+				Steps: []Step{
+					{
+						ID:      "step",
+						Name:    "Function body",
+						URI:     "http://lol/what.xml.api",
+						Timeout: strptr("nope"),
+					},
+				},
+			}
+
+			err := f.Validate(context.Background())
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "Step timeout must be a valid, positive duration")
+		})
+
 		t.Run("With an invalid cache expression", func(t *testing.T) {
 			f := Function{
 				Name: "hi",
@@ -119,6 +146,86 @@ func TestValidate(t *testing.T) {
 			require.NotNil(t, err)
 			require.Contains(t, err.Error(), "Functions must contain one step")
 		})
+
+		t.Run("With an invalid singleton mode", func(t *testing.T) {
+			f := Function{
+				Name: "hi",
+				Triggers: []Trigger{
+					{
+						EventTrigger: &EventTrigger{
+							Event: "fail",
+						},
+					},
+				},
+				Singleton: &Singleton{
+					Mode: enums.SingletonMode(99),
+				},
+				Steps: []Step{
+					{
+						ID:   "step",
+						Name: "Function body",
+						URI:  "http://lol/what.xml.api",
+					},
+				},
+			}
+
+			err := f.Validate(context.Background())
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "Singleton mode '99' is invalid")
+		})
+
+		t.Run("With an invalid cron timezone", func(t *testing.T) {
+			err := CronTrigger{Cron: "TZ=Europe/Nowhere 0 9 * * *"}.Validate(context.Background())
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "'Europe/Nowhere' isn't a valid cron timezone")
+		})
+
+		t.Run("With an invalid cron jitter", func(t *testing.T) {
+			err := CronTrigger{Cron: "0 * * * *", Jitter: strptr("2h")}.Validate(context.Background())
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "greater than the max")
+		})
+	})
+
+	t.Run("With a cron timezone and jitter", func(t *testing.T) {
+		for _, expr := range []string{"TZ=Europe/Berlin 0 9 * * 1-5", "CRON_TZ=America/New_York 30 2 * * *"} {
+			c := CronTrigger{Cron: expr, Jitter: strptr("30s")}
+			require.NoError(t, c.Validate(context.Background()))
+			require.Equal(t, 30*time.Second, c.JitterDuration())
+		}
+		require.Equal(t, "Europe/Berlin", CronTrigger{Cron: "TZ=Europe/Berlin 0 9 * * *"}.Timezone())
+	})
+
+	t.Run("With an empty cron jitter", func(t *testing.T) {
+		c := CronTrigger{Cron: "0 * * * *", Jitter: strptr("")}
+		require.NoError(t, c.Validate(context.Background()))
+		require.Zero(t, c.JitterDuration())
+	})
+
+	t.Run("With a cancel singleton", func(t *testing.T) {
+		f := Function{
+			Name: "hi",
+			Triggers: []Trigger{
+				{
+					EventTrigger: &EventTrigger{
+						Event: "ok",
+					},
+				},
+			},
+			Singleton: &Singleton{
+				Key:  strptr("event.data.user.id"),
+				Mode: enums.SingletonModeCancel,
+			},
+			Steps: []Step{
+				{
+					ID:   "step",
+					Name: "Function body",
+					URI:  "http://lol/what.xml.api",
+				},
+			},
+		}
+
+		require.NoError(t, f.Validate(context.Background()))
 	})
 }
 
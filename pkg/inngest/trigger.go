@@ -5,12 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
+
+	// Embed the timezone database so that cron timezones can always be loaded.
+	_ "time/tzdata"
 
 	"github.com/cespare/xxhash/v2"
 	"github.com/hashicorp/go-multierror"
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/expressions"
 	cron "github.com/robfig/cron/v3"
+	"github.com/xhit/go-str2duration/v2"
 )
 
 // Triggerable represents a single or multiple triggers for a function.
@@ -148,15 +153,65 @@ func (e EventTrigger) Validate(ctx context.Context) error {
 
 // CronTrigger is a trigger which invokes the function on a CRON schedule.
 type CronTrigger struct {
+	// Cron is the cron expression, optionally prefixed with a timezone via
+	// "TZ=Europe/Berlin" or "CRON_TZ=Europe/Berlin".  Expressions without a
+	// timezone are evaluated in UTC.
 	Cron string `json:"cron"`
+
+	// Jitter is an optional duration used to delay each tick by a stable,
+	// per-function offset of up to the given duration.  This spreads functions
+	// with the same schedule instead of starting them all at once.
+	Jitter *string `json:"jitter,omitempty"`
+}
+
+// Timezone returns the timezone prefix of the cron expression, or an empty
+// string if the expression has no timezone.
+func (c CronTrigger) Timezone() string {
+	for _, prefix := range []string{"TZ=", "CRON_TZ="} {
+		if strings.HasPrefix(c.Cron, prefix) {
+			tz, _, _ := strings.Cut(strings.TrimPrefix(c.Cron, prefix), " ")
+			return tz
+		}
+	}
+	return ""
+}
+
+// JitterDuration returns the parsed jitter, or zero if no valid jitter is set.
+func (c CronTrigger) JitterDuration() time.Duration {
+	if c.Jitter == nil || *c.Jitter == "" {
+		return 0
+	}
+	if dur, err := str2duration.ParseDuration(*c.Jitter); err == nil {
+		return dur
+	}
+	return 0
 }
 
 func (c CronTrigger) Validate(ctx context.Context) error {
+	if tz := c.Timezone(); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("'%s' isn't a valid cron timezone", tz)
+		}
+	}
+
 	_, err := cron.
 		NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).
 		Parse(c.Cron)
 	if err != nil {
 		return fmt.Errorf("'%s' isn't a valid cron schedule", c.Cron)
 	}
+
+	if c.Jitter != nil && *c.Jitter != "" {
+		jitter, err := str2duration.ParseDuration(*c.Jitter)
+		if err != nil {
+			return fmt.Errorf("The cron jitter of '%s' is invalid: %w", *c.Jitter, err)
+		}
+		if jitter < 0 {
+			return fmt.Errorf("The cron jitter of '%s' must not be negative", *c.Jitter)
+		}
+		if jitter > consts.MaxCronJitter {
+			return fmt.Errorf("The cron jitter of '%s' is greater than the max of: %s", *c.Jitter, consts.MaxCronJitter)
+		}
+	}
 	return nil
 }
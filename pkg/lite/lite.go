@@ -2,6 +2,7 @@ package lite
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -29,12 +30,19 @@ import (
 	connstate "github.com/inngest/inngest/pkg/connect/state"
 	"github.com/inngest/inngest/pkg/consts"
 	"github.com/inngest/inngest/pkg/coreapi"
+	"github.com/inngest/inngest/pkg/cqrs"
 	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
 	"github.com/inngest/inngest/pkg/devserver"
 	"github.com/inngest/inngest/pkg/enums"
 	"github.com/inngest/inngest/pkg/event"
+	"github.com/inngest/inngest/pkg/eventdedup"
+	"github.com/inngest/inngest/pkg/eventkey"
+	"github.com/inngest/inngest/pkg/eventroute"
+	"github.com/inngest/inngest/pkg/eventschema"
 	"github.com/inngest/inngest/pkg/execution"
 	"github.com/inngest/inngest/pkg/execution/batch"
+	"github.com/inngest/inngest/pkg/execution/cron"
+	"github.com/inngest/inngest/pkg/execution/deadletter"
 	"github.com/inngest/inngest/pkg/execution/debounce"
 	"github.com/inngest/inngest/pkg/execution/driver"
 	"github.com/inngest/inngest/pkg/execution/driver/httpdriver"
@@ -42,8 +50,13 @@ import (
 	"github.com/inngest/inngest/pkg/execution/history"
 	"github.com/inngest/inngest/pkg/execution/queue"
 	"github.com/inngest/inngest/pkg/execution/ratelimit"
+	"github.com/inngest/inngest/pkg/execution/realtime"
+	"github.com/inngest/inngest/pkg/execution/replay"
 	"github.com/inngest/inngest/pkg/execution/runner"
+	"github.com/inngest/inngest/pkg/execution/scheduledevent"
+	"github.com/inngest/inngest/pkg/execution/singleton"
 	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/postgres_state"
 	"github.com/inngest/inngest/pkg/execution/state/redis_state"
 	sv2 "github.com/inngest/inngest/pkg/execution/state/v2"
 	"github.com/inngest/inngest/pkg/expressions"
@@ -74,6 +87,11 @@ type StartOpts struct {
 	RetryInterval int           `json:"retry_interval"`
 	QueueWorkers  int           `json:"queue_workers"`
 
+	// PostgresQueue runs the queue and its dependencies on Postgres instead of
+	// Redis.  This requires PostgresURI, and functions using flow control which
+	// the Postgres queue doesn't enforce are rejected when synced.
+	PostgresQueue bool `json:"postgres-queue"`
+
 	// SigningKey is used to decide that the server should sign requests and
 	// validate responses where applicable, modelling cloud behaviour.
 	SigningKey string `json:"signing_key"`
@@ -84,6 +102,39 @@ type StartOpts struct {
 	EventKey []string `json:"event_key"`
 
 	ConnectGatewayPort int `json:"connect-gateway-port"`
+
+	// CronCatchUp defines how cron ticks missed while the server was down are
+	// handled.
+	CronCatchUp enums.CronCatchUp `json:"cron-catch-up"`
+
+	// EventSchemas is an optional path to a JSON file mapping event names to
+	// JSON Schemas which events are validated against at ingest.
+	EventSchemas string `json:"event-schemas"`
+	// EventSchemaMode determines how events failing schema validation are
+	// handled at ingest.
+	EventSchemaMode eventschema.Mode `json:"event-schema-mode"`
+
+	// EventDedupWindow is the window in which events sent with the same ID
+	// are deduplicated.  When enabled, events reusing the ID of an event sent
+	// within the window are acknowledged with the original event's internal ID
+	// but are not published, so functions won't run for them.  This is zero,
+	// disabling deduplication, by default.
+	EventDedupWindow time.Duration `json:"event-dedup-window"`
+
+	// EventScheduleMinDelay is the minimum time an event's timestamp must be
+	// in the future for the event to be held until that time.  Events with
+	// earlier timestamps are published immediately, tolerating clock skew
+	// between senders and the server.
+	EventScheduleMinDelay time.Duration `json:"event-schedule-min-delay"`
+
+	// RealtimeRoutes is an optional path to a JSON file of routes which publish
+	// matching events to realtime channels as they're delivered.
+	RealtimeRoutes string `json:"realtime-routes"`
+
+	// PriorityFactorMin and PriorityFactorMax limit the run priority factors of
+	// functions, in seconds.  When both are zero, only the limits in consts apply.
+	PriorityFactorMin int64 `json:"priority-factor-min"`
+	PriorityFactorMax int64 `json:"priority-factor-max"`
 }
 
 // Create and start a new dev server.  The dev server is used during (surprise surprise)
@@ -106,80 +157,101 @@ func New(ctx context.Context, opts StartOpts) error {
 	return start(ctx, opts)
 }
 
-func start(ctx context.Context, opts StartOpts) error {
-	l := logger.StdlibLogger(ctx)
-	ctx = logger.WithStdlib(ctx, l)
+// backends are the run state, the queue, and the queue's dependencies.
+type backends struct {
+	sm            state.Manager
+	smv2          sv2.RunService
+	queue         redis_state.QueueManager
+	queueShard    redis_state.QueueShard
+	shardSelector redis_state.ShardSelector
+	rl            ratelimit.RateLimiter
+	batcher       batch.BatchManager
+	debouncer     debounce.Debouncer
+	singleton     singleton.Singleton
+	crons         cron.CronManager
+	dedup         eventdedup.Deduplicator
+}
 
-	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{
-		InMemory:    false,
-		PostgresURI: opts.PostgresURI,
-		Directory:   opts.SQLiteDir,
-	})
-	if err != nil {
-		return err
-	}
+// redisClients are the Redis clients used by the Redis backends.
+type redisClients struct {
+	sharded     *redis_state.ShardedClient
+	unsharded   *redis_state.UnshardedClient
+	unshardedRc rueidis.Client
+}
+
+// newBackends returns the run state, queue, and the queue's dependencies.  Run
+// state is kept in Postgres when a Postgres URI is configured, and the queue and
+// its dependencies are kept in Postgres when the Postgres queue is enabled.  Both
+// are kept in Redis otherwise.
+func newBackends(ctx context.Context, opts StartOpts, db *sql.DB, dbcqrs cqrs.Manager, rc redisClients) (*backends, error) {
+	l := logger.StdlibLogger(ctx)
 
 	tick := opts.Tick
 	if tick < 1 {
 		tick = devserver.DefaultTickDuration
 	}
 
-	// Initialize the devserver
-	dbDriver := "sqlite"
-	if opts.PostgresURI != "" {
-		dbDriver = "postgres"
-	}
-	dbcqrs := base_cqrs.NewCQRS(db, dbDriver)
-	hd := base_cqrs.NewHistoryDriver(db, dbDriver)
-	hr := base_cqrs.NewHistoryReader(db, dbDriver)
-	loader := dbcqrs.(state.FunctionLoader)
-
-	stepLimitOverrides := make(map[string]int)
-	stateSizeLimitOverrides := make(map[string]int)
-
-	shardedRc, err := connectToOrCreateRedis(opts.RedisURI)
-	if err != nil {
-		return err
-	}
-
-	unshardedRc, err := connectToOrCreateRedis(opts.RedisURI)
-	if err != nil {
-		return err
-	}
-
-	unshardedClient := redis_state.NewUnshardedClient(unshardedRc, redis_state.StateDefaultKey, redis_state.QueueDefaultKey)
-	shardedClient := redis_state.NewShardedClient(redis_state.ShardedClientOpts{
-		UnshardedClient:        unshardedClient,
-		FunctionRunStateClient: shardedRc,
-		StateDefaultKey:        redis_state.StateDefaultKey,
-		FnRunIsSharded:         redis_state.AlwaysShardOnRun,
-		BatchClient:            shardedRc,
-		QueueDefaultKey:        redis_state.QueueDefaultKey,
-	})
+	if opts.PostgresQueue {
+		if opts.PostgresURI == "" {
+			return nil, fmt.Errorf("the postgres queue requires a postgres URI")
+		}
 
-	connectRcOpt, err := connectToOrCreateRedisOption(opts.RedisURI)
-	if err != nil {
-		return err
-	}
+		sm, err := postgres_state.New(ctx, db)
+		if err != nil {
+			return nil, fmt.Errorf("error creating postgres state: %w", err)
+		}
 
-	connectRc, err := rueidis.NewClient(connectRcOpt)
-	if err != nil {
-		return err
+		q := postgres_state.NewQueue(
+			db,
+			postgres_state.WithIdempotencyTTL(time.Hour),
+			postgres_state.WithNumWorkers(int32(opts.QueueWorkers)),
+			postgres_state.WithPollTick(tick),
+			postgres_state.WithConcurrencyLimit(func(ctx context.Context, fnID uuid.UUID) int {
+				return functionConcurrencyLimit(ctx, dbcqrs, fnID)
+			}),
+		)
+		queueShard := postgres_state.QueueShard
+
+		return &backends{
+			sm:         sm,
+			smv2:       postgres_state.MustRunServiceV2(sm),
+			queue:      q,
+			queueShard: queueShard,
+			shardSelector: func(ctx context.Context, _ uuid.UUID, _ *string) (redis_state.QueueShard, error) {
+				return queueShard, nil
+			},
+			rl:        ratelimit.NewPostgres(db),
+			batcher:   batch.NewPostgresBatchManager(db, q, batch.WithLogger(l)),
+			debouncer: debounce.NewPostgresDebouncer(db, queueShard, q),
+			singleton: singleton.NewPostgres(db),
+			crons:     cron.NewPostgresCronManager(db, q, cron.WithLogger(l), cron.WithCatchUp(opts.CronCatchUp)),
+			dedup:     eventdedup.NewPostgresDeduplicator(db, eventdedup.StaticWindow(opts.EventDedupWindow)),
+		}, nil
 	}
 
-	var sm state.Manager
-	t := runner.NewTracker()
-	sm, err = redis_state.New(
-		ctx,
-		redis_state.WithShardedClient(shardedClient),
-		redis_state.WithUnshardedClient(unshardedClient),
+	var (
+		sm   state.Manager
+		smv2 sv2.RunService
+		err  error
 	)
-	if err != nil {
-		return err
+	if opts.PostgresURI != "" {
+		if sm, err = postgres_state.New(ctx, db); err != nil {
+			return nil, fmt.Errorf("error creating postgres state: %w", err)
+		}
+		smv2 = postgres_state.MustRunServiceV2(sm)
+	} else {
+		sm, err = redis_state.New(
+			ctx,
+			redis_state.WithShardedClient(rc.sharded),
+			redis_state.WithUnshardedClient(rc.unsharded),
+		)
+		if err != nil {
+			return nil, err
+		}
+		smv2 = redis_state.MustRunServiceV2(sm)
 	}
-	smv2 := redis_state.MustRunServiceV2(sm)
 
-	queueShard := redis_state.QueueShard{Name: consts.DefaultQueueShardName, RedisClient: unshardedClient.Queue(), Kind: string(enums.QueueShardKindRedis)}
+	queueShard := redis_state.QueueShard{Name: consts.DefaultQueueShardName, RedisClient: rc.unsharded.Queue(), Kind: string(enums.QueueShardKindRedis)}
 
 	shardSelector := func(ctx context.Context, _ uuid.UUID, _ *string) (redis_state.QueueShard, error) {
 		return queueShard, nil
@@ -234,23 +306,10 @@ func start(ctx context.Context, opts StartOpts) error {
 					FunctionLimit:  consts.DefaultConcurrencyLimit,
 					CustomKeyLimit: consts.DefaultConcurrencyLimit,
 				}
-
 				// Ensure that we return the correct concurrency values per partition.
-				funcs, err := dbcqrs.GetFunctions(ctx)
-				if err != nil {
-					return limits
-				}
-				for _, fn := range funcs {
-					f, _ := fn.InngestFunction()
-					if f.ID == uuid.Nil {
-						f.ID = f.DeterministicUUID()
-					}
-					if p.FunctionID != nil && f.ID == *p.FunctionID && f.Concurrency != nil && f.Concurrency.PartitionConcurrency() > 0 {
-						limits.FunctionLimit = f.Concurrency.PartitionConcurrency()
-						return limits
-					}
+				if p.FunctionID != nil {
+					limits.FunctionLimit = functionConcurrencyLimit(ctx, dbcqrs, *p.FunctionID)
 				}
-
 				return limits
 			}),
 		redis_state.WithShardSelector(shardSelector),
@@ -261,12 +320,150 @@ func start(ctx context.Context, opts StartOpts) error {
 
 	rq := redis_state.NewQueue(queueShard, queueOpts...)
 
-	rl := ratelimit.New(ctx, unshardedRc, "{ratelimit}:")
+	return &backends{
+		sm:            sm,
+		smv2:          smv2,
+		queue:         rq,
+		queueShard:    queueShard,
+		shardSelector: shardSelector,
+		rl:            ratelimit.New(ctx, rc.unshardedRc, "{ratelimit}:"),
+		batcher:       batch.NewRedisBatchManager(rc.sharded.Batch(), rq, batch.WithLogger(l)),
+		debouncer:     debounce.NewRedisDebouncer(rc.unsharded.Debounce(), queueShard, rq),
+		singleton:     singleton.New(ctx, queueShard.RedisClient),
+		crons:         cron.NewRedisCronManager(queueShard.RedisClient, rq, cron.WithLogger(l), cron.WithCatchUp(opts.CronCatchUp)),
+		dedup:         eventdedup.NewRedisDeduplicator(rc.unshardedRc, "{dedup}:", eventdedup.StaticWindow(opts.EventDedupWindow)),
+	}, nil
+}
+
+// functionConcurrencyLimit returns the partition concurrency limit of the given
+// function, or the default limit if the function has none.
+func functionConcurrencyLimit(ctx context.Context, dbcqrs cqrs.Manager, fnID uuid.UUID) int {
+	funcs, err := dbcqrs.GetFunctions(ctx)
+	if err != nil {
+		return consts.DefaultConcurrencyLimit
+	}
+	for _, fn := range funcs {
+		f, _ := fn.InngestFunction()
+		if f.ID == uuid.Nil {
+			f.ID = f.DeterministicUUID()
+		}
+		if f.ID == fnID && f.Concurrency != nil && f.Concurrency.PartitionConcurrency() > 0 {
+			return f.Concurrency.PartitionConcurrency()
+		}
+	}
+	return consts.DefaultConcurrencyLimit
+}
+
+func start(ctx context.Context, opts StartOpts) error {
+	l := logger.StdlibLogger(ctx)
+	ctx = logger.WithStdlib(ctx, l)
+
+	db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{
+		InMemory:    false,
+		PostgresURI: opts.PostgresURI,
+		Directory:   opts.SQLiteDir,
+	})
+	if err != nil {
+		return err
+	}
+
+	tick := opts.Tick
+	if tick < 1 {
+		tick = devserver.DefaultTickDuration
+	}
+
+	// Initialize the devserver
+	dbDriver := "sqlite"
+	if opts.PostgresURI != "" {
+		dbDriver = "postgres"
+	}
+	dbcqrs := base_cqrs.NewCQRS(db, dbDriver)
+	if err := eventschema.LoadConfig(ctx, dbcqrs, opts.EventSchemas); err != nil {
+		return fmt.Errorf("error loading event schemas: %w", err)
+	}
+	var routes *eventroute.Router
+	if opts.RealtimeRoutes != "" {
+		var err error
+		if routes, err = eventroute.LoadFile(ctx, opts.RealtimeRoutes); err != nil {
+			return fmt.Errorf("error loading realtime routes: %w", err)
+		}
+	}
+	hd := base_cqrs.NewHistoryDriver(db, dbDriver)
+	hr := base_cqrs.NewHistoryReader(db, dbDriver)
+	loader := dbcqrs.(state.FunctionLoader)
+
+	stepLimitOverrides := make(map[string]int)
+	stateSizeLimitOverrides := make(map[string]int)
+
+	// Redis is used for run state unless a Postgres URI is configured, and for
+	// the queue and its dependencies unless the Postgres queue is enabled.
+	// Connect and realtime always use Redis.
+	shardedRc, err := connectToOrCreateRedis(opts.RedisURI)
+	if err != nil {
+		return err
+	}
+
+	unshardedRc, err := connectToOrCreateRedis(opts.RedisURI)
+	if err != nil {
+		return err
+	}
+
+	unshardedClient := redis_state.NewUnshardedClient(unshardedRc, redis_state.StateDefaultKey, redis_state.QueueDefaultKey)
+	shardedClient := redis_state.NewShardedClient(redis_state.ShardedClientOpts{
+		UnshardedClient:        unshardedClient,
+		FunctionRunStateClient: shardedRc,
+		StateDefaultKey:        redis_state.StateDefaultKey,
+		FnRunIsSharded:         redis_state.AlwaysShardOnRun,
+		BatchClient:            shardedRc,
+		QueueDefaultKey:        redis_state.QueueDefaultKey,
+	})
+
+	connectRcOpt, err := connectToOrCreateRedisOption(opts.RedisURI)
+	if err != nil {
+		return err
+	}
 
-	batcher := batch.NewRedisBatchManager(shardedClient.Batch(), rq, batch.WithLogger(l))
-	debouncer := debounce.NewRedisDebouncer(unshardedClient.Debounce(), queueShard, rq)
+	connectRc, err := rueidis.NewClient(connectRcOpt)
+	if err != nil {
+		return err
+	}
 
-	// Create a new expression aggregator, using Redis to load evaluables.
+	// Realtime subscriptions use a separate client, as a client which subscribes
+	// to Redis' pub/sub can no longer be used for other commands.
+	realtimeSubRc, err := connectToOrCreateRedis(opts.RedisURI)
+	if err != nil {
+		return err
+	}
+
+	// Create a new broadcaster which lets us broadcast realtime messages.  Recent
+	// messages are retained in Redis so that reconnecting subscribers can catch
+	// up, regardless of which instance they reconnect to.
+	broadcaster := realtime.NewRedisBroadcaster(
+		unshardedRc,
+		realtimeSubRc,
+		realtime.WithHistory(realtime.DefaultHistorySize, realtime.DefaultHistoryTTL),
+	)
+
+	t := runner.NewTracker()
+	b, err := newBackends(ctx, opts, db, dbcqrs, redisClients{
+		sharded:     shardedClient,
+		unsharded:   unshardedClient,
+		unshardedRc: unshardedRc,
+	})
+	if err != nil {
+		return err
+	}
+	sm, smv2, rq, queueShard, shardSelector := b.sm, b.smv2, b.queue, b.queueShard, b.shardSelector
+	rl, batcher, debouncer, sn, crons := b.rl, b.batcher, b.debouncer, b.singleton, b.crons
+
+	scheduler := scheduledevent.NewRedisScheduler(
+		queueShard,
+		rq,
+		scheduledevent.WithLogger(l),
+		scheduledevent.WithMinDelay(opts.EventScheduleMinDelay),
+	)
+
+	// Create a new expression aggregator, using the state store to load evaluables.
 	agg := expragg.NewAggregator(ctx, 100, 100, sm.(expragg.EvaluableLoader), expressions.ExprEvaluator, nil, nil)
 
 	conditionalTracer := itrace.NewConditionalTracer(itrace.ConnectTracer(), itrace.AlwaysTrace)
@@ -338,6 +535,7 @@ func start(ctx context.Context, opts StartOpts) error {
 				EventTopic: opts.Config.EventStream.Service.Concrete.TopicName(),
 			},
 			run.NewTraceLifecycleListener(nil),
+			deadletter.NewLifecycleListener(dbcqrs),
 		),
 		executor.WithStepLimits(func(id sv2.ID) int {
 			if override, hasOverride := stepLimitOverrides[id.FunctionID.String()]; hasOverride {
@@ -357,10 +555,13 @@ func start(ctx context.Context, opts StartOpts) error {
 		}),
 		executor.WithInvokeFailHandler(getInvokeFailHandler(ctx, pb, opts.Config.EventStream.Service.Concrete.TopicName())),
 		executor.WithSendingEventHandler(getSendingEventHandler(pb, opts.Config.EventStream.Service.Concrete.TopicName())),
+		executor.WithPriorityFactorRange(executor.StaticPriorityFactorRange(opts.PriorityFactorMin, opts.PriorityFactorMax)),
 		executor.WithDebouncer(debouncer),
+		executor.WithSingletonManager(sn),
 		executor.WithBatcher(batcher),
 		executor.WithAssignedQueueShard(queueShard),
 		executor.WithShardSelector(shardSelector),
+		executor.WithRealtimePublisher(broadcaster),
 	)
 	if err != nil {
 		return err
@@ -375,6 +576,7 @@ func start(ctx context.Context, opts StartOpts) error {
 		executor.WithServiceExecutor(exec),
 		executor.WithServiceBatcher(batcher),
 		executor.WithServiceDebouncer(debouncer),
+		executor.WithServiceCronManager(crons),
 		executor.WithServiceLogger(l),
 	)
 
@@ -389,7 +591,9 @@ func start(ctx context.Context, opts StartOpts) error {
 		runner.WithTracker(t),
 		runner.WithRateLimiter(rl),
 		runner.WithBatchManager(batcher),
+		runner.WithCronManager(crons),
 		runner.WithPublisher(pb),
+		runner.WithRealtimeRoutes(routes, broadcaster),
 		runner.WithLogger(l),
 	)
 
@@ -408,6 +612,11 @@ func start(ctx context.Context, opts StartOpts) error {
 		}
 		l.Info("using external redis, disabling in-memory persistence and snapshotting", "url", loggedURI)
 	}
+	if opts.PostgresQueue {
+		// Run state and the queue are persisted in Postgres, so the in-memory
+		// Redis only holds ephemeral connect and realtime data.
+		persistenceInterval = nil
+	}
 
 	dsOpts := devserver.StartOpts{
 		Config:             opts.Config,
@@ -420,6 +629,9 @@ func start(ctx context.Context, opts StartOpts) error {
 		ConnectGatewayPort: opts.ConnectGatewayPort,
 		ConnectGatewayHost: opts.Config.CoreAPI.Addr,
 	}
+	if opts.PostgresQueue {
+		dsOpts.FunctionValidator = postgres_state.ValidateFunction
+	}
 
 	if opts.PollInterval > 0 {
 		dsOpts.Poll = true
@@ -435,6 +647,10 @@ func start(ctx context.Context, opts StartOpts) error {
 	ds.State = sm
 	ds.Queue = rq
 	ds.Executor = exec
+	ds.EventKeys = eventkey.NewAuthorizer(opts.EventKey, dbcqrs, rl)
+
+	replays := replay.NewManager(ds.Data, hr, ds.Data, ds.Data, ds.Executor, replay.WithLogger(l))
+
 	// start the API
 	// Create a new API endpoint which hosts SDK-related functionality for
 	// registering functions.
@@ -446,13 +662,20 @@ func start(ctx context.Context, opts StartOpts) error {
 		caching := apiv1.NewCacheMiddleware(cache)
 
 		apiv1.AddRoutes(r, apiv1.Opts{
-			CachingMiddleware:  caching,
-			EventReader:        ds.Data,
-			FunctionReader:     ds.Data,
-			FunctionRunReader:  ds.Data,
-			JobQueueReader:     ds.Queue.(queue.JobQueueReader),
-			Executor:           ds.Executor,
-			QueueShardSelector: shardSelector,
+			CachingMiddleware:    caching,
+			EventReader:          ds.Data,
+			FunctionReader:       ds.Data,
+			FunctionRunReader:    ds.Data,
+			JobQueueReader:       ds.Queue.(queue.JobQueueReader),
+			Executor:             ds.Executor,
+			QueueShardSelector:   shardSelector,
+			Broadcaster:          broadcaster,
+			RealtimeJWTSecret:    realtimeJWTSecret(opts.SigningKey),
+			DeadLetterReadWriter: ds.Data,
+			FunctionPauseWriter:  ds.Data,
+			Replays:              replays,
+			EventScheduler:       scheduler,
+			Batcher:              batcher,
 		})
 	})
 
@@ -477,6 +700,7 @@ func start(ctx context.Context, opts StartOpts) error {
 		EventHandler:    ds.HandleEvent,
 		Executor:        ds.Executor,
 		HistoryReader:   hr,
+		Replays:         replays,
 		LocalSigningKey: opts.SigningKey,
 		RequireKeys:     true,
 		ConnectOpts: connectv0.Opts{
@@ -484,6 +708,7 @@ func start(ctx context.Context, opts StartOpts) error {
 			ConnectManager:             connectionManager,
 			ConnectResponseNotifier:    apiConnectProxy,
 			ConnectRequestStateManager: connectionManager,
+			RoutingManager:             connectionManager,
 			Signer:                     auth.NewJWTSessionTokenSigner(consts.DevServerConnectJwtSecret),
 			RequestAuther:              ds,
 			ConnectGatewayRetriever:    ds,
@@ -531,12 +756,30 @@ func start(ctx context.Context, opts StartOpts) error {
 			{At: "/v0", Router: core.Router},
 			{At: "/debug", Handler: middleware.Profiler()},
 		},
-		LocalEventKeys: opts.EventKey,
-		RequireKeys:    true,
-		Logger:         l,
+		LocalEventKeys:     opts.EventKey,
+		RequireKeys:        true,
+		Logger:             l,
+		EventValidator:     eventschema.NewValidator(dbcqrs),
+		EventSchemaMode:    opts.EventSchemaMode,
+		Quarantine:         dbcqrs,
+		EventKeys:          dbcqrs,
+		RateLimiter:        rl,
+		EventKeyAuthorizer: ds.EventKeys,
+		WebhookSources:     dbcqrs,
+		EventScheduler:     scheduler,
+		Deduplicator:       b.dedup,
 	})
 
-	return service.StartAll(ctx, ds, runner, executorSvc, ds.Apiservice, connGateway)
+	return service.StartAll(ctx, ds, runner, executorSvc, ds.Apiservice, connGateway, replays)
+}
+
+// realtimeJWTSecret returns the secret used to sign realtime subscription tokens.
+// Realtime subscriptions are only enabled when a signing key is configured.
+func realtimeJWTSecret(signingKey string) []byte {
+	if signingKey == "" {
+		return nil
+	}
+	return []byte(signingKey)
 }
 
 func connectToOrCreateRedis(redisURI string) (rueidis.Client, error) {
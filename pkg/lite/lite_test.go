@@ -0,0 +1,162 @@
+package lite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs/base_cqrs"
+	"github.com/inngest/inngest/pkg/execution/queue"
+	"github.com/inngest/inngest/pkg/execution/state"
+	"github.com/inngest/inngest/pkg/execution/state/redis_state"
+	"github.com/inngest/inngest/pkg/execution/state/testharness"
+	"github.com/oklog/ulid/v2"
+	"github.com/redis/rueidis"
+	"github.com/stretchr/testify/require"
+)
+
+// postgresTables are truncated between tests run against Postgres.
+var postgresTables = []string{
+	"run_state",
+	"run_steps",
+	"run_idempotency",
+	"pauses",
+	"pause_invokes",
+	"pause_signals",
+	"pause_signal_waiters",
+	"pause_leases",
+	"pause_consumes",
+	"queue_items",
+	"queue_idempotency",
+	"queue_functions",
+	"queue_singletons",
+	"debounces",
+	"debounce_pointers",
+	"debounce_leading",
+	"batch_pointers",
+	"batches",
+	"batch_items",
+	"cron_next_fires",
+	"rate_limits",
+	"event_dedup",
+}
+
+func TestNewBackends(t *testing.T) {
+	ctx := context.Background()
+	r := miniredis.RunT(t)
+
+	rc, err := rueidis.NewClient(rueidis.ClientOption{
+		InitAddress:  []string{r.Addr()},
+		DisableCache: true,
+	})
+	require.NoError(t, err)
+	defer rc.Close()
+
+	unsharded := redis_state.NewUnshardedClient(rc, redis_state.StateDefaultKey, redis_state.QueueDefaultKey)
+	sharded := redis_state.NewShardedClient(redis_state.ShardedClientOpts{
+		UnshardedClient:        unsharded,
+		FunctionRunStateClient: rc,
+		StateDefaultKey:        redis_state.StateDefaultKey,
+		FnRunIsSharded:         redis_state.NeverShardOnRun,
+		BatchClient:            rc,
+		QueueDefaultKey:        redis_state.QueueDefaultKey,
+	})
+	clients := redisClients{sharded: sharded, unsharded: unsharded, unshardedRc: rc}
+
+	t.Run("uses redis by default", func(t *testing.T) {
+		db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{InMemory: true})
+		require.NoError(t, err)
+		defer db.Close()
+
+		b, err := newBackends(ctx, StartOpts{}, db, base_cqrs.NewCQRS(db, "sqlite"), clients)
+		require.NoError(t, err)
+		require.NotNil(t, b.smv2)
+		require.Contains(t, fmt.Sprintf("%T", b.sm), "redis_state")
+		require.Contains(t, fmt.Sprintf("%T", b.queue), "redis_state")
+		require.NotNil(t, b.queueShard.RedisClient)
+	})
+
+	t.Run("requires a postgres URI for the postgres queue", func(t *testing.T) {
+		_, err := newBackends(ctx, StartOpts{PostgresQueue: true}, nil, nil, clients)
+		require.Error(t, err)
+	})
+
+	t.Run("keeps the redis queue with a postgres URI", func(t *testing.T) {
+		uri := os.Getenv("POSTGRES_URI")
+		if uri == "" {
+			t.Skip("POSTGRES_URI not set")
+		}
+
+		db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{PostgresURI: uri})
+		require.NoError(t, err)
+		defer db.Close()
+
+		b, err := newBackends(ctx, StartOpts{PostgresURI: uri}, db, base_cqrs.NewCQRS(db, "postgres"), clients)
+		require.NoError(t, err)
+		require.Contains(t, fmt.Sprintf("%T", b.sm), "postgres_state")
+		require.Contains(t, fmt.Sprintf("%T", b.queue), "redis_state")
+	})
+
+	t.Run("uses the postgres queue when enabled", func(t *testing.T) {
+		uri := os.Getenv("POSTGRES_URI")
+		if uri == "" {
+			t.Skip("POSTGRES_URI not set")
+		}
+
+		db, err := base_cqrs.New(base_cqrs.BaseCQRSOptions{PostgresURI: uri})
+		require.NoError(t, err)
+		defer db.Close()
+		truncate(t, db)
+
+		b, err := newBackends(ctx, StartOpts{PostgresURI: uri, PostgresQueue: true}, db, base_cqrs.NewCQRS(db, "postgres"), clients)
+		require.NoError(t, err)
+		require.NotNil(t, b.smv2)
+
+		// Every backend is stored in Postgres.
+		for _, v := range []any{b.sm, b.queue, b.rl, b.batcher, b.debouncer, b.singleton, b.crons, b.dedup} {
+			require.NotContains(t, fmt.Sprintf("%T", v), "redis", "%T is backed by redis", v)
+		}
+		require.Nil(t, b.queueShard.RedisClient)
+
+		shard, err := b.shardSelector(ctx, uuid.New(), nil)
+		require.NoError(t, err)
+		require.Equal(t, b.queueShard.Name, shard.Name)
+
+		t.Run("state", func(t *testing.T) {
+			testharness.CheckState(t, func() (state.Manager, func()) {
+				return b.sm, func() {
+					truncate(t, db)
+				}
+			})
+		})
+
+		t.Run("queue", func(t *testing.T) {
+			item := queue.Item{
+				WorkspaceID: uuid.New(),
+				Kind:        queue.KindEdge,
+				Identifier: state.Identifier{
+					AccountID:  uuid.New(),
+					WorkflowID: uuid.New(),
+					RunID:      ulid.MustNew(ulid.Now(), nil),
+				},
+			}
+			require.NoError(t, b.queue.Enqueue(ctx, item, time.Now(), queue.EnqueueOpts{}))
+
+			count, err := b.queue.OutstandingJobCount(ctx, item.WorkspaceID, item.Identifier.WorkflowID, item.Identifier.RunID)
+			require.NoError(t, err)
+			require.Equal(t, 1, count)
+		})
+	})
+}
+
+func truncate(t *testing.T, db *sql.DB) {
+	for _, table := range postgresTables {
+		_, err := db.Exec(fmt.Sprintf("TRUNCATE TABLE %s", table))
+		require.NoError(t, err)
+	}
+}
@@ -403,6 +403,10 @@ func (l traceLifecycle) OnFunctionCancelled(ctx context.Context, md statev2.Meta
 		)
 	}
 
+	if req.ReplacedByRunID != nil {
+		span.SetAttributes(attribute.String(consts.OtelSysSingletonReplacedByRunID, req.ReplacedByRunID.String()))
+	}
+
 	if err := span.SetEvents(ctx, evts, md.Config.EventIDMapping()); err != nil {
 		l.log.Warn("error setting events",
 			"lifecycle", "OnFunctionCancelled",
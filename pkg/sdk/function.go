@@ -103,9 +103,10 @@ func (s SDKFunction) Function() (*inngest.Function, error) {
 		}
 
 		funcStep := inngest.Step{
-			ID:   step.ID,
-			Name: step.Name,
-			URI:  url,
+			ID:      step.ID,
+			Name:    step.Name,
+			URI:     url,
+			Timeout: step.Timeout,
 			// no concurrency keys are yet provided by the SDK
 		}
 		if step.Retries != nil {
@@ -139,6 +140,8 @@ type SDKStep struct {
 	Name    string         `json:"name"`
 	Runtime map[string]any `json:"runtime"`
 	Retries *StepRetries   `json:"retries"`
+	// Timeout is an optional per-request timeout for the step, eg. "30s".
+	Timeout *string `json:"timeout,omitempty"`
 }
 
 type StepRetries struct {
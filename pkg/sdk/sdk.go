@@ -82,6 +82,9 @@ type RegisterRequest struct {
 	AppVersion string `json:"appVersion,omitempty"`
 	// Functions represents all functions hosted within this deploy.
 	Functions []SDKFunction `json:"functions"`
+	// EventSchemas optionally maps event names to JSON Schemas.  Events with
+	// a registered schema are validated at ingest.
+	EventSchemas map[string]json.RawMessage `json:"eventSchemas,omitempty"`
 	// Headers are fetched from the incoming HTTP request.  They are present
 	// on all calls to Inngest from the SDK, and are separate from the RegisterRequest
 	// JSON payload to have a single source of truth.
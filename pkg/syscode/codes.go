@@ -14,6 +14,7 @@ const (
 	CodeSigVerificationFailed     = "sig_verification_failed"
 	CodeUnknown                   = "unknown"
 	CodeBatchKeyExpressionInvalid = "batch_key_expression_invalid"
+	CodeBatchFlushIfInvalid       = "batch_flush_if_invalid"
 	CodeSyncAlreadyPending        = "sync_already_pending"
 	CodePlanUpgradeRequired       = "plan_upgrade_required"
 	CodeRequestTooLong            = "request_duration_too_long"
@@ -32,4 +33,5 @@ const (
 	CodeConnectTooManyAppsPerConnection               = "connect_too_many_apps_per_connection"
 	CodeConnectWorkerRequestExtendLeaseInvalidPayload = "connect_worker_request_extend_lease_invalid_payload"
 	CodeConnectWorkerStoppedResponding                = "connect_worker_stopped_responding"
+	CodeConnectWorkerDrained                          = "connect_worker_drained"
 )
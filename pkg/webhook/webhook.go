@@ -0,0 +1,335 @@
+// Package webhook transforms arbitrary HTTP payloads, eg. from Stripe, GitHub,
+// or Shopify, into events using per-source CEL transforms.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/inngest/inngest/pkg/event"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// costLimit bounds the runtime cost of a single transform.
+	costLimit = 1_000_000
+	// timeout bounds the wall-clock time of a single transform.
+	timeout = time.Second
+
+	// SchemeBody signs the request's body.  This is the default scheme.
+	SchemeBody = "body"
+	// SchemeTimestamped signs "<timestamp>.<body>", with the timestamp and
+	// signatures sent in a single header, eg. "t=1700000000,v1=<signature>".
+	SchemeTimestamped = "timestamped"
+
+	// DefaultTolerance is the maximum age of a timestamped signature, ensuring
+	// that captured requests can't be replayed.
+	DefaultTolerance = 5 * time.Minute
+)
+
+var (
+	ErrInvalidSignature = fmt.Errorf("invalid webhook signature")
+	ErrNoEvents         = fmt.Errorf("webhook transform returned no events")
+)
+
+var env *cel.Env
+
+func init() {
+	var err error
+	env, err = cel.NewEnv(
+		cel.Variable("headers", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("query", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("body", cel.DynType),
+		cel.Variable("raw", cel.StringType),
+		ext.Strings(),
+		ext.Encoders(),
+	)
+	if err != nil {
+		panic(fmt.Errorf("error creating webhook transform env: %w", err))
+	}
+}
+
+// Request is the incoming webhook request passed to transforms.
+type Request struct {
+	Headers http.Header
+	Query   url.Values
+	Body    []byte
+}
+
+func (r Request) vars() map[string]any {
+	headers := map[string]string{}
+	for k := range r.Headers {
+		headers[strings.ToLower(k)] = r.Headers.Get(k)
+	}
+	query := map[string]string{}
+	for k := range r.Query {
+		query[k] = r.Query.Get(k)
+	}
+
+	// The body is parsed as JSON if possible, then as a form, falling back to
+	// null.  The raw body is always available via "raw".
+	var body any
+	if err := json.Unmarshal(r.Body, &body); err != nil {
+		body = nil
+		if form, ferr := url.ParseQuery(string(r.Body)); ferr == nil && len(form) > 0 {
+			m := map[string]any{}
+			for k := range form {
+				m[k] = form.Get(k)
+			}
+			body = m
+		}
+	}
+
+	return map[string]any{
+		"headers": headers,
+		"query":   query,
+		"body":    body,
+		"raw":     string(r.Body),
+	}
+}
+
+// Transform is a compiled webhook transform.
+type Transform struct {
+	prg cel.Program
+}
+
+// Compile compiles a transform expression.  Transforms have access to
+// "headers" and "query" (maps of lowercased names to values), "body" (the
+// parsed JSON or form body), and "raw" (the body as a string), and must return
+// an event or a list of events, eg:
+//
+//	{"name": "stripe/" + body.type, "data": body.data.object, "id": body.id}
+func Compile(expr string) (*Transform, error) {
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("invalid transform: %w", iss.Err())
+	}
+	prg, err := env.Program(ast,
+		cel.CostLimit(costLimit),
+		cel.InterruptCheckFrequency(100),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transform: %w", err)
+	}
+	return &Transform{prg: prg}, nil
+}
+
+// Events runs the transform against the given request, returning the
+// resulting events.
+func (t *Transform) Events(ctx context.Context, r Request) ([]event.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	out, _, err := t.prg.ContextEval(ctx, r.vars())
+	if err != nil {
+		return nil, fmt.Errorf("error running transform: %w", err)
+	}
+
+	native, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, fmt.Errorf("transform must return an event or list of events: %w", err)
+	}
+	byt, err := protojson.Marshal(native.(*structpb.Value))
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling transform result: %w", err)
+	}
+
+	var evts []event.Event
+	switch native.(*structpb.Value).GetKind().(type) {
+	case *structpb.Value_ListValue:
+		err = json.Unmarshal(byt, &evts)
+	case *structpb.Value_StructValue:
+		evts = make([]event.Event, 1)
+		err = json.Unmarshal(byt, &evts[0])
+	default:
+		return nil, fmt.Errorf("transform must return an event or list of events")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("transform returned an invalid event: %w", err)
+	}
+	if len(evts) == 0 {
+		return nil, ErrNoEvents
+	}
+	return evts, nil
+}
+
+// Cache caches compiled transforms by source ID, such that each source's
+// transform is only compiled once until the transform changes.  The zero value
+// is ready to use.
+type Cache struct {
+	m sync.Map
+}
+
+type cached struct {
+	expr      string
+	transform *Transform
+}
+
+// Transform returns the compiled transform for the given source.
+func (c *Cache) Transform(src cqrs.WebhookSource) (*Transform, error) {
+	if v, ok := c.m.Load(src.ID); ok && v.(cached).expr == src.Transform {
+		return v.(cached).transform, nil
+	}
+	t, err := Compile(src.Transform)
+	if err != nil {
+		return nil, err
+	}
+	c.m.Store(src.ID, cached{expr: src.Transform, transform: t})
+	return t, nil
+}
+
+// Validate returns an error if the given source is invalid.
+func Validate(src cqrs.WebhookSource) error {
+	var err error
+	if src.Name == "" {
+		err = errors.Join(err, fmt.Errorf("webhook source name is required"))
+	}
+	if _, cerr := Compile(src.Transform); cerr != nil {
+		err = errors.Join(err, cerr)
+	}
+	if src.Signature != nil {
+		err = errors.Join(err, ValidateSignature(*src.Signature))
+	}
+	return err
+}
+
+// ValidateSignature returns an error if the given signature config is invalid.
+func ValidateSignature(sig cqrs.WebhookSignature) error {
+	var err error
+	if sig.Secret == "" {
+		err = errors.Join(err, fmt.Errorf("signature secret is required"))
+	}
+	if sig.Header == "" {
+		err = errors.Join(err, fmt.Errorf("signature header is required"))
+	}
+	if _, herr := hasher(sig.Algorithm); herr != nil {
+		err = errors.Join(err, herr)
+	}
+	switch sig.Encoding {
+	case "", "hex", "base64":
+	default:
+		err = errors.Join(err, fmt.Errorf("unknown signature encoding: %s", sig.Encoding))
+	}
+	switch sig.Scheme {
+	case "", SchemeBody, SchemeTimestamped:
+	default:
+		err = errors.Join(err, fmt.Errorf("unknown signature scheme: %s", sig.Scheme))
+	}
+	if sig.Tolerance < 0 {
+		err = errors.Join(err, fmt.Errorf("signature tolerance must not be negative"))
+	}
+	return err
+}
+
+// Verify verifies the HMAC signature of the request, returning
+// ErrInvalidSignature if the signature is missing or doesn't match, or if a
+// timestamped signature is older than the signature's tolerance.
+func Verify(sig cqrs.WebhookSignature, r Request) error {
+	h, err := hasher(sig.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	val := strings.TrimSpace(r.Headers.Get(sig.Header))
+	if val == "" {
+		return ErrInvalidSignature
+	}
+
+	if sig.Scheme != SchemeTimestamped {
+		return verifyMAC(sig, h, strings.TrimPrefix(val, sig.Prefix), r.Body)
+	}
+
+	// Timestamped headers contain the timestamp and one or more signatures,
+	// eg. "t=1700000000,v1=<sig>,v1=<sig>".  Many signatures may be sent while
+	// secrets are rolled.
+	var (
+		ts   string
+		sigs []string
+	)
+	for _, part := range strings.Split(val, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			sigs = append(sigs, v)
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return ErrInvalidSignature
+	}
+
+	tolerance := DefaultTolerance
+	if sig.Tolerance > 0 {
+		tolerance = time.Duration(sig.Tolerance) * time.Second
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp is outside of the tolerance", ErrInvalidSignature)
+	}
+
+	signed := append([]byte(ts+"."), r.Body...)
+	for _, s := range sigs {
+		if verifyMAC(sig, h, s, signed) == nil {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// verifyMAC verifies that val is the encoded HMAC of the given payload.
+func verifyMAC(sig cqrs.WebhookSignature, h func() hash.Hash, val string, payload []byte) error {
+	var (
+		got []byte
+		err error
+	)
+	switch sig.Encoding {
+	case "base64":
+		got, err = base64.StdEncoding.DecodeString(val)
+	default:
+		got, err = hex.DecodeString(val)
+	}
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(h, []byte(sig.Secret))
+	_, _ = mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func hasher(algo string) (func() hash.Hash, error) {
+	switch algo {
+	case "", "sha256":
+		return sha256.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unknown signature algorithm: %s", algo)
+	}
+}
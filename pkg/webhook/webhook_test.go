@@ -0,0 +1,184 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/inngest/inngest/pkg/cqrs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("maps a JSON body into a single event", func(t *testing.T) {
+		tr, err := Compile(`{"name": "stripe/" + body.type, "id": body.id, "data": body.data.object}`)
+		require.NoError(t, err)
+
+		evts, err := tr.Events(ctx, Request{
+			Body: []byte(`{"id":"evt_1","type":"charge.succeeded","data":{"object":{"amount":100}}}`),
+		})
+		require.NoError(t, err)
+		require.Len(t, evts, 1)
+		require.Equal(t, "stripe/charge.succeeded", evts[0].Name)
+		require.Equal(t, "evt_1", evts[0].ID)
+		require.EqualValues(t, 100, evts[0].Data["amount"])
+	})
+
+	t.Run("maps headers and body into many events", func(t *testing.T) {
+		tr, err := Compile(`body.commits.map(c, {"name": "github/" + headers["x-github-event"], "data": {"sha": c.id, "repo": query.repo}})`)
+		require.NoError(t, err)
+
+		h := http.Header{}
+		h.Set("X-GitHub-Event", "push")
+		evts, err := tr.Events(ctx, Request{
+			Headers: h,
+			Query:   url.Values{"repo": []string{"inngest"}},
+			Body:    []byte(`{"commits":[{"id":"a"},{"id":"b"}]}`),
+		})
+		require.NoError(t, err)
+		require.Len(t, evts, 2)
+		require.Equal(t, "github/push", evts[1].Name)
+		require.Equal(t, "b", evts[1].Data["sha"])
+		require.Equal(t, "inngest", evts[1].Data["repo"])
+	})
+
+	t.Run("parses form bodies", func(t *testing.T) {
+		tr, err := Compile(`{"name": "form/submitted", "data": {"email": body.email, "raw": raw}}`)
+		require.NoError(t, err)
+
+		evts, err := tr.Events(ctx, Request{Body: []byte(`email=a%40b.com`)})
+		require.NoError(t, err)
+		require.Equal(t, "a@b.com", evts[0].Data["email"])
+		require.Equal(t, "email=a%40b.com", evts[0].Data["raw"])
+	})
+
+	t.Run("rejects non-event results", func(t *testing.T) {
+		tr, err := Compile(`"nope"`)
+		require.NoError(t, err)
+		_, err = tr.Events(ctx, Request{Body: []byte(`{}`)})
+		require.Error(t, err)
+
+		tr, err = Compile(`[]`)
+		require.NoError(t, err)
+		_, err = tr.Events(ctx, Request{Body: []byte(`{}`)})
+		require.ErrorIs(t, err, ErrNoEvents)
+	})
+
+	t.Run("rejects invalid transforms", func(t *testing.T) {
+		_, err := Compile(`{"name": `)
+		require.Error(t, err)
+
+		_, err = Compile(`event.name`)
+		require.Error(t, err, "undeclared variables must not compile")
+	})
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"hello":"world"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	_, _ = mac.Write(body)
+	sum := mac.Sum(nil)
+
+	t.Run("hex with prefix", func(t *testing.T) {
+		sig := cqrs.WebhookSignature{Secret: "secret", Header: "X-Hub-Signature-256", Prefix: "sha256="}
+		require.NoError(t, ValidateSignature(sig))
+
+		h := http.Header{}
+		h.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(sum))
+		require.NoError(t, Verify(sig, Request{Headers: h, Body: body}))
+
+		require.ErrorIs(t, Verify(sig, Request{Headers: h, Body: []byte(`{}`)}), ErrInvalidSignature)
+		require.ErrorIs(t, Verify(sig, Request{Headers: http.Header{}, Body: body}), ErrInvalidSignature)
+	})
+
+	t.Run("base64", func(t *testing.T) {
+		sig := cqrs.WebhookSignature{Secret: "secret", Header: "X-Shopify-Hmac-Sha256", Algorithm: "sha256", Encoding: "base64"}
+		h := http.Header{}
+		h.Set("X-Shopify-Hmac-Sha256", base64.StdEncoding.EncodeToString(sum))
+		require.NoError(t, Verify(sig, Request{Headers: h, Body: body}))
+
+		sig.Secret = "wrong"
+		require.ErrorIs(t, Verify(sig, Request{Headers: h, Body: body}), ErrInvalidSignature)
+	})
+
+	t.Run("timestamped", func(t *testing.T) {
+		sig := cqrs.WebhookSignature{Secret: "secret", Header: "Stripe-Signature", Scheme: SchemeTimestamped}
+		require.NoError(t, ValidateSignature(sig))
+
+		sign := func(ts time.Time, secret string) string {
+			t := strconv.FormatInt(ts.Unix(), 10)
+			mac := hmac.New(sha256.New, []byte(secret))
+			_, _ = mac.Write([]byte(t + "." + string(body)))
+			return t + "," + hex.EncodeToString(mac.Sum(nil))
+		}
+		header := func(val string) http.Header {
+			ts, v1, _ := strings.Cut(val, ",")
+			h := http.Header{}
+			h.Set("Stripe-Signature", "t="+ts+",v1="+v1)
+			return h
+		}
+
+		h := header(sign(time.Now(), "secret"))
+		require.NoError(t, Verify(sig, Request{Headers: h, Body: body}))
+		require.ErrorIs(t, Verify(sig, Request{Headers: h, Body: []byte(`{}`)}), ErrInvalidSignature)
+
+		// Any of many signatures may match, eg. while rolling secrets.
+		_, old, _ := strings.Cut(sign(time.Now(), "old"), ",")
+		h.Set("Stripe-Signature", h.Get("Stripe-Signature")+",v1="+old)
+		require.NoError(t, Verify(sig, Request{Headers: h, Body: body}))
+
+		// Signatures outside of the tolerance are rejected.
+		h = header(sign(time.Now().Add(-DefaultTolerance-time.Minute), "secret"))
+		require.ErrorIs(t, Verify(sig, Request{Headers: h, Body: body}), ErrInvalidSignature)
+		sig.Tolerance = int((DefaultTolerance + 2*time.Minute).Seconds())
+		require.NoError(t, Verify(sig, Request{Headers: h, Body: body}))
+
+		// The body alone must not be accepted as the signed payload.
+		h = http.Header{}
+		h.Set("Stripe-Signature", "t="+strconv.FormatInt(time.Now().Unix(), 10)+",v1="+hex.EncodeToString(sum))
+		require.ErrorIs(t, Verify(sig, Request{Headers: h, Body: body}), ErrInvalidSignature)
+
+		h.Set("Stripe-Signature", "v1="+hex.EncodeToString(sum))
+		require.ErrorIs(t, Verify(sig, Request{Headers: h, Body: body}), ErrInvalidSignature)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		err := ValidateSignature(cqrs.WebhookSignature{Algorithm: "md5", Encoding: "rot13", Scheme: "jwt", Tolerance: -1})
+		require.ErrorContains(t, err, "secret is required")
+		require.ErrorContains(t, err, "unknown signature algorithm")
+		require.ErrorContains(t, err, "unknown signature encoding")
+		require.ErrorContains(t, err, "unknown signature scheme")
+		require.ErrorContains(t, err, "tolerance must not be negative")
+	})
+}
+
+func TestCache(t *testing.T) {
+	c := &Cache{}
+	src := cqrs.WebhookSource{ID: uuid.New(), Transform: `{"name": "a"}`}
+
+	a, err := c.Transform(src)
+	require.NoError(t, err)
+	b, err := c.Transform(src)
+	require.NoError(t, err)
+	require.Same(t, a, b, "transforms should be compiled once")
+
+	src.Transform = `{"name": "b"}`
+	b, err = c.Transform(src)
+	require.NoError(t, err)
+	require.NotSame(t, a, b, "changed transforms should be recompiled")
+
+	src.Transform = `{"name": `
+	_, err = c.Transform(src)
+	require.Error(t, err)
+}